@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/capture"
+	"github.com/spf13/cobra"
+)
+
+func newReplayCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay <capture-file>",
+		Short: "Replay a capture file against the current coldstarter handlers",
+		Long: `Replays every inbound packet of a capture written with --record through
+freshly loaded handlers and compares their responses with the recorded ones.
+Handlers are resolved below DRUID_ROOT, or the working directory when unset.
+The command fails when any response differs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := os.Getenv(rootEnv)
+			if root == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				root = cwd
+			}
+			report, err := capture.ReplayFile(args[0], root)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Replayed %d packets in %d sessions (%d recorded outputs)\n", report.Packets, report.Sessions, report.Outputs)
+			for _, mismatch := range report.Mismatches {
+				fmt.Fprintln(out, mismatch.String())
+			}
+			if !report.OK() {
+				return fmt.Errorf("replay found %d mismatches", len(report.Mismatches))
+			}
+			return nil
+		},
+	}
+}
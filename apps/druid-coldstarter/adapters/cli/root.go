@@ -6,8 +6,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/highcard-dev/daemon/apps/druid-coldstarter/core/services"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/servers"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +18,9 @@ const (
 )
 
 func NewRootCommand() *cobra.Command {
+	var recordPath string
+	var reportInterval time.Duration
+	var udpConfig servers.UDPConfig
 	cmd := &cobra.Command{
 		Use:   "druid-coldstarter",
 		Short: "Run the standalone Druid coldstart gate",
@@ -26,9 +31,21 @@ func NewRootCommand() *cobra.Command {
 			}
 			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 			defer stop()
-			return services.NewColdstarterService().Run(ctx, root)
+			service := services.NewColdstarterService()
+			service.SetRecordPath(recordPath)
+			service.SetReportInterval(reportInterval)
+			service.SetUDPConfig(udpConfig)
+			return service.Run(ctx, root)
 		},
 	}
+	cmd.Flags().StringVar(&recordPath, "record", "", "Write all inbound and outbound coldstarter packets to this capture file")
+	cmd.Flags().DurationVar(&reportInterval, "report-interval", 10*time.Second, "How often proxy mode reports traffic counters to the daemon")
+	cmd.Flags().IntVar(&udpConfig.MaxDatagramSize, "udp-max-datagram", servers.DefaultUDPMaxDatagramSize, "Largest UDP datagram passed to coldstarter handlers; larger ones are dropped")
+	cmd.Flags().DurationVar(&udpConfig.SessionTimeout, "udp-session-timeout", servers.DefaultUDPSessionTimeout, "End a UDP client's coldstarter session after this much silence")
+	cmd.Flags().IntVar(&udpConfig.QueueLimit, "udp-queue", 0, "Queue up to this many UDP datagrams per client before wake and forward them to the proxy target")
+	cmd.Flags().IntVar(&udpConfig.QueueTotalLimit, "udp-queue-total", servers.DefaultUDPQueueTotalLimit, "Queue at most this many UDP datagrams across all clients")
+	cmd.Flags().IntVar(&udpConfig.MaxSessions, "udp-max-sessions", servers.DefaultUDPMaxSessions, "Drop datagrams from new UDP clients while this many coldstarter sessions are open")
+	cmd.AddCommand(newReplayCommand())
 	cmd.SilenceUsage = true
 	return cmd
 }
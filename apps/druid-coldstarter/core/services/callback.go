@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/callbackapi"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/proxy"
+	"github.com/highcard-dev/daemon/internal/utils"
+)
+
+// daemonReporter sends wake events and proxy traffic to the daemon's worker
+// callback listener, authenticated with the runtime's callback token. The
+// token expires; the daemon hands out its replacement in a response header.
+type daemonReporter struct {
+	client    *callbackapi.ClientWithResponses
+	runtimeID string
+
+	mu    sync.Mutex
+	token string
+}
+
+func daemonReporterFromEnv() (*daemonReporter, error) {
+	callbackURL := strings.TrimRight(os.Getenv("DRUID_CALLBACK_URL"), "/")
+	runtimeID := os.Getenv("DRUID_SCROLL_ID")
+	if callbackURL == "" || runtimeID == "" {
+		return nil, nil
+	}
+	tlsConfig, err := utils.ClientTLSConfigFromPEM(os.Getenv("DRUID_CALLBACK_CA"))
+	if err != nil {
+		return nil, fmt.Errorf("DRUID_CALLBACK_CA: %w", err)
+	}
+	options := []callbackapi.ClientOption{}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		options = append(options, callbackapi.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	client, err := callbackapi.NewClientWithResponses(callbackURL, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonReporter{client: client, runtimeID: runtimeID, token: os.Getenv("DRUID_CALLBACK_TOKEN")}, nil
+}
+
+func (r *daemonReporter) reportTraffic(ctx context.Context, servers []proxy.Server) error {
+	body := callbackapi.TrafficReport{Ports: make([]callbackapi.PortTraffic, 0, len(servers))}
+	for _, server := range servers {
+		stats := server.Stats()
+		body.Ports = append(body.Ports, callbackapi.PortTraffic{
+			Name:           server.Name(),
+			RxBytes:        int64(stats.RXBytes),
+			TxBytes:        int64(stats.TXBytes),
+			ActiveSessions: stats.ActiveSessions,
+			TotalSessions:  int64(stats.TotalSessions),
+			LastActivityAt: stats.LastActivityAt,
+		})
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	res, err := r.client.ReportRuntimeTrafficWithResponse(ctx, r.runtimeID, body, r.authorize)
+	if err != nil {
+		return err
+	}
+	r.renew(res.HTTPResponse)
+	return callbackStatus("traffic", res.StatusCode(), res.Body)
+}
+
+func (r *daemonReporter) reportWake(ctx context.Context, wake *domain.RuntimeWakeEvent) error {
+	body := callbackapi.WakeReport{
+		Id:             wake.ID,
+		Port:           wake.Port,
+		ListeningSince: wake.ListeningSince,
+		WokeAt:         wake.WokeAt,
+		ReadyAt:        wake.ReadyAt,
+	}
+	if wake.Protocol != "" {
+		body.Protocol = &wake.Protocol
+	}
+	if wake.Handler != "" {
+		body.Handler = &wake.Handler
+	}
+	if wake.RemoteAddress != "" {
+		body.RemoteAddress = &wake.RemoteAddress
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	res, err := r.client.ReportRuntimeWakeWithResponse(ctx, r.runtimeID, body, r.authorize)
+	if err != nil {
+		return err
+	}
+	r.renew(res.HTTPResponse)
+	return callbackStatus("wake", res.StatusCode(), res.Body)
+}
+
+func (r *daemonReporter) authorize(_ context.Context, request *http.Request) error {
+	r.mu.Lock()
+	token := r.token
+	r.mu.Unlock()
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+func (r *daemonReporter) renew(response *http.Response) {
+	if response == nil {
+		return
+	}
+	if token := response.Header.Get(callbackapi.RenewedTokenHeader); token != "" {
+		r.mu.Lock()
+		r.token = token
+		r.mu.Unlock()
+	}
+}
+
+func callbackStatus(kind string, status int, body []byte) error {
+	if status >= 400 {
+		return fmt.Errorf("%s callback returned %d: %s", kind, status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
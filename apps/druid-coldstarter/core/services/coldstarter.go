@@ -3,35 +3,72 @@ package services
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/capture"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/servers"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"go.uber.org/zap"
 )
 
-type ColdstarterService struct{}
+type ColdstarterService struct {
+	recordPath     string
+	reportInterval time.Duration
+	udpConfig      servers.UDPConfig
+}
 
 type envPortService struct {
-	ports []*domain.AugmentedPort
+	ports   []*domain.AugmentedPort
+	proxies []proxyPort
+}
+
+// proxyPort is a public port that is forwarded to the real server once the
+// coldstart gate on it, if any, has finished.
+type proxyPort struct {
+	name     string
+	port     int
+	protocol string
+	target   string
 }
 
 func NewColdstarterService() *ColdstarterService {
 	return &ColdstarterService{}
 }
 
+// SetRecordPath makes Run write all coldstarter traffic to a capture file
+// that can be replayed with capture.ReplayFile.
+func (s *ColdstarterService) SetRecordPath(path string) {
+	s.recordPath = path
+}
+
+// SetReportInterval sets how often proxy mode sends traffic counters to the
+// daemon.
+func (s *ColdstarterService) SetReportInterval(interval time.Duration) {
+	s.reportInterval = interval
+}
+
+// SetUDPConfig tunes the UDP gate listeners. With a QueueLimit, datagrams
+// received before the gate finishes are forwarded to the matching UDP proxy
+// port once the server is up.
+func (s *ColdstarterService) SetUDPConfig(config servers.UDPConfig) {
+	s.udpConfig = config
+}
+
 func (s *ColdstarterService) Run(ctx context.Context, root string) error {
 	portService, err := portServiceFromEnv(root)
 	if err != nil {
 		return err
 	}
 
-	logger.Log().Info("Starting druid-coldstarter", zap.String("root", root), zap.Int("ports", len(portService.GetPorts())))
+	logger.Log().Info("Starting druid-coldstarter", zap.String("root", root), zap.Int("ports", len(portService.GetPorts())), zap.Int("proxied_ports", len(portService.proxies)))
 	for _, port := range portService.GetPorts() {
 		suffix := strings.ToUpper(port.Name)
 		logger.Log().Info("Configured coldstarter port",
@@ -45,18 +82,88 @@ func (s *ColdstarterService) Run(ctx context.Context, root string) error {
 		)
 	}
 
+	for _, port := range portService.proxies {
+		logger.Log().Info("Configured coldstarter proxy",
+			zap.String("port_name", port.name),
+			zap.Int("listen_port", port.port),
+			zap.String("protocol", port.protocol),
+			zap.String("target", port.target),
+		)
+	}
+
+	reporter, err := daemonReporterFromEnv()
+	if err != nil {
+		return err
+	}
+	if reporter == nil {
+		logger.Log().Warn("DRUID_CALLBACK_URL or DRUID_SCROLL_ID not set; wake events and proxy traffic are not reported to the daemon")
+	}
+
+	gate := gateResult{queued: map[string][]servers.Datagram{}}
+	if len(portService.GetPorts()) > 0 {
+		gate, err = s.gate(ctx, root, portService)
+		if err != nil {
+			return err
+		}
+		if gate.wake != nil && reporter != nil {
+			if err := reporter.reportWake(ctx, gate.wake); err != nil {
+				logger.Log().Warn("Failed to report wake event", zap.Error(err))
+			}
+		}
+	}
+	if len(portService.proxies) == 0 {
+		dropQueuedDatagrams(gate.queued)
+		return nil
+	}
+	return s.proxy(ctx, portService.proxies, gate, reporter)
+}
+
+// gateResult is what a finished gate hands to proxy mode: the wake that
+// finished it and the UDP datagrams queued per port name in the meantime.
+type gateResult struct {
+	wake   *domain.RuntimeWakeEvent
+	queued map[string][]servers.Datagram
+}
+
+// gate runs the coldstart handlers until one of them finishes.
+func (s *ColdstarterService) gate(ctx context.Context, root string, portService *envPortService) (gateResult, error) {
 	coldStarter := services.NewColdStarter(portService, nil, root)
+	coldStarter.SetUDPConfig(s.udpConfig)
+	if s.recordPath != "" {
+		recorder, err := capture.CreateRecorder(s.recordPath, portService.GetPorts())
+		if err != nil {
+			return gateResult{}, err
+		}
+		defer recorder.Close()
+		coldStarter.SetRecorder(recorder)
+		logger.Log().Info("Recording coldstarter traffic", zap.String("file", s.recordPath))
+	}
 
 	finish := coldStarter.Start(ctx)
 	logger.Log().Info("Coldstarter ready; waiting for wake traffic")
 	select {
 	case <-ctx.Done():
 		coldStarter.Stop()
-		return ctx.Err()
+		return gateResult{}, ctx.Err()
 	case <-finish:
 		coldStarter.Stop()
-		logger.Log().Info("Coldstarter finished; handing off to next procedure")
-		return nil
+		if len(portService.proxies) > 0 {
+			logger.Log().Info("Coldstarter finished; switching to proxy mode")
+		} else {
+			logger.Log().Info("Coldstarter finished; handing off to next procedure")
+		}
+		wake := coldStarter.Wake()
+		if wake != nil {
+			wake.ID = uuid.NewString()
+			wake.Source = domain.RuntimeWakeSourceColdstarter
+		}
+		return gateResult{wake: wake, queued: coldStarter.QueuedDatagrams()}, nil
+	}
+}
+
+func dropQueuedDatagrams(queued map[string][]servers.Datagram) {
+	for name, datagrams := range queued {
+		logger.Log().Info("Dropping queued UDP datagrams; port has no proxy target", zap.String("port_name", name), zap.Int("datagrams", len(datagrams)))
 	}
 }
 
@@ -119,8 +226,57 @@ func portServiceFromEnv(root string) (*envPortService, error) {
 			InactiveSince:      time.Now(),
 		})
 	}
-	if len(ports) == 0 {
+	proxies, err := proxyPortsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == 0 && len(proxies) == 0 {
 		return nil, fmt.Errorf("no coldstarter ports configured")
 	}
-	return &envPortService{ports: ports}, nil
+	return &envPortService{ports: ports, proxies: proxies}, nil
+}
+
+// proxyPortsFromEnv reads DRUID_PORT_<NAME>_PROXY_TARGET. The target is
+// host:port, or a bare port on localhost.
+func proxyPortsFromEnv() ([]proxyPort, error) {
+	proxies := []proxyPort{}
+	for _, entry := range os.Environ() {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, "DRUID_PORT_") || !strings.HasSuffix(key, "_PROXY_TARGET") {
+			continue
+		}
+		if key != strings.ToUpper(key) {
+			return nil, fmt.Errorf("%s must be uppercase", key)
+		}
+		suffix := strings.TrimSuffix(strings.TrimPrefix(key, "DRUID_PORT_"), "_PROXY_TARGET")
+		portValue := os.Getenv("DRUID_PORT_" + suffix)
+		if portValue == "" {
+			return nil, fmt.Errorf("DRUID_PORT_%s is required when %s is set", suffix, key)
+		}
+		port, err := strconv.Atoi(portValue)
+		if err != nil {
+			return nil, fmt.Errorf("DRUID_PORT_%s must be a port number: %w", suffix, err)
+		}
+		target := strings.TrimSpace(value)
+		if _, err := strconv.Atoi(target); err == nil {
+			target = net.JoinHostPort("127.0.0.1", target)
+		}
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			return nil, fmt.Errorf("%s must be host:port or a port number: %w", key, err)
+		}
+		if target == net.JoinHostPort("127.0.0.1", portValue) || target == net.JoinHostPort("localhost", portValue) {
+			return nil, fmt.Errorf("%s must not point at the proxied port itself", key)
+		}
+		protocol := strings.ToLower(os.Getenv("DRUID_PORT_" + suffix + "_PROTOCOL"))
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		proxies = append(proxies, proxyPort{
+			name:     strings.ToLower(suffix),
+			port:     port,
+			protocol: protocol,
+			target:   target,
+		})
+	}
+	return proxies, nil
 }
@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -42,6 +46,57 @@ func TestColdstarterRunServesGenericPortFromEnv(t *testing.T) {
 	}
 }
 
+func TestColdstarterReportsWakeEvent(t *testing.T) {
+	wakes := make(chan map[string]any, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/v1/runtimes/scroll-a/wake" || r.Header.Get("Authorization") != "Bearer runtime-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		wakes <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callback.Close()
+
+	port := freeTCPPort(t)
+	t.Setenv("DRUID_PORT_MAIN", port)
+	t.Setenv("DRUID_PORT_MAIN_COLDSTARTER", "generic")
+	t.Setenv("DRUID_SCROLL_ID", "scroll-a")
+	t.Setenv("DRUID_CALLBACK_URL", callback.URL)
+	t.Setenv("DRUID_CALLBACK_TOKEN", "runtime-token")
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- NewColdstarterService().Run(context.Background(), t.TempDir())
+	}()
+	conn := dialTCP(t, "127.0.0.1:"+port)
+	_, _ = conn.Write([]byte("wake"))
+	local := conn.LocalAddr().String()
+	defer conn.Close()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("coldstarter did not finish")
+	}
+	select {
+	case body := <-wakes:
+		if body["id"] == "" || body["port"] != "main" || body["protocol"] != "tcp" || body["handler"] != "generic" || body["remote_address"] != local {
+			t.Fatalf("wake report = %#v, want main/tcp/generic from %s", body, local)
+		}
+		if body["woke_at"] == nil || body["listening_since"] == nil {
+			t.Fatalf("wake report = %#v, want timestamps", body)
+		}
+	default:
+		t.Fatal("wake event was not reported before exit")
+	}
+}
+
 func TestColdstarterRunExitsFromSecondaryGenericPort(t *testing.T) {
 	root := t.TempDir()
 	mainPort := freeTCPPort(t)
@@ -130,6 +185,87 @@ func TestColdstarterRejectsMixedCaseEnvNames(t *testing.T) {
 	}
 }
 
+func TestColdstarterProxyForwardsAndReportsTraffic(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	go func() {
+		for {
+			conn, err := backend.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	reports := make(chan map[string]any, 16)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/internal/v1/runtimes/scroll-a/traffic" || r.Header.Get("Authorization") != "Bearer runtime-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		reports <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callback.Close()
+
+	port := freeTCPPort(t)
+	t.Setenv("DRUID_PORT_GAME", port)
+	t.Setenv("DRUID_PORT_GAME_PROXY_TARGET", backend.Addr().String())
+	t.Setenv("DRUID_SCROLL_ID", "scroll-a")
+	t.Setenv("DRUID_CALLBACK_URL", callback.URL)
+	t.Setenv("DRUID_CALLBACK_TOKEN", "runtime-token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := NewColdstarterService()
+	service.SetReportInterval(20 * time.Millisecond)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- service.Run(ctx, t.TempDir())
+	}()
+
+	conn := dialTCP(t, "127.0.0.1:"+port)
+	defer conn.Close()
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	reply := make([]byte, 5)
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	if _, err := io.ReadFull(conn, reply); err != nil || string(reply) != "hello" {
+		t.Fatalf("reply = %q err = %v, want echoed hello", reply, err)
+	}
+
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case body := <-reports:
+			ports, _ := body["ports"].([]any)
+			if len(ports) != 1 {
+				t.Fatalf("report = %#v, want one port", body)
+			}
+			game := ports[0].(map[string]any)
+			if game["name"] == "game" && game["active_sessions"] == float64(1) && game["rx_bytes"] == float64(5) && game["tx_bytes"] == float64(5) {
+				cancel()
+				if err := <-errCh; err != nil {
+					t.Fatalf("proxy mode returned %v on shutdown", err)
+				}
+				return
+			}
+		case <-deadline:
+			t.Fatal("proxy did not report the forwarded session")
+		}
+	}
+}
+
 func freeTCPPort(t *testing.T) string {
 	t.Helper()
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
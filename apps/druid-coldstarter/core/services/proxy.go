@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/proxy"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/servers"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReportInterval = 10 * time.Second
+	// readyTimeout bounds how long proxy mode waits for the woken server
+	// before giving up on reporting ready_at.
+	readyTimeout = 10 * time.Minute
+)
+
+// proxy keeps forwarding until ctx ends. Counters are sent to the daemon
+// when a reporter is configured. Datagrams queued by the gate are replayed on
+// the UDP port of the same name.
+func (s *ColdstarterService) proxy(ctx context.Context, ports []proxyPort, gate gateResult, reporter *daemonReporter) error {
+	proxies := make([]proxy.Server, 0, len(ports))
+	defer func() {
+		for _, server := range proxies {
+			if err := server.Close(); err != nil {
+				logger.Log().Warn("Failed to close coldstarter proxy", zap.String("port_name", server.Name()), zap.Error(err))
+			}
+		}
+	}()
+	queued := gate.queued
+	for _, port := range ports {
+		server, err := proxy.New(port.name, port.protocol, port.target)
+		if err != nil {
+			return err
+		}
+		if err := server.Start(port.port); err != nil {
+			return fmt.Errorf("start proxy for port %s: %w", port.name, err)
+		}
+		proxies = append(proxies, server)
+		logger.Log().Info("Coldstarter proxy ready", zap.String("port_name", port.name), zap.Int("port", port.port), zap.String("protocol", port.protocol), zap.String("target", port.target))
+		if udp, ok := server.(*proxy.UDP); ok {
+			deliverQueuedDatagrams(udp, queued[port.name])
+			delete(queued, port.name)
+		}
+		if gate.wake != nil && gate.wake.Port == port.name && port.protocol != "udp" {
+			go s.reportReady(ctx, reporter, gate, port.target)
+		}
+	}
+	dropQueuedDatagrams(queued)
+
+	interval := s.reportInterval
+	if interval <= 0 {
+		interval = defaultReportInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Log().Info("Coldstarter proxy stopped")
+			return nil
+		case <-ticker.C:
+			if reporter == nil {
+				continue
+			}
+			if err := reporter.reportTraffic(ctx, proxies); err != nil {
+				logger.Log().Warn("Failed to report proxy traffic", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reportReady waits until the woken port's target accepts connections and
+// updates the wake event with ready_at.
+func (s *ColdstarterService) reportReady(ctx context.Context, reporter *daemonReporter, gate gateResult, target string) {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		conn, err := (&net.Dialer{Timeout: time.Second}).DialContext(ctx, "tcp", target)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			logger.Log().Warn("Woken server did not accept connections; ready time not reported", zap.String("port_name", gate.wake.Port), zap.String("target", target))
+			return
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+	wake := *gate.wake
+	readyAt := time.Now().UTC()
+	wake.ReadyAt = &readyAt
+	logger.Log().Info("Woken server is ready", zap.String("port_name", wake.Port), zap.Duration("startup", readyAt.Sub(wake.WokeAt)))
+	if reporter == nil {
+		return
+	}
+	if err := reporter.reportWake(ctx, &wake); err != nil {
+		logger.Log().Warn("Failed to report wake readiness", zap.Error(err))
+	}
+}
+
+// deliverQueuedDatagrams hands the gate's queue to the proxy, grouped by
+// client so every client keeps its own upstream socket and order.
+func deliverQueuedDatagrams(udp *proxy.UDP, datagrams []servers.Datagram) {
+	order := []string{}
+	clients := map[string]*net.UDPAddr{}
+	payloads := map[string][][]byte{}
+	for _, datagram := range datagrams {
+		key := datagram.Remote.String()
+		if _, ok := clients[key]; !ok {
+			order = append(order, key)
+			clients[key] = datagram.Remote
+		}
+		payloads[key] = append(payloads[key], datagram.Data)
+	}
+	for _, key := range order {
+		udp.Deliver(clients[key], payloads[key])
+	}
+}
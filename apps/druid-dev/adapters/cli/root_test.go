@@ -22,7 +22,7 @@ func (a testAuthorizer) CheckHeader(*fiber.Ctx) (*ports.AuthContext, error) { re
 func (a testAuthorizer) CheckQuery(string, string) (*ports.AuthContext, error) {
 	return a.context, a.err
 }
-func (a testAuthorizer) GenerateQueryToken(string, string) string { return "token" }
+func (a testAuthorizer) GenerateQueryToken(string, string, string) string { return "token" }
 
 func TestSafePathStaysBelowMountedRoot(t *testing.T) {
 	root := t.TempDir()
@@ -0,0 +1,118 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache keeps one Token per daemon in a JSON file only the user can read.
+type Cache struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewCache(path string) *Cache {
+	return &Cache{path: path}
+}
+
+// DefaultCachePath is ~/.druid/auth/tokens.json.
+func DefaultCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".druid", "auth", "tokens.json"), nil
+}
+
+// Get returns the cached token of daemon, or nil if there is none.
+func (c *Cache) Get(daemon string) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[daemon], nil
+}
+
+func (c *Cache) Put(daemon string, token *Token) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.load()
+	if err != nil {
+		return err
+	}
+	tokens[daemon] = token
+	return c.save(tokens)
+}
+
+// Delete forgets the token of daemon and reports whether there was one.
+func (c *Cache) Delete(daemon string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tokens, err := c.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := tokens[daemon]; !ok {
+		return false, nil
+	}
+	delete(tokens, daemon)
+	return true, c.save(tokens)
+}
+
+// BearerToken returns the ID token of daemon, refreshing and storing it
+// first when it is about to expire. It returns "" when daemon has no login.
+func (c *Cache) BearerToken(ctx context.Context, httpClient *http.Client, daemon string) (string, error) {
+	token, err := c.Get(daemon)
+	if err != nil || token == nil {
+		return "", err
+	}
+	if !token.expired() {
+		return token.IDToken, nil
+	}
+	refreshed, err := Refresh(ctx, httpClient, token)
+	if err != nil {
+		return "", fmt.Errorf("%w; run druid auth login again", err)
+	}
+	if err := c.Put(daemon, refreshed); err != nil {
+		return "", err
+	}
+	return refreshed.IDToken, nil
+}
+
+func (c *Cache) load() (map[string]*Token, error) {
+	tokens := map[string]*Token{}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (c *Cache) save(tokens map[string]*Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
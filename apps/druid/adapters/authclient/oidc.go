@@ -0,0 +1,128 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services"
+	"golang.org/x/oauth2"
+)
+
+// refreshBefore refreshes tokens that expire this soon, so a command does
+// not fail halfway through.
+const refreshBefore = time.Minute
+
+// Token is the OIDC login of one daemon. The ID token is what the daemon
+// accepts; the refresh token renews it without another device login.
+type Token struct {
+	Issuer       string    `json:"issuer"`
+	ClientID     string    `json:"client_id"`
+	TokenURL     string    `json:"token_url"`
+	IDToken      string    `json:"id_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Subject returns the sub claim of the ID token. It is not verified; the
+// daemon does that.
+func (t *Token) Subject() string {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(t.IDToken, claims); err != nil {
+		return ""
+	}
+	subject, _ := claims["sub"].(string)
+	return subject
+}
+
+func (t *Token) expired() bool {
+	return time.Now().Add(refreshBefore).After(t.Expiry)
+}
+
+// DeviceLogin runs the OAuth 2.0 device authorization flow against the
+// issuer a daemon advertises. prompt shows the user where to enter the code;
+// DeviceLogin then polls until the login is approved, denied or expires.
+func DeviceLogin(ctx context.Context, httpClient *http.Client, discovery domain.DaemonDiscovery, prompt func(*oauth2.DeviceAuthResponse)) (*Token, error) {
+	if discovery.OIDCIssuer == "" {
+		return nil, errors.New("the daemon does not advertise an OIDC issuer; start it with --oidc-issuer")
+	}
+	provider, err := services.DiscoverOIDCProvider(ctx, httpClient, discovery.OIDCIssuer)
+	if err != nil {
+		return nil, err
+	}
+	if provider.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("OIDC issuer %s does not support the device authorization flow", discovery.OIDCIssuer)
+	}
+	config := &oauth2.Config{
+		ClientID: discovery.OIDCClientID,
+		Scopes:   discovery.OIDCScopes,
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: provider.DeviceAuthorizationEndpoint,
+			TokenURL:      provider.TokenEndpoint,
+			AuthStyle:     oauth2.AuthStyleInParams,
+		},
+	}
+	if httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
+	device, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+	prompt(device)
+	token, err := config.DeviceAccessToken(ctx, device)
+	if err != nil {
+		return nil, fmt.Errorf("device login failed: %w", err)
+	}
+	return newToken(provider.Issuer, discovery.OIDCClientID, provider.TokenEndpoint, token, "")
+}
+
+// Refresh trades the refresh token for a new ID token.
+func Refresh(ctx context.Context, httpClient *http.Client, token *Token) (*Token, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.New("the login has expired and has no refresh token")
+	}
+	config := &oauth2.Config{
+		ClientID: token.ClientID,
+		Endpoint: oauth2.Endpoint{TokenURL: token.TokenURL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+	if httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
+	refreshed, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+	if err != nil {
+		return nil, fmt.Errorf("refreshing the login failed: %w", err)
+	}
+	return newToken(token.Issuer, token.ClientID, token.TokenURL, refreshed, token.RefreshToken)
+}
+
+func newToken(issuer string, clientID string, tokenURL string, token *oauth2.Token, previousRefreshToken string) (*Token, error) {
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return nil, errors.New("the OIDC issuer returned no ID token; is the openid scope requested?")
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(idToken, claims); err != nil {
+		return nil, fmt.Errorf("the OIDC issuer returned a malformed ID token: %w", err)
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("the OIDC issuer returned an ID token without expiry")
+	}
+	refreshToken := token.RefreshToken
+	if refreshToken == "" {
+		refreshToken = previousRefreshToken
+	}
+	return &Token{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		TokenURL:     tokenURL,
+		IDToken:      idToken,
+		RefreshToken: refreshToken,
+		Expiry:       time.Unix(int64(exp), 0),
+	}, nil
+}
@@ -0,0 +1,161 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"golang.org/x/oauth2"
+)
+
+type fakeIssuer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	polls     int
+	refreshes int
+	lifetime  time.Duration
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	issuer := &fakeIssuer{lifetime: time.Hour}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        issuer.URL,
+			"device_authorization_endpoint": issuer.URL + "/device",
+			"token_endpoint":                issuer.URL + "/token",
+			"jwks_uri":                      issuer.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "druid-cli" {
+			http.Error(w, "unknown client", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-1",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": issuer.URL + "/activate",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			issuer.polls++
+			if issuer.polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(issuer.tokenResponse(t, "refresh-1"))
+		case "refresh_token":
+			if r.FormValue("refresh_token") != "refresh-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			issuer.refreshes++
+			// No refresh_token in the response: the old one stays valid.
+			_ = json.NewEncoder(w).Encode(issuer.tokenResponse(t, ""))
+		default:
+			http.Error(w, "unsupported grant", http.StatusBadRequest)
+		}
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+func (i *fakeIssuer) tokenResponse(t *testing.T, refreshToken string) map[string]any {
+	idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": i.URL,
+		"sub": "alice",
+		"aud": "druid-cli",
+		"exp": time.Now().Add(i.lifetime).Unix(),
+	}).SignedString([]byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response := map[string]any{"access_token": "access", "token_type": "Bearer", "expires_in": 3600, "id_token": idToken}
+	if refreshToken != "" {
+		response["refresh_token"] = refreshToken
+	}
+	return response
+}
+
+func TestDeviceLoginPollsUntilApproved(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	var prompted *oauth2.DeviceAuthResponse
+	token, err := DeviceLogin(context.Background(), nil, domain.DaemonDiscovery{
+		OIDCIssuer:   issuer.URL,
+		OIDCClientID: "druid-cli",
+		OIDCScopes:   []string{"openid", "offline_access"},
+	}, func(device *oauth2.DeviceAuthResponse) {
+		prompted = device
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompted == nil || prompted.UserCode != "ABCD-EFGH" {
+		t.Fatalf("prompt = %#v", prompted)
+	}
+	if issuer.polls != 2 {
+		t.Fatalf("polls = %d, want 2", issuer.polls)
+	}
+	if token.Subject() != "alice" || token.RefreshToken != "refresh-1" || token.TokenURL != issuer.URL+"/token" || time.Until(token.Expiry) < 50*time.Minute {
+		t.Fatalf("token = %#v", token)
+	}
+}
+
+func TestCacheRefreshesExpiringTokens(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	cache := NewCache(filepath.Join(t.TempDir(), "auth", "tokens.json"))
+	stale := &Token{
+		Issuer:       issuer.URL,
+		ClientID:     "druid-cli",
+		TokenURL:     issuer.URL + "/token",
+		IDToken:      "stale",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(10 * time.Second),
+	}
+	if err := cache.Put("https://druid.example", stale); err != nil {
+		t.Fatal(err)
+	}
+
+	bearer, err := cache.BearerToken(context.Background(), nil, "https://druid.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bearer == "stale" || issuer.refreshes != 1 {
+		t.Fatalf("bearer = %q after %d refreshes", bearer, issuer.refreshes)
+	}
+	stored, err := cache.Get("https://druid.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.IDToken != bearer || stored.RefreshToken != "refresh-1" {
+		t.Fatalf("stored = %#v", stored)
+	}
+
+	// The refreshed token is fresh, so the next call uses it as is.
+	if again, err := cache.BearerToken(context.Background(), nil, "https://druid.example"); err != nil || again != bearer || issuer.refreshes != 1 {
+		t.Fatalf("again = %q, err = %v, refreshes = %d", again, err, issuer.refreshes)
+	}
+	if bearer, err := cache.BearerToken(context.Background(), nil, "https://other.example"); err != nil || bearer != "" {
+		t.Fatalf("other daemon bearer = %q, err = %v", bearer, err)
+	}
+}
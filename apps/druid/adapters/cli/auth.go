@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"github.com/highcard-dev/daemon/apps/druid/adapters/authclient"
+	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
+)
+
+var AuthCommand = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in to a runtime daemon",
+}
+
+var authLoginCommand = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to a runtime daemon with its OIDC issuer",
+	Long: `Log in with the OIDC device flow against the issuer the daemon advertises
+at /.well-known/druid-configuration. The ID token and refresh token are cached
+in ~/.druid/auth/tokens.json per context, or per daemon outside of a
+context, and sent automatically by every command that talks to it, unless
+--daemon-token is given.
+
+Examples:
+  druid auth login --daemon-url https://druid.example.com:8081
+  druid auth login --context staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active, err := activeDaemonContext()
+		if err != nil {
+			return err
+		}
+		daemon, err := newAnonymousDaemonClient(active)
+		if err != nil {
+			return err
+		}
+		discovery, err := daemon.Discovery(cmd.Context())
+		if err != nil {
+			return err
+		}
+		token, err := authclient.DeviceLogin(cmd.Context(), nil, *discovery, func(device *oauth2.DeviceAuthResponse) {
+			cmd.Printf("Open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+			if device.VerificationURIComplete != "" {
+				cmd.Printf("or open %s\n", device.VerificationURIComplete)
+			}
+			cmd.Println("Waiting for the login to be approved...")
+		})
+		if err != nil {
+			return err
+		}
+		cachePath, err := authclient.DefaultCachePath()
+		if err != nil {
+			return err
+		}
+		if err := authclient.NewCache(cachePath).Put(daemonLoginKey(active), token); err != nil {
+			return err
+		}
+		cmd.Printf("Logged in to %s as %s\n", daemonLoginKey(active), token.Subject())
+		return nil
+	},
+}
+
+var authLogoutCommand = &cobra.Command{
+	Use:   "logout",
+	Short: "Forget the cached login of a runtime daemon",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active, err := activeDaemonContext()
+		if err != nil {
+			return err
+		}
+		cachePath, err := authclient.DefaultCachePath()
+		if err != nil {
+			return err
+		}
+		removed, err := authclient.NewCache(cachePath).Delete(daemonLoginKey(active))
+		if err != nil {
+			return err
+		}
+		if !removed {
+			cmd.Printf("Not logged in to %s\n", daemonLoginKey(active))
+			return nil
+		}
+		cmd.Printf("Logged out of %s\n", daemonLoginKey(active))
+		return nil
+	},
+}
+
+func init() {
+	AuthCommand.AddCommand(authLoginCommand, authLogoutCommand)
+	RootCmd.AddCommand(AuthCommand)
+}
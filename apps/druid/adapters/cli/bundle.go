@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/spf13/cobra"
+)
+
+var bundleOutput string
+var bundleImages bool
+var bundlePlatform string
+
+var BundleCommand = &cobra.Command{
+	Use:   "bundle",
+	Short: "Move scroll artifacts without a registry",
+}
+
+var BundleExportCommand = &cobra.Command{
+	Use:   "export <artifact>",
+	Short: "Write a scroll artifact to an OCI image layout tarball",
+	Long: `Write a scroll artifact, data layers included, to an OCI image layout tarball.
+
+The tarball can be passed wherever an artifact is accepted, for example
+druid create --from-bundle, druid update --from-bundle or druid pull, so scrolls
+can be created and updated with no registry access. With --images the container
+images of the scroll's procedures are added for --platform, each tagged with its
+full reference (docker.io/library/alpine:3.20) so OCI layout tools such as
+skopeo can load them into the host's image store.
+
+The daemon does not load bundled images itself. Load them into the backend
+before creating or updating from the bundle; the Docker backend refuses a
+bundle whose images it does not have instead of pulling them. Images pinned by
+images.lock run by digest, which Docker only knows for images pulled from a
+registry, so copy those into a local registry mirror instead.`,
+	Example: `  druid bundle export artifacts.druid.gg/druid-team/minecraft:1.21 -o minecraft.tar
+  druid bundle export artifacts.druid.gg/druid-team/minecraft:1.21 -o minecraft.tar --images --platform linux/arm64`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output := bundleOutput
+		if output == "" {
+			return fmt.Errorf("--output is required")
+		}
+		if !strings.EqualFold(filepath.Ext(output), domain.ScrollBundleExt) {
+			return fmt.Errorf("bundle output %s must end in %s", output, domain.ScrollBundleExt)
+		}
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		bundle, err := oci.ExportBundle(args[0], output, registry.BundleOptions{
+			Images:   bundleImages,
+			Platform: bundlePlatform,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", bundle.Digest, output)
+		for _, image := range bundle.Images {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", image)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(BundleCommand)
+	BundleCommand.AddCommand(BundleExportCommand)
+	BundleExportCommand.Flags().StringVarP(&bundleOutput, "output", "o", "", "Bundle file to write (.tar)")
+	BundleExportCommand.Flags().BoolVar(&bundleImages, "images", false, "Include the container images of the scroll's procedures")
+	BundleExportCommand.Flags().StringVar(&bundlePlatform, "platform", "linux/"+runtime.GOARCH, "Platform of the exported container images")
+}
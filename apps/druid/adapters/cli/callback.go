@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	appservices "github.com/highcard-dev/daemon/apps/druid/core/services"
 	"github.com/highcard-dev/daemon/internal/callbackapi"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
 )
 
 type runtimeCallbackHandler struct {
 	callbacks            *appservices.WorkerCallbackManager
+	supervisor           *appservices.RuntimeSupervisor
+	tokens               *coreservices.RuntimeCallbackTokens
 	allowUnauthenticated bool
 }
 
@@ -24,6 +30,9 @@ func (h runtimeCallbackHandler) CompleteWorker(c *fiber.Ctx, runtimeID callbacka
 	if result.ArtifactDigest != nil {
 		runtimeResult.ArtifactDigest = *result.ArtifactDigest
 	}
+	if result.ImageLock != nil {
+		runtimeResult.ImageLock = *result.ImageLock
+	}
 	if result.Error != nil {
 		runtimeResult.Error = *result.Error
 	}
@@ -38,3 +47,90 @@ func (h runtimeCallbackHandler) CompleteWorker(c *fiber.Ctx, runtimeID callbacka
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+func (h runtimeCallbackHandler) ReportRuntimeTraffic(c *fiber.Ctx, runtimeID callbackapi.Runtime) error {
+	var report callbackapi.TrafficReport
+	if err := c.BodyParser(&report); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err := h.checkRuntimeIdentity(c, runtimeID); err != nil {
+		return err
+	}
+	traffic := make([]domain.RuntimePortTraffic, 0, len(report.Ports))
+	for _, port := range report.Ports {
+		if port.Name == "" || port.RxBytes < 0 || port.TxBytes < 0 || port.ActiveSessions < 0 || port.TotalSessions < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "traffic report ports need a name and non-negative counters")
+		}
+		traffic = append(traffic, domain.RuntimePortTraffic{
+			Name:           port.Name,
+			RXBytes:        uint64(port.RxBytes),
+			TXBytes:        uint64(port.TxBytes),
+			ActiveSessions: port.ActiveSessions,
+			TotalSessions:  uint64(port.TotalSessions),
+			LastActivityAt: port.LastActivityAt,
+		})
+	}
+	if err := h.supervisor.RecordProxyTraffic(string(runtimeID), traffic); err != nil {
+		if errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h runtimeCallbackHandler) ReportRuntimeWake(c *fiber.Ctx, runtimeID callbackapi.Runtime) error {
+	var report callbackapi.WakeReport
+	if err := c.BodyParser(&report); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err := h.checkRuntimeIdentity(c, runtimeID); err != nil {
+		return err
+	}
+	if report.Id == "" || report.Port == "" || report.WokeAt.IsZero() {
+		return fiber.NewError(fiber.StatusBadRequest, "wake events need an id, port and woke_at")
+	}
+	event := domain.RuntimeWakeEvent{
+		ID:             report.Id,
+		Source:         domain.RuntimeWakeSourceColdstarter,
+		Port:           report.Port,
+		ListeningSince: report.ListeningSince,
+		WokeAt:         report.WokeAt,
+		ReadyAt:        report.ReadyAt,
+	}
+	if report.Protocol != nil {
+		event.Protocol = *report.Protocol
+	}
+	if report.Handler != nil {
+		event.Handler = *report.Handler
+	}
+	if report.RemoteAddress != nil {
+		event.RemoteAddress = *report.RemoteAddress
+	}
+	if _, err := h.supervisor.RecordWakeEvent(string(runtimeID), event); err != nil {
+		if errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// checkRuntimeIdentity makes sure a runtime token is only used for reports
+// about its own runtime. Tokens past half their lifetime get a replacement
+// in the Druid-Callback-Token response header.
+func (h runtimeCallbackHandler) checkRuntimeIdentity(c *fiber.Ctx, runtimeID callbackapi.Runtime) error {
+	if h.allowUnauthenticated {
+		return nil
+	}
+	identity, ok := c.Locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
+	if !ok || identity.Kind != "runtime" || identity.RuntimeID != string(runtimeID) {
+		return fiber.NewError(fiber.StatusForbidden, "runtime identity does not match runtime")
+	}
+	if h.tokens != nil {
+		if token := h.tokens.Renew(identity); token != "" {
+			c.Set(callbackapi.RenewedTokenHeader, token)
+		}
+	}
+	return nil
+}
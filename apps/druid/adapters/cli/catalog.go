@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var catalogJSON bool
+
+var SearchCommand = &cobra.Command{
+	Use:   "search <registry/namespace>",
+	Short: "List scroll repositories in a registry",
+	Long: `List the repositories of a registry, optionally limited to a namespace, through
+the OCI distribution catalog API. Each repository is described by its "latest"
+tag, or its newest semver tag, using the gg.druid.scroll.* manifest annotations.
+
+Examples:
+  druid search artifacts.druid.gg
+  druid search artifacts.druid.gg/druid-team --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		repos, err := oci.ListRepositories(args[0])
+		if err != nil {
+			return err
+		}
+		entries := []registry.CatalogEntry{}
+		for _, repo := range repos {
+			tags, err := oci.ListTags(repo)
+			if err != nil {
+				logger.Log().Warn("Skipping repository", zap.String("repo", repo), zap.Error(err))
+				continue
+			}
+			tag := registry.LatestTag(tags)
+			if tag == "" {
+				continue
+			}
+			entry, err := oci.DescribeTag(repo, tag)
+			if err != nil {
+				logger.Log().Warn("Skipping repository", zap.String("repo", repo), zap.Error(err))
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return printCatalog(cmd.OutOrStdout(), entries)
+	},
+}
+
+var TagsCommand = &cobra.Command{
+	Use:   "tags <repo>",
+	Short: "List the tags of a scroll repository",
+	Long: `List the tags of a scroll repository, newest semver first, with the
+gg.druid.scroll.* annotations of each tag.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		tags, err := oci.ListTags(args[0])
+		if err != nil {
+			return err
+		}
+		entries := make([]registry.CatalogEntry, 0, len(tags))
+		for _, tag := range tags {
+			entry, err := oci.DescribeTag(args[0], tag)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return printCatalog(cmd.OutOrStdout(), entries)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(SearchCommand, TagsCommand)
+	SearchCommand.Flags().BoolVar(&catalogJSON, "json", false, "Print the result as JSON")
+	TagsCommand.Flags().BoolVar(&catalogJSON, "json", false, "Print the result as JSON")
+}
+
+func printCatalog(out io.Writer, entries []registry.CatalogEntry) error {
+	if catalogJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORY\tTAG\tNAME\tAPP VERSION\tVERSION\tMIN RAM\tMIN DISK\tMIN CPU\tCATEGORY\tPORTS")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Repository, e.Tag, dash(e.Name), dash(e.AppVersion), dash(e.Version),
+			dash(e.MinRam), dash(e.MinDisk), dash(e.MinCpu), dash(e.Category), dash(e.PortList()))
+	}
+	return w.Flush()
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
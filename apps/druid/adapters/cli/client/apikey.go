@@ -0,0 +1,128 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var apiKeyName string
+var apiKeyRole string
+var apiKeyScrolls []string
+var apiKeyCommands []string
+var apiKeyExpires time.Duration
+
+var APIKeyCommand = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage API keys for the daemon management API",
+}
+
+var APIKeyCreateCommand = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key",
+	Long: `Create an API key for automation against the management API.
+
+The key is printed once and cannot be shown again. It carries a role (operator
+unless --role says otherwise) and can be limited to some scrolls, some
+commands and a lifetime. Pass it with --daemon-token or DRUID_DAEMON_TOKEN.`,
+	Example: `  druid apikey create --name ci --scroll my-server --command backup --expires 720h
+  druid apikey create --name dashboard --role viewer`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		request := api.CreateAPIKeyRequest{Name: apiKeyName}
+		if apiKeyRole != "" {
+			role := api.RuntimeRole(apiKeyRole)
+			request.Role = &role
+		}
+		if len(apiKeyScrolls) > 0 {
+			request.Scrolls = &apiKeyScrolls
+		}
+		if len(apiKeyCommands) > 0 {
+			request.Commands = &apiKeyCommands
+		}
+		if apiKeyExpires < 0 {
+			return fmt.Errorf("--expires must not be negative")
+		}
+		if apiKeyExpires > 0 {
+			expiresAt := time.Now().Add(apiKeyExpires).UTC()
+			request.ExpiresAt = &expiresAt
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		created, err := daemon.CreateAPIKey(cmd.Context(), request)
+		if err != nil {
+			return err
+		}
+		return printJSON(created)
+	},
+}
+
+var APIKeyListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		keys, err := daemon.ListAPIKeys(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if outputJSON() {
+			return printJSON(keys)
+		}
+		return printAPIKeys(keys)
+	},
+}
+
+var APIKeyRevokeCommand = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		if err := daemon.RevokeAPIKey(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Revoked api key %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	APIKeyCreateCommand.Flags().StringVar(&apiKeyName, "name", "", "Name of the key")
+	APIKeyCreateCommand.Flags().StringVar(&apiKeyRole, "role", "", "Role of the key: owner, operator, viewer or console-only")
+	APIKeyCreateCommand.Flags().StringArrayVar(&apiKeyScrolls, "scroll", nil, "Scroll runtime the key may use; repeatable")
+	APIKeyCreateCommand.Flags().StringArrayVar(&apiKeyCommands, "command", nil, "Command the key may run; repeatable")
+	APIKeyCreateCommand.Flags().DurationVar(&apiKeyExpires, "expires", 0, "Lifetime of the key, e.g. 720h; zero never expires")
+	APIKeyCreateCommand.MarkFlagRequired("name")
+}
+
+func printAPIKeys(keys []api.RuntimeAPIKey) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tSCROLLS\tEXPIRES")
+	for _, key := range keys {
+		scrolls := "*"
+		if key.Scrolls != nil && len(*key.Scrolls) > 0 {
+			scrolls = strings.Join(*key.Scrolls, ",")
+		}
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expires = key.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", key.Id, key.Name, key.Role, scrolls, expires)
+	}
+	return w.Flush()
+}
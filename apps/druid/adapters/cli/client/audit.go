@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var auditScroll string
+var auditSubject string
+var auditAction string
+var auditSince time.Duration
+var auditLimit int
+var auditJSON bool
+
+var AuditCommand = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the daemon audit log",
+	Long: `Show who changed what through the management and public APIs, and what
+was typed into consoles, oldest first.`,
+	Example: `  druid audit --scroll my-server --since 24h
+  druid audit --action scroll.stop --limit 20
+  druid audit --subject apikey:ci --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := api.ListAuditParams{}
+		if auditScroll != "" {
+			params.Scroll = &auditScroll
+		}
+		if auditSubject != "" {
+			params.Subject = &auditSubject
+		}
+		if auditAction != "" {
+			params.Action = &auditAction
+		}
+		if auditSince > 0 {
+			since := time.Now().Add(-auditSince).UTC()
+			params.Since = &since
+		}
+		if auditLimit > 0 {
+			params.Limit = &auditLimit
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		records, err := daemon.ListAudit(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+		if auditJSON || outputJSON() {
+			return printJSON(records)
+		}
+		return printAudit(records)
+	},
+}
+
+func init() {
+	AuditCommand.Flags().StringVar(&auditScroll, "scroll", "", "Only records of this scroll runtime")
+	AuditCommand.Flags().StringVar(&auditSubject, "subject", "", "Only records of this subject, e.g. apikey:<name> or local")
+	AuditCommand.Flags().StringVar(&auditAction, "action", "", "Only records of this action, e.g. scroll.stop or console.input")
+	AuditCommand.Flags().DurationVar(&auditSince, "since", 0, "Only records newer than this, e.g. 24h")
+	AuditCommand.Flags().IntVar(&auditLimit, "limit", 0, "Only the newest records")
+	AuditCommand.Flags().BoolVar(&auditJSON, "json", false, "Print the records as JSON")
+}
+
+func printAudit(records []api.AuditRecord) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tSUBJECT\tSCROLL\tACTION\tRESULT\tPARAMS")
+	for _, record := range records {
+		scroll := ""
+		if record.ScrollId != nil {
+			scroll = *record.ScrollId
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", record.At.Local().Format(time.RFC3339), record.Subject, scroll, record.Action, record.Result, auditParams(record.Params))
+	}
+	return w.Flush()
+}
+
+func auditParams(params *map[string]string) string {
+	if params == nil {
+		return ""
+	}
+	names := make([]string, 0, len(*params))
+	for name := range *params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%q", name, (*params)[name]))
+	}
+	return strings.Join(parts, " ")
+}
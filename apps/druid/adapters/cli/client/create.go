@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 
 	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -13,20 +16,35 @@ var CreateCommand = &cobra.Command{
 	Example: `  druid create ./scroll my-scroll -p 8080:http
   druid create artifacts.example/app:v1 my-scroll -p 8080:80
   druid create ./scroll my-scroll -p 127.0.0.1:8080:http
-  druid create ./scroll my-scroll -p 8443:http/https`,
-	Args: cobra.RangeArgs(1, 2),
+  druid create ./scroll my-scroll -p 8443:http/https
+  druid create --from-bundle scroll.tar my-scroll`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if createFromBundle != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		artifact := args[0]
+		artifact := ""
+		if createFromBundle != "" {
+			bundle, err := bundleArtifact(createFromBundle)
+			if err != nil {
+				return err
+			}
+			artifact = bundle
+		} else {
+			artifact, args = args[0], args[1:]
+		}
 		name := ""
-		if len(args) == 2 {
-			name = args[1]
+		if len(args) == 1 {
+			name = args[0]
 		}
 		runtimeClient, err := runtimeDaemonClient()
 		if err != nil {
 			return err
 		}
 
-		scroll, err := createScrollWithRouting(cmd.Context(), runtimeClient, artifact, name, registryCredentials(), createPublishes)
+		scroll, err := createScrollWithRouting(cmd.Context(), runtimeClient, artifact, name, registryCredentials(artifact), createPublishes)
 		if err != nil {
 			return err
 		}
@@ -35,9 +53,24 @@ var CreateCommand = &cobra.Command{
 }
 
 var createPublishes []string
+var createFromBundle string
 
 func init() {
 	CreateCommand.Flags().StringArrayVarP(&createPublishes, "publish", "p", nil, "Publish routing as [external-ip:]public-port:target[/protocol]")
+	CreateCommand.Flags().StringVar(&createFromBundle, "from-bundle", "", "Create from a bundle written by druid bundle export; its images must already be loaded into the backend")
+}
+
+// bundleArtifact checks a bundle path and makes it absolute, since the daemon
+// resolves local artifacts from its own working directory.
+func bundleArtifact(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if !domain.IsScrollBundle(abs) {
+		return "", fmt.Errorf("%s is not a bundle; export one with druid bundle export", path)
+	}
+	return abs, nil
 }
 
 func createScrollWithRouting(ctx context.Context, daemon RuntimeDaemon, artifact string, name string, registryCredentials []api.RegistryCredential, publishes []string) (*api.RuntimeScroll, error) {
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var eventsFollow bool
+
+var EventsCommand = &cobra.Command{
+	Use:   "events <name>",
+	Short: "Show runtime events of a scroll, such as coldstart wakes",
+	Long:  "Prints one JSON event per line, oldest first. With --follow new events are printed as the daemon records them.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		events, err := daemon.GetScrollEvents(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		}
+		if !eventsFollow {
+			return nil
+		}
+		if config.FollowEvents == nil {
+			return fmt.Errorf("event streaming is not configured")
+		}
+		return config.FollowEvents(cmd.Context(), args[0], cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	EventsCommand.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep printing new events")
+}
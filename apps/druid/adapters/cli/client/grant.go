@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var grantRole string
+var grantPermissions []string
+var grantRevoke bool
+
+var GrantCommand = &cobra.Command{
+	Use:   "grant <name> <subject>",
+	Short: "Give a token subject access to a scroll runtime",
+	Long: `Give a token subject access to a scroll runtime.
+
+Roles are owner, operator (everything but delete and managing grants), viewer
+(read only) and console-only (attach to consoles). --permission adds single
+permissions on top of the role, such as scroll:run:backup. Granting a subject
+again replaces its earlier grant.`,
+	Example: `  druid grant my-server alice@example.com --role viewer
+  druid grant my-server bob@example.com --role console-only --permission scroll:run:restart
+  druid grant my-server alice@example.com --revoke`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		grant, err := grantFromFlags()
+		if err != nil {
+			return err
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		scroll, err := daemon.SetScrollGrant(cmd.Context(), args[0], args[1], grant)
+		if err != nil {
+			return err
+		}
+		return printJSON(scroll)
+	},
+}
+
+func init() {
+	GrantCommand.Flags().StringVar(&grantRole, "role", "", "Role of the subject: owner, operator, viewer or console-only")
+	GrantCommand.Flags().StringArrayVar(&grantPermissions, "permission", nil, "Extra permission such as scroll:run:<command>; repeatable")
+	GrantCommand.Flags().BoolVar(&grantRevoke, "revoke", false, "Remove the grant of the subject")
+}
+
+func grantFromFlags() (*api.RuntimeGrantRequest, error) {
+	if grantRevoke {
+		if grantRole != "" || len(grantPermissions) > 0 {
+			return nil, fmt.Errorf("--revoke cannot be combined with --role or --permission")
+		}
+		return nil, nil
+	}
+	if grantRole == "" && len(grantPermissions) == 0 {
+		return nil, fmt.Errorf("pass --role, --permission or --revoke")
+	}
+	grant := &api.RuntimeGrantRequest{}
+	if grantRole != "" {
+		role := api.RuntimeRole(grantRole)
+		grant.Role = &role
+	}
+	if len(grantPermissions) > 0 {
+		grant.Permissions = &grantPermissions
+	}
+	return grant, nil
+}
@@ -15,6 +15,9 @@ var ListCommand = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if outputJSON() {
+			return printJSON(scrolls)
+		}
 		return printScrolls(scrolls)
 	},
 }
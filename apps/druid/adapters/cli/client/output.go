@@ -18,6 +18,12 @@ func printScrolls(scrolls []api.RuntimeScroll) error {
 	return w.Flush()
 }
 
+// outputJSON reports whether list commands should print JSON instead of a
+// table.
+func outputJSON() bool {
+	return config.Output != nil && config.Output() == "json"
+}
+
 func printJSON(v interface{}) error {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
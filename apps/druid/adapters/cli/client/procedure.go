@@ -116,6 +116,13 @@ func procedureStatus(name string, status domain.LockStatus, consoles map[string]
 }
 
 func printProcedureRows(rows []procedureRow) error {
+	if outputJSON() {
+		out := make([]map[string]string, 0, len(rows))
+		for _, row := range rows {
+			out = append(out, map[string]string{"command": row.command, "procedure": row.procedure, "status": row.status, "console": row.console})
+		}
+		return printJSON(out)
+	}
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "COMMAND\tPROCEDURE\tSTATUS\tCONSOLE")
 	for _, row := range rows {
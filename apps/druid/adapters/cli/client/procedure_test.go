@@ -108,6 +108,38 @@ func (f *fakeProcedureDaemon) UpdateScroll(ctx context.Context, id string, artif
 	return nil, nil
 }
 
+func (f *fakeProcedureDaemon) RollbackScroll(ctx context.Context, id string, to *int, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) SetScrollUpdatePolicy(ctx context.Context, id string, policy *api.RuntimeUpdatePolicy) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) SetScrollGrant(ctx context.Context, id string, subject string, grant *api.RuntimeGrantRequest) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) CreateAPIKey(ctx context.Context, request api.CreateAPIKeyRequest) (*api.CreatedAPIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) ListAPIKeys(ctx context.Context) ([]api.RuntimeAPIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) RevokeAPIKey(ctx context.Context, id string) error {
+	return nil
+}
+
+func (f *fakeProcedureDaemon) ListAudit(ctx context.Context, params api.ListAuditParams) ([]api.AuditRecord, error) {
+	return nil, nil
+}
+
+func (f *fakeProcedureDaemon) ListQuotas(ctx context.Context, params api.ListQuotasParams) ([]api.OwnerQuotaStatus, error) {
+	return nil, nil
+}
+
 func (f *fakeProcedureDaemon) ListScrolls(ctx context.Context) ([]api.RuntimeScroll, error) {
 	return nil, nil
 }
@@ -136,6 +168,10 @@ func (f *fakeProcedureDaemon) GetScrollPorts(ctx context.Context, id string) ([]
 	return nil, nil
 }
 
+func (f *fakeProcedureDaemon) GetScrollEvents(ctx context.Context, id string) ([]api.RuntimeEvent, error) {
+	return nil, nil
+}
+
 func (f *fakeProcedureDaemon) StartScroll(ctx context.Context, id string) (*api.RuntimeScroll, error) {
 	return nil, nil
 }
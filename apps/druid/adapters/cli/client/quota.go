@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var quotaOwner string
+
+var QuotaCommand = &cobra.Command{
+	Use:   "quota",
+	Short: "Show owner quotas and their usage",
+	Long: `Show the limits of the daemon quota policy next to what each owner uses.
+Token subjects see their own quota; operators and local callers see every
+owner unless --owner picks one.`,
+	Example: `  druid quota
+  druid quota --owner user-123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := api.ListQuotasParams{}
+		if quotaOwner != "" {
+			params.Owner = &quotaOwner
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		quotas, err := daemon.ListQuotas(cmd.Context(), params)
+		if err != nil {
+			return err
+		}
+		if outputJSON() {
+			return printJSON(quotas)
+		}
+		return printQuotas(quotas)
+	},
+}
+
+func init() {
+	QuotaCommand.Flags().StringVar(&quotaOwner, "owner", "", "Only the quota of this owner")
+}
+
+func printQuotas(quotas []api.OwnerQuotaStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OWNER\tSCROLLS\tRUNNING\tCPU\tMEMORY\tDISK\tREPOSITORIES")
+	for _, quota := range quotas {
+		limits, usage := quota.Limits, quota.Usage
+		repositories := "*"
+		if limits.AllowedRepositories != nil && len(*limits.AllowedRepositories) > 0 {
+			repositories = strings.Join(*limits.AllowedRepositories, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", quota.Owner,
+			quotaCount(usage.Scrolls, limits.MaxScrolls),
+			quotaCount(usage.RunningCommands, limits.MaxRunningCommands),
+			quotaAmount(usage.Resources.Cpu, limits.MaxCpu),
+			quotaAmount(usage.Resources.Memory, limits.MaxMemory),
+			quotaAmount(usage.Resources.Disk, limits.MaxDisk),
+			repositories)
+	}
+	return w.Flush()
+}
+
+func quotaCount(used int, limit *int) string {
+	if limit == nil || *limit == 0 {
+		return fmt.Sprintf("%d", used)
+	}
+	return fmt.Sprintf("%d/%d", used, *limit)
+}
+
+func quotaAmount(used *string, limit *string) string {
+	value := "0"
+	if used != nil && *used != "" {
+		value = *used
+	}
+	if limit == nil || *limit == "" {
+		return value
+	}
+	return value + "/" + *limit
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/highcard-dev/daemon/internal/api"
 	"github.com/highcard-dev/daemon/internal/core/domain"
@@ -12,6 +13,9 @@ import (
 type RuntimeDaemon interface {
 	CreateScroll(ctx context.Context, name string, artifact string, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error)
 	UpdateScroll(ctx context.Context, id string, artifact string, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error)
+	SetScrollUpdatePolicy(ctx context.Context, id string, policy *api.RuntimeUpdatePolicy) (*api.RuntimeScroll, error)
+	SetScrollGrant(ctx context.Context, id string, subject string, grant *api.RuntimeGrantRequest) (*api.RuntimeScroll, error)
+	RollbackScroll(ctx context.Context, id string, to *int, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error)
 	ListScrolls(ctx context.Context) ([]api.RuntimeScroll, error)
 	GetScroll(ctx context.Context, id string) (*api.RuntimeScroll, error)
 	DeleteScroll(ctx context.Context, id string) (*api.DeletedScroll, error)
@@ -19,18 +23,29 @@ type RuntimeDaemon interface {
 	GetScrollQueue(ctx context.Context, id string) (domain.ProcedureStatusMap, error)
 	GetScrollConsoles(ctx context.Context, id string) (map[string]domain.Console, error)
 	GetScrollPorts(ctx context.Context, id string) ([]api.RuntimePortStatus, error)
+	GetScrollEvents(ctx context.Context, id string) ([]api.RuntimeEvent, error)
 	StartScroll(ctx context.Context, id string) (*api.RuntimeScroll, error)
 	StopScroll(ctx context.Context, id string) (*api.RuntimeScroll, error)
 	GetScrollRoutingTargets(ctx context.Context, id string) ([]api.RuntimeRoutingTarget, error)
 	ApplyScrollRouting(ctx context.Context, id string, assignments []api.RuntimeRouteAssignment) (*api.RuntimeScroll, error)
 	GetScrollUIPackages(ctx context.Context, id string) (map[string]api.RuntimeUIPackage, error)
 	PublishScrollUIPackage(ctx context.Context, id string, scope string, path string) (*api.RuntimeScroll, error)
+	CreateAPIKey(ctx context.Context, request api.CreateAPIKeyRequest) (*api.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]api.RuntimeAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	ListAudit(ctx context.Context, params api.ListAuditParams) ([]api.AuditRecord, error)
+	ListQuotas(ctx context.Context, params api.ListQuotasParams) ([]api.OwnerQuotaStatus, error)
 }
 
 type Config struct {
-	Daemon              func() (RuntimeDaemon, error)
-	AttachConsole       func(ctx context.Context, scroll string, console string) error
-	RegistryCredentials func() []api.RegistryCredential
+	Daemon        func() (RuntimeDaemon, error)
+	AttachConsole func(ctx context.Context, scroll string, console string) error
+	FollowEvents  func(ctx context.Context, scroll string, out io.Writer) error
+	// RegistryCredentials returns the credentials the daemon needs to pull
+	// artifact, and no others.
+	RegistryCredentials func(artifact string) []api.RegistryCredential
+	// Output is the format of list commands, table or json.
+	Output func() string
 }
 
 var config Config
@@ -39,28 +54,43 @@ func Register(root *cobra.Command, cfg Config) {
 	config = cfg
 	RoutingCommand.AddCommand(RoutingTargetsCommand, RoutingApplyCommand)
 	ProcedureCommand.AddCommand(ProcedureListCommand, ProcedureAttachCommand)
+	APIKeyCommand.AddCommand(APIKeyCreateCommand, APIKeyListCommand, APIKeyRevokeCommand)
 	root.AddCommand(
+		APIKeyCommand,
+		AuditCommand,
 		CreateCommand,
 		DeleteCommand,
 		DescribeCommand,
+		EventsCommand,
+		GrantCommand,
 		ListCommand,
 		PortsCommand,
 		ProcedureCommand,
+		QuotaCommand,
 		StartCommand,
 		StopCommand,
+		RollbackCommand,
 		RoutingCommand,
 		UpdateCommand,
+		UpdatePolicyCommand,
 	)
 }
 
 func RegistryCredentials(in []domain.RegistryCredential) []api.RegistryCredential {
 	out := make([]api.RegistryCredential, 0, len(in))
 	for _, credential := range in {
-		out = append(out, api.RegistryCredential{
+		item := api.RegistryCredential{
 			Host:     credential.Host,
 			Username: credential.Username,
 			Password: credential.Password,
-		})
+		}
+		if credential.IdentityToken != "" {
+			item.IdentityToken = &credential.IdentityToken
+		}
+		if credential.RegistryToken != "" {
+			item.RegistryToken = &credential.RegistryToken
+		}
+		out = append(out, item)
 	}
 	return out
 }
@@ -72,9 +102,25 @@ func runtimeDaemonClient() (RuntimeDaemon, error) {
 	return config.Daemon()
 }
 
-func registryCredentials() []api.RegistryCredential {
-	if config.RegistryCredentials == nil {
+func registryCredentials(artifact string) []api.RegistryCredential {
+	if config.RegistryCredentials == nil || artifact == "" {
 		return nil
 	}
-	return config.RegistryCredentials()
+	return config.RegistryCredentials(artifact)
+}
+
+// runtimeRegistryCredentials returns the credentials for pulling artifact,
+// or the current artifact of runtime id when artifact is empty.
+func runtimeRegistryCredentials(ctx context.Context, daemon RuntimeDaemon, id string, artifact string) ([]api.RegistryCredential, error) {
+	if config.RegistryCredentials == nil {
+		return nil, nil
+	}
+	if artifact == "" {
+		scroll, err := daemon.GetScroll(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		artifact = scroll.Artifact
+	}
+	return registryCredentials(artifact), nil
 }
@@ -0,0 +1,42 @@
+package client
+
+import "github.com/spf13/cobra"
+
+var rollbackTo int
+
+var RollbackCommand = &cobra.Command{
+	Use:   "rollback <name>",
+	Short: "Roll a scroll runtime back to an earlier artifact digest",
+	Long: `Roll a scroll runtime back to an earlier artifact digest.
+
+Without --to the runtime goes back to the most recent deployment in its
+update_history with a different digest. --to picks an entry of update_history
+by index, oldest first, as shown by druid describe. Data in skip_update chunks
+is kept, as with druid update.`,
+	Example: `  druid rollback my-server
+  druid rollback my-server --to 0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var to *int
+		if cmd.Flags().Changed("to") {
+			to = &rollbackTo
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		credentials, err := runtimeRegistryCredentials(cmd.Context(), daemon, args[0], "")
+		if err != nil {
+			return err
+		}
+		scroll, err := daemon.RollbackScroll(cmd.Context(), args[0], to, credentials)
+		if err != nil {
+			return err
+		}
+		return printJSON(scroll)
+	},
+}
+
+func init() {
+	RollbackCommand.Flags().IntVar(&rollbackTo, "to", 0, "Index of the update_history entry to roll back to")
+}
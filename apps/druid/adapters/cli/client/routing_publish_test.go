@@ -148,6 +148,38 @@ func (f *fakeRoutingDaemon) UpdateScroll(ctx context.Context, id string, artifac
 	return &api.RuntimeScroll{Id: id, Artifact: artifact, Root: "/root", ScrollName: id, Status: api.RuntimeScrollStatusCreated}, nil
 }
 
+func (f *fakeRoutingDaemon) RollbackScroll(ctx context.Context, id string, to *int, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) SetScrollUpdatePolicy(ctx context.Context, id string, policy *api.RuntimeUpdatePolicy) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) SetScrollGrant(ctx context.Context, id string, subject string, grant *api.RuntimeGrantRequest) (*api.RuntimeScroll, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) CreateAPIKey(ctx context.Context, request api.CreateAPIKeyRequest) (*api.CreatedAPIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) ListAPIKeys(ctx context.Context) ([]api.RuntimeAPIKey, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) RevokeAPIKey(ctx context.Context, id string) error {
+	return nil
+}
+
+func (f *fakeRoutingDaemon) ListAudit(ctx context.Context, params api.ListAuditParams) ([]api.AuditRecord, error) {
+	return nil, nil
+}
+
+func (f *fakeRoutingDaemon) ListQuotas(ctx context.Context, params api.ListQuotasParams) ([]api.OwnerQuotaStatus, error) {
+	return nil, nil
+}
+
 func (f *fakeRoutingDaemon) ListScrolls(ctx context.Context) ([]api.RuntimeScroll, error) {
 	return nil, nil
 }
@@ -176,6 +208,10 @@ func (f *fakeRoutingDaemon) GetScrollPorts(ctx context.Context, id string) ([]ap
 	return nil, nil
 }
 
+func (f *fakeRoutingDaemon) GetScrollEvents(ctx context.Context, id string) ([]api.RuntimeEvent, error) {
+	return nil, nil
+}
+
 func (f *fakeRoutingDaemon) StartScroll(ctx context.Context, id string) (*api.RuntimeScroll, error) {
 	f.startCalls++
 	return nil, nil
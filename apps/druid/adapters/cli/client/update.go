@@ -2,23 +2,47 @@ package client
 
 import "github.com/spf13/cobra"
 
+var updateFromBundle string
+
 var UpdateCommand = &cobra.Command{
 	Use:   "update <name> [artifact]",
 	Short: "Update a daemon-managed scroll runtime",
-	Args:  cobra.RangeArgs(1, 2),
+	Example: `  druid update my-scroll artifacts.example/app:v2
+  druid update my-scroll --from-bundle app-v2.tar`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if updateFromBundle != "" {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		artifact := ""
 		if len(args) == 2 {
 			artifact = args[1]
 		}
+		if updateFromBundle != "" {
+			bundle, err := bundleArtifact(updateFromBundle)
+			if err != nil {
+				return err
+			}
+			artifact = bundle
+		}
 		daemon, err := runtimeDaemonClient()
 		if err != nil {
 			return err
 		}
-		scroll, err := daemon.UpdateScroll(cmd.Context(), args[0], artifact, registryCredentials())
+		credentials, err := runtimeRegistryCredentials(cmd.Context(), daemon, args[0], artifact)
+		if err != nil {
+			return err
+		}
+		scroll, err := daemon.UpdateScroll(cmd.Context(), args[0], artifact, credentials)
 		if err != nil {
 			return err
 		}
 		return printJSON(scroll)
 	},
 }
+
+func init() {
+	UpdateCommand.Flags().StringVar(&updateFromBundle, "from-bundle", "", "Update from a bundle written by druid bundle export; its images must already be loaded into the backend")
+}
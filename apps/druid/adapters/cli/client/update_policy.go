@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var updatePolicyPinned bool
+var updatePolicySemver string
+var updatePolicyChannel string
+var updatePolicyWindow string
+var updatePolicyClear bool
+
+var UpdatePolicyCommand = &cobra.Command{
+	Use:   "update-policy <name>",
+	Short: "Set how the daemon updates a scroll runtime on its own",
+	Long: `Set how the daemon updates a scroll runtime on its own.
+
+--semver follows the highest tag matching a range such as ~1.2, --channel
+follows a pull_channel entry of the runtime's scroll.yaml and --pinned keeps
+the current artifact. Updates run inside the maintenance window and are rolled
+back when the serve command fails afterwards.`,
+	Example: `  druid update-policy my-server --semver "~1.2" --window 03:00-05:00
+  druid update-policy my-server --channel stable
+  druid update-policy my-server --clear`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, err := updatePolicyFromFlags()
+		if err != nil {
+			return err
+		}
+		daemon, err := runtimeDaemonClient()
+		if err != nil {
+			return err
+		}
+		scroll, err := daemon.SetScrollUpdatePolicy(cmd.Context(), args[0], policy)
+		if err != nil {
+			return err
+		}
+		return printJSON(scroll)
+	},
+}
+
+func init() {
+	UpdatePolicyCommand.Flags().BoolVar(&updatePolicyPinned, "pinned", false, "Never update automatically")
+	UpdatePolicyCommand.Flags().StringVar(&updatePolicySemver, "semver", "", "Follow the highest tag matching this semver range")
+	UpdatePolicyCommand.Flags().StringVar(&updatePolicyChannel, "channel", "", "Follow this pull_channel of the scroll")
+	UpdatePolicyCommand.Flags().StringVar(&updatePolicyWindow, "window", "", "Maintenance window as HH:MM-HH:MM in daemon local time")
+	UpdatePolicyCommand.Flags().BoolVar(&updatePolicyClear, "clear", false, "Remove the update policy")
+}
+
+func updatePolicyFromFlags() (*api.RuntimeUpdatePolicy, error) {
+	modes := 0
+	for _, set := range []bool{updatePolicyPinned, updatePolicySemver != "", updatePolicyChannel != "", updatePolicyClear} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return nil, fmt.Errorf("pass exactly one of --pinned, --semver, --channel or --clear")
+	}
+	if updatePolicyClear {
+		if updatePolicyWindow != "" {
+			return nil, fmt.Errorf("--window cannot be combined with --clear")
+		}
+		return nil, nil
+	}
+	policy := &api.RuntimeUpdatePolicy{Mode: api.Pinned}
+	switch {
+	case updatePolicySemver != "":
+		policy.Mode = api.Semver
+		policy.Constraint = &updatePolicySemver
+	case updatePolicyChannel != "":
+		policy.Mode = api.Channel
+		policy.Channel = &updatePolicyChannel
+	}
+	if updatePolicyWindow != "" {
+		policy.Window = &updatePolicyWindow
+	}
+	return policy, nil
+}
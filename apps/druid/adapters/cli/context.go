@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var contextAdd domain.DaemonContext
+var contextAddUse bool
+
+var ContextCommand = &cobra.Command{
+	Use:   "context",
+	Short: "Manage named daemon contexts",
+	Long: `Contexts name the daemons the CLI talks to, so switching between a local
+Docker daemon and remote ones does not need --daemon-* flags. They are kept
+under contexts in ~/.druid.yaml; current_context is the one in use unless
+--context or DRUID_CONTEXT picks another. --daemon-* flags still override
+the active context.`,
+}
+
+var contextAddCommand = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a context",
+	Example: `  druid context add local --socket /run/druid/runtime.sock
+  druid context add staging --url https://druid.staging.example:8081 --ca staging-ca.pem --auth oidc --use
+  druid context add prod --url https://druid.example:8081 --auth token --token-env DRUID_PROD_TOKEN --namespace games`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		added := contextAdd
+		added.Name = args[0]
+		if err := added.Validate(); err != nil {
+			return err
+		}
+		contexts, err := loadDaemonContexts()
+		if err != nil {
+			return err
+		}
+		verb := "Added"
+		if existing := domain.FindDaemonContext(contexts, added.Name); existing != nil {
+			*existing = added
+			verb = "Replaced"
+		} else {
+			contexts = append(contexts, added)
+		}
+		viper.Set("contexts", contexts)
+		if contextAddUse {
+			viper.Set("current_context", added.Name)
+		}
+		if err := viper.WriteConfig(); err != nil {
+			return err
+		}
+		if added.Token != "" {
+			if err := os.Chmod(viper.ConfigFileUsed(), 0600); err != nil {
+				return err
+			}
+		}
+		cmd.Printf("%s context %s\n", verb, added.Name)
+		if added.Token != "" {
+			cmd.PrintErrln("WARNING: the token is stored unencrypted in " + viper.ConfigFileUsed() + "; use --token-env to keep it out of the file")
+		}
+		return nil
+	},
+}
+
+var contextUseCommand = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a context the current one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contexts, err := loadDaemonContexts()
+		if err != nil {
+			return err
+		}
+		if domain.FindDaemonContext(contexts, args[0]) == nil {
+			return fmt.Errorf("context %q not found", args[0])
+		}
+		viper.Set("current_context", args[0])
+		if err := viper.WriteConfig(); err != nil {
+			return err
+		}
+		cmd.Printf("Switched to context %s\n", args[0])
+		return nil
+	},
+}
+
+var contextListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List contexts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contexts, err := loadDaemonContexts()
+		if err != nil {
+			return err
+		}
+		current := viper.GetString("current_context")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CURRENT\tNAME\tTARGET\tAUTH\tNAMESPACE\tOUTPUT")
+		for _, daemonContext := range contexts {
+			marker := ""
+			if daemonContext.Name == current {
+				marker = "*"
+			}
+			target := daemonContext.Target()
+			if daemonContext.Socket == "" && daemonContext.URL == "" {
+				target = "(default socket)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", marker, daemonContext.Name, target, orDash(string(daemonContext.Auth)), orDash(daemonContext.Namespace), orDash(daemonContext.Output))
+		}
+		return w.Flush()
+	},
+}
+
+var contextDeleteCommand = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contexts, err := loadDaemonContexts()
+		if err != nil {
+			return err
+		}
+		kept := make([]domain.DaemonContext, 0, len(contexts))
+		for _, daemonContext := range contexts {
+			if daemonContext.Name != args[0] {
+				kept = append(kept, daemonContext)
+			}
+		}
+		if len(kept) == len(contexts) {
+			return fmt.Errorf("context %q not found", args[0])
+		}
+		viper.Set("contexts", kept)
+		if viper.GetString("current_context") == args[0] {
+			viper.Set("current_context", "")
+		}
+		if err := viper.WriteConfig(); err != nil {
+			return err
+		}
+		cmd.Printf("Deleted context %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	flags := contextAddCommand.Flags()
+	flags.StringVar(&contextAdd.URL, "url", "", "Daemon HTTP(S) URL")
+	flags.StringVar(&contextAdd.Socket, "socket", "", "Daemon Unix socket path")
+	flags.StringVar((*string)(&contextAdd.Auth), "auth", "", "How to authenticate: token, oidc or none (default: a druid auth login if there is one)")
+	flags.StringVar(&contextAdd.Token, "token", "", "Bearer token or API key for --auth token; stored in plain text")
+	flags.StringVar(&contextAdd.TokenEnv, "token-env", "", "Environment variable holding the token for --auth token")
+	flags.StringVar(&contextAdd.CA, "ca", "", "PEM CA file trusted for an https URL")
+	flags.StringVar(&contextAdd.Cert, "cert", "", "PEM client certificate for mTLS")
+	flags.StringVar(&contextAdd.Key, "key", "", "PEM key of --cert")
+	flags.StringVar(&contextAdd.Namespace, "namespace", "", "Kubernetes namespace new scrolls are created in")
+	flags.StringVar(&contextAdd.Output, "output", "", "Output format of list commands: table or json")
+	flags.BoolVar(&contextAddUse, "use", false, "Make the context the current one")
+
+	ContextCommand.AddCommand(contextAddCommand, contextUseCommand, contextListCommand, contextDeleteCommand)
+	RootCmd.AddCommand(ContextCommand)
+}
+
+func loadDaemonContexts() ([]domain.DaemonContext, error) {
+	var contexts []domain.DaemonContext
+	if err := viper.UnmarshalKey("contexts", &contexts); err != nil {
+		return nil, fmt.Errorf("invalid contexts in %s: %w", viper.ConfigFileUsed(), err)
+	}
+	return contexts, nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
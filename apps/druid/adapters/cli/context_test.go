@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/spf13/viper"
+)
+
+func setDaemonContexts(t *testing.T, current string, contexts ...domain.DaemonContext) {
+	t.Helper()
+	viper.Set("contexts", contexts)
+	viper.Set("current_context", current)
+	t.Cleanup(viper.Reset)
+}
+
+func setRootFlag(t *testing.T, name string, value string) {
+	t.Helper()
+	flag := RootCmd.PersistentFlags().Lookup(name)
+	previous := flag.Value.String()
+	if err := flag.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+	flag.Changed = true
+	t.Cleanup(func() {
+		_ = flag.Value.Set(previous)
+		flag.Changed = false
+	})
+}
+
+func TestActiveDaemonContextAppliesFlagsOnTop(t *testing.T) {
+	setDaemonContexts(t, "staging",
+		domain.DaemonContext{Name: "local", Socket: "/run/druid/runtime.sock"},
+		domain.DaemonContext{Name: "staging", URL: "https://druid.staging.example:8081/", Auth: domain.DaemonAuthOIDC, CA: "staging-ca.pem", Namespace: "games", Output: "json"},
+	)
+
+	active, err := activeDaemonContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.Name != "staging" || active.URL != "https://druid.staging.example:8081" || active.Socket != "" || active.CA != "staging-ca.pem" || active.Namespace != "games" || active.Output != "json" {
+		t.Fatalf("active = %#v", active)
+	}
+	if daemonLoginKey(active) != "staging" {
+		t.Fatalf("login key = %q", daemonLoginKey(active))
+	}
+
+	setRootFlag(t, "namespace", "other")
+	setRootFlag(t, "output", "table")
+	active, err = activeDaemonContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.Name != "staging" || active.Namespace != "other" || active.Output != "table" {
+		t.Fatalf("flags were not applied: %#v", active)
+	}
+
+	setRootFlag(t, "context", "local")
+	active, err = activeDaemonContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.Name != "local" || active.Socket != "/run/druid/runtime.sock" || active.URL != "" {
+		t.Fatalf("--context local = %#v", active)
+	}
+
+	setRootFlag(t, "daemon-url", "https://druid.example")
+	active, err = activeDaemonContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.Name != "" || active.URL != "https://druid.example" || active.Socket != "" || daemonLoginKey(active) != "https://druid.example" {
+		t.Fatalf("--daemon-url should leave the context behind: %#v", active)
+	}
+}
+
+func TestActiveDaemonContextRejectsUnknownContext(t *testing.T) {
+	setDaemonContexts(t, "gone")
+	if _, err := activeDaemonContext(); err == nil {
+		t.Fatal("an unknown current_context was accepted")
+	}
+}
+
+func TestDaemonBearerTokenFollowsContextAuth(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DRUID_PROD_TOKEN", "druid_secret")
+
+	token, err := daemonBearerToken(domain.DaemonContext{Name: "prod", Auth: domain.DaemonAuthToken, TokenEnv: "DRUID_PROD_TOKEN"})
+	if err != nil || token != "druid_secret" {
+		t.Fatalf("token = %q, err = %v", token, err)
+	}
+	if token, err := daemonBearerToken(domain.DaemonContext{Name: "local", Socket: "/run/druid/runtime.sock"}); err != nil || token != "" {
+		t.Fatalf("auto without login: token = %q, err = %v", token, err)
+	}
+	if _, err := daemonBearerToken(domain.DaemonContext{Name: "staging", Auth: domain.DaemonAuthOIDC}); err == nil {
+		t.Fatal("auth oidc without a login should fail")
+	}
+}
+
+func TestDaemonTokenFlagOverridesContextTokenEnv(t *testing.T) {
+	t.Setenv("DRUID_PROD_TOKEN", "druid_context")
+	setDaemonContexts(t, "prod", domain.DaemonContext{Name: "prod", URL: "https://druid.example:8081", Auth: domain.DaemonAuthToken, TokenEnv: "DRUID_PROD_TOKEN"})
+	setRootFlag(t, "daemon-token", "druid_flag")
+
+	active, err := activeDaemonContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, err := daemonBearerToken(active); err != nil || token != "druid_flag" {
+		t.Fatalf("token = %q, err = %v", token, err)
+	}
+}
+
+func TestContextAddKeepsStoredTokenPrivate(t *testing.T) {
+	config := filepath.Join(t.TempDir(), ".druid.yaml")
+	if err := os.WriteFile(config, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.SetConfigFile(config)
+	t.Cleanup(viper.Reset)
+	previous := contextAdd
+	contextAdd = domain.DaemonContext{URL: "https://druid.example:8081", Auth: domain.DaemonAuthToken, Token: "druid_secret"}
+	t.Cleanup(func() { contextAdd = previous })
+
+	contextAddCommand.SetErr(io.Discard)
+	contextAddCommand.SetOut(io.Discard)
+	if err := contextAddCommand.RunE(contextAddCommand, []string{"prod"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Fatalf("config mode = %o, want 600", mode)
+	}
+}
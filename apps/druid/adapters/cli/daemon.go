@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -12,9 +14,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 	runtimehandlers "github.com/highcard-dev/daemon/apps/druid/adapters/http/handlers"
 	appservices "github.com/highcard-dev/daemon/apps/druid/core/services"
+	constants "github.com/highcard-dev/daemon/internal"
 	"github.com/highcard-dev/daemon/internal/callbackapi"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 	"github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	runtimebackend "github.com/highcard-dev/daemon/internal/runtime"
 	runtimedocker "github.com/highcard-dev/daemon/internal/runtime/docker"
 	runtimekubernetes "github.com/highcard-dev/daemon/internal/runtime/kubernetes"
@@ -45,10 +50,33 @@ var runtimePublicListen string
 var runtimeAllowUnauthenticatedPublic bool
 var runtimeAllowUnauthenticatedManagement bool
 var runtimeWorkerTimeout time.Duration
+var runtimeIdleCheckInterval time.Duration
+var runtimeAutoUpdateInterval time.Duration
+var runtimeAuditRetention time.Duration
+var runtimeUpdateWindow string
+var runtimeUpdateHealthTimeout time.Duration
 var runtimeWorkerCallbackListen string
 var runtimeWorkerCallbackURL string
+var runtimeTLSCert string
+var runtimeTLSKey string
+var runtimeTLSClientCA string
+var runtimeTLSClientAllowedCNs []string
+var runtimePublicTLSCert string
+var runtimePublicTLSKey string
+var runtimeCallbackTLSCert string
+var runtimeCallbackTLSKey string
+var runtimeCallbackTLSCA string
 var runtimeAuthJWKSURL string
 var runtimePublicJWKSURL string
+var runtimeOIDCIssuer string
+var runtimeOIDCClientID string
+var runtimeOIDCScopes []string
+var runtimeVerifyKeys []string
+var runtimeTrustPolicy string
+var runtimeQuotaPolicy string
+var runtimeAdmissionPolicy string
+var runtimeConsoleLimits = domain.DefaultConsoleLimits()
+var runtimePublicPolicy string
 var dockerWorkerImage string
 var dockerStorage string
 var dockerBindRoot string
@@ -80,10 +108,37 @@ func init() {
 	DaemonCommand.Flags().BoolVar(&runtimeAllowUnauthenticatedPublic, "unsafe-allow-unauthenticated-public", false, "Allow unauthenticated public HTTP routes without --auth-jwks-url")
 	DaemonCommand.Flags().BoolVar(&runtimeAllowUnauthenticatedManagement, "unsafe-allow-unauthenticated-management", false, "Allow unauthenticated management HTTP routes; local Docker development only")
 	DaemonCommand.Flags().DurationVar(&runtimeWorkerTimeout, "worker-timeout", 20*time.Minute, "Maximum time for runtime materialization workers")
+	DaemonCommand.Flags().DurationVar(&runtimeIdleCheckInterval, "idle-check-interval", 30*time.Second, "How often Docker serve commands are checked against keepAliveTraffic; 0 disables idle stop and wake")
+	DaemonCommand.Flags().DurationVar(&runtimeAutoUpdateInterval, "auto-update-interval", 15*time.Minute, "How often scrolls with an update policy are checked for new versions; 0 disables auto-updates (default: DRUID_AUTO_UPDATE_INTERVAL)")
+	DaemonCommand.Flags().DurationVar(&runtimeAuditRetention, "audit-retention", 90*24*time.Hour, "How long audit records are kept; 0 keeps them forever (default: DRUID_AUDIT_RETENTION)")
+	DaemonCommand.Flags().StringVar(&runtimeUpdateWindow, "update-window", "", "Default maintenance window for auto-updates as HH:MM-HH:MM in local time; empty allows any time (default: DRUID_UPDATE_WINDOW)")
+	DaemonCommand.Flags().DurationVar(&runtimeUpdateHealthTimeout, "update-health-timeout", 2*time.Minute, "How long the serve command must survive an auto-update before it is kept (default: DRUID_UPDATE_HEALTH_TIMEOUT)")
+	DaemonCommand.Flags().IntVar(&runtimeConsoleLimits.MaxInputBytes, "console-max-input-bytes", runtimeConsoleLimits.MaxInputBytes, "Largest console websocket input message; 0 is unlimited (default: DRUID_CONSOLE_MAX_INPUT_BYTES)")
+	DaemonCommand.Flags().IntVar(&runtimeConsoleLimits.InputRate, "console-input-rate", runtimeConsoleLimits.InputRate, "Console input messages per second per connection; 0 is unlimited (default: DRUID_CONSOLE_INPUT_RATE)")
+	DaemonCommand.Flags().IntVar(&runtimeConsoleLimits.InputBurst, "console-input-burst", runtimeConsoleLimits.InputBurst, "Console input messages a connection may send at once (default: DRUID_CONSOLE_INPUT_BURST)")
+	DaemonCommand.Flags().IntVar(&runtimeConsoleLimits.MaxViewers, "console-max-viewers", runtimeConsoleLimits.MaxViewers, "Websocket connections per console; 0 is unlimited (default: DRUID_CONSOLE_MAX_VIEWERS)")
+	DaemonCommand.Flags().DurationVar(&runtimeConsoleLimits.IdleTimeout, "console-idle-timeout", 0, "Disconnect console websockets without input for this long; 0 never does (default: DRUID_CONSOLE_IDLE_TIMEOUT)")
 	DaemonCommand.Flags().StringVar(&runtimeWorkerCallbackListen, "worker-callback-listen", "", "Optional internal worker callback listen address, for example :8083")
 	DaemonCommand.Flags().StringVar(&runtimeWorkerCallbackURL, "worker-callback-url", "", "URL workers use to call back to this daemon")
+	DaemonCommand.Flags().StringVar(&runtimeTLSCert, "tls-cert", "", "PEM certificate for the --listen management listener; reloaded on change (default: DRUID_TLS_CERT)")
+	DaemonCommand.Flags().StringVar(&runtimeTLSKey, "tls-key", "", "PEM key of --tls-cert (default: DRUID_TLS_KEY)")
+	DaemonCommand.Flags().StringVar(&runtimeTLSClientCA, "tls-client-ca", "", "PEM CAs management clients must present a certificate from (mTLS) (default: DRUID_TLS_CLIENT_CA)")
+	DaemonCommand.Flags().StringSliceVar(&runtimeTLSClientAllowedCNs, "tls-client-allowed-cn", nil, "Common name of client certificates that act as operators; other certificates act as the subject cert:<common name>; repeatable (default: DRUID_TLS_CLIENT_ALLOWED_CN)")
+	DaemonCommand.Flags().StringVar(&runtimePublicTLSCert, "public-tls-cert", "", "PEM certificate for --public-listen; defaults to --tls-cert (default: DRUID_PUBLIC_TLS_CERT)")
+	DaemonCommand.Flags().StringVar(&runtimePublicTLSKey, "public-tls-key", "", "PEM key of --public-tls-cert (default: DRUID_PUBLIC_TLS_KEY)")
+	DaemonCommand.Flags().StringVar(&runtimeCallbackTLSCert, "worker-callback-tls-cert", "", "PEM certificate for the worker callback listener (default: DRUID_WORKER_CALLBACK_TLS_CERT)")
+	DaemonCommand.Flags().StringVar(&runtimeCallbackTLSKey, "worker-callback-tls-key", "", "PEM key of --worker-callback-tls-cert (default: DRUID_WORKER_CALLBACK_TLS_KEY)")
+	DaemonCommand.Flags().StringVar(&runtimeCallbackTLSCA, "worker-callback-tls-ca", "", "PEM CA workers trust for callbacks; defaults to the callback certificate (default: DRUID_WORKER_CALLBACK_TLS_CA)")
 	DaemonCommand.Flags().StringVar(&runtimeAuthJWKSURL, "auth-jwks-url", "", "JWKS URL used to validate customer JWTs")
+	DaemonCommand.Flags().StringVar(&runtimeOIDCIssuer, "oidc-issuer", "", "OIDC issuer whose ID tokens the management API accepts and druid auth login uses (default: DRUID_OIDC_ISSUER)")
+	DaemonCommand.Flags().StringVar(&runtimeOIDCClientID, "oidc-client-id", "", "OIDC client ID of the CLI; ID tokens must be issued to it (default: DRUID_OIDC_CLIENT_ID)")
+	DaemonCommand.Flags().StringSliceVar(&runtimeOIDCScopes, "oidc-scopes", nil, "Scopes druid auth login requests (default: DRUID_OIDC_SCOPES or openid,offline_access)")
 	DaemonCommand.Flags().StringVar(&runtimePublicJWKSURL, "public-jwks-url", "", "Public JWKS URL workers use to validate daemon runtime tokens")
+	DaemonCommand.Flags().StringSliceVar(&runtimeVerifyKeys, "verify-key", nil, "PEM public key scroll artifacts must be signed with; repeatable (default: DRUID_VERIFY_KEY)")
+	DaemonCommand.Flags().StringVar(&runtimeTrustPolicy, "trust-policy", "", "YAML file with per-repo signature trust rules (default: DRUID_TRUST_POLICY)")
+	DaemonCommand.Flags().StringVar(&runtimeQuotaPolicy, "quota-policy", "", "YAML file with per-owner scroll, command and resource quotas (default: DRUID_QUOTA_POLICY)")
+	DaemonCommand.Flags().StringVar(&runtimeAdmissionPolicy, "admission-policy", "", "YAML file with image, port, mount and TTY rules every scroll must pass (default: DRUID_ADMISSION_POLICY)")
+	DaemonCommand.Flags().StringVar(&runtimePublicPolicy, "public-policy", "", "YAML file with CORS, rate limits and enabled routes of --public-listen; reloaded on SIGHUP (default: DRUID_PUBLIC_POLICY)")
 	DaemonCommand.Flags().StringVar(&dockerWorkerImage, "docker-worker-image", "", "Docker image used for sibling worker containers (default: DRUID_DOCKER_WORKER_IMAGE)")
 	DaemonCommand.Flags().StringVar(&dockerStorage, "docker-storage", "", "Docker runtime storage mode: volume or bind (default: DRUID_DOCKER_STORAGE or volume)")
 	DaemonCommand.Flags().StringVar(&dockerBindRoot, "docker-bind-root", "", "Host root for Docker bind storage (default: DRUID_DOCKER_BIND_ROOT)")
@@ -148,9 +203,56 @@ func runRuntimeDaemon() error {
 	supervisor := appservices.NewRuntimeSupervisor(runtime.Store, manager, runtime.Backend)
 	callbacks := appservices.NewWorkerCallbackManager()
 	supervisor.SetWorkerTimeout(runtimeWorkerTimeout)
+	trustPolicy, err := registry.LoadScrollTrustPolicy(runtimeTrustPolicy, runtimeVerifyKeys)
+	if err != nil {
+		return err
+	}
+	supervisor.SetRegistryCredentials(loadRegistryStore().ResolvedCredentials())
+	registryConfig, err := loadRegistryConfig()
+	if err != nil {
+		return err
+	}
+	registryConfig, err = registry.ResolveRegistryConfig(registryConfig)
+	if err != nil {
+		return err
+	}
+	supervisor.SetRegistryConfig(registryConfig)
+	supervisor.SetUpdateHealthTimeout(runtimeUpdateHealthTimeout)
+	if runtimeUpdateWindow != "" {
+		window, err := domain.ParseMaintenanceWindow(runtimeUpdateWindow)
+		if err != nil {
+			return err
+		}
+		supervisor.SetUpdateWindow(&window)
+	}
+	if trustPolicy.Enabled() {
+		supervisor.SetTrustPolicy(trustPolicy)
+		logger.Log().Info("Scroll signature verification enabled", zap.Int("keys", len(trustPolicy.Keys)), zap.Int("rules", len(trustPolicy.Rules)))
+	}
+	if runtimeQuotaPolicy != "" {
+		quotaPolicy, err := appservices.LoadQuotaPolicy(runtimeQuotaPolicy)
+		if err != nil {
+			return err
+		}
+		supervisor.SetQuotaPolicy(quotaPolicy)
+		logger.Log().Info("Owner quotas enabled", zap.Int("owners", len(quotaPolicy.Owners)))
+	}
+	if runtimeAdmissionPolicy != "" {
+		admissionPolicy, err := appservices.LoadScrollAdmissionPolicy(runtimeAdmissionPolicy)
+		if err != nil {
+			return err
+		}
+		supervisor.SetAdmissionPolicy(admissionPolicy)
+		logger.Log().Info("Scroll admission policy enabled", zap.String("path", runtimeAdmissionPolicy))
+	}
+	tlsConfigs, err := loadRuntimeTLS()
+	if err != nil {
+		return err
+	}
 	callbackConfig := ports.RuntimeWorkerCallbackConfig{
 		Listen: runtimeWorkerCallbackListen,
 		URL:    runtimeWorkerCallbackURL,
+		TLS:    tlsConfigs.callback != nil,
 	}
 	callbackBackend, _ := runtime.Backend.(ports.RuntimeWorkerCallbackBackend)
 	if callbackBackend != nil {
@@ -173,9 +275,33 @@ func runRuntimeDaemon() error {
 	runtimeWorkerCallbackListen = callbackConfig.Listen
 	runtimeWorkerCallbackURL = callbackConfig.URL
 	supervisor.SetWorkerCallbacks(callbacks, runtimeWorkerCallbackURL)
+	supervisor.SetWorkerCallbackCA(tlsConfigs.callbackCA)
+	if callbackListener != nil && tlsConfigs.callback != nil {
+		callbackListener = tls.NewListener(callbackListener, tlsConfigs.callback)
+	}
+	workloadAuthenticator, _ := runtime.Backend.(ports.RuntimeWorkloadAuthenticator)
+	var callbackAuthenticator ports.RuntimeWorkloadAuthenticator = workloadAuthenticator
+	var callbackTokens *services.RuntimeCallbackTokens
+	if callbackListener != nil {
+		callbackTokens, err = services.NewRuntimeCallbackTokens(filepath.Join(runtime.Store.StateDir(), "callback.key"), workloadAuthenticator)
+		if err != nil {
+			callbackListener.Close()
+			return err
+		}
+		supervisor.SetRuntimeCallbackTokens(callbackTokens)
+		callbackAuthenticator = callbackTokens
+	}
 	if err := supervisor.Start(); err != nil {
 		return err
 	}
+	idleCtx, stopIdle := context.WithCancel(context.Background())
+	defer stopIdle()
+	if supervisor.StartIdleController(idleCtx, runtimeIdleCheckInterval) {
+		logger.Log().Info("Idle controller started", zap.Duration("interval", runtimeIdleCheckInterval))
+	}
+	if supervisor.StartAutoUpdater(idleCtx, runtimeAutoUpdateInterval) {
+		logger.Log().Info("Auto-updater started", zap.Duration("interval", runtimeAutoUpdateInterval), zap.String("window", runtimeUpdateWindow))
+	}
 
 	authorizer, err := services.NewAuthorizer(buildJWKSURLs([]string{runtimeAuthJWKSURL}), "")
 	if err != nil {
@@ -183,26 +309,56 @@ func runRuntimeDaemon() error {
 	}
 	scrollHandler := runtimehandlers.NewScrollHandler(supervisor, consoleService, logManager, authorizer)
 	scrollHandler.SetAllowUnauthenticatedPublic(runtimeAllowUnauthenticatedPublic)
+	var apiKeys *services.APIKeyService
+	if keyStore, ok := runtime.Store.(ports.RuntimeAPIKeyStore); ok {
+		apiKeys = services.NewAPIKeyService(keyStore)
+		scrollHandler.SetAPIKeys(apiKeys)
+	}
+	if auditStore, ok := runtime.Store.(ports.RuntimeAuditStore); ok {
+		audit := services.NewAuditLog(auditStore, runtimeAuditRetention)
+		scrollHandler.SetAuditLog(audit)
+		if audit.StartPruner(idleCtx, time.Hour) {
+			logger.Log().Info("Audit log pruner started", zap.Duration("retention", runtimeAuditRetention))
+		}
+	}
 	websocketHandler := runtimehandlers.NewWebsocketHandler(consoleService)
 	websocketHandler.SetScrollHandler(scrollHandler)
 	websocketHandler.SetAuthorizer(authorizer)
 	websocketHandler.SetAllowUnauthenticatedPublic(runtimeAllowUnauthenticatedPublic)
+	websocketHandler.SetConsoleLimits(runtimeConsoleLimits)
+	discovery := domain.DaemonDiscovery{Version: constants.Version}
+	var userAuthenticator ports.RuntimeUserAuthenticator
+	if runtimeOIDCIssuer != "" {
+		oidc, err := services.NewOIDCAuthenticator(idleCtx, runtimeOIDCIssuer, runtimeOIDCClientID)
+		if err != nil {
+			return err
+		}
+		userAuthenticator = oidc
+		discovery.OIDCIssuer = runtimeOIDCIssuer
+		discovery.OIDCClientID = runtimeOIDCClientID
+		discovery.OIDCScopes = runtimeOIDCScopes
+	}
 	handlers := runtimehandlers.RouteHandlers{
 		Server: runtimehandlers.NewRuntimeServer(
 			runtimehandlers.NewHealthHandler(),
 			scrollHandler,
 		),
 		Websocket: websocketHandler,
+		Discovery: &discovery,
 	}
 
 	managementApp := fiber.New(fiber.Config{DisableStartupMessage: true, ErrorHandler: runtimehandlers.ErrorHandler})
 	managementApp.Use(runtimehandlers.RequestLogger)
-	workloadAuthenticator, _ := runtime.Backend.(ports.RuntimeWorkloadAuthenticator)
-	managementApp.Use(workloadIdentityMiddleware(workloadAuthenticator, runtimeAllowUnauthenticatedManagement))
+	managementApp.Use(workloadIdentityMiddleware(workloadAuthenticator, apiKeys, userAuthenticator, runtimeTLSClientAllowedCNs, runtimeAllowUnauthenticatedManagement))
 	runtimehandlers.RegisterManagementRoutes(managementApp, handlers)
 
 	var publicApp *fiber.App
 	if runtimePublicListen != "" {
+		publicPolicy, err := loadPublicPolicy(idleCtx)
+		if err != nil {
+			return err
+		}
+		handlers.PublicPolicy = publicPolicy
 		publicApp = fiber.New(fiber.Config{DisableStartupMessage: true, ErrorHandler: runtimehandlers.ErrorHandler})
 		publicApp.Use(runtimehandlers.RequestLogger)
 		runtimehandlers.RegisterPublicRoutes(publicApp, handlers)
@@ -212,10 +368,10 @@ func runRuntimeDaemon() error {
 		callbackAllowUnsafe := workerCallbackAllowsUnsafeFallback(workloadAuthenticator, runtimeAllowUnauthenticatedManagement)
 		callbackApp = fiber.New(fiber.Config{DisableStartupMessage: true, ErrorHandler: runtimehandlers.ErrorHandler})
 		callbackApp.Use(runtimehandlers.RequestLogger)
-		callbackApp.Use(workloadIdentityMiddleware(workloadAuthenticator, callbackAllowUnsafe))
-		callbackapi.RegisterHandlers(callbackApp, runtimeCallbackHandler{callbacks: callbacks, allowUnauthenticated: callbackAllowUnsafe})
+		callbackApp.Use(workloadIdentityMiddleware(callbackAuthenticator, nil, nil, nil, callbackAllowUnsafe))
+		callbackapi.RegisterHandlers(callbackApp, runtimeCallbackHandler{callbacks: callbacks, supervisor: supervisor, tokens: callbackTokens, allowUnauthenticated: callbackAllowUnsafe})
 	}
-	return listenRuntimeHTTP(managementApp, publicApp, callbackApp, callbackListener, runtime.Store.StateDir())
+	return listenRuntimeHTTP(managementApp, publicApp, callbackApp, callbackListener, tlsConfigs, runtime.Store.StateDir())
 }
 
 func workerCallbackAllowsUnsafeFallback(authenticator ports.RuntimeWorkloadAuthenticator, allowUnsafeManagement bool) bool {
@@ -232,9 +388,47 @@ func loadRuntimeDaemonEnv() {
 	if runtimeAuthJWKSURL == "" {
 		runtimeAuthJWKSURL = os.Getenv("DRUID_AUTH_JWKS_URL")
 	}
+	for env, value := range map[string]*string{
+		"DRUID_TLS_CERT":                 &runtimeTLSCert,
+		"DRUID_TLS_KEY":                  &runtimeTLSKey,
+		"DRUID_TLS_CLIENT_CA":            &runtimeTLSClientCA,
+		"DRUID_PUBLIC_TLS_CERT":          &runtimePublicTLSCert,
+		"DRUID_PUBLIC_TLS_KEY":           &runtimePublicTLSKey,
+		"DRUID_WORKER_CALLBACK_TLS_CERT": &runtimeCallbackTLSCert,
+		"DRUID_WORKER_CALLBACK_TLS_KEY":  &runtimeCallbackTLSKey,
+		"DRUID_WORKER_CALLBACK_TLS_CA":   &runtimeCallbackTLSCA,
+		"DRUID_OIDC_ISSUER":              &runtimeOIDCIssuer,
+		"DRUID_OIDC_CLIENT_ID":           &runtimeOIDCClientID,
+		"DRUID_QUOTA_POLICY":             &runtimeQuotaPolicy,
+		"DRUID_ADMISSION_POLICY":         &runtimeAdmissionPolicy,
+		"DRUID_PUBLIC_POLICY":            &runtimePublicPolicy,
+	} {
+		if *value == "" {
+			*value = os.Getenv(env)
+		}
+	}
 	if runtimePublicJWKSURL == "" {
 		runtimePublicJWKSURL = os.Getenv("DRUID_PUBLIC_JWKS_URL")
 	}
+	if len(runtimeVerifyKeys) == 0 {
+		if raw := strings.TrimSpace(os.Getenv("DRUID_VERIFY_KEY")); raw != "" {
+			runtimeVerifyKeys = strings.Split(raw, ",")
+		}
+	}
+	if len(runtimeTLSClientAllowedCNs) == 0 {
+		if raw := strings.TrimSpace(os.Getenv("DRUID_TLS_CLIENT_ALLOWED_CN")); raw != "" {
+			runtimeTLSClientAllowedCNs = strings.Split(raw, ",")
+		}
+	}
+	if runtimeTrustPolicy == "" {
+		runtimeTrustPolicy = os.Getenv("DRUID_TRUST_POLICY")
+	}
+	if len(runtimeOIDCScopes) == 0 {
+		runtimeOIDCScopes = []string{"openid", "offline_access"}
+		if raw := strings.TrimSpace(os.Getenv("DRUID_OIDC_SCOPES")); raw != "" {
+			runtimeOIDCScopes = strings.Split(raw, ",")
+		}
+	}
 	if !runtimeAllowUnauthenticatedPublic {
 		runtimeAllowUnauthenticatedPublic = envBool("DRUID_UNSAFE_ALLOW_UNAUTHENTICATED_PUBLIC")
 	}
@@ -244,11 +438,49 @@ func loadRuntimeDaemonEnv() {
 	if runtimeWorkerTimeout == 0 {
 		runtimeWorkerTimeout = 20 * time.Minute
 	}
+	if raw := strings.TrimSpace(os.Getenv("DRUID_IDLE_CHECK_INTERVAL")); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			runtimeIdleCheckInterval = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("DRUID_AUTO_UPDATE_INTERVAL")); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			runtimeAutoUpdateInterval = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("DRUID_AUDIT_RETENTION")); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			runtimeAuditRetention = parsed
+		}
+	}
+	if runtimeUpdateWindow == "" {
+		runtimeUpdateWindow = os.Getenv("DRUID_UPDATE_WINDOW")
+	}
+	if raw := strings.TrimSpace(os.Getenv("DRUID_UPDATE_HEALTH_TIMEOUT")); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			runtimeUpdateHealthTimeout = parsed
+		}
+	}
 	if raw := strings.TrimSpace(os.Getenv("DRUID_WORKER_TIMEOUT")); raw != "" {
 		if parsed, err := time.ParseDuration(raw); err == nil {
 			runtimeWorkerTimeout = parsed
 		}
 	}
+	for env, value := range map[string]*int{
+		"DRUID_CONSOLE_MAX_INPUT_BYTES": &runtimeConsoleLimits.MaxInputBytes,
+		"DRUID_CONSOLE_INPUT_RATE":      &runtimeConsoleLimits.InputRate,
+		"DRUID_CONSOLE_INPUT_BURST":     &runtimeConsoleLimits.InputBurst,
+		"DRUID_CONSOLE_MAX_VIEWERS":     &runtimeConsoleLimits.MaxViewers,
+	} {
+		if parsed, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env))); err == nil {
+			*value = parsed
+		}
+	}
+	if raw := strings.TrimSpace(os.Getenv("DRUID_CONSOLE_IDLE_TIMEOUT")); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			runtimeConsoleLimits.IdleTimeout = parsed
+		}
+	}
 }
 
 func envBool(name string) bool {
@@ -264,8 +496,11 @@ func validateRuntimeDaemonAuthConfig() error {
 	if runtimePublicListen != "" && runtimeAuthJWKSURL == "" && !runtimeAllowUnauthenticatedPublic {
 		return fmt.Errorf("public listener %s requires --auth-jwks-url or --unsafe-allow-unauthenticated-public", runtimePublicListen)
 	}
-	if runtimeListen != "" && runtimeBackendName != "kubernetes" && !runtimeAllowUnauthenticatedManagement {
-		return fmt.Errorf("management listener %s requires Kubernetes workload identity or --unsafe-allow-unauthenticated-management for local Docker development", runtimeListen)
+	if runtimeOIDCIssuer != "" && runtimeOIDCClientID == "" {
+		return fmt.Errorf("--oidc-issuer requires --oidc-client-id")
+	}
+	if runtimeListen != "" && runtimeBackendName != "kubernetes" && !runtimeAllowUnauthenticatedManagement && runtimeOIDCIssuer == "" {
+		return fmt.Errorf("management listener %s requires Kubernetes workload identity, --oidc-issuer or --unsafe-allow-unauthenticated-management for local Docker development", runtimeListen)
 	}
 	if runtimeListen != "" && runtimeBackendName == "kubernetes" && !runtimeAllowUnauthenticatedManagement && strings.TrimSpace(k8sOperatorServiceAccount) == "" {
 		return fmt.Errorf("Kubernetes management listener requires --k8s-operator-service-account")
@@ -273,20 +508,64 @@ func validateRuntimeDaemonAuthConfig() error {
 	if runtimeWorkerTimeout <= 0 {
 		return fmt.Errorf("worker timeout must be greater than zero")
 	}
+	if len(runtimeTLSClientAllowedCNs) > 0 && runtimeTLSClientCA == "" {
+		return fmt.Errorf("--tls-client-allowed-cn requires --tls-client-ca")
+	}
 	return nil
 }
 
-func workloadIdentityMiddleware(authenticator ports.RuntimeWorkloadAuthenticator, allowUnsafe bool) fiber.Handler {
+// workloadIdentityMiddleware authenticates management and callback requests.
+// Daemon-issued API keys and, with users set, OIDC ID tokens are accepted on
+// the API and websocket paths; the handlers then check what the key or the
+// token subject may do. Client certificates named in certOperators act as
+// operators, other verified certificates as a subject on the same paths.
+func workloadIdentityMiddleware(authenticator ports.RuntimeWorkloadAuthenticator, apiKeys *services.APIKeyService, users ports.RuntimeUserAuthenticator, certOperators []string, allowUnsafe bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		if c.Path() == "/health" || c.Path() == "/api/v1/health" {
+		if c.Path() == "/health" || c.Path() == "/api/v1/health" || c.Path() == "/.well-known/druid-configuration" {
 			return c.Next()
 		}
+		clientPath := strings.HasPrefix(c.Path(), "/api/") || strings.HasPrefix(c.Path(), "/ws/")
 		token := strings.TrimSpace(strings.TrimPrefix(c.Get("Authorization"), "Bearer "))
+		if token == "" {
+			// A client certificate verified against --tls-client-ca stands
+			// in for an operator or user token.
+			if name, ok := verifiedClientCertificate(c); ok {
+				if subject, ok := certificateSubject(name, certOperators, clientPath); ok {
+					if subject != "" {
+						c.Locals(runtimehandlers.OwnerLocal, subject)
+					}
+					c.Locals("druid-workload-identity", ports.RuntimeWorkloadIdentity{Kind: "certificate", ServiceAccount: name})
+					return c.Next()
+				}
+			}
+		}
 		if allowUnsafe && token == "" {
 			runtimeID := strings.TrimSpace(c.Get("X-Druid-Runtime-ID"))
 			c.Locals("druid-workload-identity", ports.RuntimeWorkloadIdentity{Kind: "unsafe", RuntimeID: runtimeID, PodUID: "unsafe-local"})
 			return c.Next()
 		}
+		if apiKeys != nil && strings.HasPrefix(token, domain.RuntimeAPIKeyPrefix) && clientPath {
+			key, err := apiKeys.Authenticate(token)
+			if err != nil {
+				return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+			}
+			c.Locals(runtimehandlers.APIKeyLocal, key)
+			c.Locals("druid-workload-identity", ports.RuntimeWorkloadIdentity{Kind: "apikey"})
+			return c.Next()
+		}
+		if users != nil && clientPath && strings.Count(token, ".") == 2 {
+			// Kubernetes service account tokens are JWTs as well, so a
+			// token the issuer does not vouch for falls through to them.
+			subject, err := users.AuthenticateUser(c.Context(), token)
+			if err == nil {
+				c.Locals(runtimehandlers.OwnerLocal, subject)
+				c.Locals("druid-workload-identity", ports.RuntimeWorkloadIdentity{Kind: "user"})
+				return c.Next()
+			}
+			if authenticator == nil {
+				return fiber.NewError(fiber.StatusUnauthorized, err.Error())
+			}
+		}
 		if authenticator == nil {
 			return fiber.NewError(fiber.StatusUnauthorized, "workload identity authentication is unavailable")
 		}
@@ -302,6 +581,10 @@ func workloadIdentityMiddleware(authenticator ports.RuntimeWorkloadAuthenticator
 			c.Locals("druid-workload-identity", identity)
 			return c.Next()
 		}
+		if identity.Kind == "runtime" && strings.HasPrefix(c.Path(), "/internal/v1/runtimes/"+identity.RuntimeID+"/") {
+			c.Locals("druid-workload-identity", identity)
+			return c.Next()
+		}
 		return fiber.NewError(fiber.StatusForbidden, "workload identity is not authorized for this request")
 	}
 }
@@ -313,21 +596,21 @@ func openWorkerCallbackListener(listen string) (net.Listener, error) {
 	return net.Listen("tcp", listen)
 }
 
-func listenRuntimeHTTP(managementApp *fiber.App, publicApp *fiber.App, callbackApp *fiber.App, callbackListener net.Listener, stateDir string) error {
+func listenRuntimeHTTP(managementApp *fiber.App, publicApp *fiber.App, callbackApp *fiber.App, callbackListener net.Listener, tlsConfigs runtimeTLS, stateDir string) error {
 	errCh := make(chan error, 4)
 	go func() {
 		errCh <- listenRuntimeDaemon(managementApp, stateDir)
 	}()
 	if runtimeListen != "" {
 		go func() {
-			logger.Log().Info("Starting runtime management listener", zap.String("listen", runtimeListen), zap.String("stateDir", stateDir))
-			errCh <- managementApp.Listen(runtimeListen)
+			logger.Log().Info("Starting runtime management listener", zap.String("listen", runtimeListen), zap.Bool("tls", tlsConfigs.management != nil), zap.Bool("mtls", runtimeTLSClientCA != ""), zap.String("stateDir", stateDir))
+			errCh <- listenRuntimeTCP(managementApp, runtimeListen, tlsConfigs.management)
 		}()
 	}
 	if publicApp != nil {
 		go func() {
-			logger.Log().Info("Starting runtime public listener", zap.String("listen", runtimePublicListen), zap.String("stateDir", stateDir))
-			errCh <- publicApp.Listen(runtimePublicListen)
+			logger.Log().Info("Starting runtime public listener", zap.String("listen", runtimePublicListen), zap.Bool("tls", tlsConfigs.public != nil), zap.String("stateDir", stateDir))
+			errCh <- listenRuntimeTCP(publicApp, runtimePublicListen, tlsConfigs.public)
 		}()
 	}
 	if callbackApp != nil {
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	runtimehandlers "github.com/highcard-dev/daemon/apps/druid/adapters/http/handlers"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// loadPublicPolicy reads --public-policy, or returns the defaults without
+// one. With a file it is read again on every SIGHUP until ctx ends; a file
+// that no longer parses keeps the previous policy.
+func loadPublicPolicy(ctx context.Context) (*runtimehandlers.PublicPolicy, error) {
+	if runtimePublicPolicy == "" {
+		return runtimehandlers.NewPublicPolicy(domain.DefaultPublicAPIPolicy()), nil
+	}
+	policy, err := runtimehandlers.LoadPublicAPIPolicy(runtimePublicPolicy)
+	if err != nil {
+		return nil, err
+	}
+	publicPolicy := runtimehandlers.NewPublicPolicy(policy)
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hangup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangup:
+				policy, err := runtimehandlers.LoadPublicAPIPolicy(runtimePublicPolicy)
+				if err != nil {
+					logger.Log().Error("Keeping the previous public policy", zap.String("path", runtimePublicPolicy), zap.Error(err))
+					continue
+				}
+				publicPolicy.Set(policy)
+				logger.Log().Info("Public policy reloaded", zap.String("path", runtimePublicPolicy), zap.Strings("origins", policy.CORS.AllowOrigins))
+			}
+		}
+	}()
+	return publicPolicy, nil
+}
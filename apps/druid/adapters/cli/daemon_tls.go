@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/utils"
+)
+
+// runtimeTLS holds the server TLS configs of the daemon's TCP listeners; nil
+// means plain HTTP. callbackCA is the PEM workers trust for callbacks.
+type runtimeTLS struct {
+	management *tls.Config
+	public     *tls.Config
+	callback   *tls.Config
+	callbackCA string
+}
+
+func loadRuntimeTLS() (runtimeTLS, error) {
+	var configs runtimeTLS
+	var err error
+	if runtimeTLSClientCA != "" && runtimeTLSCert == "" {
+		return configs, fmt.Errorf("--tls-client-ca needs --tls-cert and --tls-key")
+	}
+	if runtimeTLSCert != "" || runtimeTLSKey != "" {
+		if configs.management, err = utils.ServerTLSConfig(runtimeTLSCert, runtimeTLSKey, runtimeTLSClientCA); err != nil {
+			return configs, fmt.Errorf("management TLS: %w", err)
+		}
+	}
+	publicCert, publicKey := runtimePublicTLSCert, runtimePublicTLSKey
+	if publicCert == "" && publicKey == "" {
+		publicCert, publicKey = runtimeTLSCert, runtimeTLSKey
+	}
+	if publicCert != "" || publicKey != "" {
+		if configs.public, err = utils.ServerTLSConfig(publicCert, publicKey, ""); err != nil {
+			return configs, fmt.Errorf("public TLS: %w", err)
+		}
+	}
+	if runtimeCallbackTLSCert != "" || runtimeCallbackTLSKey != "" {
+		if configs.callback, err = utils.ServerTLSConfig(runtimeCallbackTLSCert, runtimeCallbackTLSKey, ""); err != nil {
+			return configs, fmt.Errorf("worker callback TLS: %w", err)
+		}
+		// A self-signed callback certificate is its own CA.
+		caFile := runtimeCallbackTLSCA
+		if caFile == "" {
+			caFile = runtimeCallbackTLSCert
+		}
+		ca, err := os.ReadFile(caFile)
+		if err != nil {
+			return configs, fmt.Errorf("worker callback CA: %w", err)
+		}
+		configs.callbackCA = string(ca)
+	}
+	return configs, nil
+}
+
+func listenRuntimeTCP(app *fiber.App, listen string, config *tls.Config) error {
+	if config == nil {
+		return app.Listen(listen)
+	}
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+	return app.Listener(tls.NewListener(listener, config))
+}
+
+// verifiedClientCertificate returns the common name of a client certificate
+// the management listener verified against --tls-client-ca.
+func verifiedClientCertificate(c *fiber.Ctx) (string, bool) {
+	state := c.Context().TLSConnectionState()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+// certificateSubject returns the subject a verified client certificate with
+// common name acts as. Names in operators act as operators, with no subject;
+// the rest act as "cert:<name>", which owner scoping and grants restrict, and
+// only on the API and websocket paths.
+func certificateSubject(name string, operators []string, clientPath bool) (string, bool) {
+	for _, operator := range operators {
+		if name != "" && name == strings.TrimSpace(operator) {
+			return "", true
+		}
+	}
+	if name == "" || !clientPath {
+		return "", false
+	}
+	return "cert:" + name, true
+}
@@ -2,12 +2,18 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	runtimehandlers "github.com/highcard-dev/daemon/apps/druid/adapters/http/handlers"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/runtime/docker"
 )
 
 type recordingWorkloadAuthenticator struct {
@@ -21,10 +27,86 @@ func (a *recordingWorkloadAuthenticator) AuthenticateWorkload(_ context.Context,
 	return ports.RuntimeWorkloadIdentity{Kind: "operator", RuntimeID: "runtime-a"}, nil
 }
 
+type staticUserAuthenticator map[string]string
+
+func (a staticUserAuthenticator) AuthenticateUser(_ context.Context, token string) (string, error) {
+	if subject, ok := a[token]; ok {
+		return subject, nil
+	}
+	return "", errors.New("unknown user token")
+}
+
+func TestOIDCUserTokensCarryTheirSubject(t *testing.T) {
+	users := staticUserAuthenticator{"user.id.token": "alice"}
+	for _, tc := range []struct {
+		name          string
+		authenticator ports.RuntimeWorkloadAuthenticator
+		token         string
+		status        int
+		body          string
+	}{
+		{name: "user", token: "user.id.token", status: fiber.StatusOK, body: "user:alice"},
+		{name: "unknown without workload identity", token: "other.id.token", status: fiber.StatusUnauthorized},
+		{name: "workload token", authenticator: &recordingWorkloadAuthenticator{}, token: "service.account.token", status: fiber.StatusOK, body: "operator:"},
+	} {
+		app := fiber.New()
+		app.Use(workloadIdentityMiddleware(tc.authenticator, nil, users, nil, false))
+		app.Get("/api/v1/scrolls", func(c *fiber.Ctx) error {
+			identity := c.Locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
+			subject, _ := c.Locals(runtimehandlers.OwnerLocal).(string)
+			return c.SendString(identity.Kind + ":" + subject)
+		})
+		request := httptest.NewRequest("GET", "/api/v1/scrolls", nil)
+		request.Header.Set("Authorization", "Bearer "+tc.token)
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != tc.status || (tc.body != "" && string(body) != tc.body) {
+			t.Fatalf("%s: status=%d body=%q", tc.name, response.StatusCode, body)
+		}
+	}
+}
+
+func TestAPIKeysReachTheHandlers(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiKeys := services.NewAPIKeyService(store)
+	_, token, err := apiKeys.Create(domain.RuntimeAPIKey{Name: "ci", Role: domain.RuntimeRoleViewer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Use(workloadIdentityMiddleware(nil, apiKeys, nil, nil, false))
+	app.Get("/api/v1/scrolls", func(c *fiber.Ctx) error {
+		key, _ := c.Locals(runtimehandlers.APIKeyLocal).(*domain.RuntimeAPIKey)
+		if key == nil {
+			return c.SendString("")
+		}
+		return c.SendString(key.Name)
+	})
+
+	request := httptest.NewRequest("GET", "/api/v1/scrolls", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := app.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != fiber.StatusOK || string(body) != "ci" {
+		t.Fatalf("status=%d body=%q", response.StatusCode, body)
+	}
+}
+
 func TestUnsafeModeStillAuthenticatesPresentedWorkloadToken(t *testing.T) {
 	authenticator := &recordingWorkloadAuthenticator{}
 	app := fiber.New()
-	app.Use(workloadIdentityMiddleware(authenticator, true))
+	app.Use(workloadIdentityMiddleware(authenticator, nil, nil, nil, true))
 	app.Post("/api/v1/scrolls/runtime-a/commands/test", func(c *fiber.Ctx) error {
 		identity := c.Locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
 		return c.SendString(identity.Kind)
@@ -46,7 +128,7 @@ func TestUnsafeModeStillAuthenticatesPresentedWorkloadToken(t *testing.T) {
 func TestUnsafeModeRetainsHeaderFallbackWithoutToken(t *testing.T) {
 	authenticator := &recordingWorkloadAuthenticator{}
 	app := fiber.New()
-	app.Use(workloadIdentityMiddleware(authenticator, true))
+	app.Use(workloadIdentityMiddleware(authenticator, nil, nil, nil, true))
 	app.Post("/api/v1/scrolls/runtime-a/commands/test", func(c *fiber.Ctx) error {
 		identity := c.Locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
 		return c.SendString(identity.Kind + ":" + identity.RuntimeID)
@@ -64,3 +146,75 @@ func TestUnsafeModeRetainsHeaderFallbackWithoutToken(t *testing.T) {
 		t.Fatalf("status=%d body=%q authenticator=%#v", response.StatusCode, body, authenticator)
 	}
 }
+
+func TestRuntimeCallbackTokenIsScopedToItsRuntime(t *testing.T) {
+	next := &recordingWorkloadAuthenticator{}
+	tokens, err := services.NewRuntimeCallbackTokens(filepath.Join(t.TempDir(), "callback.key"), next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Use(workloadIdentityMiddleware(tokens, nil, nil, nil, false))
+	app.Post("/internal/v1/runtimes/:id/traffic", func(c *fiber.Ctx) error {
+		identity := c.Locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
+		return c.SendString(identity.Kind + ":" + identity.RuntimeID)
+	})
+	app.Post("/internal/v1/workers/:id/complete", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	for _, tc := range []struct {
+		path   string
+		status int
+	}{
+		{path: "/internal/v1/runtimes/runtime-a/traffic", status: fiber.StatusOK},
+		{path: "/internal/v1/runtimes/runtime-b/traffic", status: fiber.StatusForbidden},
+		{path: "/internal/v1/workers/runtime-a/complete", status: fiber.StatusForbidden},
+	} {
+		request := httptest.NewRequest("POST", tc.path, nil)
+		request.Header.Set("Authorization", "Bearer "+tokens.Issue("runtime-a"))
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+		if response.StatusCode != tc.status {
+			t.Fatalf("%s status = %d, want %d", tc.path, response.StatusCode, tc.status)
+		}
+	}
+	if next.called {
+		t.Fatal("runtime callback token was passed on to the platform authenticator")
+	}
+
+	request := httptest.NewRequest("POST", "/internal/v1/runtimes/runtime-a/traffic", nil)
+	request.Header.Set("Authorization", "Bearer "+tokens.Issue("runtime-a")+"x")
+	response, err := app.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("tampered token status = %d, want 401", response.StatusCode)
+	}
+}
+
+func TestClientCertificatesOutsideTheAllowListActAsSubjects(t *testing.T) {
+	operators := []string{"ops"}
+	for _, tc := range []struct {
+		name       string
+		clientPath bool
+		subject    string
+		ok         bool
+	}{
+		{name: "ops", clientPath: false, ok: true},
+		{name: "ops", clientPath: true, ok: true},
+		{name: "workload", clientPath: true, subject: "cert:workload", ok: true},
+		{name: "workload", clientPath: false},
+		{name: "", clientPath: true},
+	} {
+		subject, ok := certificateSubject(tc.name, operators, tc.clientPath)
+		if subject != tc.subject || ok != tc.ok {
+			t.Fatalf("%q on client path %v: subject=%q ok=%v", tc.name, tc.clientPath, subject, ok)
+		}
+	}
+}
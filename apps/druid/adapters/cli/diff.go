@@ -0,0 +1,426 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+)
+
+var diffJSON bool
+
+// Layer actions describe what `druid update` does with a layer of the target
+// artifact, or with a layer only the base has.
+const (
+	layerActionAdd       = "add"
+	layerActionReplace   = "replace"
+	layerActionUnchanged = "unchanged"
+	layerActionPreserve  = "preserve" // data chunk protected by skip_update
+	layerActionKeep      = "keep"     // not in the target; update leaves it in place
+)
+
+var DiffCommand = &cobra.Command{
+	Use:   "diff <artifact-a> <artifact-b|runtime-id>",
+	Short: "Show what changes between two scroll artifacts, or an artifact and a runtime",
+	Long: `Compare two scroll artifacts layer by layer and show a semantic diff of their
+scroll.yaml (commands, procedures, images, ports).
+
+With two artifacts the diff goes from artifact-a to artifact-b. When the second
+argument is a runtime id, the diff shows what "druid update <runtime-id>
+<artifact-a>" would change: the runtime's live scroll.yaml and the artifact it
+was materialized from are the base, artifact-a is the target.
+
+Data chunks are listed with the action an update takes. Chunks marked
+skip_update in the target scroll.yaml are preserved, and layers missing from
+the target are kept in place.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		first, err := loadArtifactSnapshot(oci, args[0])
+		if err != nil {
+			return err
+		}
+		var diff scrollDiff
+		if _, ref, _ := utils.ParseArtifactRef(args[1]); ref != "" {
+			second, err := loadArtifactSnapshot(oci, args[1])
+			if err != nil {
+				return err
+			}
+			diff = diffScrolls(first, second)
+		} else {
+			runtime, err := loadRuntimeSnapshot(cmd, oci, args[1])
+			if err != nil {
+				return err
+			}
+			diff = diffScrolls(runtime, first)
+		}
+		if diffJSON {
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(diff)
+		}
+		diff.print(cmd.OutOrStdout())
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(DiffCommand)
+	DiffCommand.Flags().BoolVar(&diffJSON, "json", false, "Print the diff as JSON")
+}
+
+type scrollSnapshot struct {
+	Ref    string
+	Digest string
+	// Layers is nil when the layers are unknown, e.g. for a runtime created
+	// from a local directory.
+	Layers []v1.Descriptor
+	File   *domain.File
+}
+
+type layerChange struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	Action string `json:"action"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+type scrollDiff struct {
+	From       string        `json:"from"`
+	FromDigest string        `json:"from_digest,omitempty"`
+	To         string        `json:"to"`
+	ToDigest   string        `json:"to_digest,omitempty"`
+	Layers     []layerChange `json:"layers"`
+	Scroll     []fieldChange `json:"scroll"`
+	// LayersUnknown is set when one side has no manifest to compare.
+	LayersUnknown bool `json:"layers_unknown,omitempty"`
+}
+
+func loadArtifactSnapshot(oci *registry.OciClient, artifact string) (scrollSnapshot, error) {
+	desc, manifest, err := oci.FetchManifest(artifact)
+	if err != nil {
+		return scrollSnapshot{}, err
+	}
+	repo, _, _ := utils.ParseArtifactRef(artifact)
+	pinned := repo + "@" + desc.Digest.String()
+	scrollYAML, err := oci.FetchFile(pinned, "scroll.yaml")
+	if err != nil {
+		return scrollSnapshot{}, err
+	}
+	scroll, err := domain.NewScrollFromBytes("", scrollYAML)
+	if err != nil {
+		return scrollSnapshot{}, fmt.Errorf("parse scroll.yaml of %s: %w", artifact, err)
+	}
+	layers := manifest.Layers
+	if layers == nil {
+		layers = []v1.Descriptor{}
+	}
+	return scrollSnapshot{Ref: artifact, Digest: desc.Digest.String(), Layers: layers, File: &scroll.File}, nil
+}
+
+// loadRuntimeSnapshot combines the live scroll.yaml of a runtime with the
+// manifest of the artifact digest it was materialized from.
+func loadRuntimeSnapshot(cmd *cobra.Command, oci *registry.OciClient, id string) (scrollSnapshot, error) {
+	daemon, err := newDaemonClient()
+	if err != nil {
+		return scrollSnapshot{}, err
+	}
+	runtime, err := daemon.GetScroll(cmd.Context(), id)
+	if err != nil {
+		return scrollSnapshot{}, err
+	}
+	file, err := daemon.GetScrollConfig(cmd.Context(), id)
+	if err != nil {
+		return scrollSnapshot{}, err
+	}
+	snapshot := scrollSnapshot{Ref: id, File: file}
+	if _, err := os.Stat(runtime.Artifact); err == nil {
+		return snapshot, nil
+	}
+	artifact := runtime.Artifact
+	if runtime.ArtifactDigest != nil && *runtime.ArtifactDigest != "" {
+		repo, _, _ := utils.ParseArtifactRef(runtime.Artifact)
+		artifact = repo + "@" + *runtime.ArtifactDigest
+	}
+	desc, manifest, err := oci.FetchManifest(artifact)
+	if err != nil {
+		return scrollSnapshot{}, fmt.Errorf("runtime %s: %w", id, err)
+	}
+	snapshot.Digest = desc.Digest.String()
+	snapshot.Layers = manifest.Layers
+	if snapshot.Layers == nil {
+		snapshot.Layers = []v1.Descriptor{}
+	}
+	return snapshot, nil
+}
+
+func diffScrolls(from scrollSnapshot, to scrollSnapshot) scrollDiff {
+	diff := scrollDiff{
+		From:       from.Ref,
+		FromDigest: from.Digest,
+		To:         to.Ref,
+		ToDigest:   to.Digest,
+		Layers:     []layerChange{},
+		Scroll:     diffScrollFiles(from.File, to.File),
+	}
+	if from.Layers == nil || to.Layers == nil {
+		diff.LayersUnknown = true
+		return diff
+	}
+	skipData := map[string]bool{}
+	if to.File != nil {
+		collectSkipUpdatePaths(skipData, "", to.File.Chunks)
+	}
+	base := scrollLayers(from.Layers)
+	target := scrollLayers(to.Layers)
+	for _, path := range sortedKeys(target) {
+		desc := target[path]
+		change := layerChange{Path: path, Kind: layerKind(desc), To: desc.Digest.String(), Size: desc.Size}
+		old, existed := base[path]
+		if existed {
+			change.From = old.Digest.String()
+		}
+		switch {
+		case change.Kind == "data" && shouldSkipWorkerUpdate(dataRelPath(path), skipData):
+			change.Action = layerActionPreserve
+		case !existed:
+			change.Action = layerActionAdd
+		case old.Digest == desc.Digest:
+			change.Action = layerActionUnchanged
+		default:
+			change.Action = layerActionReplace
+		}
+		diff.Layers = append(diff.Layers, change)
+	}
+	for _, path := range sortedKeys(base) {
+		if _, ok := target[path]; ok {
+			continue
+		}
+		desc := base[path]
+		diff.Layers = append(diff.Layers, layerChange{Path: path, Kind: layerKind(desc), Action: layerActionKeep, From: desc.Digest.String(), Size: desc.Size})
+	}
+	return diff
+}
+
+// scrollLayers indexes the scroll fs and data layers of a manifest by path.
+func scrollLayers(layers []v1.Descriptor) map[string]v1.Descriptor {
+	out := map[string]v1.Descriptor{}
+	for _, desc := range layers {
+		if layerKind(desc) == "" {
+			continue
+		}
+		path := desc.Annotations["org.opencontainers.image.path"]
+		if path == "" {
+			path = desc.Annotations[v1.AnnotationTitle]
+		}
+		if path != "" {
+			out[path] = desc
+		}
+	}
+	return out
+}
+
+func layerKind(desc v1.Descriptor) string {
+	switch domain.ArtifactType(strings.TrimSuffix(desc.MediaType, "+gzip")) {
+	case domain.ArtifactTypeScrollFs:
+		return "fs"
+	case domain.ArtifactTypeScrollData:
+		return "data"
+	}
+	return ""
+}
+
+// dataRelPath maps a data layer path to the path skip_update chunks use,
+// relative to the data directory.
+func dataRelPath(path string) string {
+	if path == domain.ScrollDataDir {
+		return ""
+	}
+	return strings.TrimPrefix(path, domain.ScrollDataDir+"/")
+}
+
+func diffScrollFiles(from *domain.File, to *domain.File) []fieldChange {
+	if from == nil {
+		from = &domain.File{}
+	}
+	if to == nil {
+		to = &domain.File{}
+	}
+	changes := []fieldChange{}
+	add := func(field string, a string, b string) {
+		if a != b {
+			changes = append(changes, fieldChange{Field: field, From: a, To: b})
+		}
+	}
+	add("name", from.Name, to.Name)
+	add("version", versionString(from), versionString(to))
+	add("app_version", from.AppVersion, to.AppVersion)
+
+	fromPorts := portsByName(from.Ports)
+	toPorts := portsByName(to.Ports)
+	for _, name := range unionKeys(fromPorts, toPorts) {
+		add("ports."+name, fromPorts[name], toPorts[name])
+	}
+
+	for _, name := range unionKeys(from.Commands, to.Commands) {
+		a, b := from.Commands[name], to.Commands[name]
+		field := "commands." + name
+		switch {
+		case a == nil:
+			add(field, "", commandSummary(b))
+			continue
+		case b == nil:
+			add(field, commandSummary(a), "")
+			continue
+		}
+		add(field+".run", string(a.Run), string(b.Run))
+		add(field+".needs", strings.Join(a.Needs, ","), strings.Join(b.Needs, ","))
+		for i := 0; i < len(a.Procedures) || i < len(b.Procedures); i++ {
+			var pa, pb *domain.Procedure
+			if i < len(a.Procedures) {
+				pa = a.Procedures[i]
+			}
+			if i < len(b.Procedures) {
+				pb = b.Procedures[i]
+			}
+			procedure := pb
+			if procedure == nil {
+				procedure = pa
+			}
+			procField := "procedures." + domain.ProcedureName(name, i, procedure)
+			switch {
+			case pa == nil:
+				add(procField, "", procedureSummary(pb))
+			case pb == nil:
+				add(procField, procedureSummary(pa), "")
+			default:
+				add(procField+".image", pa.Image, pb.Image)
+				add(procField+".command", strings.Join(pa.Command, " "), strings.Join(pb.Command, " "))
+				add(procField+".mode", pa.Mode, pb.Mode)
+				add(procField+".settings", procedureSettings(pa), procedureSettings(pb))
+			}
+		}
+	}
+	return changes
+}
+
+// procedureSettings renders everything but the image and command, which are
+// diffed on their own.
+func procedureSettings(procedure *domain.Procedure) string {
+	rest := *procedure
+	rest.Image = ""
+	rest.Command = nil
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func versionString(file *domain.File) string {
+	if file.Version == nil {
+		return ""
+	}
+	return file.Version.String()
+}
+
+func portsByName(ports []domain.Port) map[string]string {
+	out := map[string]string{}
+	for _, port := range ports {
+		name := port.Name
+		if name == "" {
+			name = fmt.Sprint(port.Port)
+		}
+		out[name] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+	}
+	return out
+}
+
+func commandSummary(command *domain.CommandInstructionSet) string {
+	images := []string{}
+	for _, procedure := range command.Procedures {
+		if procedure != nil && procedure.Image != "" {
+			images = append(images, procedure.Image)
+		}
+	}
+	summary := fmt.Sprintf("%d procedures", len(command.Procedures))
+	if len(images) > 0 {
+		summary += " (" + strings.Join(images, ", ") + ")"
+	}
+	return summary
+}
+
+func procedureSummary(procedure *domain.Procedure) string {
+	if procedure == nil {
+		return ""
+	}
+	if procedure.IsSignal() {
+		return "signal " + procedure.Signal
+	}
+	return strings.TrimSpace(procedure.Image + " " + strings.Join(procedure.Command, " "))
+}
+
+func sortedKeys[V any](in map[string]V) []string {
+	keys := make([]string, 0, len(in))
+	for key := range in {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys[V any](a map[string]V, b map[string]V) []string {
+	merged := map[string]bool{}
+	for key := range a {
+		merged[key] = true
+	}
+	for key := range b {
+		merged[key] = true
+	}
+	return sortedKeys(merged)
+}
+
+func (d scrollDiff) print(out io.Writer) {
+	fmt.Fprintf(out, "--- %s %s\n+++ %s %s\n", d.From, d.FromDigest, d.To, d.ToDigest)
+	fmt.Fprintln(out, "\nscroll.yaml:")
+	if len(d.Scroll) == 0 {
+		fmt.Fprintln(out, "  no changes")
+	}
+	for _, change := range d.Scroll {
+		switch {
+		case change.From == "":
+			fmt.Fprintf(out, "  + %s: %s\n", change.Field, change.To)
+		case change.To == "":
+			fmt.Fprintf(out, "  - %s: %s\n", change.Field, change.From)
+		default:
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", change.Field, change.From, change.To)
+		}
+	}
+	fmt.Fprintln(out, "\nlayers:")
+	if d.LayersUnknown {
+		fmt.Fprintln(out, "  unknown (local artifact)")
+		return
+	}
+	for _, layer := range d.Layers {
+		fmt.Fprintf(out, "  %-9s %-4s %s (%s)\n", layer.Action, layer.Kind, layer.Path, utils.HumanizeBytes(layer.Size))
+	}
+}
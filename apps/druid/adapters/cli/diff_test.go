@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	ocidigest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func testLayer(kind domain.ArtifactType, path string, content string) v1.Descriptor {
+	return v1.Descriptor{
+		MediaType:   string(kind),
+		Digest:      ocidigest.FromString(content),
+		Size:        int64(len(content)),
+		Annotations: map[string]string{v1.AnnotationTitle: path},
+	}
+}
+
+func TestDiffScrollsClassifiesLayersUnderSkipUpdate(t *testing.T) {
+	from := scrollSnapshot{
+		Ref: "registry.example/scroll:1",
+		Layers: []v1.Descriptor{
+			testLayer(domain.ArtifactTypeScrollFs, "scroll.yaml", "v1"),
+			testLayer(domain.ArtifactTypeScrollFs, "update", "same"),
+			testLayer(domain.ArtifactTypeScrollData, "data/world", "world-v1"),
+			testLayer(domain.ArtifactTypeScrollData, "data/server", "server-v1"),
+			testLayer(domain.ArtifactTypeScrollData, "data/legacy", "legacy"),
+		},
+		File: &domain.File{},
+	}
+	to := scrollSnapshot{
+		Ref: "registry.example/scroll:2",
+		Layers: []v1.Descriptor{
+			testLayer(domain.ArtifactTypeScrollFs, "scroll.yaml", "v2"),
+			testLayer(domain.ArtifactTypeScrollFs, "update", "same"),
+			testLayer(domain.ArtifactTypeScrollData+"+gzip", "data/world", "world-v2"),
+			testLayer(domain.ArtifactTypeScrollData, "data/server", "server-v2"),
+			testLayer(domain.ArtifactTypeScrollData, "data/mods", "mods"),
+		},
+		File: &domain.File{Chunks: []*domain.Chunks{{Name: "world", Path: "world", SkipUpdate: true}}},
+	}
+
+	diff := diffScrolls(from, to)
+	got := map[string]string{}
+	for _, layer := range diff.Layers {
+		got[layer.Path] = layer.Kind + ":" + layer.Action
+	}
+	want := map[string]string{
+		"scroll.yaml": "fs:replace",
+		"update":      "fs:unchanged",
+		"data/world":  "data:preserve",
+		"data/server": "data:replace",
+		"data/mods":   "data:add",
+		"data/legacy": "data:keep",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("layers = %v", got)
+	}
+	for path, action := range want {
+		if got[path] != action {
+			t.Errorf("%s = %q, want %q", path, got[path], action)
+		}
+	}
+}
+
+func TestDiffScrollsWithoutManifestOnlyDiffsScroll(t *testing.T) {
+	diff := diffScrolls(scrollSnapshot{Ref: "local", File: &domain.File{Name: "a"}}, scrollSnapshot{Ref: "b", Layers: []v1.Descriptor{}, File: &domain.File{Name: "b"}})
+	if !diff.LayersUnknown || len(diff.Layers) != 0 {
+		t.Fatalf("expected unknown layers, got %+v", diff)
+	}
+	if len(diff.Scroll) != 1 || diff.Scroll[0].Field != "name" {
+		t.Fatalf("scroll changes = %+v", diff.Scroll)
+	}
+}
+
+func TestDiffScrollFilesReportsSemanticChanges(t *testing.T) {
+	from := &domain.File{
+		Ports: []domain.Port{{Name: "game", Port: 27015, Protocol: "udp"}, {Name: "rcon", Port: 27020, Protocol: "tcp"}},
+		Commands: map[string]*domain.CommandInstructionSet{
+			"start": {Procedures: []*domain.Procedure{{Image: "game:1", Command: []string{"./run"}}}},
+			"old":   {Procedures: []*domain.Procedure{{Image: "tool:1"}}},
+		},
+	}
+	to := &domain.File{
+		Ports: []domain.Port{{Name: "game", Port: 27016, Protocol: "udp"}, {Name: "query", Port: 27017, Protocol: "udp"}},
+		Commands: map[string]*domain.CommandInstructionSet{
+			"start": {Procedures: []*domain.Procedure{
+				{Image: "game:2", Command: []string{"./run"}, Env: map[string]string{"MODE": "pvp"}},
+				{Type: domain.ProcedureTypeSignal, Target: "start.0", Signal: "SIGTERM"},
+			}},
+			"backup": {Procedures: []*domain.Procedure{{Image: "backup:1"}}},
+		},
+	}
+
+	got := map[string]fieldChange{}
+	for _, change := range diffScrollFiles(from, to) {
+		got[change.Field] = change
+	}
+	expect := func(field string, fromValue string, toValue string) {
+		t.Helper()
+		change, ok := got[field]
+		if !ok {
+			t.Fatalf("missing change for %s in %+v", field, got)
+		}
+		if change.From != fromValue || change.To != toValue {
+			t.Fatalf("%s = %q -> %q, want %q -> %q", field, change.From, change.To, fromValue, toValue)
+		}
+	}
+	expect("ports.game", "27015/udp", "27016/udp")
+	expect("ports.rcon", "27020/tcp", "")
+	expect("ports.query", "", "27017/udp")
+	expect("procedures.start.0.image", "game:1", "game:2")
+	expect("procedures.start.1", "", "signal SIGTERM")
+	expect("commands.old", "1 procedures (tool:1)", "")
+	expect("commands.backup", "", "1 procedures (backup:1)")
+	if _, ok := got["procedures.start.0.settings"]; !ok {
+		t.Fatal("env change should show up in procedure settings")
+	}
+	if _, ok := got["procedures.start.0.command"]; ok {
+		t.Fatal("unchanged command should not be reported")
+	}
+}
+
+func TestScrollDiffPrint(t *testing.T) {
+	diff := scrollDiff{
+		From:   "a",
+		To:     "b",
+		Scroll: []fieldChange{{Field: "version", From: "1.0.0", To: "1.1.0"}},
+		Layers: []layerChange{{Path: "data/world", Kind: "data", Action: layerActionPreserve, Size: 2048}},
+	}
+	var out bytes.Buffer
+	diff.print(&out)
+	for _, want := range []string{"~ version: 1.0.0 -> 1.1.0", "preserve", "data/world"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+var LockCommand = &cobra.Command{
+	Use:   "lock [dir]",
+	Short: "Pin the container images of a scroll to digests",
+	Long: `Resolve the image of every container procedure in scroll.yaml to a digest
+and write the pins to ` + domain.ImageLockFile + ` next to it. The lock file is pushed with
+the scroll, and the Docker and Kubernetes backends run the pinned digests
+instead of the tags. Run it again to move the pins to whatever the tags point
+at now.`,
+	Example: `  druid lock
+  druid lock ./scrolls/minecraft`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := currentWorkingDir()
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		scroll, err := domain.NewScroll(dir)
+		if err != nil {
+			return err
+		}
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		lock, err := oci.LockImages(&scroll.File)
+		if err != nil {
+			return err
+		}
+		if err := lock.Write(dir); err != nil {
+			return err
+		}
+		for _, image := range scroll.ContainerImages() {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", image, lock.Images[image])
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "wrote %s\n", filepath.Join(dir, domain.ImageLockFile))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(LockCommand)
+}
@@ -7,11 +7,14 @@ import (
 	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
 var registryHost string
 var registryUser string
 var registryPassword string
+var registryIdentityToken string
+var registryCredentialHelper string
 
 var LoginCommand = &cobra.Command{
 	Use:   "login",
@@ -19,27 +22,55 @@ var LoginCommand = &cobra.Command{
 	Long: `Add or update registry credentials in the configuration.
 Supports multiple registries with path-based credential matching.
 
+When a credential helper is given, or ~/.docker/config.json names one for the
+host (credHelpers or credsStore), the secret is stored by
+docker-credential-<helper> and the configuration only records the helper.
+Otherwise the secret is written to the configuration file in plain text.
+
 Examples:
   druid login --host registry-1.docker.io -u user -p pass
-  druid login --host artifacts.druid.gg/project1 -u user1 -p pass1
-  druid login --host artifacts.druid.gg/project2 -u user2 -p pass2`,
+  druid login --host artifacts.druid.gg/project1 -u user1 -p pass1 --credential-helper osxkeychain
+  druid login --host artifacts.druid.gg/project2 --identity-token <refresh-token>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cred := auth.Credential{Username: registryUser, Password: registryPassword}
+		if registryIdentityToken != "" {
+			cred = auth.Credential{RefreshToken: registryIdentityToken}
+		} else if registryUser == "" || registryPassword == "" {
+			return fmt.Errorf("login requires --user and --password, or --identity-token")
+		}
 
-		if err := registry.ValidateCredentials(registryHost, registryUser, registryPassword); err != nil {
+		if err := registry.ValidateCredentials(registryHost, cred); err != nil {
 			return fmt.Errorf("login failed: %w", err)
 		}
 
 		cmd.Println("Login succeeded")
 
-		var registries []domain.RegistryCredential
-		viper.UnmarshalKey("registries", &registries)
+		helper := registryCredentialHelper
+		if helper == "" {
+			dockerConfig, err := registry.LoadDockerConfig(registry.DefaultDockerConfigPath())
+			if err != nil {
+				return err
+			}
+			helper = dockerConfig.HelperFor(registryHost)
+		}
 
-		newCred := domain.RegistryCredential{
-			Host:     registryHost,
-			Username: registryUser,
-			Password: registryPassword,
+		newCred := domain.RegistryCredential{Host: registryHost}
+		if helper != "" {
+			if err := registry.StoreWithCredentialHelper(helper, registryHost, cred); err != nil {
+				return fmt.Errorf("store credentials: %w", err)
+			}
+			newCred.CredentialHelper = helper
+			cmd.Println("Credentials stored with docker-credential-" + helper)
+		} else {
+			newCred.Username = cred.Username
+			newCred.Password = cred.Password
+			newCred.IdentityToken = cred.RefreshToken
+			cmd.PrintErrln("WARNING: credentials are stored unencrypted in " + viper.ConfigFileUsed() + "; use --credential-helper to keep them in a keychain")
 		}
 
+		var registries []domain.RegistryCredential
+		viper.UnmarshalKey("registries", &registries)
+
 		found := false
 		for i := range registries {
 			if registries[i].Host == registryHost {
@@ -64,8 +95,8 @@ func init() {
 	LoginCommand.Flags().StringVar(&registryHost, "host", "", "OCI registry host (e.g., artifacts.druid.gg/project1)")
 	LoginCommand.Flags().StringVarP(&registryUser, "user", "u", "", "username")
 	LoginCommand.Flags().StringVarP(&registryPassword, "password", "p", "", "User password")
+	LoginCommand.Flags().StringVar(&registryIdentityToken, "identity-token", "", "OAuth2 refresh token used instead of username and password")
+	LoginCommand.Flags().StringVar(&registryCredentialHelper, "credential-helper", "", "Store the secret with docker-credential-<helper>, e.g. osxkeychain, secretservice, wincred or pass")
 
 	LoginCommand.MarkFlagRequired("host")
-	LoginCommand.MarkFlagRequired("user")
-	LoginCommand.MarkFlagRequired("password")
 }
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"github.com/spf13/cobra"
 )
@@ -19,9 +18,12 @@ var PullCommand = &cobra.Command{
 			dir = args[1]
 		}
 
-		registryClient := registry.NewOciClient(loadRegistryStore())
+		registryClient, err := newOciClient()
+		if err != nil {
+			return err
+		}
 
-		err := registryClient.PullSelective(dir, artifact, !pullNoData, nil)
+		err = registryClient.PullSelective(dir, artifact, !pullNoData, nil)
 		if err != nil {
 			logger.Log().Error("Failed to pull from registry")
 			return err
@@ -1,13 +1,13 @@
 package cli
 
 import (
+	"crypto"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
-	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	"github.com/highcard-dev/daemon/internal/utils"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"github.com/spf13/cobra"
@@ -23,14 +23,14 @@ var pushPackMeta bool
 var pushSmart bool
 var pushCategory string
 var pushDisableTarReproducible bool
+var pushSign bool
+var pushLockImages bool
 
 var PushCommand = &cobra.Command{
 	Use:   "push [artifact] [dir]",
 	Short: "Generate OCI Artifacts and push to a remote registry",
 	Args:  cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		credStore := loadRegistryStore()
-
 		fullPath := currentWorkingDir()
 		artifact := ""
 		switch len(args) {
@@ -53,6 +53,14 @@ var PushCommand = &cobra.Command{
 			return err
 		}
 
+		// Load the key before pushing so a bad key does not leave an unsigned tag behind.
+		var signingKey crypto.Signer
+		if pushSign {
+			if signingKey, err = loadSigningKey(); err != nil {
+				return err
+			}
+		}
+
 		repo := scroll.Name
 		tag := scroll.AppVersion
 
@@ -62,29 +70,42 @@ var PushCommand = &cobra.Command{
 
 		logger.Log().Info("Pushing "+repo+":"+tag+" to registry", zap.String("path", fullPath))
 
-		ociClient := registry.NewOciClient(credStore)
+		ociClient, err := newOciClient()
+		if err != nil {
+			return err
+		}
 		if pushDisableTarReproducible {
 			ociClient.DisableTarReproducible()
 		}
 
+		if pushLockImages {
+			lock, err := ociClient.LockImages(&scroll.File)
+			if err != nil {
+				return err
+			}
+			if err := lock.Write(fullPath); err != nil {
+				return err
+			}
+		}
+
 		overrides := map[string]string{}
 		if pushMinRAM != "" {
-			overrides["gg.druid.scroll.minRam"] = pushMinRAM
+			overrides[domain.AnnotationScrollMinRam] = pushMinRAM
 		}
 		if pushMinCPU != "" {
-			overrides["gg.druid.scroll.minCpu"] = pushMinCPU
+			overrides[domain.AnnotationScrollMinCpu] = pushMinCPU
 		}
 		if pushMinDisk != "" {
-			overrides["gg.druid.scroll.minDisk"] = pushMinDisk
+			overrides[domain.AnnotationScrollMinDisk] = pushMinDisk
 		}
 		if pushImage != "" {
-			overrides["gg.druid.scroll.image"] = pushImage
+			overrides[domain.AnnotationScrollImage] = pushImage
 		}
 		if pushSmart {
-			overrides["gg.druid.scroll.smart"] = "true"
+			overrides[domain.AnnotationScrollSmart] = "true"
 		}
 		if pushCategory != "" {
-			overrides["gg.druid.scroll.category"] = pushCategory
+			overrides[domain.AnnotationScrollCategory] = pushCategory
 		}
 		for _, p := range pushScrollPorts {
 			parts := strings.Split(p, "=")
@@ -93,13 +114,18 @@ var PushCommand = &cobra.Command{
 			if len(parts) == 2 {
 				port = parts[1]
 			}
-			overrides[fmt.Sprintf("gg.druid.scroll.port.%s", name)] = port
+			overrides[domain.AnnotationScrollPortPrefix+name] = port
 		}
 
-		_, err = ociClient.Push(fullPath, repo, tag, overrides, pushPackMeta, &scroll.File)
+		desc, err := ociClient.Push(fullPath, repo, tag, overrides, pushPackMeta, &scroll.File)
 		if err != nil {
 			return err
 		}
+		if signingKey != nil {
+			if _, err := ociClient.Sign(repo+"@"+desc.Digest.String(), signingKey); err != nil {
+				return fmt.Errorf("pushed %s:%s but signing failed: %w", repo, tag, err)
+			}
+		}
 
 		logger.Log().Info("Pushed "+scroll.Name+" to registry", zap.String("path", fullPath))
 		return nil
@@ -114,7 +140,10 @@ func init() {
 	PushCommand.Flags().BoolVarP(&pushSmart, "smart", "s", false, "Indicates, if the scroll is able to run as a smart deployment (Will be added as a manifest annotation gg.druid.scroll.smart)")
 	PushCommand.Flags().StringVar(&pushCategory, "category", pushCategory, "Category of the scroll. (Will be added as a manifest annotation gg.druid.scroll.category)")
 	PushCommand.Flags().StringVarP(&pushImage, "image", "i", pushImage, "Image to use for the scroll. (Will be added as a manifest annotation gg.druid.scroll.image)")
-	PushCommand.Flags().StringSliceVarP(&pushScrollPorts, "port", "p", pushScrollPorts, "Ports to expose. Format webserver=80, dns=53/udp or just ftp (Will be added as a manifest annotation gg.druid.scroll.ports.<name>)")
+	PushCommand.Flags().StringSliceVarP(&pushScrollPorts, "port", "p", pushScrollPorts, "Ports to expose. Format webserver=80, dns=53/udp or just ftp (Will be added as a manifest annotation gg.druid.scroll.port.<name>)")
 	PushCommand.Flags().BoolVarP(&pushPackMeta, "pack-meta", "m", pushPackMeta, "Pack the meta folder into the scroll.")
+	PushCommand.Flags().BoolVar(&pushLockImages, "lock-images", false, "Pin the container images of the scroll's procedures to digests in "+domain.ImageLockFile+" before pushing (see druid lock)")
+	PushCommand.Flags().BoolVar(&pushSign, "sign", false, "Sign the pushed manifest with --key (see druid sign)")
+	PushCommand.Flags().StringVarP(&signKeyPath, "key", "k", "", "PEM private key used by --sign (default: DRUID_SIGNING_KEY)")
 	PushCommand.PersistentFlags().BoolVar(&pushDisableTarReproducible, "no-tar-reproducible", false, "Preserve file timestamps in pushed tar layers.")
 }
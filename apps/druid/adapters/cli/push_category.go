@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -14,8 +13,6 @@ var PushCategoryCommand = &cobra.Command{
 	Short: "Push locale markdown files (e.g. de-DE.md) from a scroll directory as separate OCI layers.",
 	Args:  cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		credStore := loadRegistryStore()
-
 		repo := args[0]
 		category := args[1]
 		scrollDir := currentWorkingDir()
@@ -25,12 +22,15 @@ var PushCategoryCommand = &cobra.Command{
 
 		logger.Log().Info("Pushing "+repo+" category to registry", zap.String("scrollDir", scrollDir))
 
-		ociClient := registry.NewOciClient(credStore)
+		ociClient, err := newOciClient()
+		if err != nil {
+			return err
+		}
 		if pushDisableTarReproducible {
 			ociClient.DisableTarReproducible()
 		}
 
-		_, err := ociClient.PushCategory(scrollDir, repo, category)
+		_, err = ociClient.PushCategory(scrollDir, repo, category)
 
 		if err != nil {
 			return err
@@ -2,12 +2,17 @@ package cli
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/highcard-dev/daemon/apps/druid/adapters/authclient"
 	"github.com/highcard-dev/daemon/apps/druid/adapters/cli/client"
 	"github.com/highcard-dev/daemon/apps/druid/adapters/daemonclient"
 	"github.com/highcard-dev/daemon/apps/druid/adapters/websocketclient"
 	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +24,13 @@ var runtimeStateDir string
 var runtimeBackendName string
 var daemonSocket string
 var daemonURL string
+var daemonToken string
+var daemonCA string
+var daemonCert string
+var daemonKey string
+var daemonContextName string
+var daemonNamespace string
+var outputFormat string
 
 var RootCmd = &cobra.Command{
 	Use:   "druid",
@@ -37,20 +49,182 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path to config file (default: ~/.druid.yaml)")
 	RootCmd.PersistentFlags().StringVar(&daemonSocket, "daemon-socket", utils.DefaultRuntimeSocketPath(), "Runtime daemon Unix socket path for REST-backed commands")
 	RootCmd.PersistentFlags().StringVar(&daemonURL, "daemon-url", "", "Runtime daemon HTTP URL for REST-backed commands")
+	RootCmd.PersistentFlags().StringVar(&daemonToken, "daemon-token", os.Getenv("DRUID_DAEMON_TOKEN"), "Bearer token or API key for the runtime daemon (env DRUID_DAEMON_TOKEN)")
+	RootCmd.PersistentFlags().StringVar(&daemonCA, "daemon-ca", os.Getenv("DRUID_DAEMON_CA"), "PEM CA file trusted for an https --daemon-url (env DRUID_DAEMON_CA)")
+	RootCmd.PersistentFlags().StringVar(&daemonCert, "daemon-cert", os.Getenv("DRUID_DAEMON_CERT"), "PEM client certificate for a daemon that requires mTLS (env DRUID_DAEMON_CERT)")
+	RootCmd.PersistentFlags().StringVar(&daemonKey, "daemon-key", os.Getenv("DRUID_DAEMON_KEY"), "PEM key of --daemon-cert (env DRUID_DAEMON_KEY)")
+	RootCmd.PersistentFlags().StringVar(&daemonContextName, "context", os.Getenv("DRUID_CONTEXT"), "Daemon context from ~/.druid.yaml to use instead of current_context (env DRUID_CONTEXT)")
+	RootCmd.PersistentFlags().StringVar(&daemonNamespace, "namespace", "", "Kubernetes namespace new scrolls are created in (default: the context's namespace)")
+	RootCmd.PersistentFlags().StringVar(&outputFormat, "output", "", "Output format of list commands: table or json (default: the context's output or table)")
 
 	client.Register(RootCmd, client.Config{
 		Daemon: func() (client.RuntimeDaemon, error) {
-			return daemonclient.NewOpenAPIClientForTarget(daemonSocket, daemonURL)
+			return newDaemonClient()
 		},
 		AttachConsole: func(ctx context.Context, scroll string, console string) error {
-			return websocketclient.NewAttacherForTarget(daemonSocket, daemonURL).Attach(ctx, scroll, console)
+			attacher, err := newAttacher()
+			if err != nil {
+				return err
+			}
+			return attacher.Attach(ctx, scroll, console)
 		},
-		RegistryCredentials: func() []api.RegistryCredential {
-			return client.RegistryCredentials(loadRegistryStore().Credentials())
+		FollowEvents: func(ctx context.Context, scroll string, out io.Writer) error {
+			attacher, err := newAttacher()
+			if err != nil {
+				return err
+			}
+			return attacher.FollowEvents(ctx, scroll, out)
+		},
+		RegistryCredentials: func(artifact string) []api.RegistryCredential {
+			return client.RegistryCredentials(loadRegistryStore().ResolvedCredentialsFor(artifactPullRepos(artifact)...))
+		},
+		Output: func() string {
+			active, err := activeDaemonContext()
+			if err != nil {
+				return outputFormat
+			}
+			return active.Output
 		},
 	})
 }
 
+func newDaemonClient() (*daemonclient.OpenAPIClient, error) {
+	active, err := activeDaemonContext()
+	if err != nil {
+		return nil, err
+	}
+	daemon, err := newAnonymousDaemonClient(active)
+	if err != nil {
+		return nil, err
+	}
+	token, err := daemonBearerToken(active)
+	if err != nil {
+		return nil, err
+	}
+	daemon.SetToken(token)
+	daemon.SetNamespace(active.Namespace)
+	return daemon, nil
+}
+
+// newAnonymousDaemonClient connects like newDaemonClient but sends no token.
+func newAnonymousDaemonClient(active domain.DaemonContext) (*daemonclient.OpenAPIClient, error) {
+	daemon, err := daemonclient.NewOpenAPIClientForTarget(active.Socket, active.URL)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := utils.ClientTLSConfig(active.CA, active.Cert, active.Key)
+	if err != nil {
+		return nil, err
+	}
+	daemon.SetTLSConfig(tlsConfig)
+	return daemon, nil
+}
+
+func newAttacher() (*websocketclient.Attacher, error) {
+	active, err := activeDaemonContext()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := utils.ClientTLSConfig(active.CA, active.Cert, active.Key)
+	if err != nil {
+		return nil, err
+	}
+	token, err := daemonBearerToken(active)
+	if err != nil {
+		return nil, err
+	}
+	attacher := websocketclient.NewAttacherForTarget(active.Socket, active.URL)
+	attacher.SetTLSConfig(tlsConfig)
+	attacher.SetToken(token)
+	return attacher, nil
+}
+
+// activeDaemonContext returns the context named by --context, DRUID_CONTEXT
+// or current_context, with the --daemon-* flags applied on top. Pointing the
+// flags at another daemon leaves the context behind altogether.
+func activeDaemonContext() (domain.DaemonContext, error) {
+	var active domain.DaemonContext
+	name := daemonContextName
+	if name == "" {
+		name = viper.GetString("current_context")
+	}
+	if name != "" {
+		contexts, err := loadDaemonContexts()
+		if err != nil {
+			return active, err
+		}
+		found := domain.FindDaemonContext(contexts, name)
+		if found == nil {
+			return active, fmt.Errorf("context %q not found; see druid context list", name)
+		}
+		active = *found
+	}
+	flags := RootCmd.PersistentFlags()
+	if flags.Changed("daemon-socket") || flags.Changed("daemon-url") {
+		active = domain.DaemonContext{Socket: daemonSocket, URL: daemonURL}
+		if daemonURL != "" {
+			active.Socket = ""
+		}
+	}
+	if active.Socket == "" && active.URL == "" {
+		active.Socket = daemonSocket
+	}
+	active.URL = strings.TrimRight(active.URL, "/")
+	if daemonToken != "" {
+		active.Auth = domain.DaemonAuthToken
+		active.Token = daemonToken
+		active.TokenEnv = ""
+	}
+	for flag, value := range map[*string]string{&active.CA: daemonCA, &active.Cert: daemonCert, &active.Key: daemonKey, &active.Namespace: daemonNamespace, &active.Output: outputFormat} {
+		if value != "" {
+			*flag = value
+		}
+	}
+	if err := domain.ValidateOutputFormat(active.Output); err != nil {
+		return active, err
+	}
+	return active, nil
+}
+
+// daemonLoginKey names the druid auth login cache entry of active: the
+// context name, or the daemon itself outside of a context.
+func daemonLoginKey(active domain.DaemonContext) string {
+	if active.Name != "" {
+		return active.Name
+	}
+	return active.Target()
+}
+
+// daemonBearerToken returns the token active authenticates with, refreshing
+// a cached login that is about to expire.
+func daemonBearerToken(active domain.DaemonContext) (string, error) {
+	switch active.Auth {
+	case domain.DaemonAuthNone:
+		return "", nil
+	case domain.DaemonAuthToken:
+		token := active.Token
+		if active.TokenEnv != "" {
+			token = os.Getenv(active.TokenEnv)
+		}
+		if token == "" {
+			return "", fmt.Errorf("context %s has no token; set %s", active.Name, active.TokenEnv)
+		}
+		return token, nil
+	}
+	cachePath, err := authclient.DefaultCachePath()
+	if err != nil {
+		return "", nil
+	}
+	token, err := authclient.NewCache(cachePath).BearerToken(context.Background(), nil, daemonLoginKey(active))
+	if err != nil {
+		return "", err
+	}
+	if token == "" && active.Auth == domain.DaemonAuthOIDC {
+		return "", fmt.Errorf("not logged in to %s; run druid auth login", daemonLoginKey(active))
+	}
+	return token, nil
+}
+
 func initConfig() {
 	viper.AutomaticEnv()
 
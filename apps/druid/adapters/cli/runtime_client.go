@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
 func loadRegistryStore() *registry.CredentialStore {
@@ -17,5 +22,53 @@ func loadRegistryStore() *registry.CredentialStore {
 			registries = append(registries, domain.RegistryCredential{Host: host, Username: user, Password: password})
 		}
 	}
-	return registry.NewCredentialStore(registries)
+	store := registry.NewCredentialStore(registries)
+	dockerConfig, err := registry.LoadDockerConfig(registry.DefaultDockerConfigPath())
+	if err != nil {
+		logger.Log().Warn("Ignoring docker config", zap.Error(err))
+	}
+	store.SetDockerConfig(dockerConfig)
+	return store
+}
+
+// loadRegistryConfig reads registry_hosts, registry_mirrors and
+// registry_rewrites from the druid config.
+func loadRegistryConfig() (domain.RegistryConfig, error) {
+	var config domain.RegistryConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		return domain.RegistryConfig{}, fmt.Errorf("invalid registry config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return domain.RegistryConfig{}, err
+	}
+	return config, nil
+}
+
+// artifactPullRepos lists the repositories a pull of artifact may reach:
+// the repository itself, its rewrite and the mirrors of the rewrite.
+func artifactPullRepos(artifact string) []string {
+	repo, _, _ := utils.ParseArtifactRef(artifact)
+	if repo == "" {
+		return nil
+	}
+	repos := []string{repo}
+	config, err := loadRegistryConfig()
+	if err != nil {
+		return repos
+	}
+	rewritten := config.Rewrite(repo)
+	repos = append(repos, rewritten)
+	return append(repos, config.MirrorsFor(rewritten)...)
+}
+
+// newOciClient returns a registry client with the configured credentials,
+// mirrors, rewrites and host settings.
+func newOciClient() (*registry.OciClient, error) {
+	config, err := loadRegistryConfig()
+	if err != nil {
+		return nil, err
+	}
+	client := registry.NewOciClient(loadRegistryStore())
+	client.SetRegistryConfig(config)
+	return client, nil
 }
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var signKeyPath string
+
+var SignCommand = &cobra.Command{
+	Use:   "sign <artifact>",
+	Short: "Sign a pushed scroll artifact with a local key",
+	Long: `Attach a cosign-compatible signature to a scroll artifact as an OCI referrer.
+The key is an unencrypted PEM private key (ECDSA P-256, Ed25519 or RSA).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := loadSigningKey()
+		if err != nil {
+			return err
+		}
+		oci, err := newOciClient()
+		if err != nil {
+			return err
+		}
+		signature, err := oci.Sign(args[0], key)
+		if err != nil {
+			return err
+		}
+		logger.Log().Info("Signed "+args[0], zap.String("signature", signature.Digest.String()))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(SignCommand)
+	SignCommand.Flags().StringVarP(&signKeyPath, "key", "k", "", "PEM private key used to sign (default: DRUID_SIGNING_KEY)")
+}
+
+func loadSigningKey() (crypto.Signer, error) {
+	path := signKeyPath
+	if path == "" {
+		path = os.Getenv("DRUID_SIGNING_KEY")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("signing requires --key or DRUID_SIGNING_KEY")
+	}
+	return registry.LoadSigningKey(path)
+}
@@ -1,19 +1,29 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"text/tabwriter"
 
+	appservices "github.com/highcard-dev/daemon/apps/druid/core/services"
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/spf13/cobra"
 )
 
 var strict bool
+var validatePolicy string
+var validateJSON bool
 
 var ValidateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validates the scroll file",
-	Long:  `This command validates the scroll file to ensure it meets the required criteria.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `This command validates the scroll file to ensure it meets the required criteria.
+
+With --policy the scroll is also checked against a daemon admission policy
+(see druid daemon --admission-policy), with images pinned by images.lock.
+Every violation is reported and the command fails when there are any.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scrollDir := currentWorkingDir()
 		if len(args) > 0 {
@@ -30,12 +40,59 @@ var ValidateCmd = &cobra.Command{
 			return fmt.Errorf("failed to validate scroll: %w", err)
 		}
 
-		fmt.Println("Scroll validated successfully.")
+		if validatePolicy != "" {
+			policy, err := appservices.LoadScrollAdmissionPolicy(validatePolicy)
+			if err != nil {
+				return err
+			}
+			lock, err := domain.ReadImageLock(scrollDir)
+			if err != nil {
+				return err
+			}
+			var pins map[string]string
+			if lock != nil {
+				pins = lock.Images
+			}
+			violations := policy.Evaluate(&scroll.File, pins)
+			if err := printViolations(cmd.OutOrStdout(), violations); err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%w: %d policy violations", domain.ErrScrollNotAdmitted, len(violations))
+			}
+		}
+
+		if !validateJSON {
+			fmt.Fprintln(cmd.OutOrStdout(), "Scroll validated successfully.")
+		}
 		return nil
 	},
 }
 
+func printViolations(out io.Writer, violations []domain.PolicyViolation) error {
+	if validateJSON {
+		if violations == nil {
+			violations = []domain.PolicyViolation{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(violations)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tCOMMAND\tPROCEDURE\tMESSAGE")
+	for _, v := range violations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.Rule, dash(v.Command), dash(v.Procedure), v.Message)
+	}
+	return w.Flush()
+}
+
 func init() {
 	RootCmd.AddCommand(ValidateCmd)
 	ValidateCmd.Flags().BoolVar(&strict, "strict", false, "Enable strict validation mode")
+	ValidateCmd.Flags().StringVar(&validatePolicy, "policy", "", "Also check the scroll against this admission policy file")
+	ValidateCmd.Flags().BoolVar(&validateJSON, "json", false, "Print policy violations as JSON")
 }
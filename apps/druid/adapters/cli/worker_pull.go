@@ -16,6 +16,7 @@ import (
 	"github.com/highcard-dev/daemon/internal/core/ports"
 	coreservices "github.com/highcard-dev/daemon/internal/core/services"
 	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -35,6 +36,9 @@ var WorkerPullCommand = &cobra.Command{
 		if workerPullAction.TokenFile == "" {
 			workerPullAction.TokenFile = os.Getenv("DRUID_WORKER_TOKEN_FILE")
 		}
+		if workerPullAction.CallbackCA == "" {
+			workerPullAction.CallbackCA = os.Getenv("DRUID_WORKER_CALLBACK_CA")
+		}
 		result := runWorkerPull(workerPullAction)
 		if result.Error != "" {
 			_ = reportWorkerResult(workerPullAction, result)
@@ -66,18 +70,30 @@ func runWorkerPull(action ports.RuntimeWorkerAction) ports.RuntimeWorkerResult {
 	if root == "" {
 		root = "/scroll"
 	}
-	oci := registry.NewOciClient(loadWorkerRegistryStore())
-	digest, err := oci.ResolveDigest(action.Artifact)
+	config, err := loadWorkerRegistryConfig()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	oci := config.ociClient()
+	artifact, digest, err := verifyWorkerArtifact(action.Artifact, oci, config.Trust)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if digest == "" {
+		digest, err = oci.ResolveDigest(artifact)
+	}
 	if err == nil {
 		result.ArtifactDigest = digest
 	}
 	switch action.Mode {
 	case ports.RuntimeWorkerModeUpdate:
-		err = pullWorkerUpdate(root, action.Artifact, oci)
+		err = pullWorkerUpdate(root, artifact, oci)
 	case ports.RuntimeWorkerModeRestore:
-		err = pullWorkerRestore(root, action.Artifact, oci)
+		err = pullWorkerRestore(root, artifact, oci)
 	default:
-		err = pullWorkerCreate(root, action.Artifact, oci)
+		err = pullWorkerCreate(root, artifact, oci)
 	}
 	if err != nil {
 		result.Error = err.Error()
@@ -92,28 +108,98 @@ func runWorkerPull(action ports.RuntimeWorkerAction) ports.RuntimeWorkerResult {
 		result.Error = err.Error()
 		return result
 	}
+	lock, err := domain.ReadImageLock(root)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if lock != nil {
+		result.ImageLock = lock.Images
+	}
 	result.ScrollYAML = string(scrollYAML)
 	return result
 }
 
-func loadWorkerRegistryStore() *registry.CredentialStore {
-	var config struct {
-		Registries []domain.RegistryCredential `json:"registries"`
-	}
+// workerRegistryConfig is the registry config the daemon hands to workers.
+type workerRegistryConfig struct {
+	Registries []domain.RegistryCredential `json:"registries"`
+	Trust      *domain.ScrollTrustPolicy   `json:"trust,omitempty"`
+	domain.RegistryConfig
+}
+
+func (c workerRegistryConfig) ociClient() *registry.OciClient {
+	oci := registry.NewOciClient(registry.NewCredentialStore(c.Registries))
+	oci.SetRegistryConfig(c.RegistryConfig)
+	return oci
+}
+
+// loadWorkerRegistryConfig reads the registry config of a worker. A config
+// that cannot be read is an error rather than an empty one, since an empty
+// one would also drop the trust policy.
+func loadWorkerRegistryConfig() (workerRegistryConfig, error) {
+	var config workerRegistryConfig
 	if raw := os.Getenv("DRUID_RUNTIME_REGISTRY_CONFIG_JSON"); raw != "" {
-		_ = json.Unmarshal([]byte(raw), &config)
+		if err := json.Unmarshal([]byte(raw), &config); err != nil {
+			return config, fmt.Errorf("invalid DRUID_RUNTIME_REGISTRY_CONFIG_JSON: %w", err)
+		}
 	}
 	if len(config.Registries) == 0 {
-		_ = viper.UnmarshalKey("registries", &config.Registries)
+		if err := viper.UnmarshalKey("registries", &config.Registries); err != nil {
+			return config, fmt.Errorf("invalid registries: %w", err)
+		}
 	}
-	if len(config.Registries) == 0 {
-		if path := viper.ConfigFileUsed(); path != "" {
-			if raw, err := os.ReadFile(path); err == nil {
-				_ = json.Unmarshal(raw, &config)
-			}
+	if config.RegistryConfig.Empty() {
+		if err := viper.Unmarshal(&config.RegistryConfig); err != nil {
+			return config, fmt.Errorf("invalid registry config: %w", err)
+		}
+	}
+	if config.Trust == nil && viper.IsSet("trust") {
+		config.Trust = &domain.ScrollTrustPolicy{}
+		if err := viper.UnmarshalKey("trust", config.Trust); err != nil {
+			return config, fmt.Errorf("invalid trust policy: %w", err)
+		}
+	}
+	// The config the daemon writes for Kubernetes workers is JSON with json
+	// tags viper does not read; other formats are already loaded by viper.
+	path := viper.ConfigFileUsed()
+	if (len(config.Registries) == 0 || config.Trust == nil) && strings.EqualFold(filepath.Ext(path), ".json") {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return config, err
+		}
+		var file workerRegistryConfig
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return config, fmt.Errorf("invalid %s: %w", path, err)
+		}
+		if len(config.Registries) == 0 {
+			config.Registries = file.Registries
+		}
+		if config.Trust == nil {
+			config.Trust = file.Trust
+		}
+		if config.RegistryConfig.Empty() {
+			config.RegistryConfig = file.RegistryConfig
 		}
 	}
-	return registry.NewCredentialStore(config.Registries)
+	return config, nil
+}
+
+// verifyWorkerArtifact applies the trust policy before anything is pulled.
+// A trusted OCI artifact is rewritten to the verified digest; local paths
+// carry no signature and are refused while a policy is active.
+func verifyWorkerArtifact(artifact string, oci *registry.OciClient, trust *domain.ScrollTrustPolicy) (string, string, error) {
+	if !trust.Enabled() {
+		return artifact, "", nil
+	}
+	if _, err := os.Stat(artifact); err == nil {
+		return "", "", fmt.Errorf("%w: local artifact %s cannot be verified", registry.ErrUntrustedArtifact, artifact)
+	}
+	digest, err := oci.VerifyTrusted(artifact, trust)
+	if err != nil {
+		return "", "", err
+	}
+	repo, _, _ := utils.ParseArtifactRef(artifact)
+	return repo + "@" + digest, digest, nil
 }
 
 func pullWorkerCreate(root string, artifact string, oci ports.OciRegistryInterface) error {
@@ -129,7 +215,7 @@ func pullWorkerCreate(root string, artifact string, oci ports.OciRegistryInterfa
 			return err
 		}
 	}
-	if info, err := os.Stat(artifact); err == nil {
+	if info, err := os.Stat(artifact); err == nil && !domain.IsScrollBundle(artifact) {
 		if !info.IsDir() {
 			if filepath.Base(artifact) != "scroll.yaml" {
 				return fmt.Errorf("local file artifact must be scroll.yaml")
@@ -158,6 +244,12 @@ func pullWorkerUpdate(root string, artifact string, oci ports.OciRegistryInterfa
 	if err != nil {
 		return err
 	}
+	// A lock file the new artifact dropped must not pin the old images.
+	if exists, _ := utils.FileExists(filepath.Join(tmp, domain.ImageLockFile)); !exists {
+		if err := os.Remove(filepath.Join(root, domain.ImageLockFile)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	skipData := map[string]bool{}
 	collectSkipUpdatePaths(skipData, "", scroll.Chunks)
 	return mergePulledRoot(tmp, root, skipData)
@@ -328,7 +420,17 @@ func reportWorkerResult(action ports.RuntimeWorkerAction, result ports.RuntimeWo
 	if base == action.CallbackURL || base == "" {
 		return fmt.Errorf("worker callback URL %q must end with %s", action.CallbackURL, suffix)
 	}
-	client, err := callbackapi.NewClientWithResponses(base)
+	tlsConfig, err := utils.ClientTLSConfigFromPEM(action.CallbackCA)
+	if err != nil {
+		return fmt.Errorf("worker callback CA: %w", err)
+	}
+	options := []callbackapi.ClientOption{}
+	if tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		options = append(options, callbackapi.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	client, err := callbackapi.NewClientWithResponses(base, options...)
 	if err != nil {
 		return err
 	}
@@ -337,6 +439,9 @@ func reportWorkerResult(action ports.RuntimeWorkerAction, result ports.RuntimeWo
 		Error:          workerString(result.Error),
 		ScrollYaml:     workerString(result.ScrollYAML),
 	}
+	if len(result.ImageLock) > 0 {
+		body.ImageLock = &result.ImageLock
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	res, err := client.CompleteWorkerWithResponse(ctx, action.RuntimeID, body, func(_ context.Context, request *http.Request) error {
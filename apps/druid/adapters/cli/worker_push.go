@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
-	"github.com/highcard-dev/daemon/internal/core/services/registry"
 	"github.com/highcard-dev/daemon/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -28,8 +27,11 @@ var WorkerPushCommand = &cobra.Command{
 			return err
 		}
 		repo, tag := utils.SplitArtifact(workerPushArtifact)
-		oci := registry.NewOciClient(loadWorkerRegistryStore())
-		_, err = oci.Push(workerPushRoot, repo, tag, nil, false, &scroll.File)
+		config, err := loadWorkerRegistryConfig()
+		if err != nil {
+			return err
+		}
+		_, err = config.ociClient().Push(workerPushRoot, repo, tag, nil, false, &scroll.File)
 		return err
 	},
 }
@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
@@ -108,6 +109,39 @@ func TestWorkerRestoreStagesBeforeReplacingRoot(t *testing.T) {
 	}
 }
 
+func TestWorkerPullRejectsLocalArtifactUnderTrustPolicy(t *testing.T) {
+	artifact := t.TempDir()
+	mustWrite(t, filepath.Join(artifact, "scroll.yaml"), "name: local\n")
+	t.Setenv("DRUID_RUNTIME_REGISTRY_CONFIG_JSON", `{"registries":[],"trust":{"rules":[{"repo":"registry.local/*","allow_unsigned":true}]}}`)
+
+	root := t.TempDir()
+	result := runWorkerPull(ports.RuntimeWorkerAction{Artifact: artifact, MountPath: root})
+	if !strings.Contains(result.Error, "untrusted scroll artifact") {
+		t.Fatalf("error = %q, want untrusted local artifact", result.Error)
+	}
+	if result.ScrollYAML != "" {
+		t.Fatal("rejected artifact reported a scroll.yaml")
+	}
+	if _, err := os.Stat(filepath.Join(root, "scroll.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("rejected artifact was materialized, stat err = %v", err)
+	}
+}
+
+func TestWorkerPullFailsOnUnreadableRegistryConfig(t *testing.T) {
+	artifact := t.TempDir()
+	mustWrite(t, filepath.Join(artifact, "scroll.yaml"), "name: local\n")
+	t.Setenv("DRUID_RUNTIME_REGISTRY_CONFIG_JSON", `{"trust":{"rules":[`)
+
+	root := t.TempDir()
+	result := runWorkerPull(ports.RuntimeWorkerAction{Artifact: artifact, MountPath: root})
+	if !strings.Contains(result.Error, "DRUID_RUNTIME_REGISTRY_CONFIG_JSON") {
+		t.Fatalf("error = %q, want invalid registry config", result.Error)
+	}
+	if _, err := os.Stat(filepath.Join(root, "scroll.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("artifact was materialized without its trust policy, stat err = %v", err)
+	}
+}
+
 func TestWorkerCollectSkipUpdatePaths(t *testing.T) {
 	root := filepath.Join(t.TempDir(), "root")
 	mustWrite(t, filepath.Join(root, "scroll.yaml"), `name: skip-test
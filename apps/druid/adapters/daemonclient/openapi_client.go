@@ -3,6 +3,7 @@ package daemonclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,8 @@ type OpenAPIClient struct {
 	client     *api.ClientWithResponses
 	server     string
 	httpClient *http.Client
+	transport  *http.Transport
+	namespace  string
 }
 
 func NewOpenAPIClient(daemonSocket string) (*OpenAPIClient, error) {
@@ -34,12 +37,13 @@ func NewOpenAPIClient(daemonSocket string) (*OpenAPIClient, error) {
 func NewOpenAPIClientForTarget(daemonSocket string, daemonURL string) (*OpenAPIClient, error) {
 	if daemonURL != "" {
 		server := strings.TrimRight(daemonURL, "/")
-		httpClient := &http.Client{Timeout: daemonRequestTimeout}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		httpClient := &http.Client{Transport: transport, Timeout: daemonRequestTimeout}
 		client, err := api.NewClientWithResponses(server, api.WithHTTPClient(httpClient))
 		if err != nil {
 			return nil, err
 		}
-		return &OpenAPIClient{client: client, server: server, httpClient: httpClient}, nil
+		return &OpenAPIClient{client: client, server: server, httpClient: httpClient, transport: transport}, nil
 	}
 	if daemonSocket == "" {
 		daemonSocket = utils.DefaultRuntimeSocketPath()
@@ -54,7 +58,64 @@ func NewOpenAPIClientForTarget(daemonSocket string, daemonURL string) (*OpenAPIC
 	if err != nil {
 		return nil, err
 	}
-	return &OpenAPIClient{client: client, server: "http://druid", httpClient: httpClient}, nil
+	return &OpenAPIClient{client: client, server: "http://druid", httpClient: httpClient, transport: transport}, nil
+}
+
+// SetTLSConfig sets the CAs and client certificate used for an https daemon
+// URL. The Unix socket ignores it.
+func (c *OpenAPIClient) SetTLSConfig(config *tls.Config) {
+	c.transport.TLSClientConfig = config
+}
+
+// SetToken sends token as a bearer credential on every request, e.g. an API
+// key for a daemon whose management listener requires authentication.
+func (c *OpenAPIClient) SetToken(token string) {
+	if token == "" {
+		return
+	}
+	c.httpClient.Transport = bearerTransport{token: token, next: c.httpClient.Transport}
+}
+
+// SetNamespace sets the Kubernetes namespace CreateScroll asks for.
+func (c *OpenAPIClient) SetNamespace(namespace string) {
+	c.namespace = namespace
+}
+
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
+
+// Discovery fetches /.well-known/druid-configuration, which tells clients how
+// to log in to the daemon.
+func (c *OpenAPIClient) Discovery(ctx context.Context) (*domain.DaemonDiscovery, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+"/.well-known/druid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode, body); err != nil {
+		return nil, err
+	}
+	var discovery domain.DaemonDiscovery
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return nil, err
+	}
+	return &discovery, nil
 }
 
 func (c *OpenAPIClient) CreateScroll(ctx context.Context, name string, artifact string, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error) {
@@ -66,6 +127,9 @@ func (c *OpenAPIClient) CreateScroll(ctx context.Context, name string, artifact
 		Artifact: artifact,
 		Name:     requestName,
 	}
+	if c.namespace != "" {
+		request.Namespace = &c.namespace
+	}
 	if len(registryCredentials) > 0 {
 		request.RegistryCredentials = &registryCredentials
 	}
@@ -97,6 +161,129 @@ func (c *OpenAPIClient) UpdateScroll(ctx context.Context, id string, artifact st
 	return res.JSON200, nil
 }
 
+// RollbackScroll moves a runtime back to the update history entry at index
+// to, or to the previous digest when to is nil.
+func (c *OpenAPIClient) RollbackScroll(ctx context.Context, id string, to *int, registryCredentials []api.RegistryCredential) (*api.RuntimeScroll, error) {
+	request := api.RollbackScrollJSONRequestBody{To: to}
+	if len(registryCredentials) > 0 {
+		request.RegistryCredentials = &registryCredentials
+	}
+	res, err := c.client.RollbackScrollWithResponse(ctx, id, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	return res.JSON200, nil
+}
+
+// SetScrollUpdatePolicy replaces the update policy of a runtime; nil removes
+// it.
+func (c *OpenAPIClient) SetScrollUpdatePolicy(ctx context.Context, id string, policy *api.RuntimeUpdatePolicy) (*api.RuntimeScroll, error) {
+	if policy == nil {
+		res, err := c.client.DeleteScrollUpdatePolicyWithResponse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+			return nil, err
+		}
+		return res.JSON200, nil
+	}
+	res, err := c.client.SetScrollUpdatePolicyWithResponse(ctx, id, *policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	return res.JSON200, nil
+}
+
+// SetScrollGrant gives subject access to a runtime; nil revokes its grant.
+func (c *OpenAPIClient) SetScrollGrant(ctx context.Context, id string, subject string, grant *api.RuntimeGrantRequest) (*api.RuntimeScroll, error) {
+	if grant == nil {
+		res, err := c.client.DeleteScrollGrantWithResponse(ctx, id, subject)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+			return nil, err
+		}
+		return res.JSON200, nil
+	}
+	res, err := c.client.SetScrollGrantWithResponse(ctx, id, subject, *grant)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	return res.JSON200, nil
+}
+
+func (c *OpenAPIClient) CreateAPIKey(ctx context.Context, request api.CreateAPIKeyRequest) (*api.CreatedAPIKey, error) {
+	res, err := c.client.CreateAPIKeyWithResponse(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	return res.JSON201, nil
+}
+
+func (c *OpenAPIClient) ListAPIKeys(ctx context.Context) ([]api.RuntimeAPIKey, error) {
+	res, err := c.client.ListAPIKeysWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, nil
+	}
+	return *res.JSON200, nil
+}
+
+func (c *OpenAPIClient) RevokeAPIKey(ctx context.Context, id string) error {
+	res, err := c.client.DeleteAPIKeyWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ensureStatus(res.StatusCode(), res.Body)
+}
+
+func (c *OpenAPIClient) ListAudit(ctx context.Context, params api.ListAuditParams) ([]api.AuditRecord, error) {
+	res, err := c.client.ListAuditWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, nil
+	}
+	return *res.JSON200, nil
+}
+
+func (c *OpenAPIClient) ListQuotas(ctx context.Context, params api.ListQuotasParams) ([]api.OwnerQuotaStatus, error) {
+	res, err := c.client.ListQuotasWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, nil
+	}
+	return *res.JSON200, nil
+}
+
 func (c *OpenAPIClient) ListScrolls(ctx context.Context) ([]api.RuntimeScroll, error) {
 	res, err := c.client.ListScrollsWithResponse(ctx)
 	if err != nil {
@@ -215,6 +402,20 @@ func (c *OpenAPIClient) GetScrollPorts(ctx context.Context, id string) ([]api.Ru
 	return *res.JSON200, nil
 }
 
+func (c *OpenAPIClient) GetScrollEvents(ctx context.Context, id string) ([]api.RuntimeEvent, error) {
+	res, err := c.client.GetScrollEventsWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStatus(res.StatusCode(), res.Body); err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, nil
+	}
+	return *res.JSON200, nil
+}
+
 func (c *OpenAPIClient) StartScroll(ctx context.Context, id string) (*api.RuntimeScroll, error) {
 	res, err := c.client.StartScrollWithResponse(ctx, id)
 	if err != nil {
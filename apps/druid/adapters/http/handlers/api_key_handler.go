@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services"
+)
+
+// APIKeyLocal is the fiber local holding the *domain.RuntimeAPIKey a request
+// authenticated with.
+const APIKeyLocal = "druid-api-key"
+
+func (h *ScrollHandler) SetAPIKeys(apiKeys *services.APIKeyService) {
+	h.apiKeys = apiKeys
+}
+
+// requestAPIKey returns the API key the caller authenticated with, if any.
+func requestAPIKey(c *fiber.Ctx) *domain.RuntimeAPIKey {
+	key, _ := c.Locals(APIKeyLocal).(*domain.RuntimeAPIKey)
+	return key
+}
+
+// authorizeAPIKeyAdmin lets only operators and local callers manage keys, so
+// a key can never mint a broader one.
+func (h *ScrollHandler) authorizeAPIKeyAdmin(c *fiber.Ctx) error {
+	if requestSubject(c) != "" || requestAPIKey(c) != nil {
+		return fiber.NewError(fiber.StatusForbidden, "only operators and local callers may manage api keys")
+	}
+	if h.apiKeys == nil {
+		return fiber.NewError(fiber.StatusNotImplemented, "api keys are not supported by this runtime backend")
+	}
+	return nil
+}
+
+func (h *ScrollHandler) ListAPIKeys(c *fiber.Ctx) error {
+	if err := h.authorizeAPIKeyAdmin(c); err != nil {
+		return err
+	}
+	keys, err := h.apiKeys.List()
+	if err != nil {
+		return err
+	}
+	return c.JSON(keys)
+}
+
+func (h *ScrollHandler) CreateAPIKey(c *fiber.Ctx) error {
+	if err := h.authorizeAPIKeyAdmin(c); err != nil {
+		return err
+	}
+	var request api.CreateAPIKeyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	key := domain.RuntimeAPIKey{
+		Name:      request.Name,
+		ExpiresAt: request.ExpiresAt,
+		CreatedBy: requestInitiator(c),
+	}
+	if request.Role != nil {
+		key.Role = domain.RuntimeRole(*request.Role)
+	}
+	if request.Scrolls != nil {
+		key.Scrolls = *request.Scrolls
+	}
+	if request.Commands != nil {
+		key.Commands = *request.Commands
+	}
+	if err := key.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	created, token, err := h.apiKeys.Create(key)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(struct {
+		*domain.RuntimeAPIKey
+		Key string `json:"key"`
+	}{created, token})
+}
+
+func (h *ScrollHandler) DeleteAPIKey(c *fiber.Ctx, id string) error {
+	if err := h.authorizeAPIKeyAdmin(c); err != nil {
+		return err
+	}
+	err := h.apiKeys.Revoke(id)
+	if errors.Is(err, domain.ErrRuntimeAPIKeyNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
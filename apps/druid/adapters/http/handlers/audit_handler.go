@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/services"
+)
+
+// maxAuditConsoleInput bounds how much of a console input message is kept.
+const maxAuditConsoleInput = 1024
+
+// auditActions names the audited routes by method and route path. Mutating
+// routes missing here are recorded under their method and path.
+var auditActions = map[string]string{
+	"POST /api/v1/scrolls":                                "scroll.create",
+	"POST /api/v1/scrolls/ensure":                         "scroll.ensure",
+	"DELETE /api/v1/scrolls/:id":                          "scroll.delete",
+	"POST /api/v1/scrolls/:id/start":                      "scroll.start",
+	"POST /api/v1/scrolls/:id/stop":                       "scroll.stop",
+	"POST /api/v1/scrolls/:id/update":                     "scroll.update",
+	"POST /api/v1/scrolls/:id/rollback":                   "scroll.rollback",
+	"PUT /api/v1/scrolls/:id/update-policy":               "scroll.update-policy.set",
+	"DELETE /api/v1/scrolls/:id/update-policy":            "scroll.update-policy.delete",
+	"PUT /api/v1/scrolls/:id/grants/:subject":             "scroll.grant.set",
+	"DELETE /api/v1/scrolls/:id/grants/:subject":          "scroll.grant.delete",
+	"POST /api/v1/scrolls/:id/commands/:command":          "scroll.command",
+	"POST /api/v1/scrolls/:id/routing":                    "scroll.routing.apply",
+	"POST /api/v1/scrolls/:id/ui/packages/:scope/publish": "scroll.ui.publish",
+	"POST /api/v1/scrolls/:id/backup":                     "scroll.backup",
+	"POST /api/v1/scrolls/:id/restore":                    "scroll.restore",
+	"POST /api/v1/apikeys":                                "apikey.create",
+	"DELETE /api/v1/apikeys/:id":                          "apikey.revoke",
+	"POST /:id/api/v1/command":                            "scroll.command",
+	"PUT /:id/api/v1/scroll/commands/:command":            "scroll.commands.add",
+	"DELETE /:id/api/v1/scroll/commands/:command":         "scroll.commands.remove",
+	"POST /:id/api/v1/ui/packages/:scope/publish":         "scroll.ui.publish",
+}
+
+func (h *ScrollHandler) SetAuditLog(audit *services.AuditLog) {
+	h.audit = audit
+}
+
+// AuditRequests records every mutating request that reaches a route once it
+// has been handled, including the ones that were denied.
+func (h *ScrollHandler) AuditRequests(c *fiber.Ctx) error {
+	method := c.Method()
+	if h.audit == nil || (method != fiber.MethodPost && method != fiber.MethodPut && method != fiber.MethodDelete && method != fiber.MethodPatch) {
+		return c.Next()
+	}
+	err := c.Next()
+	route := c.Route()
+	if route == nil || route.Path == "/" {
+		return err
+	}
+
+	action, ok := auditActions[method+" "+route.Path]
+	if !ok {
+		action = method + " " + c.Path()
+	}
+	params := map[string]string{}
+	scrollID := ""
+	for _, name := range route.Params {
+		value := c.Params(name)
+		if name == "id" && !strings.HasPrefix(route.Path, "/api/v1/apikeys") {
+			scrollID = value
+			continue
+		}
+		params[name] = value
+	}
+	for name, value := range c.Queries() {
+		params[name] = value
+	}
+	var body map[string]json.RawMessage
+	if json.Unmarshal(c.Body(), &body) == nil {
+		for name, raw := range body {
+			var value string
+			if json.Unmarshal(raw, &value) != nil {
+				value = string(raw)
+			}
+			params[name] = value
+		}
+	}
+	if scrollID == "" && (action == "scroll.create" || action == "scroll.ensure") {
+		scrollID = params["name"]
+		if scrollID == "" {
+			scrollID = params["id"]
+		}
+	}
+
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+		}
+	}
+	record := domain.AuditRecord{
+		Subject:  requestInitiator(c),
+		SourceIP: c.IP(),
+		ScrollID: scrollID,
+		Action:   action,
+		Params:   params,
+		Result:   auditResult(status),
+		Status:   status,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	h.audit.Record(record)
+	return err
+}
+
+func auditResult(status int) string {
+	switch {
+	case status == fiber.StatusUnauthorized || status == fiber.StatusForbidden:
+		return domain.AuditResultDenied
+	case status >= 400:
+		return domain.AuditResultError
+	}
+	return domain.AuditResultOK
+}
+
+func (h *ScrollHandler) ListAudit(c *fiber.Ctx, params api.ListAuditParams) error {
+	if h.audit == nil {
+		return fiber.NewError(fiber.StatusNotImplemented, "the audit log is not supported by this runtime backend")
+	}
+	filter := domain.AuditFilter{}
+	if params.Scroll != nil {
+		filter.ScrollID = *params.Scroll
+	}
+	if requestSubject(c) != "" || requestAPIKey(c) != nil {
+		if filter.ScrollID == "" {
+			return fiber.NewError(fiber.StatusForbidden, "filter the audit log by a scroll")
+		}
+		if _, err := h.authorize(c, filter.ScrollID, domain.PermissionScrollAudit); err != nil {
+			return err
+		}
+	}
+	if params.Subject != nil {
+		filter.Subject = *params.Subject
+	}
+	if params.Action != nil {
+		filter.Action = *params.Action
+	}
+	if params.Since != nil {
+		filter.Since = *params.Since
+	}
+	if params.Until != nil {
+		filter.Until = *params.Until
+	}
+	if params.Limit != nil {
+		if *params.Limit < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "limit must not be negative")
+		}
+		filter.Limit = *params.Limit
+	}
+	records, err := h.audit.List(filter)
+	if err != nil {
+		return err
+	}
+	return c.JSON(records)
+}
+
+// auditConsole records a console attach or input on a websocket connection.
+func (h *WebsocketHandler) auditConsole(c *websocket.Conn, action string, consoleID string, result string, input string) {
+	h.recordConsole(c, action, consoleID, result, "", input)
+}
+
+// rejectConsole counts and audits a connection or input the console limits
+// or query token checks turned away.
+func (h *WebsocketHandler) rejectConsole(c *websocket.Conn, action string, consoleID string, reason string, input string) {
+	h.guard.reject(reason)
+	h.recordConsole(c, action, consoleID, domain.AuditResultDenied, reason, input)
+}
+
+func (h *WebsocketHandler) recordConsole(c *websocket.Conn, action string, consoleID string, result string, reason string, input string) {
+	if h.scrolls == nil || h.scrolls.audit == nil {
+		return
+	}
+	params := map[string]string{"console": c.Params("console")}
+	if reason != "" {
+		params["reason"] = reason
+	}
+	if action == domain.AuditActionConsoleInput {
+		if len(input) > maxAuditConsoleInput {
+			input = input[:maxAuditConsoleInput] + fmt.Sprintf("... (%d bytes)", len(input))
+		}
+		params["input"] = input
+	}
+	if params["console"] == "" {
+		params["console"] = consoleID
+	}
+	h.scrolls.audit.Record(domain.AuditRecord{
+		Subject:  initiatorFromLocals(func(key string) interface{} { return c.Locals(key) }),
+		SourceIP: c.IP(),
+		ScrollID: c.Params("id"),
+		Action:   action,
+		Params:   params,
+		Result:   result,
+	})
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 )
 
-const ownerLocal = "druid-owner-id"
+// OwnerLocal is the fiber local holding the subject a request authenticated
+// as.
+const OwnerLocal = "druid-owner-id"
 
 func (h *ScrollHandler) PublicAuth(c *fiber.Ctx) error {
 	if h.authorizer == nil {
@@ -25,18 +30,21 @@ func (h *ScrollHandler) PublicAuth(c *fiber.Ctx) error {
 		}
 		return fiber.NewError(fiber.StatusUnauthorized, "missing authorization token")
 	}
-	c.Locals(ownerLocal, auth.Subject)
+	c.Locals(OwnerLocal, auth.Subject)
 	id := c.Params("id")
 	if id == "" {
 		return c.Next()
 	}
-	if err := h.authorizeRuntimeOwner(id, auth.Subject); err != nil {
+	if err := h.authorizeRuntimeAccess(id, auth.Subject); err != nil {
 		return err
 	}
 	return c.Next()
 }
 
-func (h *ScrollHandler) authorizeRuntimeOwner(id string, subject string) error {
+// authorizeRuntimeAccess lets subject through if it is the owner of the
+// runtime or holds any grant on it. The handlers check the permission each
+// route needs.
+func (h *ScrollHandler) authorizeRuntimeAccess(id string, subject string) error {
 	if subject == "" {
 		return fiber.NewError(fiber.StatusUnauthorized, "missing subject")
 	}
@@ -44,21 +52,110 @@ func (h *ScrollHandler) authorizeRuntimeOwner(id string, subject string) error {
 	if err != nil {
 		return err
 	}
-	if runtimeScroll.OwnerID != "" && runtimeScroll.OwnerID != subject {
-		return fiber.NewError(fiber.StatusForbidden, "runtime owner mismatch")
+	if len(runtimeScroll.PermissionsFor(subject)) == 0 {
+		return fiber.NewError(fiber.StatusForbidden, "no access to runtime")
 	}
 	return nil
 }
 
+// requestSubject returns the token subject of the caller, or "" for
+// operator service accounts and local access, which grants do not restrict.
+func requestSubject(c *fiber.Ctx) string {
+	subject, _ := c.Locals(OwnerLocal).(string)
+	return subject
+}
+
+// authorize returns runtime id if the caller holds one of permissions on it.
+func (h *ScrollHandler) authorize(c *fiber.Ctx, id string, permissions ...string) (*domain.RuntimeScroll, error) {
+	runtimeScroll, err := h.getScroll(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, permission := range permissions {
+		if callerAllows(c, runtimeScroll, permission) {
+			return runtimeScroll, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusForbidden, "missing permission "+permissions[0])
+}
+
+// callerAllows reports whether the caller holds permission on runtimeScroll.
+// Callers with neither a subject nor an API key hold every permission.
+func callerAllows(c *fiber.Ctx, runtimeScroll *domain.RuntimeScroll, permission string) bool {
+	if key := requestAPIKey(c); key != nil {
+		return key.Allows(runtimeScroll.ID, permission)
+	}
+	if subject := requestSubject(c); subject != "" {
+		return runtimeScroll.Allows(subject, permission)
+	}
+	return true
+}
+
+// callerSees reports whether runtimeScroll shows up in the caller's list.
+func callerSees(c *fiber.Ctx, runtimeScroll *domain.RuntimeScroll) bool {
+	if key := requestAPIKey(c); key != nil {
+		return key.AllowsScroll(runtimeScroll.ID)
+	}
+	if subject := requestSubject(c); subject != "" {
+		return len(runtimeScroll.PermissionsFor(subject)) > 0
+	}
+	return true
+}
+
+// requestInitiator names the caller of a management request for the update
+// history of a runtime.
+func requestInitiator(c *fiber.Ctx) string {
+	return initiatorFromLocals(func(key string) interface{} { return c.Locals(key) })
+}
+
+// initiatorFromLocals names the caller from request locals, which HTTP and
+// websocket connections expose with different signatures.
+func initiatorFromLocals(locals func(key string) interface{}) string {
+	if subject, ok := locals(OwnerLocal).(string); ok && subject != "" {
+		return subject
+	}
+	if key, ok := locals(APIKeyLocal).(*domain.RuntimeAPIKey); ok && key != nil {
+		return "apikey:" + key.Name
+	}
+	identity, ok := locals("druid-workload-identity").(ports.RuntimeWorkloadIdentity)
+	if !ok {
+		return "api"
+	}
+	switch identity.Kind {
+	case "operator":
+		return "operator:" + identity.Namespace + "/" + identity.ServiceAccount
+	case "unsafe":
+		return "local"
+	case "certificate":
+		return "cert:" + identity.ServiceAccount
+	}
+	return identity.Kind
+}
+
+// PublicQueryAuth accepts a query token of the runtime that was issued for
+// the console and has not been used before.
 func (h *WebsocketHandler) PublicQueryAuth(c *websocket.Conn) bool {
 	if h.authorizer == nil {
 		return h.allowUnauthenticatedPublic
 	}
 	auth, err := h.authorizer.CheckQuery(c.Params("id"), c.Query("token"))
 	if err != nil {
+		h.rejectConsole(c, domain.AuditActionConsoleAttach, c.Params("console"), consoleRejectToken, "")
 		return false
 	}
-	return auth != nil || h.allowUnauthenticatedPublic
+	if auth != nil {
+		c.Locals(OwnerLocal, auth.Subject)
+		if auth.Console != c.Params("console") {
+			h.rejectConsole(c, domain.AuditActionConsoleAttach, c.Params("console"), consoleRejectToken, "")
+			return false
+		}
+		if !h.guard.consumeToken(auth.TokenID, auth.ExpiresAt, time.Now()) {
+			h.rejectConsole(c, domain.AuditActionConsoleAttach, c.Params("console"), consoleRejectReplay, "")
+			return false
+		}
+		return true
+	}
+	return h.allowUnauthenticatedPublic
 }
 
 type jwksProvider interface {
@@ -76,3 +173,10 @@ func RuntimeJWKS(authorizer ports.AuthorizerServiceInterface) fiber.Handler {
 		return c.JSON(map[string]any{"keys": []any{}})
 	}
 }
+
+// DaemonDiscovery serves /.well-known/druid-configuration.
+func DaemonDiscovery(discovery domain.DaemonDiscovery) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(discovery)
+	}
+}
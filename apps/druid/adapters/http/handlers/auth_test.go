@@ -0,0 +1,377 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	appservices "github.com/highcard-dev/daemon/apps/druid/core/services"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/runtime/docker"
+)
+
+// subjectAuthorizer takes the bearer token as the subject.
+type subjectAuthorizer struct{}
+
+func (subjectAuthorizer) CheckHeader(c *fiber.Ctx) (*ports.AuthContext, error) {
+	subject := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	if subject == "" {
+		return nil, nil
+	}
+	return &ports.AuthContext{Subject: subject}, nil
+}
+
+func (subjectAuthorizer) CheckQuery(runtimeID string, token string) (*ports.AuthContext, error) {
+	return &ports.AuthContext{Subject: token, RuntimeID: runtimeID}, nil
+}
+
+func (subjectAuthorizer) GenerateQueryToken(runtimeID string, ownerID string, console string) string {
+	return ownerID
+}
+
+func TestManagementRoutesEnforceScrollGrants(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateScroll(&domain.RuntimeScroll{
+		ID:      "scroll-1",
+		OwnerID: "alice",
+		Status:  domain.RuntimeScrollStatusStopped,
+		Grants: []domain.RuntimeGrant{
+			{Subject: "bob", Role: domain.RuntimeRoleViewer, Permissions: []string{domain.PermissionScrollRun("backup")}},
+			{Subject: "erin", Role: domain.RuntimeRoleOperator, Permissions: []string{domain.PermissionScrollGrants}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor := appservices.NewRuntimeSupervisor(store, services.NewRuntimeScrollManager(store), nil)
+	logs := services.NewLogManager()
+	scrolls := NewScrollHandler(supervisor, services.NewConsoleManager(logs), logs)
+	websockets := &WebsocketHandler{}
+	websockets.SetScrollHandler(scrolls)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	RegisterManagementRoutes(app, RouteHandlers{
+		Server:     NewRuntimeServer(NewHealthHandler(), scrolls),
+		Websocket:  websockets,
+		Authorizer: subjectAuthorizer{},
+	})
+
+	for _, tc := range []struct {
+		subject string
+		method  string
+		path    string
+		body    string
+		want    int
+	}{
+		{"bob", http.MethodGet, "/api/v1/scrolls/scroll-1", "", http.StatusOK},
+		{"bob", http.MethodPost, "/api/v1/scrolls/scroll-1/start", "", http.StatusForbidden},
+		{"bob", http.MethodDelete, "/api/v1/scrolls/scroll-1", "", http.StatusForbidden},
+		{"bob", http.MethodPost, "/api/v1/scrolls/scroll-1/commands/restart", "", http.StatusForbidden},
+		{"bob", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"role":"viewer"}`, http.StatusForbidden},
+		{"carol", http.MethodGet, "/api/v1/scrolls/scroll-1", "", http.StatusForbidden},
+		{"carol", http.MethodGet, "/ws/v1/scrolls/scroll-1/events", "", http.StatusForbidden},
+		{"bob", http.MethodGet, "/ws/v1/scrolls/scroll-1/events", "", http.StatusUpgradeRequired},
+		{"alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"role":"unknown"}`, http.StatusBadRequest},
+		{"alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"permissions":["scroll:strat"]}`, http.StatusBadRequest},
+		{"alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"permissions":["scroll:*"]}`, http.StatusBadRequest},
+		{"alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"permissions":["scroll:run:back*"]}`, http.StatusBadRequest},
+		{"erin", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/erin", `{"role":"owner"}`, http.StatusForbidden},
+		{"erin", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/frank", `{"permissions":["scroll:delete"]}`, http.StatusForbidden},
+		{"erin", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/frank", `{"role":"viewer","permissions":["scroll:run:*"]}`, http.StatusOK},
+		{"frank", http.MethodDelete, "/api/v1/scrolls/scroll-1", "", http.StatusForbidden},
+		{"alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/carol", `{"role":"console-only"}`, http.StatusOK},
+		{"carol", http.MethodGet, "/api/v1/scrolls/scroll-1", "", http.StatusForbidden},
+		{"carol", http.MethodGet, "/api/v1/scrolls/scroll-1/consoles", "", http.StatusOK},
+		{"alice", http.MethodDelete, "/api/v1/scrolls/scroll-1/grants/dave", "", http.StatusNotFound},
+		{"alice", http.MethodDelete, "/api/v1/scrolls/scroll-1/grants/carol", "", http.StatusOK},
+		{"carol", http.MethodGet, "/api/v1/scrolls/scroll-1/consoles", "", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+tc.subject)
+		if tc.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s %s %s = %d, want %d", tc.subject, tc.method, tc.path, resp.StatusCode, tc.want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scrolls", nil)
+	req.Header.Set("Authorization", "Bearer carol")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var listed []domain.RuntimeScroll
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("carol lists %d runtimes without a grant", len(listed))
+	}
+}
+
+func TestManagementRoutesEnforceAPIKeyScope(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"scroll-1", "scroll-2"} {
+		if err := store.CreateScroll(&domain.RuntimeScroll{ID: id, OwnerID: "alice", Status: domain.RuntimeScrollStatusStopped}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	apiKeys := services.NewAPIKeyService(store)
+	key, _, err := apiKeys.Create(domain.RuntimeAPIKey{Name: "ci", Role: domain.RuntimeRoleViewer, Scrolls: []string{"scroll-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	supervisor := appservices.NewRuntimeSupervisor(store, services.NewRuntimeScrollManager(store), nil)
+	logs := services.NewLogManager()
+	scrolls := NewScrollHandler(supervisor, services.NewConsoleManager(logs), logs)
+	scrolls.SetAPIKeys(apiKeys)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(func(c *fiber.Ctx) error {
+		if c.Get("Authorization") != "" {
+			c.Locals(APIKeyLocal, key)
+		}
+		return c.Next()
+	})
+	RegisterManagementRoutes(app, RouteHandlers{
+		Server:    NewRuntimeServer(NewHealthHandler(), scrolls),
+		Websocket: &WebsocketHandler{},
+	})
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "/api/v1/scrolls/scroll-1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/scrolls/scroll-2", "", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/scrolls/scroll-1/start", "", http.StatusForbidden},
+		{http.MethodGet, "/api/v1/apikeys", "", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/apikeys", `{"name":"wider"}`, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer key")
+		if tc.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s %s = %d, want %d", tc.method, tc.path, resp.StatusCode, tc.want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/apikeys", strings.NewReader(`{"name":"deploy","scrolls":["scroll-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var created struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusCreated || !strings.HasPrefix(created.Key, domain.RuntimeAPIKeyPrefix) {
+		t.Fatalf("create api key = %d %#v", resp.StatusCode, created)
+	}
+	if _, err := apiKeys.Authenticate(created.Key); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagementRoutesRecordAudit(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateScroll(&domain.RuntimeScroll{ID: "scroll-1", OwnerID: "alice", Status: domain.RuntimeScrollStatusStopped}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor := appservices.NewRuntimeSupervisor(store, services.NewRuntimeScrollManager(store), nil)
+	logs := services.NewLogManager()
+	scrolls := NewScrollHandler(supervisor, services.NewConsoleManager(logs), logs)
+	scrolls.SetAuditLog(services.NewAuditLog(store, 0))
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	RegisterManagementRoutes(app, RouteHandlers{
+		Server:     NewRuntimeServer(NewHealthHandler(), scrolls),
+		Websocket:  &WebsocketHandler{},
+		Authorizer: subjectAuthorizer{},
+	})
+
+	do := func(subject string, method string, path string, body string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if subject != "" {
+			req.Header.Set("Authorization", "Bearer "+subject)
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	do("mallory", http.MethodPost, "/api/v1/scrolls/scroll-1/stop", "").Body.Close()
+	do("alice", http.MethodPut, "/api/v1/scrolls/scroll-1/grants/bob", `{"role":"viewer"}`).Body.Close()
+	do("", http.MethodPost, "/api/v1/apikeys", `{"name":"ci","password":"hunter2"}`).Body.Close()
+
+	records, err := store.ListAudit(domain.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("recorded %d audit records, want 3: %#v", len(records), records)
+	}
+	if got := records[0]; got.Subject != "mallory" || got.ScrollID != "scroll-1" || got.Action != "scroll.stop" || got.Result != domain.AuditResultDenied {
+		t.Fatalf("denied stop = %#v", got)
+	}
+	if got := records[1]; got.Action != "scroll.grant.set" || got.Params["subject"] != "bob" || got.Params["role"] != "viewer" || got.Result != domain.AuditResultOK {
+		t.Fatalf("grant = %#v", got)
+	}
+	if got := records[2]; got.Action != "apikey.create" || got.Params["password"] != "[redacted]" {
+		t.Fatalf("api key create = %#v", got)
+	}
+
+	for _, tc := range []struct {
+		subject string
+		path    string
+		want    int
+	}{
+		{"", "/api/v1/audit", http.StatusOK},
+		{"bob", "/api/v1/audit", http.StatusForbidden},
+		{"bob", "/api/v1/audit?scroll=scroll-1", http.StatusForbidden},
+		{"alice", "/api/v1/audit?scroll=scroll-1", http.StatusOK},
+	} {
+		resp := do(tc.subject, http.MethodGet, tc.path, "")
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("%s GET %s = %d, want %d", tc.subject, tc.path, resp.StatusCode, tc.want)
+		}
+	}
+}
+
+func TestManagementRoutesScopeQuotas(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	running := domain.ProcedureStatusMap{"start": {"main": {Status: domain.ScrollLockStatusRunning}}}
+	for _, runtimeScroll := range []*domain.RuntimeScroll{
+		{ID: "scroll-1", OwnerID: "alice", Status: domain.RuntimeScrollStatusRunning, Procedures: running},
+		{ID: "scroll-2", OwnerID: "alice", Status: domain.RuntimeScrollStatusStopped},
+		{ID: "scroll-3", OwnerID: "bob", Status: domain.RuntimeScrollStatusStopped},
+	} {
+		if err := store.CreateScroll(runtimeScroll); err != nil {
+			t.Fatal(err)
+		}
+	}
+	supervisor := appservices.NewRuntimeSupervisor(store, services.NewRuntimeScrollManager(store), nil)
+	supervisor.SetQuotaPolicy(&domain.QuotaPolicy{Default: domain.QuotaLimits{MaxScrolls: 5, MaxRunningCommands: 1}})
+	logs := services.NewLogManager()
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	RegisterManagementRoutes(app, RouteHandlers{
+		Server:     NewRuntimeServer(NewHealthHandler(), NewScrollHandler(supervisor, services.NewConsoleManager(logs), logs)),
+		Websocket:  &WebsocketHandler{},
+		Authorizer: subjectAuthorizer{},
+	})
+
+	do := func(subject string, method string, path string) (*http.Response, []domain.OwnerQuotaStatus) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if subject != "" {
+			req.Header.Set("Authorization", "Bearer "+subject)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var quotas []domain.OwnerQuotaStatus
+		if resp.StatusCode == http.StatusOK && method == http.MethodGet {
+			if err := json.NewDecoder(resp.Body).Decode(&quotas); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return resp, quotas
+	}
+
+	resp, quotas := do("alice", http.MethodGet, "/api/v1/quotas")
+	if resp.StatusCode != http.StatusOK || len(quotas) != 1 || quotas[0].Owner != "alice" || quotas[0].Usage.Scrolls != 2 || quotas[0].Usage.RunningCommands != 1 || quotas[0].Limits.MaxScrolls != 5 {
+		t.Fatalf("alice quotas = %d %#v", resp.StatusCode, quotas)
+	}
+	if resp, _ := do("alice", http.MethodGet, "/api/v1/quotas?owner=bob"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("alice reading bob's quota = %d, want 403", resp.StatusCode)
+	}
+	resp, quotas = do("", http.MethodGet, "/api/v1/quotas")
+	if resp.StatusCode != http.StatusOK || len(quotas) != 2 || quotas[1].Owner != "bob" {
+		t.Fatalf("local quotas = %d %#v", resp.StatusCode, quotas)
+	}
+	if resp, _ := do("alice", http.MethodPost, "/api/v1/scrolls/scroll-2/start"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("start past max_running_commands = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestEventStreamFiltersScrollsTheCallerCannotRead(t *testing.T) {
+	store, err := docker.NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, runtimeScroll := range []*domain.RuntimeScroll{
+		{ID: "scroll-1", OwnerID: "alice", Grants: []domain.RuntimeGrant{{Subject: "bob", Role: domain.RuntimeRoleViewer}}},
+		{ID: "scroll-2", OwnerID: "alice"},
+	} {
+		if err := store.CreateScroll(runtimeScroll); err != nil {
+			t.Fatal(err)
+		}
+	}
+	supervisor := appservices.NewRuntimeSupervisor(store, services.NewRuntimeScrollManager(store), nil)
+	logs := services.NewLogManager()
+	websockets := &WebsocketHandler{}
+	websockets.SetScrollHandler(NewScrollHandler(supervisor, services.NewConsoleManager(logs), logs))
+	scopedKey := &domain.RuntimeAPIKey{Role: domain.RuntimeRoleOperator, Scrolls: []string{"scroll-1"}}
+
+	for _, tc := range []struct {
+		id      string
+		subject string
+		key     *domain.RuntimeAPIKey
+		want    bool
+	}{
+		{"scroll-1", "alice", nil, true},
+		{"scroll-2", "alice", nil, true},
+		{"scroll-1", "bob", nil, true},
+		{"scroll-2", "bob", nil, false},
+		{"scroll-1", "", scopedKey, true},
+		{"scroll-2", "", scopedKey, false},
+		{"", "bob", nil, false},
+	} {
+		if got := websockets.mayAccess(tc.id, tc.subject, tc.key, domain.PermissionScrollRead); got != tc.want {
+			t.Errorf("events of %q for subject %q key %v = %v, want %v", tc.id, tc.subject, tc.key != nil, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+// Reasons a console connection or its input is rejected, as reported in
+// audit records and metrics.
+const (
+	consoleRejectToken     = "token"
+	consoleRejectReplay    = "replay"
+	consoleRejectViewers   = "viewers"
+	consoleRejectInputSize = "input_size"
+	consoleRejectInputRate = "input_rate"
+	consoleRejectIdle      = "idle"
+)
+
+// consoleGuard applies domain.ConsoleLimits to console websockets. The zero
+// value has no limits.
+type consoleGuard struct {
+	limits domain.ConsoleLimits
+
+	mu      sync.Mutex
+	viewers map[string]int
+	// usedTokens holds the jti of consumed query tokens until they expire.
+	usedTokens map[string]time.Time
+
+	rejected sync.Map // reason -> *atomic.Int64
+}
+
+// consumeToken accepts a query token once. Tokens without a jti cannot be
+// told apart and are refused.
+func (g *consoleGuard) consumeToken(tokenID string, expires *time.Time, now time.Time) bool {
+	if tokenID == "" {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.usedTokens == nil {
+		g.usedTokens = map[string]time.Time{}
+	}
+	for id, until := range g.usedTokens {
+		if now.After(until) {
+			delete(g.usedTokens, id)
+		}
+	}
+	if _, used := g.usedTokens[tokenID]; used {
+		return false
+	}
+	until := now.Add(time.Hour)
+	if expires != nil {
+		until = *expires
+	}
+	g.usedTokens[tokenID] = until
+	return true
+}
+
+// acquireViewer counts a connection to consoleID, unless the console has
+// MaxViewers already. Every acquired viewer must be released.
+func (g *consoleGuard) acquireViewer(consoleID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.viewers == nil {
+		g.viewers = map[string]int{}
+	}
+	if g.limits.MaxViewers > 0 && g.viewers[consoleID] >= g.limits.MaxViewers {
+		return false
+	}
+	g.viewers[consoleID]++
+	return true
+}
+
+func (g *consoleGuard) releaseViewer(consoleID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.viewers[consoleID] <= 1 {
+		delete(g.viewers, consoleID)
+		return
+	}
+	g.viewers[consoleID]--
+}
+
+func (g *consoleGuard) reject(reason string) {
+	counter, _ := g.rejected.LoadOrStore(reason, &atomic.Int64{})
+	counter.(*atomic.Int64).Add(1)
+}
+
+func (g *consoleGuard) newInputBucket(now time.Time) *inputBucket {
+	burst := g.limits.InputBurst
+	if burst < g.limits.InputRate {
+		burst = g.limits.InputRate
+	}
+	return &inputBucket{rate: float64(g.limits.InputRate), burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// writeMetrics writes the counters in the Prometheus text format.
+func (g *consoleGuard) writeMetrics(w io.Writer) {
+	fmt.Fprintln(w, "# HELP druid_console_viewers Websocket connections attached to consoles.")
+	fmt.Fprintln(w, "# TYPE druid_console_viewers gauge")
+	g.mu.Lock()
+	viewers := 0
+	for _, count := range g.viewers {
+		viewers += count
+	}
+	g.mu.Unlock()
+	fmt.Fprintf(w, "druid_console_viewers %d\n", viewers)
+
+	fmt.Fprintln(w, "# HELP druid_console_rejected_total Console connections and input rejected by the console limits, by reason.")
+	fmt.Fprintln(w, "# TYPE druid_console_rejected_total counter")
+	counts := map[string]int64{}
+	g.rejected.Range(func(key, value any) bool {
+		counts[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "druid_console_rejected_total{reason=%q} %d\n", reason, counts[reason])
+	}
+}
+
+// inputBucket is the token bucket limiting the input rate of one
+// connection. A zero rate allows everything.
+type inputBucket struct {
+	rate, burst, tokens float64
+	last                time.Time
+}
+
+func (b *inputBucket) allow(now time.Time) bool {
+	if b.rate <= 0 {
+		return true
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Metrics serves the console counters for Prometheus.
+func (h *WebsocketHandler) Metrics(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4")
+	h.guard.writeMetrics(c.Response().BodyWriter())
+	return nil
+}
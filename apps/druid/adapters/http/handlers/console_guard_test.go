@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+func TestConsoleGuardConsumesQueryTokensOnce(t *testing.T) {
+	var guard consoleGuard
+	now := time.Now()
+	expires := now.Add(time.Minute)
+
+	if !guard.consumeToken("jti-1", &expires, now) {
+		t.Fatal("a fresh token was refused")
+	}
+	if guard.consumeToken("jti-1", &expires, now.Add(time.Second)) {
+		t.Fatal("a replayed token was accepted")
+	}
+	if guard.consumeToken("", &expires, now) {
+		t.Fatal("a token without id was accepted")
+	}
+	if !guard.consumeToken("jti-2", &expires, now) {
+		t.Fatal("another token was refused")
+	}
+	guard.consumeToken("jti-3", nil, now.Add(2*time.Minute))
+	if len(guard.usedTokens) != 1 {
+		t.Fatalf("expired tokens were kept: %v", guard.usedTokens)
+	}
+}
+
+func TestConsoleGuardCapsViewersPerConsole(t *testing.T) {
+	guard := consoleGuard{limits: domain.ConsoleLimits{MaxViewers: 2}}
+
+	if !guard.acquireViewer("game/serve") || !guard.acquireViewer("game/serve") {
+		t.Fatal("viewers below the cap were refused")
+	}
+	if guard.acquireViewer("game/serve") {
+		t.Fatal("a third viewer was accepted")
+	}
+	if !guard.acquireViewer("game/install") {
+		t.Fatal("the cap applies per console")
+	}
+	guard.releaseViewer("game/serve")
+	if !guard.acquireViewer("game/serve") {
+		t.Fatal("a released slot was not reused")
+	}
+}
+
+func TestInputBucketLimitsRateAfterBurst(t *testing.T) {
+	guard := consoleGuard{limits: domain.ConsoleLimits{InputRate: 2, InputBurst: 3}}
+	now := time.Now()
+	bucket := guard.newInputBucket(now)
+
+	for i := 0; i < 3; i++ {
+		if !bucket.allow(now) {
+			t.Fatalf("message %d of the burst was refused", i)
+		}
+	}
+	if bucket.allow(now) {
+		t.Fatal("a message past the burst was accepted")
+	}
+	if !bucket.allow(now.Add(500 * time.Millisecond)) {
+		t.Fatal("the bucket did not refill at the input rate")
+	}
+	if unlimited := (&consoleGuard{}).newInputBucket(now); !unlimited.allow(now) || !unlimited.allow(now) {
+		t.Fatal("a zero rate limited input")
+	}
+}
+
+func TestConsoleGuardWritesMetrics(t *testing.T) {
+	var guard consoleGuard
+	guard.acquireViewer("game/serve")
+	guard.reject(consoleRejectInputRate)
+	guard.reject(consoleRejectInputRate)
+	guard.reject(consoleRejectReplay)
+
+	var out strings.Builder
+	guard.writeMetrics(&out)
+	for _, line := range []string{
+		"druid_console_viewers 1",
+		`druid_console_rejected_total{reason="input_rate"} 2`,
+		`druid_console_rejected_total{reason="replay"} 1`,
+	} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Fatalf("metrics miss %q:\n%s", line, out.String())
+		}
+	}
+}
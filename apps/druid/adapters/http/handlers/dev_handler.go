@@ -9,21 +9,24 @@ func (h *ScrollHandler) CreateDaemonToken(c *fiber.Ctx) error {
 	if h == nil || h.authorizer == nil {
 		return c.JSON(map[string]string{"token": ""})
 	}
-	runtimeScroll, err := h.getScroll(c.Params("id"))
+	runtimeScroll, err := h.authorize(c, c.Params("id"), domain.PermissionScrollConsoleAttach)
 	if err != nil {
 		return err
 	}
 	ownerID := runtimeScroll.OwnerID
-	if subject, ok := c.Locals(ownerLocal).(string); ok && subject != "" {
+	if subject, ok := c.Locals(OwnerLocal).(string); ok && subject != "" {
 		ownerID = subject
 	}
 	if h.authorizer == nil {
 		return c.JSON(map[string]string{"token": ""})
 	}
-	return c.JSON(map[string]string{"token": h.authorizer.GenerateQueryToken(runtimeScroll.ID, ownerID)})
+	return c.JSON(map[string]string{"token": h.authorizer.GenerateQueryToken(runtimeScroll.ID, ownerID, c.Query("console"))})
 }
 
 func (h *ScrollHandler) AddDaemonCommand(c *fiber.Ctx) error {
+	if _, err := h.authorize(c, c.Params("id"), domain.PermissionScrollCommands); err != nil {
+		return err
+	}
 	var request domain.CommandInstructionSet
 	if err := c.BodyParser(&request); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
@@ -35,6 +38,9 @@ func (h *ScrollHandler) AddDaemonCommand(c *fiber.Ctx) error {
 }
 
 func (h *ScrollHandler) RemoveDaemonCommand(c *fiber.Ctx) error {
+	if _, err := h.authorize(c, c.Params("id"), domain.PermissionScrollCommands); err != nil {
+		return err
+	}
 	if err := h.supervisor.RemoveCommand(c.Params("id"), c.Params("command")); err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"gopkg.in/yaml.v2"
+)
+
+// PublicPolicy applies a domain.PublicAPIPolicy to the public listener. Set
+// swaps it while requests are served, so the daemon can reload it on
+// SIGHUP; rate limit counters start over with every Set.
+type PublicPolicy struct {
+	current atomic.Pointer[publicPolicyState]
+}
+
+type publicPolicyState struct {
+	policy     domain.PublicAPIPolicy
+	cors       fiber.Handler
+	perIP      *windowLimiter
+	perSubject *windowLimiter
+}
+
+func NewPublicPolicy(policy domain.PublicAPIPolicy) *PublicPolicy {
+	p := &PublicPolicy{}
+	p.Set(policy)
+	return p
+}
+
+// LoadPublicAPIPolicy reads a policy file; what it leaves out keeps the
+// defaults.
+func LoadPublicAPIPolicy(path string) (domain.PublicAPIPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.PublicAPIPolicy{}, fmt.Errorf("read public policy: %w", err)
+	}
+	var policy domain.PublicAPIPolicy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return domain.PublicAPIPolicy{}, fmt.Errorf("parse public policy %s: %w", path, err)
+	}
+	policy = policy.WithDefaults()
+	if err := policy.Validate(); err != nil {
+		return domain.PublicAPIPolicy{}, fmt.Errorf("public policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Set replaces the policy. It must be valid, see LoadPublicAPIPolicy.
+func (p *PublicPolicy) Set(policy domain.PublicAPIPolicy) {
+	policy = policy.WithDefaults()
+	p.current.Store(&publicPolicyState{
+		policy: policy,
+		cors: cors.New(cors.Config{
+			AllowOrigins:     strings.Join(policy.CORS.AllowOrigins, ","),
+			AllowMethods:     "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,PROPFIND,MOVE,MKCOL,COPY",
+			AllowHeaders:     strings.Join(policy.CORS.AllowHeaders, ","),
+			AllowCredentials: policy.CredentialsAllowed(),
+			ExposeHeaders:    strings.Join(policy.CORS.ExposeHeaders, ","),
+		}),
+		perIP:      newWindowLimiter(policy.RateLimit.PerIP, policy.RateLimit.Window),
+		perSubject: newWindowLimiter(policy.RateLimit.PerSubject, policy.RateLimit.Window),
+	})
+}
+
+func (p *PublicPolicy) Policy() domain.PublicAPIPolicy {
+	return p.current.Load().policy
+}
+
+func (p *PublicPolicy) CORS(c *fiber.Ctx) error {
+	return p.current.Load().cors(c)
+}
+
+// LimitIP rate limits requests by client IP.
+func (p *PublicPolicy) LimitIP(c *fiber.Ctx) error {
+	return limitRequest(c, p.current.Load().perIP, c.IP())
+}
+
+// LimitSubject rate limits requests by token subject. It runs after
+// PublicAuth; requests without a subject are left to LimitIP.
+func (p *PublicPolicy) LimitSubject(c *fiber.Ctx) error {
+	subject := requestSubject(c)
+	if subject == "" {
+		return c.Next()
+	}
+	return limitRequest(c, p.current.Load().perSubject, subject)
+}
+
+// Route serves handler while the policy enables route and 404 otherwise.
+func (p *PublicPolicy) Route(route string, handler fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !p.current.Load().policy.RouteEnabled(route) {
+			return fiber.ErrNotFound
+		}
+		return handler(c)
+	}
+}
+
+func limitRequest(c *fiber.Ctx, limiter *windowLimiter, key string) error {
+	if ok, retryAfter := limiter.allow(key, time.Now()); !ok {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		return fiber.NewError(fiber.StatusTooManyRequests, "rate limit exceeded")
+	}
+	return c.Next()
+}
+
+// windowLimiter allows limit requests per key in fixed windows. A zero
+// limit allows everything.
+type windowLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newWindowLimiter(limit int, window time.Duration) *windowLimiter {
+	return &windowLimiter{limit: limit, window: window, windows: map[string]*rateWindow{}}
+}
+
+func (l *windowLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= l.window {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	w := l.windows[key]
+	if w == nil || now.Sub(w.start) >= l.window {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
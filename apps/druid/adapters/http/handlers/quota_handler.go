@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+func (h *ScrollHandler) ListQuotas(c *fiber.Ctx, params api.ListQuotasParams) error {
+	owner := ""
+	if params.Owner != nil {
+		owner = *params.Owner
+	}
+	if requestAPIKey(c) != nil {
+		return fiber.NewError(fiber.StatusForbidden, "api keys have no quota")
+	}
+	if subject := requestSubject(c); subject != "" {
+		if owner != "" && owner != subject {
+			return fiber.NewError(fiber.StatusForbidden, "cannot read the quota of another owner")
+		}
+		owner = subject
+	}
+	if owner == "" {
+		statuses, err := h.supervisor.QuotaStatuses()
+		if err != nil {
+			return err
+		}
+		return c.JSON(statuses)
+	}
+	status, err := h.supervisor.QuotaStatus(owner)
+	if err != nil {
+		return err
+	}
+	return c.JSON([]*domain.OwnerQuotaStatus{status})
+}
+
+// admissionError turns quota rejections into 403, and artifacts the owner
+// may not deploy and scrolls the admission policy rejects into 422.
+func admissionError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrArtifactNotAdmitted), errors.Is(err, domain.ErrScrollNotAdmitted), errors.Is(err, domain.ErrBundleImagesMissing):
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+	return err
+}
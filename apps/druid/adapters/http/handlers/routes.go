@@ -3,8 +3,8 @@ package handlers
 import (
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/highcard-dev/daemon/internal/api"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 )
 
@@ -12,6 +12,12 @@ type RouteHandlers struct {
 	Server     *RuntimeServer
 	Websocket  *WebsocketHandler
 	Authorizer ports.AuthorizerServiceInterface
+	// Discovery is served at /.well-known/druid-configuration on the
+	// management routes when set.
+	Discovery *domain.DaemonDiscovery
+	// PublicPolicy shapes the public routes; nil uses
+	// domain.DefaultPublicAPIPolicy.
+	PublicPolicy *PublicPolicy
 }
 
 type RuntimeServer struct {
@@ -31,15 +37,28 @@ func RegisterRoutes(app *fiber.App, handlers RouteHandlers) {
 func RegisterManagementRoutes(app *fiber.App, handlers RouteHandlers) {
 	if handlers.Authorizer != nil {
 		app.Use(func(ctx *fiber.Ctx) error {
-			if _, err := handlers.Authorizer.CheckHeader(ctx); err != nil {
+			auth, err := handlers.Authorizer.CheckHeader(ctx)
+			if err != nil {
 				return fiber.NewError(fiber.StatusUnauthorized, err.Error())
 			}
+			if auth != nil {
+				ctx.Locals(OwnerLocal, auth.Subject)
+			}
 			return ctx.Next()
 		})
 	}
+	if handlers.Server != nil && handlers.Server.ScrollHandler != nil {
+		app.Use(handlers.Server.AuditRequests)
+	}
 	api.RegisterHandlersWithOptions(app, handlers.Server, api.FiberServerOptions{})
 	app.Get("/health", handlers.Server.GetHealthAuth)
+	app.Get("/metrics", handlers.Websocket.Metrics)
+	if handlers.Discovery != nil {
+		app.Get("/.well-known/druid-configuration", DaemonDiscovery(*handlers.Discovery))
+	}
 	app.Get("/ws/v1/scrolls/:id/consoles/:console", websocket.New(handlers.Websocket.AttachConsole))
+	app.Get("/ws/v1/events", websocket.New(handlers.Websocket.StreamEvents))
+	app.Get("/ws/v1/scrolls/:id/events", handlers.Websocket.RequireScrollPermission(domain.PermissionScrollRead), websocket.New(handlers.Websocket.StreamEvents))
 }
 
 func RegisterPublicRoutes(app *fiber.App, handlers RouteHandlers) {
@@ -47,30 +66,33 @@ func RegisterPublicRoutes(app *fiber.App, handlers RouteHandlers) {
 	if handlers.Server != nil && handlers.Server.ScrollHandler != nil {
 		authorizer = handlers.Server.ScrollHandler.authorizer
 	}
-	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "https://app.druid.gg,http://localhost:3000,http://127.0.0.1:3000",
-		AllowMethods:     "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD,PROPFIND,MOVE,MKCOL,COPY",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Requested-With,Cache-Control,DNT,Keep-Alive,User-Agent,If-Modified-Since,Depth,Destination,Overwrite,If,Lock-Token,Timeout,Dav",
-		AllowCredentials: true,
-		ExposeHeaders:    "Druid-Version",
-	}))
+	policy := handlers.PublicPolicy
+	if policy == nil {
+		policy = NewPublicPolicy(domain.DefaultPublicAPIPolicy())
+	}
+	app.Use(policy.CORS)
+	app.Use(policy.LimitIP)
+	if handlers.Server != nil && handlers.Server.ScrollHandler != nil {
+		app.Use(handlers.Server.AuditRequests)
+	}
 	app.Get("/health", handlers.Server.GetHealthAuth)
 	app.Get("/.well-known/jwks.json", RuntimeJWKS(authorizer))
 	app.Get("/:id/ws/v1/serve/:console", websocket.New(handlers.Websocket.AttachScrollConsole))
 	if handlers.Server != nil && handlers.Server.ScrollHandler != nil {
 		app.Use("/:id", handlers.Server.PublicAuth)
 	}
-	app.Get("/:id/api/v1/health", handlers.Server.GetHealthAuth)
-	app.Get("/:id/api/v1/token", handlers.Server.CreateDaemonToken)
-	app.Get("/:id/api/v1/scroll", handlers.Server.GetDaemonScroll)
-	app.Put("/:id/api/v1/scroll/commands/:command", handlers.Server.AddDaemonCommand)
-	app.Delete("/:id/api/v1/scroll/commands/:command", handlers.Server.RemoveDaemonCommand)
-	app.Post("/:id/api/v1/command", handlers.Server.RunDaemonCommand)
-	app.Get("/:id/api/v1/queue", handlers.Server.GetDaemonQueue)
-	app.Get("/:id/api/v1/consoles", handlers.Server.GetDaemonConsoles)
-	app.Get("/:id/api/v1/logs", handlers.Server.GetDaemonLogs)
-	app.Get("/:id/api/v1/logs/:stream", handlers.Server.GetDaemonStreamLogs)
-	app.Get("/:id/api/v1/ports", handlers.Server.GetDaemonPorts)
-	app.Get("/:id/api/v1/ui/packages", handlers.Server.GetDaemonUIPackages)
-	app.Post("/:id/api/v1/ui/packages/:scope/publish", handlers.Server.PublishDaemonUIPackage)
+	app.Use("/:id", policy.LimitSubject)
+	app.Get("/:id/api/v1/health", policy.Route("health", handlers.Server.GetHealthAuth))
+	app.Get("/:id/api/v1/token", policy.Route("token", handlers.Server.CreateDaemonToken))
+	app.Get("/:id/api/v1/scroll", policy.Route("scroll", handlers.Server.GetDaemonScroll))
+	app.Put("/:id/api/v1/scroll/commands/:command", policy.Route("scroll/commands", handlers.Server.AddDaemonCommand))
+	app.Delete("/:id/api/v1/scroll/commands/:command", policy.Route("scroll/commands", handlers.Server.RemoveDaemonCommand))
+	app.Post("/:id/api/v1/command", policy.Route("command", handlers.Server.RunDaemonCommand))
+	app.Get("/:id/api/v1/queue", policy.Route("queue", handlers.Server.GetDaemonQueue))
+	app.Get("/:id/api/v1/consoles", policy.Route("consoles", handlers.Server.GetDaemonConsoles))
+	app.Get("/:id/api/v1/logs", policy.Route("logs", handlers.Server.GetDaemonLogs))
+	app.Get("/:id/api/v1/logs/:stream", policy.Route("logs", handlers.Server.GetDaemonStreamLogs))
+	app.Get("/:id/api/v1/ports", policy.Route("ports", handlers.Server.GetDaemonPorts))
+	app.Get("/:id/api/v1/ui/packages", policy.Route("ui/packages", handlers.Server.GetDaemonUIPackages))
+	app.Post("/:id/api/v1/ui/packages/:scope/publish", policy.Route("ui/packages/publish", handlers.Server.PublishDaemonUIPackage))
 }
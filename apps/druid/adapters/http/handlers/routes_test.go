@@ -3,9 +3,13 @@ package handlers
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 )
 
 func TestRouteSplitKeepsManagementAndPublicSurfacesSeparate(t *testing.T) {
@@ -58,6 +62,90 @@ func TestPublicRoutesAnswerCorsPreflight(t *testing.T) {
 	}
 }
 
+func TestPublicPolicyChangesCorsOriginsOnReload(t *testing.T) {
+	policy := NewPublicPolicy(domain.PublicAPIPolicy{CORS: domain.PublicCORSPolicy{AllowOrigins: []string{"https://panel.example"}}})
+	public := fiber.New(fiber.Config{DisableStartupMessage: true})
+	RegisterPublicRoutes(public, RouteHandlers{Server: NewRuntimeServer(NewHealthHandler(), nil), Websocket: &WebsocketHandler{}, PublicPolicy: policy})
+
+	allowed := func(origin string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodOptions, "/scroll-1/api/v1/scroll", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		resp, err := public.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.Header.Get("Access-Control-Allow-Origin")
+	}
+	if got := allowed("https://panel.example"); got != "https://panel.example" {
+		t.Fatalf("configured origin got %q", got)
+	}
+	if got := allowed("https://app.druid.gg"); got != "" {
+		t.Fatalf("default origin is still allowed: %q", got)
+	}
+
+	policy.Set(domain.PublicAPIPolicy{CORS: domain.PublicCORSPolicy{AllowOrigins: []string{"https://other.example"}}})
+	if got := allowed("https://panel.example"); got != "" {
+		t.Fatalf("origin removed by the reload is still allowed: %q", got)
+	}
+	if got := allowed("https://other.example"); got != "https://other.example" {
+		t.Fatalf("reloaded origin got %q", got)
+	}
+}
+
+func TestPublicPolicyDisablesRoutesAndRateLimits(t *testing.T) {
+	policy := NewPublicPolicy(domain.PublicAPIPolicy{
+		Routes:    []string{"health"},
+		RateLimit: domain.PublicRateLimit{PerIP: 2, Window: time.Hour},
+	})
+	public := fiber.New(fiber.Config{DisableStartupMessage: true})
+	RegisterPublicRoutes(public, RouteHandlers{Server: NewRuntimeServer(NewHealthHandler(), nil), Websocket: &WebsocketHandler{}, PublicPolicy: policy})
+
+	if status := requestStatus(t, public, "/scroll-1/api/v1/token"); status != http.StatusNotFound {
+		t.Fatalf("disabled token route = %d, want 404", status)
+	}
+	if status := requestStatus(t, public, "/scroll-1/api/v1/health"); status != http.StatusOK {
+		t.Fatalf("enabled health route = %d, want 200", status)
+	}
+	resp, err := public.Test(httptest.NewRequest(http.MethodGet, "/scroll-1/api/v1/health", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+		t.Fatalf("third request = %d retry-after %q, want 429", resp.StatusCode, resp.Header.Get(fiber.HeaderRetryAfter))
+	}
+}
+
+func TestLoadPublicAPIPolicyKeepsDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "public.yaml")
+	if err := os.WriteFile(path, []byte("cors:\n  allow_origins: [https://panel.example]\nrate_limit:\n  per_subject: 60\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPublicAPIPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.CORS.AllowHeaders) == 0 || !policy.CredentialsAllowed() || policy.RateLimit.Window != time.Minute || policy.RateLimit.PerSubject != 60 {
+		t.Fatalf("policy = %#v", policy)
+	}
+
+	for _, invalid := range []string{
+		"cors:\n  allow_origins: [\"*\"]\n",
+		"routes: [files]\n",
+		"rate_limits: {}\n",
+	} {
+		if err := os.WriteFile(path, []byte(invalid), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPublicAPIPolicy(path); err == nil {
+			t.Errorf("%q was accepted", invalid)
+		}
+	}
+}
+
 func requestStatus(t *testing.T, app *fiber.App, path string) int {
 	t.Helper()
 	req := httptest.NewRequest(http.MethodGet, path, nil)
@@ -17,6 +17,8 @@ type ScrollHandler struct {
 	consoleService             *services.ConsoleManager
 	logService                 *services.LogManager
 	authorizer                 ports.AuthorizerServiceInterface
+	apiKeys                    *services.APIKeyService
+	audit                      *services.AuditLog
 	allowUnauthenticatedPublic bool
 }
 
@@ -43,11 +45,18 @@ func registryCredentials(in *[]api.RegistryCredential) []domain.RegistryCredenti
 	}
 	out := make([]domain.RegistryCredential, 0, len(*in))
 	for _, credential := range *in {
-		out = append(out, domain.RegistryCredential{
+		item := domain.RegistryCredential{
 			Host:     credential.Host,
 			Username: credential.Username,
 			Password: credential.Password,
-		})
+		}
+		if credential.IdentityToken != nil {
+			item.IdentityToken = *credential.IdentityToken
+		}
+		if credential.RegistryToken != nil {
+			item.RegistryToken = *credential.RegistryToken
+		}
+		out = append(out, item)
 	}
 	return out
 }
@@ -57,7 +66,13 @@ func (h *ScrollHandler) ListScrolls(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(scrolls)
+	visible := make([]*domain.RuntimeScroll, 0, len(scrolls))
+	for _, runtimeScroll := range scrolls {
+		if callerSees(c, runtimeScroll) {
+			visible = append(visible, runtimeScroll)
+		}
+	}
+	return c.JSON(visible)
 }
 
 func (h *ScrollHandler) CreateScroll(c *fiber.Ctx) error {
@@ -75,6 +90,13 @@ func (h *ScrollHandler) CreateScroll(c *fiber.Ctx) error {
 	if request.OwnerId != nil {
 		ownerID = *request.OwnerId
 	}
+	ownerID, err := subjectOwner(c, ownerID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeCreate(c, name); err != nil {
+		return err
+	}
 	namespace := ""
 	if request.Namespace != nil {
 		namespace = *request.Namespace
@@ -84,7 +106,7 @@ func (h *ScrollHandler) CreateScroll(c *fiber.Ctx) error {
 		if errors.Is(err, domain.ErrRuntimeScrollAlreadyExists) {
 			return fiber.NewError(fiber.StatusConflict, err.Error())
 		}
-		return err
+		return admissionError(err)
 	}
 	return c.Status(fiber.StatusCreated).JSON(runtimeScroll)
 }
@@ -104,6 +126,24 @@ func (h *ScrollHandler) EnsureScroll(c *fiber.Ctx) error {
 	if request.OwnerId != nil {
 		ownerID = *request.OwnerId
 	}
+	ownerID, err := subjectOwner(c, ownerID)
+	if err != nil {
+		return err
+	}
+	if requestSubject(c) != "" || requestAPIKey(c) != nil {
+		// Ensuring an existing runtime updates it and never changes its owner.
+		existing, err := h.supervisor.Get(services.RuntimeScrollIDFromName(name))
+		if err == nil {
+			if !callerAllows(c, existing, domain.PermissionScrollUpdate) {
+				return fiber.NewError(fiber.StatusForbidden, "missing permission "+domain.PermissionScrollUpdate)
+			}
+			ownerID = ""
+		} else if !errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+			return err
+		} else if err := authorizeCreate(c, name); err != nil {
+			return err
+		}
+	}
 	namespace := ""
 	if request.Namespace != nil {
 		namespace = *request.Namespace
@@ -114,16 +154,17 @@ func (h *ScrollHandler) EnsureScroll(c *fiber.Ctx) error {
 		OwnerID:             ownerID,
 		Namespace:           namespace,
 		RegistryCredentials: registryCredentials(request.RegistryCredentials),
+		Initiator:           requestInitiator(c),
 	}
 	runtimeScroll, err := h.supervisor.Ensure(options)
 	if err != nil {
-		return err
+		return admissionError(err)
 	}
 	return c.JSON(runtimeScroll)
 }
 
 func (h *ScrollHandler) GetScroll(c *fiber.Ctx, id string) error {
-	runtimeScroll, err := h.getScroll(id)
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollRead)
 	if err != nil {
 		return err
 	}
@@ -131,7 +172,7 @@ func (h *ScrollHandler) GetScroll(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) DeleteScroll(c *fiber.Ctx, id string) error {
-	runtimeScroll, err := h.getScroll(id)
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollDelete)
 	if err != nil {
 		return err
 	}
@@ -145,18 +186,18 @@ func (h *ScrollHandler) DeleteScroll(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) StartScroll(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollStart); err != nil {
 		return err
 	}
 	runtimeScroll, err := h.supervisor.StartScroll(id)
 	if err != nil {
-		return err
+		return admissionError(err)
 	}
 	return c.JSON(runtimeScroll)
 }
 
 func (h *ScrollHandler) StopScroll(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollStop); err != nil {
 		return err
 	}
 	runtimeScroll, err := h.supervisor.Stop(id)
@@ -167,7 +208,7 @@ func (h *ScrollHandler) StopScroll(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) UpdateScroll(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollUpdate); err != nil {
 		return err
 	}
 	var request api.UpdateScrollRequest
@@ -180,7 +221,73 @@ func (h *ScrollHandler) UpdateScroll(c *fiber.Ctx, id string) error {
 	if request.Artifact != nil {
 		artifact = *request.Artifact
 	}
-	runtimeScroll, err := h.supervisor.Update(id, artifact, registryCredentials(request.RegistryCredentials))
+	runtimeScroll, err := h.supervisor.Update(id, artifact, requestInitiator(c), registryCredentials(request.RegistryCredentials))
+	if err != nil {
+		return admissionError(err)
+	}
+	return c.JSON(runtimeScroll)
+}
+
+func (h *ScrollHandler) RollbackScroll(c *fiber.Ctx, id string) error {
+	if _, err := h.authorize(c, id, domain.PermissionScrollUpdate); err != nil {
+		return err
+	}
+	var request api.RollbackScrollRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+	to := -1
+	if request.To != nil {
+		if *request.To < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "to must not be negative")
+		}
+		to = *request.To
+	}
+	runtimeScroll, err := h.supervisor.Rollback(id, to, requestInitiator(c), registryCredentials(request.RegistryCredentials))
+	if errors.Is(err, domain.ErrNoRollbackTarget) {
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return admissionError(err)
+	}
+	return c.JSON(runtimeScroll)
+}
+
+func (h *ScrollHandler) SetScrollUpdatePolicy(c *fiber.Ctx, id string) error {
+	if _, err := h.authorize(c, id, domain.PermissionScrollUpdate); err != nil {
+		return err
+	}
+	var request api.RuntimeUpdatePolicy
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	policy := &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicyMode(request.Mode)}
+	if request.Constraint != nil {
+		policy.Constraint = *request.Constraint
+	}
+	if request.Channel != nil {
+		policy.Channel = *request.Channel
+	}
+	if request.Window != nil {
+		policy.Window = *request.Window
+	}
+	if err := policy.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	runtimeScroll, err := h.supervisor.SetUpdatePolicy(id, policy)
+	if err != nil {
+		return err
+	}
+	return c.JSON(runtimeScroll)
+}
+
+func (h *ScrollHandler) DeleteScrollUpdatePolicy(c *fiber.Ctx, id string) error {
+	if _, err := h.authorize(c, id, domain.PermissionScrollUpdate); err != nil {
+		return err
+	}
+	runtimeScroll, err := h.supervisor.SetUpdatePolicy(id, nil)
 	if err != nil {
 		return err
 	}
@@ -188,26 +295,26 @@ func (h *ScrollHandler) UpdateScroll(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) RunScrollCommand(c *fiber.Ctx, id string, command string, params api.RunScrollCommandParams) error {
-	runtimeScroll, err := h.getScroll(id)
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollRun(command))
 	if err != nil {
 		return err
 	}
 	if params.Sync != nil && *params.Sync {
 		updated, err := h.supervisor.RunAndWait(runtimeScroll.ID, command)
 		if err != nil {
-			return err
+			return admissionError(err)
 		}
 		return c.JSON(updated)
 	}
 	updated, err := h.supervisor.RunWithContext(c.UserContext(), runtimeScroll.ID, command)
 	if err != nil {
-		return err
+		return admissionError(err)
 	}
 	return c.JSON(updated)
 }
 
 func (h *ScrollHandler) GetScrollConfig(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead); err != nil {
 		return err
 	}
 	scrollFile, err := h.supervisor.ScrollFile(id)
@@ -218,7 +325,7 @@ func (h *ScrollHandler) GetScrollConfig(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) GetScrollQueue(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead); err != nil {
 		return err
 	}
 	queue, err := h.supervisor.Queue(id)
@@ -229,7 +336,7 @@ func (h *ScrollHandler) GetScrollQueue(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) GetScrollConsoles(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead, domain.PermissionScrollConsoleAttach); err != nil {
 		return err
 	}
 	prefix := id + "/"
@@ -243,7 +350,7 @@ func (h *ScrollHandler) GetScrollConsoles(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) GetScrollLogs(c *fiber.Ctx, id string) error {
-	logs, err := h.scrollLogs(id)
+	logs, err := h.scrollLogs(c, id)
 	if err != nil {
 		return err
 	}
@@ -265,6 +372,9 @@ func (h *ScrollHandler) RunDaemonCommand(c *fiber.Ctx) error {
 	if request.Command == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "command is required")
 	}
+	if _, err := h.authorize(c, c.Params("id"), domain.PermissionScrollRun(request.Command)); err != nil {
+		return err
+	}
 	var err error
 	if request.Sync {
 		_, err = h.supervisor.RunAndWait(c.Params("id"), request.Command)
@@ -272,7 +382,7 @@ func (h *ScrollHandler) RunDaemonCommand(c *fiber.Ctx) error {
 		_, err = h.supervisor.RunWithContext(c.UserContext(), c.Params("id"), request.Command)
 	}
 	if err != nil {
-		return err
+		return admissionError(err)
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
@@ -286,7 +396,7 @@ func (h *ScrollHandler) GetDaemonConsoles(c *fiber.Ctx) error {
 }
 
 func (h *ScrollHandler) GetDaemonLogs(c *fiber.Ctx) error {
-	logs, err := h.scrollLogs(c.Params("id"))
+	logs, err := h.scrollLogs(c, c.Params("id"))
 	if err != nil {
 		return err
 	}
@@ -298,7 +408,7 @@ func (h *ScrollHandler) GetDaemonLogs(c *fiber.Ctx) error {
 }
 
 func (h *ScrollHandler) GetDaemonStreamLogs(c *fiber.Ctx) error {
-	logs, err := h.scrollLogs(c.Params("id"))
+	logs, err := h.scrollLogs(c, c.Params("id"))
 	if err != nil {
 		return err
 	}
@@ -311,7 +421,7 @@ func (h *ScrollHandler) GetDaemonPorts(c *fiber.Ctx) error {
 }
 
 func (h *ScrollHandler) GetScrollPorts(c *fiber.Ctx, id string) error {
-	runtimeScroll, err := h.getScroll(id)
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollRead)
 	if err != nil {
 		return err
 	}
@@ -322,8 +432,20 @@ func (h *ScrollHandler) GetScrollPorts(c *fiber.Ctx, id string) error {
 	return c.JSON(statuses)
 }
 
+func (h *ScrollHandler) GetScrollEvents(c *fiber.Ctx, id string) error {
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollRead)
+	if err != nil {
+		return err
+	}
+	events, err := h.supervisor.Events(runtimeScroll.ID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(events)
+}
+
 func (h *ScrollHandler) GetScrollRoutingTargets(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead); err != nil {
 		return err
 	}
 	targets, err := h.supervisor.RoutingTargets(id)
@@ -334,7 +456,7 @@ func (h *ScrollHandler) GetScrollRoutingTargets(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) ApplyScrollRouting(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRouting); err != nil {
 		return err
 	}
 	var request struct {
@@ -345,13 +467,13 @@ func (h *ScrollHandler) ApplyScrollRouting(c *fiber.Ctx, id string) error {
 	}
 	runtimeScroll, err := h.supervisor.ApplyRouting(id, request.Assignments)
 	if err != nil {
-		return err
+		return admissionError(err)
 	}
 	return c.JSON(runtimeScroll)
 }
 
 func (h *ScrollHandler) GetScrollUIPackages(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead); err != nil {
 		return err
 	}
 	packages, err := h.supervisor.UIPackages(id)
@@ -365,7 +487,7 @@ func (h *ScrollHandler) GetScrollUIPackages(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) PublishScrollUIPackage(c *fiber.Ctx, id string, scope api.PublishScrollUIPackageParamsScope) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollUIPublish); err != nil {
 		return err
 	}
 	var request struct {
@@ -396,7 +518,7 @@ func (h *ScrollHandler) PublishDaemonUIPackage(c *fiber.Ctx) error {
 }
 
 func (h *ScrollHandler) BackupScroll(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollBackup); err != nil {
 		return err
 	}
 	var request api.RuntimeArtifactOperationRequest
@@ -411,7 +533,7 @@ func (h *ScrollHandler) BackupScroll(c *fiber.Ctx, id string) error {
 }
 
 func (h *ScrollHandler) RestoreScroll(c *fiber.Ctx, id string) error {
-	if _, err := h.getScroll(id); err != nil {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRestore); err != nil {
 		return err
 	}
 	var request api.RuntimeArtifactOperationRequest
@@ -429,6 +551,76 @@ func (h *ScrollHandler) RestoreScroll(c *fiber.Ctx, id string) error {
 	return c.JSON(runtimeScroll)
 }
 
+func (h *ScrollHandler) SetScrollGrant(c *fiber.Ctx, id string, subject string) error {
+	runtimeScroll, err := h.authorize(c, id, domain.PermissionScrollGrants)
+	if err != nil {
+		return err
+	}
+	var request api.RuntimeGrantRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	grant := domain.RuntimeGrant{Subject: subject}
+	if request.Role != nil {
+		grant.Role = domain.RuntimeRole(*request.Role)
+	}
+	if request.Permissions != nil {
+		grant.Permissions = *request.Permissions
+	}
+	if err := grant.Validate(); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	// Nobody can hand out more than they hold, so scroll:grants alone does
+	// not lead to owner rights.
+	for _, permission := range grant.GrantedPermissions() {
+		if !callerAllows(c, runtimeScroll, permission) {
+			return fiber.NewError(fiber.StatusForbidden, "cannot grant "+permission+" without holding it")
+		}
+	}
+	runtimeScroll, err = h.supervisor.SetGrant(id, grant)
+	if err != nil {
+		return err
+	}
+	return c.JSON(runtimeScroll)
+}
+
+func (h *ScrollHandler) DeleteScrollGrant(c *fiber.Ctx, id string, subject string) error {
+	if _, err := h.authorize(c, id, domain.PermissionScrollGrants); err != nil {
+		return err
+	}
+	runtimeScroll, err := h.supervisor.RemoveGrant(id, subject)
+	if errors.Is(err, domain.ErrRuntimeGrantNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(runtimeScroll)
+}
+
+// subjectOwner makes a token subject the owner of the runtimes it creates.
+// Management callers without a subject may name any owner.
+func subjectOwner(c *fiber.Ctx, ownerID string) (string, error) {
+	subject := requestSubject(c)
+	if subject == "" {
+		return ownerID, nil
+	}
+	if ownerID != "" && ownerID != subject {
+		return "", fiber.NewError(fiber.StatusForbidden, "cannot create a runtime for another owner")
+	}
+	return subject, nil
+}
+
+// authorizeCreate checks that an API key may create runtime name. Token
+// subjects may always create runtimes they own.
+func authorizeCreate(c *fiber.Ctx, name string) error {
+	key := requestAPIKey(c)
+	if key == nil || key.Allows(services.RuntimeScrollIDFromName(name), domain.PermissionScrollCreate) {
+		return nil
+	}
+	return fiber.NewError(fiber.StatusForbidden, "missing permission "+domain.PermissionScrollCreate)
+}
+
 func (h *ScrollHandler) getScroll(id string) (*domain.RuntimeScroll, error) {
 	runtimeScroll, err := h.supervisor.Get(id)
 	if errors.Is(err, domain.ErrRuntimeScrollNotFound) {
@@ -437,8 +629,8 @@ func (h *ScrollHandler) getScroll(id string) (*domain.RuntimeScroll, error) {
 	return runtimeScroll, err
 }
 
-func (h *ScrollHandler) scrollLogs(id string) (map[string][]string, error) {
-	if _, err := h.getScroll(id); err != nil {
+func (h *ScrollHandler) scrollLogs(c *fiber.Ctx, id string) (map[string][]string, error) {
+	if _, err := h.authorize(c, id, domain.PermissionScrollRead); err != nil {
 		return nil, err
 	}
 	prefix := id + "/"
@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
 	"time"
 
 	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 	"github.com/highcard-dev/daemon/internal/core/services"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
@@ -15,10 +21,18 @@ type WebsocketHandler struct {
 	scrolls                    *ScrollHandler
 	authorizer                 ports.AuthorizerServiceInterface
 	allowUnauthenticatedPublic bool
+	guard                      consoleGuard
 }
 
 func NewWebsocketHandler(consoleService *services.ConsoleManager) *WebsocketHandler {
-	return &WebsocketHandler{consoleService: consoleService}
+	h := &WebsocketHandler{consoleService: consoleService}
+	h.guard.limits = domain.DefaultConsoleLimits()
+	return h
+}
+
+// SetConsoleLimits replaces the console limits; call it before serving.
+func (h *WebsocketHandler) SetConsoleLimits(limits domain.ConsoleLimits) {
+	h.guard.limits = limits
 }
 
 func (h *WebsocketHandler) SetScrollHandler(scrolls *ScrollHandler) {
@@ -36,11 +50,56 @@ func (h *WebsocketHandler) SetAllowUnauthenticatedPublic(allow bool) {
 func (h *WebsocketHandler) AttachConsole(c *websocket.Conn) {
 	consoleID := c.Params("console")
 	if id := c.Params("id"); id != "" {
+		subject, _ := c.Locals(OwnerLocal).(string)
+		key, _ := c.Locals(APIKeyLocal).(*domain.RuntimeAPIKey)
+		if !h.mayAccess(id, subject, key, domain.PermissionScrollConsoleAttach) {
+			h.auditConsole(c, domain.AuditActionConsoleAttach, consoleID, domain.AuditResultDenied, "")
+			_ = c.Close()
+			return
+		}
 		consoleID = id + "/" + consoleID
 	}
+	if !h.guard.acquireViewer(consoleID) {
+		h.rejectConsole(c, domain.AuditActionConsoleAttach, consoleID, consoleRejectViewers, "")
+		_ = c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many viewers"))
+		_ = c.Close()
+		return
+	}
+	defer h.guard.releaseViewer(consoleID)
+	h.auditConsole(c, domain.AuditActionConsoleAttach, consoleID, domain.AuditResultOK, "")
 	h.attach(c, consoleID)
 }
 
+// mayAccess reports whether subject or key holds permission on runtime id.
+// Callers with neither passed management or public auth already.
+func (h *WebsocketHandler) mayAccess(id string, subject string, key *domain.RuntimeAPIKey, permission string) bool {
+	if key != nil {
+		return key.Allows(id, permission)
+	}
+	if subject == "" || h.scrolls == nil || h.scrolls.supervisor == nil {
+		return true
+	}
+	runtimeScroll, err := h.scrolls.supervisor.Get(id)
+	if err != nil {
+		return false
+	}
+	return runtimeScroll.Allows(subject, permission)
+}
+
+// RequireScrollPermission rejects a websocket upgrade for runtime :id unless
+// the caller holds permission on it.
+func (h *WebsocketHandler) RequireScrollPermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if h.scrolls == nil || h.scrolls.supervisor == nil {
+			return c.Next()
+		}
+		if _, err := h.scrolls.authorize(c, c.Params("id"), permission); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
 func (h *WebsocketHandler) AttachScrollConsole(c *websocket.Conn) {
 	if !h.PublicQueryAuth(c) {
 		_ = c.Close()
@@ -49,6 +108,67 @@ func (h *WebsocketHandler) AttachScrollConsole(c *websocket.Conn) {
 	h.AttachConsole(c)
 }
 
+// StreamEvents sends runtime events as JSON text messages. With an :id param
+// only events of that scroll are sent. Events of scrolls the caller may not
+// read are dropped.
+func (h *WebsocketHandler) StreamEvents(c *websocket.Conn) {
+	defer c.Close()
+	if h.scrolls == nil || h.scrolls.supervisor == nil {
+		return
+	}
+	scrollID := c.Params("id")
+	subject, _ := c.Locals(OwnerLocal).(string)
+	key, _ := c.Locals(APIKeyLocal).(*domain.RuntimeAPIKey)
+	unrestricted := subject == "" && key == nil
+	subscription := h.scrolls.supervisor.SubscribeEvents()
+	if subscription == nil {
+		return
+	}
+	defer h.scrolls.supervisor.UnsubscribeEvents(subscription)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	pingTicker := time.NewTicker(30 * time.Second)
+	defer pingTicker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case data, ok := <-subscription:
+			if !ok || data == nil {
+				return
+			}
+			if scrollID != "" || !unrestricted {
+				var event domain.RuntimeEvent
+				if err := json.Unmarshal(*data, &event); err != nil {
+					continue
+				}
+				if scrollID != "" && event.ScrollID != scrollID {
+					continue
+				}
+				if !unrestricted && !h.mayAccess(event.ScrollID, subject, key, domain.PermissionScrollRead) {
+					continue
+				}
+			}
+			if err := c.WriteMessage(websocket.TextMessage, *data); err != nil {
+				return
+			}
+		case <-pingTicker.C:
+			if err := c.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (h *WebsocketHandler) attach(c *websocket.Conn, consoleID string) {
 	defer c.Close()
 
@@ -61,19 +181,39 @@ func (h *WebsocketHandler) attach(c *websocket.Conn, consoleID string) {
 	subscription := console.Channel.Subscribe()
 	defer console.Channel.Unsubscribe(subscription)
 
+	// The reader sets closeMessage before done is closed; only this
+	// goroutine writes to the connection.
+	var closeMessage []byte
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
+		bucket := h.guard.newInputBucket(time.Now())
 		for {
-			_, data, err := c.ReadMessage()
+			data, reason, err := h.readInput(c)
+			if reason == consoleRejectIdle {
+				h.guard.reject(reason)
+			} else if reason != "" {
+				h.rejectConsole(c, domain.AuditActionConsoleInput, consoleID, reason, string(data))
+			}
+			if reason != "" {
+				closeMessage = websocket.FormatCloseMessage(consoleRejectCloseCode(reason), "console limit: "+reason)
+				return
+			}
 			if err != nil {
 				return
 			}
+			if !bucket.allow(time.Now()) {
+				h.rejectConsole(c, domain.AuditActionConsoleInput, consoleID, consoleRejectInputRate, string(data))
+				closeMessage = websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "console limit: "+consoleRejectInputRate)
+				return
+			}
 			if console.WriteInput != nil {
 				if err := console.WriteInput(string(data)); err != nil {
+					h.auditConsole(c, domain.AuditActionConsoleInput, consoleID, domain.AuditResultError, string(data))
 					logger.Log().Debug("Failed to write console input", zap.Error(err))
 					return
 				}
+				h.auditConsole(c, domain.AuditActionConsoleInput, consoleID, domain.AuditResultOK, string(data))
 			}
 		}
 	}()
@@ -83,6 +223,9 @@ func (h *WebsocketHandler) attach(c *websocket.Conn, consoleID string) {
 	for {
 		select {
 		case <-done:
+			if closeMessage != nil {
+				_ = c.WriteMessage(websocket.CloseMessage, closeMessage)
+			}
 			return
 		case data, ok := <-subscription:
 			if !ok || data == nil {
@@ -98,3 +241,43 @@ func (h *WebsocketHandler) attach(c *websocket.Conn, consoleID string) {
 		}
 	}
 }
+
+// readInput reads the next input message within MaxInputBytes and
+// IdleTimeout. A non-empty reason rejects the connection; data then holds
+// the start of an oversized message.
+func (h *WebsocketHandler) readInput(c *websocket.Conn) ([]byte, string, error) {
+	limits := h.guard.limits
+	if limits.IdleTimeout > 0 {
+		_ = c.SetReadDeadline(time.Now().Add(limits.IdleTimeout))
+	}
+	_, reader, err := c.NextReader()
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, consoleRejectIdle, err
+		}
+		return nil, "", err
+	}
+	if limits.MaxInputBytes <= 0 {
+		data, err := io.ReadAll(reader)
+		return data, "", err
+	}
+	data, err := io.ReadAll(io.LimitReader(reader, int64(limits.MaxInputBytes)+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > limits.MaxInputBytes {
+		return data[:limits.MaxInputBytes], consoleRejectInputSize, nil
+	}
+	return data, "", nil
+}
+
+func consoleRejectCloseCode(reason string) int {
+	switch reason {
+	case consoleRejectInputSize:
+		return websocket.CloseMessageTooBig
+	case consoleRejectIdle:
+		return websocket.CloseNormalClosure
+	}
+	return websocket.ClosePolicyViolation
+}
@@ -2,9 +2,11 @@ package websocketclient
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
@@ -18,6 +20,8 @@ import (
 type Attacher struct {
 	daemonSocket string
 	daemonURL    string
+	token        string
+	tlsConfig    *tls.Config
 }
 
 func NewAttacher(daemonSocket string) *Attacher {
@@ -28,13 +32,30 @@ func NewAttacherForTarget(daemonSocket string, daemonURL string) *Attacher {
 	return &Attacher{daemonSocket: daemonSocket, daemonURL: strings.TrimRight(daemonURL, "/")}
 }
 
+// SetTLSConfig sets the CAs and client certificate used for a wss daemon URL.
+func (a *Attacher) SetTLSConfig(config *tls.Config) {
+	a.tlsConfig = config
+}
+
+// SetToken sends token as a bearer credential when dialing the daemon.
+func (a *Attacher) SetToken(token string) {
+	a.token = token
+}
+
+func (a *Attacher) header() http.Header {
+	if a.token == "" {
+		return nil
+	}
+	return http.Header{"Authorization": []string{"Bearer " + a.token}}
+}
+
 func (a *Attacher) Attach(ctx context.Context, scroll string, console string) error {
 	wsURL, err := a.websocketURL(scroll, console)
 	if err != nil {
 		return err
 	}
 	dialer := a.dialer()
-	conn, _, err := dialer.Dial(wsURL, nil)
+	conn, _, err := dialer.Dial(wsURL, a.header())
 	if err != nil {
 		return err
 	}
@@ -55,8 +76,53 @@ func (a *Attacher) Attach(ctx context.Context, scroll string, console string) er
 	}
 }
 
+// FollowEvents writes every runtime event of scroll to out, one JSON object
+// per line, until ctx ends or the daemon closes the stream.
+func (a *Attacher) FollowEvents(ctx context.Context, scroll string, out io.Writer) error {
+	wsURL, err := a.urlFor(fmt.Sprintf("/ws/v1/scrolls/%s/events", url.PathEscape(scroll)))
+	if err != nil {
+		return err
+	}
+	conn, _, err := a.dialer().DialContext(ctx, wsURL, a.header())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	done := make(chan error, 1)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err := fmt.Fprintln(out, string(data)); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-done:
+		if gw.IsCloseError(err, gw.CloseNormalClosure) {
+			return nil
+		}
+		return err
+	}
+}
+
 func (a *Attacher) websocketURL(scroll string, console string) (string, error) {
-	escapedPath := fmt.Sprintf("/ws/v1/scrolls/%s/consoles/%s", url.PathEscape(scroll), url.PathEscape(console))
+	return a.urlFor(fmt.Sprintf("/ws/v1/scrolls/%s/consoles/%s", url.PathEscape(scroll), url.PathEscape(console)))
+}
+
+func (a *Attacher) urlFor(escapedPath string) (string, error) {
 	if a.daemonURL == "" {
 		return "ws://druid" + escapedPath, nil
 	}
@@ -70,7 +136,7 @@ func (a *Attacher) websocketURL(scroll string, console string) (string, error) {
 
 func (a *Attacher) dialer() *gw.Dialer {
 	if a.daemonURL != "" {
-		return &gw.Dialer{}
+		return &gw.Dialer{TLSClientConfig: a.tlsConfig}
 	}
 	daemonSocket := a.daemonSocket
 	if daemonSocket == "" {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
@@ -12,6 +13,11 @@ func (s *RuntimeSupervisor) Run(id string, command string) (*domain.RuntimeScrol
 }
 
 func (s *RuntimeSupervisor) RunWithContext(ctx context.Context, id string, command string) (*domain.RuntimeScroll, error) {
+	release, err := s.admitCommand(id, command)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	session, err := s.sessionFor(id)
 	if err != nil {
 		return nil, err
@@ -21,6 +27,11 @@ func (s *RuntimeSupervisor) RunWithContext(ctx context.Context, id string, comma
 
 // RunAndWait waits for the requested command rather than every command in the runtime queue.
 func (s *RuntimeSupervisor) RunAndWait(id string, command string) (*domain.RuntimeScroll, error) {
+	release, err := s.admitCommand(id, command)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	session, err := s.sessionFor(id)
 	if err != nil {
 		return nil, err
@@ -36,7 +47,12 @@ func (s *RuntimeSupervisor) Ports(id string) ([]domain.RuntimePortStatus, error)
 	if err != nil {
 		return nil, err
 	}
-	return session.Ports()
+	statuses, err := session.Ports()
+	if err != nil {
+		return nil, err
+	}
+	s.proxyTraffic.apply(id, statuses, time.Now())
+	return statuses, nil
 }
 
 func (s *RuntimeSupervisor) RoutingTargets(id string) ([]domain.RuntimeRoutingTarget, error) {
@@ -48,6 +64,9 @@ func (s *RuntimeSupervisor) RoutingTargets(id string) ([]domain.RuntimeRoutingTa
 }
 
 func (s *RuntimeSupervisor) ApplyRouting(id string, assignments []domain.RuntimeRouteAssignment) (*domain.RuntimeScroll, error) {
+	if err := s.admissionPolicy.AdmitRouting(assignments); err != nil {
+		return nil, err
+	}
 	session, err := s.sessionFor(id)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadScrollAdmissionPolicy reads the admission policy file at path.
+func LoadScrollAdmissionPolicy(path string) (*domain.ScrollAdmissionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read admission policy: %w", err)
+	}
+	policy := &domain.ScrollAdmissionPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("parse admission policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("admission policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// SetAdmissionPolicy restricts the images, ports, mounts and TTYs of every
+// scroll. It applies to sessions started afterwards, so set it before Start.
+func (s *RuntimeSupervisor) SetAdmissionPolicy(policy *domain.ScrollAdmissionPolicy) {
+	s.admissionPolicy = policy
+}
+
+// admitScroll checks a freshly materialized scroll and marks runtimeScroll
+// as failed when the policy rejects it, or when it comes from a bundle and
+// the backend lacks its images.
+func (s *RuntimeSupervisor) admitScroll(runtimeScroll *domain.RuntimeScroll, artifact string, scroll *domain.Scroll, imageLock map[string]string) error {
+	if err := s.checkScroll(artifact, scroll, imageLock); err != nil {
+		runtimeScroll.Status = domain.RuntimeScrollStatusError
+		runtimeScroll.LastError = err.Error()
+		_ = s.store.UpdateScroll(runtimeScroll)
+		return err
+	}
+	return nil
+}
+
+// checkScroll applies the admission policy and the bundle image check to
+// scroll from artifact.
+func (s *RuntimeSupervisor) checkScroll(artifact string, scroll *domain.Scroll, imageLock map[string]string) error {
+	if err := s.admissionPolicy.Admit(&scroll.File, imageLock); err != nil {
+		return err
+	}
+	return s.checkBundleImages(artifact, scroll, imageLock)
+}
+
+// checkBundleImages fails when a scroll from a bundle runs images the
+// backend does not have, instead of letting the first start try to pull
+// them. Backends that cannot tell are not checked.
+func (s *RuntimeSupervisor) checkBundleImages(artifact string, scroll *domain.Scroll, imageLock map[string]string) error {
+	inspector, ok := s.runtimeBackend.(ports.RuntimeImageInspector)
+	if !ok || !domain.IsScrollBundle(artifact) {
+		return nil
+	}
+	images := scroll.ContainerImages()
+	for idx, image := range images {
+		if ref := imageLock[image]; ref != "" {
+			images[idx] = ref
+		}
+	}
+	missing, err := inspector.MissingImages(context.Background(), images)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrBundleImagesMissing, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// admitTargetScroll checks the scroll.yaml of artifact before an update
+// writes it into the runtime root, so a rejected update leaves the current
+// scroll in place. The materialized scroll is checked again in case the tag
+// moved in between.
+func (s *RuntimeSupervisor) admitTargetScroll(artifact string, registryCredentials []domain.RegistryCredential) error {
+	if s.admissionPolicy == nil && !domain.IsScrollBundle(artifact) {
+		return nil
+	}
+	scrollYAML, imageLock, err := s.fetchTargetScroll(artifact, registryCredentials)
+	if err != nil {
+		return err
+	}
+	scroll, err := domain.NewScrollFromBytes("", scrollYAML)
+	if err != nil {
+		return err
+	}
+	return s.checkScroll(artifact, scroll, imageLock)
+}
+
+// fetchTargetScroll returns the scroll.yaml and image lock of artifact
+// without materializing it.
+func (s *RuntimeSupervisor) fetchTargetScroll(artifact string, registryCredentials []domain.RegistryCredential) ([]byte, map[string]string, error) {
+	if info, err := os.Stat(artifact); err == nil && !domain.IsScrollBundle(artifact) {
+		if !info.IsDir() {
+			scrollYAML, err := os.ReadFile(artifact)
+			return scrollYAML, nil, err
+		}
+		scrollYAML, err := os.ReadFile(filepath.Join(artifact, "scroll.yaml"))
+		if err != nil {
+			return nil, nil, err
+		}
+		lock, err := domain.ReadImageLock(artifact)
+		if err != nil || lock == nil {
+			return scrollYAML, nil, err
+		}
+		return scrollYAML, lock.Images, nil
+	}
+	oci := s.ociClient(registryCredentials)
+	scrollYAML, err := oci.FetchFile(artifact, "scroll.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := oci.FetchFile(artifact, domain.ImageLockFile)
+	if errors.Is(err, registry.ErrFileNotInArtifact) {
+		return scrollYAML, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	lock, err := domain.ParseImageLock(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scrollYAML, lock.Images, nil
+}
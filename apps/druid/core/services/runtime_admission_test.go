@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+)
+
+func newAdmissionSupervisor(t *testing.T, policy *domain.ScrollAdmissionPolicy) (*RuntimeSupervisor, *fakeWorkerBackend, string) {
+	t.Helper()
+	artifact := t.TempDir()
+	if err := os.WriteFile(filepath.Join(artifact, "scroll.yaml"), []byte(cachedScrollYAML("start")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := newTestStateStore(t)
+	callbacks := NewWorkerCallbackManager()
+	backend := &fakeWorkerBackend{callbacks: callbacks, scrollYAML: cachedScrollYAML("start")}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
+	supervisor.SetWorkerCallbacks(callbacks, "http://druid-cli:8083")
+	supervisor.SetAdmissionPolicy(policy)
+	return supervisor, backend, artifact
+}
+
+func TestRuntimeSupervisorCreateRejectsScrollOutsideAdmissionPolicy(t *testing.T) {
+	supervisor, _, artifact := newAdmissionSupervisor(t, &domain.ScrollAdmissionPolicy{
+		Images: domain.ImageAdmissionRules{AllowedRegistries: []string{"ghcr.io"}},
+	})
+
+	_, err := supervisor.Create(artifact, "game", nil)
+	var admissionErr *domain.ScrollAdmissionError
+	if !errors.As(err, &admissionErr) || !errors.Is(err, domain.ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want admission error", err)
+	}
+	if len(admissionErr.Violations) != 1 || admissionErr.Violations[0].Rule != domain.PolicyRuleImageRegistry || admissionErr.Violations[0].Procedure != "start.0" {
+		t.Fatalf("violations = %#v", admissionErr.Violations)
+	}
+	runtimeScroll, err := supervisor.Get("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtimeScroll.Status != domain.RuntimeScrollStatusError || runtimeScroll.LastError == "" {
+		t.Fatalf("rejected scroll = %s %q, want error status", runtimeScroll.Status, runtimeScroll.LastError)
+	}
+}
+
+func TestRuntimeSupervisorUpdateRejectsScrollBeforeTouchingTheRoot(t *testing.T) {
+	supervisor, backend, artifact := newAdmissionSupervisor(t, nil)
+	created, err := supervisor.Create(artifact, "game", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spawned := backend.spawnCount
+	supervisor.SetAdmissionPolicy(&domain.ScrollAdmissionPolicy{
+		Images: domain.ImageAdmissionRules{AllowedRegistries: []string{"ghcr.io"}},
+	})
+
+	if _, err := supervisor.Update("game", artifact, "local", nil); !errors.Is(err, domain.ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want not admitted", err)
+	}
+	if backend.spawnCount != spawned || backend.stopRoot != "" {
+		t.Fatalf("a rejected update ran the worker (%d) or stopped %q", backend.spawnCount-spawned, backend.stopRoot)
+	}
+	runtimeScroll, err := supervisor.Get("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtimeScroll.Status != created.Status || runtimeScroll.ScrollYAML != created.ScrollYAML || len(runtimeScroll.UpdateHistory) != 0 {
+		t.Fatalf("rejected update changed the scroll: %s %#v", runtimeScroll.Status, runtimeScroll.UpdateHistory)
+	}
+}
+
+func TestRuntimeSupervisorUpdateMarksErrorWhenPulledScrollIsRejected(t *testing.T) {
+	supervisor, backend, artifact := newAdmissionSupervisor(t, nil)
+	if _, err := supervisor.Create(artifact, "game", nil); err != nil {
+		t.Fatal(err)
+	}
+	supervisor.SetAdmissionPolicy(&domain.ScrollAdmissionPolicy{
+		Images: domain.ImageAdmissionRules{AllowedRegistries: []string{"docker.io"}},
+	})
+	// The artifact changed between the check and the pull.
+	backend.scrollYAML = strings.ReplaceAll(cachedScrollYAML("start"), "alpine:3.20", "ghcr.io/other/game:1")
+
+	if _, err := supervisor.Update("game", artifact, "local", nil); !errors.Is(err, domain.ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want not admitted", err)
+	}
+	runtimeScroll, err := supervisor.Get("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtimeScroll.Status != domain.RuntimeScrollStatusError || runtimeScroll.LastError == "" {
+		t.Fatalf("rejected update = %s %q, want error status", runtimeScroll.Status, runtimeScroll.LastError)
+	}
+}
+
+func TestRuntimeSessionChecksAdmissionPolicyBeforeRunCommand(t *testing.T) {
+	supervisor, backend, artifact := newAdmissionSupervisor(t, nil)
+	ran := false
+	backend.runCommand = func(ports.RuntimeCommand) (*int, error) {
+		ran = true
+		return nil, nil
+	}
+	if _, err := supervisor.Create(artifact, "game", nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := supervisor.sessionFor("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session.admissionPolicy = &domain.ScrollAdmissionPolicy{Images: domain.ImageAdmissionRules{RequireDigest: true}}
+
+	if _, err := supervisor.RunAndWait("game", "start"); !errors.Is(err, domain.ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want not admitted", err)
+	}
+	if ran {
+		t.Fatal("the backend ran a command the policy rejects")
+	}
+}
+
+func TestRuntimeSupervisorApplyRoutingRejectsForbiddenPublicPort(t *testing.T) {
+	supervisor, _, artifact := newAdmissionSupervisor(t, &domain.ScrollAdmissionPolicy{
+		Ports: domain.PortAdmissionRules{Forbidden: []string{"22", "1-1023"}},
+	})
+	if _, err := supervisor.Create(artifact, "game", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := supervisor.ApplyRouting("game", []domain.RuntimeRouteAssignment{{Name: "ssh", PublicPort: 22}}); !errors.Is(err, domain.ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want not admitted", err)
+	}
+	if _, err := supervisor.ApplyRouting("game", []domain.RuntimeRouteAssignment{{Name: "game", PublicPort: 25565}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadScrollAdmissionPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admission.yaml")
+	if err := os.WriteFile(path, []byte(`images:
+  allowed_registries: [ghcr.io/highcard-dev/*]
+  require_digest: true
+ports:
+  forbidden: ["22", "1-1023"]
+  max_expected_ports: 2
+mounts:
+  read_only: [/etc/*]
+deny_tty: true
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadScrollAdmissionPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !policy.Images.RequireDigest || len(policy.Ports.Forbidden) != 2 || policy.Ports.MaxExpectedPorts != 2 || !policy.DenyTTY {
+		t.Fatalf("policy = %#v", policy)
+	}
+
+	if err := os.WriteFile(path, []byte("ports:\n  forbidden: [\"100-1\"]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScrollAdmissionPolicy(path); err == nil {
+		t.Fatal("an inverted port range was accepted")
+	}
+	if err := os.WriteFile(path, []byte("tty: false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadScrollAdmissionPolicy(path); err == nil {
+		t.Fatal("an unknown rule was accepted")
+	}
+}
+
+type imageInspectingBackend struct {
+	*fakeWorkerBackend
+	present map[string]bool
+}
+
+func (b *imageInspectingBackend) MissingImages(_ context.Context, images []string) ([]string, error) {
+	var missing []string
+	for _, image := range images {
+		if !b.present[image] {
+			missing = append(missing, image)
+		}
+	}
+	return missing, nil
+}
+
+func TestRuntimeSupervisorCreateFromBundleFailsWhenImagesAreNotLoaded(t *testing.T) {
+	store := newTestStateStore(t)
+	callbacks := NewWorkerCallbackManager()
+	backend := &imageInspectingBackend{
+		fakeWorkerBackend: &fakeWorkerBackend{callbacks: callbacks, scrollYAML: cachedScrollYAML("start")},
+		present:           map[string]bool{},
+	}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
+	supervisor.SetWorkerCallbacks(callbacks, "http://druid-cli:8083")
+	bundle := filepath.Join(t.TempDir(), "game"+domain.ScrollBundleExt)
+	if err := os.WriteFile(bundle, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := supervisor.Create(bundle, "game", nil); !errors.Is(err, domain.ErrBundleImagesMissing) || !strings.Contains(err.Error(), "alpine:3.20") {
+		t.Fatalf("err = %v, want missing bundle images", err)
+	}
+
+	backend.present["alpine:3.20"] = true
+	if _, err := supervisor.Create(bundle, "game-loaded", nil); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/core/services/registry"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// updateHealthPollInterval is how often the serve command is checked while
+// an auto-update is on probation.
+var updateHealthPollInterval = 2 * time.Second
+
+// autoUpdateConcurrency bounds how many runtimes one check updates at once,
+// so the health waits of a few runtimes do not use up a maintenance window.
+var autoUpdateConcurrency = 4
+
+// autoUpdateInitiator names the auto-updater in the update history.
+const autoUpdateInitiator = "auto-update"
+
+// updateTarget is the artifact an update policy currently points at. An empty
+// Artifact means the policy matched nothing.
+type updateTarget struct {
+	Artifact string
+	Digest   string
+}
+
+type updateTargetResolver func(runtimeScroll *domain.RuntimeScroll, registryCredentials []domain.RegistryCredential) (updateTarget, error)
+
+// SetRegistryCredentials sets the credentials the daemon pulls with when no
+// caller hands them in, as for auto-updates of runtimes without an owner.
+func (s *RuntimeSupervisor) SetRegistryCredentials(credentials []domain.RegistryCredential) {
+	s.registryCredentials = credentials
+}
+
+// rememberRegistryCredentials keeps the credentials a caller deployed runtime
+// id with, so the daemon pulls its auto-updates with them.
+func (s *RuntimeSupervisor) rememberRegistryCredentials(id string, credentials []domain.RegistryCredential) {
+	store, ok := s.store.(ports.RuntimeRegistryCredentialStore)
+	if !ok {
+		return
+	}
+	if err := store.SetRegistryCredentials(id, credentials); err != nil {
+		logger.Log().Warn("Failed to store registry credentials", zap.String("scroll", id), zap.Error(err))
+	}
+}
+
+// updateCredentials returns the credentials the daemon pulls with on its own
+// for runtimeScroll: those it was last deployed with. Only runtimes without
+// an owner fall back to the daemon's credentials, so an owner cannot reach
+// repositories that only the daemon may read.
+func (s *RuntimeSupervisor) updateCredentials(runtimeScroll *domain.RuntimeScroll) ([]domain.RegistryCredential, error) {
+	if store, ok := s.store.(ports.RuntimeRegistryCredentialStore); ok {
+		credentials, err := store.GetRegistryCredentials(runtimeScroll.ID)
+		if err != nil || len(credentials) > 0 {
+			return credentials, err
+		}
+	}
+	if runtimeScroll.OwnerID != "" {
+		return nil, nil
+	}
+	return s.registryCredentials, nil
+}
+
+// SetUpdateWindow sets the maintenance window for update policies that do
+// not name their own. nil allows auto-updates at any time.
+func (s *RuntimeSupervisor) SetUpdateWindow(window *domain.MaintenanceWindow) {
+	s.updateWindow = window
+}
+
+// SetUpdateHealthTimeout sets how long the serve command of an auto-updated
+// runtime must keep running before the update sticks.
+func (s *RuntimeSupervisor) SetUpdateHealthTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	s.updateHealthTimeout = timeout
+}
+
+// SetUpdatePolicy replaces the update policy of a runtime. nil removes it.
+func (s *RuntimeSupervisor) SetUpdatePolicy(id string, policy *domain.RuntimeUpdatePolicy) (*domain.RuntimeScroll, error) {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return s.mutateScroll(id, func(runtimeScroll *domain.RuntimeScroll) {
+		runtimeScroll.UpdatePolicy = policy
+	})
+}
+
+// mutateScroll applies change to the persisted runtime scroll. A live
+// session persists its own copy, so that copy is changed instead.
+func (s *RuntimeSupervisor) mutateScroll(id string, change func(*domain.RuntimeScroll)) (*domain.RuntimeScroll, error) {
+	s.mu.Lock()
+	session := s.sessions[id]
+	s.mu.Unlock()
+	if session != nil {
+		session.mu.Lock()
+		change(session.runtimeScroll)
+		err := s.store.UpdateScroll(session.runtimeScroll)
+		session.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return s.store.GetScroll(id)
+	}
+	runtimeScroll, err := s.store.GetScroll(id)
+	if err != nil {
+		return nil, err
+	}
+	change(runtimeScroll)
+	if err := s.store.UpdateScroll(runtimeScroll); err != nil {
+		return nil, err
+	}
+	return s.store.GetScroll(id)
+}
+
+// StartAutoUpdater checks runtimes with an update policy every interval and,
+// inside their maintenance window, moves them to the artifact the policy
+// resolves to. Idle runtimes are left alone until they wake. It returns
+// false when interval disables the updater.
+func (s *RuntimeSupervisor) StartAutoUpdater(ctx context.Context, interval time.Duration) bool {
+	if interval <= 0 {
+		return false
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.checkAutoUpdates(ctx, now)
+			}
+		}
+	}()
+	return true
+}
+
+func (s *RuntimeSupervisor) checkAutoUpdates(ctx context.Context, now time.Time) {
+	scrolls, err := s.store.ListScrolls()
+	if err != nil {
+		logger.Log().Warn("Failed to list runtime scrolls for auto-update", zap.Error(err))
+		return
+	}
+	slots := make(chan struct{}, autoUpdateConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, runtimeScroll := range scrolls {
+		if !runtimeScroll.UpdatePolicy.AutoUpdates() {
+			continue
+		}
+		switch runtimeScroll.Status {
+		case domain.RuntimeScrollStatusRunning, domain.RuntimeScrollStatusStopped, domain.RuntimeScrollStatusCreated:
+		default:
+			continue
+		}
+		if !s.inUpdateWindow(runtimeScroll.UpdatePolicy, now) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case slots <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(runtimeScroll *domain.RuntimeScroll) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := s.autoUpdate(runtimeScroll); err != nil {
+				logger.Log().Warn("Auto-update failed", zap.String("scroll", runtimeScroll.ID), zap.Error(err))
+			}
+		}(runtimeScroll)
+	}
+}
+
+func (s *RuntimeSupervisor) inUpdateWindow(policy *domain.RuntimeUpdatePolicy, now time.Time) bool {
+	window := s.updateWindow
+	if policy.Window != "" {
+		parsed, err := domain.ParseMaintenanceWindow(policy.Window)
+		if err != nil {
+			return false
+		}
+		window = &parsed
+	}
+	return window == nil || window.Contains(now.Local())
+}
+
+// autoUpdate moves runtimeScroll to the target of its update policy. When
+// the update fails, or a running serve command errors within the health
+// timeout, the runtime goes back to the digest it ran before and the failed
+// digest is held.
+func (s *RuntimeSupervisor) autoUpdate(runtimeScroll *domain.RuntimeScroll) error {
+	credentials, err := s.updateCredentials(runtimeScroll)
+	if err != nil {
+		return err
+	}
+	target, err := s.resolveUpdate(runtimeScroll, credentials)
+	if err != nil {
+		return err
+	}
+	if target.Artifact == "" {
+		return nil
+	}
+	if target.Digest == "" {
+		if target.Artifact == runtimeScroll.Artifact {
+			return nil
+		}
+	} else if target.Digest == runtimeScroll.ArtifactDigest || target.Digest == runtimeScroll.UpdatePolicy.HeldDigest {
+		return nil
+	}
+
+	id := runtimeScroll.ID
+	previousArtifact := runtimeScroll.Artifact
+	previousDigest := runtimeScroll.ArtifactDigest
+	wasRunning := runtimeScroll.Status == domain.RuntimeScrollStatusRunning
+	logger.Log().Info("Auto-updating runtime scroll",
+		zap.String("scroll", id),
+		zap.String("from", previousArtifact),
+		zap.String("to", target.Artifact),
+		zap.String("digest", target.Digest),
+	)
+	_, updateErr := s.updateExistingScroll(runtimeScroll, target.Artifact, target.Digest, credentials, true, runtimeUpdate{
+		action:    domain.RuntimeUpdateActionUpdate,
+		initiator: autoUpdateInitiator,
+	})
+	if updateErr == nil {
+		if !wasRunning {
+			return nil
+		}
+		if updateErr = s.waitForHealthyServe(id); updateErr == nil {
+			return nil
+		}
+		if _, err := s.Stop(id); err != nil {
+			logger.Log().Warn("Failed to stop runtime before rollback", zap.String("scroll", id), zap.Error(err))
+		}
+	}
+
+	if previousDigest == "" {
+		return fmt.Errorf("update to %s failed and no previous digest is known to roll back to: %w", target.Artifact, updateErr)
+	}
+	logger.Log().Warn("Rolling back auto-update",
+		zap.String("scroll", id),
+		zap.String("artifact", target.Artifact),
+		zap.String("previous_digest", previousDigest),
+		zap.Error(updateErr),
+	)
+	current, err := s.store.GetScroll(id)
+	if err != nil {
+		return err
+	}
+	if current.UpdatePolicy != nil {
+		current.UpdatePolicy.HeldDigest = target.Digest
+	}
+	repo, _, _ := utils.ParseArtifactRef(previousArtifact)
+	if _, err := s.updateExistingScroll(current, repo+"@"+previousDigest, previousDigest, credentials, false, runtimeUpdate{
+		action:    domain.RuntimeUpdateActionRollback,
+		initiator: autoUpdateInitiator,
+		artifact:  previousArtifact,
+	}); err != nil {
+		return fmt.Errorf("roll back to %s: %w (update failed: %v)", previousDigest, err, updateErr)
+	}
+	if wasRunning {
+		if _, err := s.StartScroll(id); err != nil {
+			return fmt.Errorf("restart after rollback: %w", err)
+		}
+	}
+	return fmt.Errorf("rolled back update to %s: %w", target.Artifact, updateErr)
+}
+
+// waitForHealthyServe watches the serve command of a restarted runtime for
+// the update health timeout. Restart mode hides crashes behind a backoff, so
+// a restart after serve was running or a crash loop counts as a failure too,
+// as does a serve command that never ran before the timeout.
+func (s *RuntimeSupervisor) waitForHealthyServe(id string) error {
+	file, err := s.ScrollFile(id)
+	if err != nil {
+		return err
+	}
+	if file.Serve == "" {
+		return nil
+	}
+	deadline := time.Now().Add(s.updateHealthTimeout)
+	wasRunning := false
+	for {
+		s.mu.Lock()
+		session := s.sessions[id]
+		s.mu.Unlock()
+		if session == nil {
+			// Stopped by someone else; nothing left to judge.
+			return nil
+		}
+		switch status := session.getQueueStatus(file.Serve); {
+		case status == domain.ScrollLockStatusError:
+			return fmt.Errorf("serve command %s failed after update", file.Serve)
+		case session.queueRestarts(file.Serve) > 0:
+			return fmt.Errorf("serve command %s is crash looping after update", file.Serve)
+		case status == domain.ScrollLockStatusRunning:
+			wasRunning = true
+		case wasRunning:
+			return fmt.Errorf("serve command %s exited after update", file.Serve)
+		}
+		if !time.Now().Before(deadline) {
+			if !wasRunning {
+				return fmt.Errorf("serve command %s did not run within %s after update", file.Serve, s.updateHealthTimeout)
+			}
+			return nil
+		}
+		time.Sleep(updateHealthPollInterval)
+	}
+}
+
+// resolveRegistryUpdateTarget lists the tags of the runtime's repository and
+// picks the one its update policy allows.
+func (s *RuntimeSupervisor) resolveRegistryUpdateTarget(runtimeScroll *domain.RuntimeScroll, registryCredentials []domain.RegistryCredential) (updateTarget, error) {
+	repo, _, _ := utils.ParseArtifactRef(runtimeScroll.Artifact)
+	if repo == "" {
+		return updateTarget{}, fmt.Errorf("artifact %q is not a registry reference", runtimeScroll.Artifact)
+	}
+	var file *domain.File
+	if runtimeScroll.ScrollYAML != "" {
+		scroll, err := domain.NewScrollFromBytes("", []byte(runtimeScroll.ScrollYAML))
+		if err != nil {
+			return updateTarget{}, err
+		}
+		file = &scroll.File
+	}
+	oci := s.ociClient(registryCredentials)
+	tags, err := oci.ListTags(repo)
+	if err != nil {
+		return updateTarget{}, err
+	}
+	tag, err := selectUpdateTag(runtimeScroll.UpdatePolicy, file, tags)
+	if err != nil || tag == "" {
+		return updateTarget{}, err
+	}
+	artifact := repo + ":" + tag
+	digest, err := oci.ResolveDigest(artifact)
+	if err != nil {
+		return updateTarget{}, err
+	}
+	return updateTarget{Artifact: artifact, Digest: digest}, nil
+}
+
+// selectUpdateTag applies an update policy to the tags of a repository. A
+// pull channel names either a tag to follow or a semver range.
+func selectUpdateTag(policy *domain.RuntimeUpdatePolicy, file *domain.File, tags []string) (string, error) {
+	switch policy.Mode {
+	case domain.RuntimeUpdatePolicySemver:
+		return registry.HighestMatchingTag(tags, policy.Constraint)
+	case domain.RuntimeUpdatePolicyChannel:
+		var channel string
+		if file != nil {
+			channel = file.PullChannel[policy.Channel]
+		}
+		if channel == "" {
+			return "", fmt.Errorf("scroll has no pull channel %q", policy.Channel)
+		}
+		if slices.Contains(tags, channel) {
+			return channel, nil
+		}
+		tag, err := registry.HighestMatchingTag(tags, channel)
+		if err != nil {
+			return "", fmt.Errorf("pull channel %q points to %q, which is neither a tag nor a semver range", policy.Channel, channel)
+		}
+		return tag, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+)
+
+// versionedWorkerBackend materializes the previous scroll for its digest
+// and a broken serve command for anything else.
+type versionedWorkerBackend struct {
+	*fakeWorkerBackend
+	artifacts []string
+}
+
+func (f *versionedWorkerBackend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorkerAction) error {
+	f.artifacts = append(f.artifacts, action.Artifact)
+	if strings.HasSuffix(action.Artifact, "@sha256:old") {
+		f.scrollYAML, f.digest = cachedScrollYAML("start"), "sha256:old"
+	} else {
+		f.scrollYAML, f.digest = strings.ReplaceAll(updatedScrollYAML("broken"), "alpine:3.20", "broken:2"), "sha256:new"
+	}
+	return f.fakeWorkerBackend.SpawnPullWorker(ctx, action)
+}
+
+func newAutoUpdateSupervisor(t *testing.T, status domain.RuntimeScrollStatus, policy *domain.RuntimeUpdatePolicy) (*RuntimeSupervisor, ports.RuntimeScrollStore, *versionedWorkerBackend) {
+	t.Helper()
+	store := newTestStateStore(t)
+	if err := store.CreateScroll(&domain.RuntimeScroll{
+		ID:             "auto",
+		Artifact:       "registry.local/lab:1.0",
+		ArtifactDigest: "sha256:old",
+		Root:           "runtime://auto",
+		ScrollName:     "cached",
+		ScrollYAML:     cachedScrollYAML("start"),
+		Status:         status,
+		Procedures:     domain.ProcedureStatusMap{},
+		UpdatePolicy:   policy,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	callbacks := NewWorkerCallbackManager()
+	backend := &versionedWorkerBackend{fakeWorkerBackend: &fakeWorkerBackend{callbacks: callbacks}}
+	backend.runCommand = func(command ports.RuntimeCommand) (*int, error) {
+		if command.Command.Procedures[0].Image == "broken:2" {
+			return nil, errors.New("server crashed")
+		}
+		return nil, nil
+	}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
+	supervisor.SetWorkerCallbacks(callbacks, "http://druid-cli:8083")
+	supervisor.SetUpdateHealthTimeout(300 * time.Millisecond)
+	supervisor.resolveUpdate = func(*domain.RuntimeScroll, []domain.RegistryCredential) (updateTarget, error) {
+		return updateTarget{Artifact: "registry.local/lab:2.0", Digest: "sha256:new"}, nil
+	}
+	t.Cleanup(func() {
+		_, _ = supervisor.Stop("auto")
+	})
+	return supervisor, store, backend
+}
+
+func TestAutoUpdateRollsBackWhenServeFails(t *testing.T) {
+	interval := updateHealthPollInterval
+	updateHealthPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { updateHealthPollInterval = interval })
+	supervisor, store, backend := newAutoUpdateSupervisor(t, domain.RuntimeScrollStatusRunning, &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "^1"})
+
+	runtimeScroll, err := store.GetScroll("auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = supervisor.autoUpdate(runtimeScroll)
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("autoUpdate error = %v, want rollback", err)
+	}
+	want := []string{"registry.local/lab@sha256:new", "registry.local/lab@sha256:old"}
+	if strings.Join(backend.artifacts, ",") != strings.Join(want, ",") {
+		t.Fatalf("pulled artifacts = %v, want %v", backend.artifacts, want)
+	}
+	restored, err := store.GetScroll("auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Artifact != "registry.local/lab:1.0" || restored.ArtifactDigest != "sha256:old" {
+		t.Fatalf("restored scroll = %s@%s", restored.Artifact, restored.ArtifactDigest)
+	}
+	if restored.UpdatePolicy == nil || restored.UpdatePolicy.HeldDigest != "sha256:new" {
+		t.Fatalf("update policy = %#v, want held sha256:new", restored.UpdatePolicy)
+	}
+	if n := len(restored.UpdateHistory); n != 3 || restored.UpdateHistory[n-1].Action != domain.RuntimeUpdateActionRollback || restored.UpdateHistory[n-1].Initiator != autoUpdateInitiator {
+		t.Fatalf("update history = %#v, want the rollback recorded last", restored.UpdateHistory)
+	}
+
+	if err := supervisor.autoUpdate(restored); err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.artifacts) != 2 {
+		t.Fatalf("held digest was pulled again: %v", backend.artifacts)
+	}
+}
+
+func TestCheckAutoUpdatesHonorsMaintenanceWindow(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	policy := &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "^1", Window: "03:00-05:00"}
+	supervisor, store, backend := newAutoUpdateSupervisor(t, domain.RuntimeScrollStatusStopped, policy)
+
+	supervisor.checkAutoUpdates(context.Background(), now)
+	if len(backend.artifacts) != 0 {
+		t.Fatalf("updated outside the window: %v", backend.artifacts)
+	}
+
+	if _, err := supervisor.SetUpdatePolicy("auto", &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "^1", Window: "11:00-13:00"}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor.checkAutoUpdates(context.Background(), now)
+	updated, err := store.GetScroll("auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Artifact != "registry.local/lab:2.0" || updated.ArtifactDigest != "sha256:new" {
+		t.Fatalf("updated scroll = %s@%s", updated.Artifact, updated.ArtifactDigest)
+	}
+	if updated.Status != domain.RuntimeScrollStatusStopped {
+		t.Fatalf("status = %s, stopped scrolls stay stopped", updated.Status)
+	}
+}
+
+func TestCheckAutoUpdatesUpdatesRuntimesConcurrently(t *testing.T) {
+	policy := &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "^1"}
+	supervisor, store, _ := newAutoUpdateSupervisor(t, domain.RuntimeScrollStatusStopped, policy)
+	if err := store.CreateScroll(&domain.RuntimeScroll{
+		ID:           "auto-2",
+		Artifact:     "registry.local/lab:1.0",
+		Status:       domain.RuntimeScrollStatusStopped,
+		Procedures:   domain.ProcedureStatusMap{},
+		UpdatePolicy: policy,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// Each resolve waits for the other, which only returns when both
+	// runtimes are checked at the same time.
+	arrived := make(chan string, 2)
+	both := make(chan struct{})
+	var once sync.Once
+	supervisor.resolveUpdate = func(runtimeScroll *domain.RuntimeScroll, _ []domain.RegistryCredential) (updateTarget, error) {
+		arrived <- runtimeScroll.ID
+		if len(arrived) == 2 {
+			once.Do(func() { close(both) })
+		}
+		select {
+		case <-both:
+			return updateTarget{}, nil
+		case <-time.After(time.Second):
+			return updateTarget{}, errors.New("runtimes were checked one after another")
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		supervisor.checkAutoUpdates(context.Background(), time.Now())
+		close(done)
+	}()
+	select {
+	case <-both:
+	case <-time.After(2 * time.Second):
+		t.Fatal("auto-updates did not run concurrently")
+	}
+	<-done
+}
+
+func TestAutoUpdatePullsWithTheCredentialsTheRuntimeWasDeployedWith(t *testing.T) {
+	policy := &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "^1"}
+	supervisor, store, _ := newAutoUpdateSupervisor(t, domain.RuntimeScrollStatusStopped, policy)
+	daemon := []domain.RegistryCredential{{Host: "registry.local", Username: "daemon"}}
+	supervisor.SetRegistryCredentials(daemon)
+	var used []domain.RegistryCredential
+	supervisor.resolveUpdate = func(_ *domain.RuntimeScroll, registryCredentials []domain.RegistryCredential) (updateTarget, error) {
+		used = registryCredentials
+		return updateTarget{}, nil
+	}
+	check := func(want string) {
+		t.Helper()
+		runtimeScroll, err := store.GetScroll("auto")
+		if err != nil {
+			t.Fatal(err)
+		}
+		used = nil
+		if err := supervisor.autoUpdate(runtimeScroll); err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if len(used) > 0 {
+			got = used[0].Username
+		}
+		if got != want {
+			t.Fatalf("pulled as %q, want %q", got, want)
+		}
+	}
+
+	check("daemon")
+	if _, err := supervisor.mutateScroll("auto", func(runtimeScroll *domain.RuntimeScroll) {
+		runtimeScroll.OwnerID = "alice"
+	}); err != nil {
+		t.Fatal(err)
+	}
+	check("")
+	supervisor.rememberRegistryCredentials("auto", []domain.RegistryCredential{{Host: "registry.local", Username: "alice"}})
+	check("alice")
+}
+
+func TestSelectUpdateTagFollowsPullChannel(t *testing.T) {
+	file := &domain.File{PullChannel: map[string]string{"stable": "lts", "beta": "~2.0"}}
+	tags := []string{"1.0.0", "lts", "2.0.1", "2.0.3", "2.1.0"}
+	for channel, want := range map[string]string{"stable": "lts", "beta": "2.0.3"} {
+		got, err := selectUpdateTag(&domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicyChannel, Channel: channel}, file, tags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("channel %s = %q, want %q", channel, got, want)
+		}
+	}
+	if _, err := selectUpdateTag(&domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicyChannel, Channel: "nightly"}, file, tags); err == nil {
+		t.Fatal("expected error for an unknown channel")
+	}
+}
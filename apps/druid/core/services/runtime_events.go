@@ -0,0 +1,100 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// maxWakeEvents bounds the wake history persisted with each scroll.
+const maxWakeEvents = 20
+
+// RecordWakeEvent persists a coldstart wake and publishes it on the event
+// stream. An event with a known ID replaces the stored one, so a coldstarter
+// can report ReadyAt once the server answers.
+func (s *RuntimeSupervisor) RecordWakeEvent(id string, event domain.RuntimeWakeEvent) (*domain.RuntimeWakeEvent, error) {
+	if event.Port == "" {
+		return nil, errors.New("wake event port is required")
+	}
+	if event.WokeAt.IsZero() {
+		return nil, errors.New("wake event woke_at is required")
+	}
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Source == "" {
+		event.Source = domain.RuntimeWakeSourceColdstarter
+	}
+	event.ReceivedAt = time.Now().UTC()
+	session, err := s.sessionFor(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.recordWakeEvent(event); err != nil {
+		return nil, err
+	}
+	s.publishEvent(domain.RuntimeEvent{Type: domain.RuntimeEventTypeWake, ScrollID: id, At: event.ReceivedAt, Wake: &event})
+	return &event, nil
+}
+
+// Events returns the recorded events of a scroll, oldest first.
+func (s *RuntimeSupervisor) Events(id string) ([]domain.RuntimeEvent, error) {
+	runtimeScroll, err := s.store.GetScroll(id)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.RuntimeEvent, 0, len(runtimeScroll.WakeEvents))
+	for i := range runtimeScroll.WakeEvents {
+		wake := runtimeScroll.WakeEvents[i]
+		events = append(events, domain.RuntimeEvent{Type: domain.RuntimeEventTypeWake, ScrollID: id, At: wake.ReceivedAt, Wake: &wake})
+	}
+	return events, nil
+}
+
+// SubscribeEvents streams JSON encoded domain.RuntimeEvent values of all
+// scrolls until UnsubscribeEvents.
+func (s *RuntimeSupervisor) SubscribeEvents() chan *[]byte {
+	return s.events.Subscribe()
+}
+
+func (s *RuntimeSupervisor) UnsubscribeEvents(subscription chan *[]byte) {
+	s.events.Unsubscribe(subscription)
+}
+
+func (s *RuntimeSupervisor) publishEvent(event domain.RuntimeEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Log().Warn("Failed to encode runtime event", zap.String("scroll", event.ScrollID), zap.Error(err))
+		return
+	}
+	if !s.events.Broadcast(data) {
+		logger.Log().Warn("Runtime event stream is full; dropping event", zap.String("scroll", event.ScrollID), zap.String("type", event.Type))
+	}
+}
+
+func (s *RuntimeSession) recordWakeEvent(event domain.RuntimeWakeEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.runtimeScroll.WakeEvents
+	replaced := false
+	for i := range events {
+		if events[i].ID == event.ID {
+			events[i] = event
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		events = append(events, event)
+	}
+	if len(events) > maxWakeEvents {
+		events = append([]domain.RuntimeWakeEvent(nil), events[len(events)-maxWakeEvents:]...)
+	}
+	s.runtimeScroll.WakeEvents = events
+	return s.store.UpdateScroll(s.runtimeScroll)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+// SetGrant gives grant.Subject access to a runtime, replacing an earlier
+// grant for the same subject.
+func (s *RuntimeSupervisor) SetGrant(id string, grant domain.RuntimeGrant) (*domain.RuntimeScroll, error) {
+	if err := grant.Validate(); err != nil {
+		return nil, err
+	}
+	return s.mutateScroll(id, func(runtimeScroll *domain.RuntimeScroll) {
+		for i := range runtimeScroll.Grants {
+			if runtimeScroll.Grants[i].Subject == grant.Subject {
+				runtimeScroll.Grants[i] = grant
+				return
+			}
+		}
+		runtimeScroll.Grants = append(runtimeScroll.Grants, grant)
+	})
+}
+
+// RemoveGrant takes away the access subject was granted on a runtime.
+func (s *RuntimeSupervisor) RemoveGrant(id string, subject string) (*domain.RuntimeScroll, error) {
+	runtimeScroll, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	bySubject := func(grant domain.RuntimeGrant) bool { return grant.Subject == subject }
+	if !slices.ContainsFunc(runtimeScroll.Grants, bySubject) {
+		return nil, domain.ErrRuntimeGrantNotFound
+	}
+	return s.mutateScroll(id, func(runtimeScroll *domain.RuntimeScroll) {
+		runtimeScroll.Grants = slices.DeleteFunc(runtimeScroll.Grants, bySubject)
+	})
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// idleWakeGate is an in-process coldstarter bound to the host ports an idle
+// serve command released. The first accepted packet starts serve again.
+type idleWakeGate struct {
+	coldStarter *coreservices.ColdStarter
+	cancel      context.CancelFunc
+}
+
+type idlePortService struct {
+	ports []*domain.AugmentedPort
+}
+
+func (p *idlePortService) GetPorts() []*domain.AugmentedPort {
+	return p.ports
+}
+
+// StartIdleController polls running scrolls and scales their serve command to
+// zero when the backend reports a missed keepAliveTraffic budget. Scrolls that
+// were idle before a daemon restart get their wake listeners back. It returns
+// false when the backend stops idle workloads on its own.
+func (s *RuntimeSupervisor) StartIdleController(ctx context.Context, interval time.Duration) bool {
+	backend, ok := s.runtimeBackend.(ports.RuntimeIdleBackend)
+	if !ok || interval <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	s.idleCtx = ctx
+	s.mu.Unlock()
+	s.restoreWakeGates()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.disarmAllWakeGates()
+				return
+			case <-ticker.C:
+				s.checkIdleScrolls(backend)
+			}
+		}
+	}()
+	return true
+}
+
+func (s *RuntimeSupervisor) restoreWakeGates() {
+	scrolls, err := s.store.ListScrolls()
+	if err != nil {
+		logger.Log().Warn("Failed to list runtime scrolls for idle wake listeners", zap.Error(err))
+		return
+	}
+	for _, runtimeScroll := range scrolls {
+		if runtimeScroll.Status != domain.RuntimeScrollStatusIdle {
+			continue
+		}
+		session, err := s.startSession(runtimeScroll)
+		if err != nil {
+			s.markScrollError(runtimeScroll, err)
+			continue
+		}
+		if err := s.armWakeGate(session); err != nil {
+			logger.Log().Error("Failed to restore idle wake listener", zap.String("scroll", runtimeScroll.ID), zap.Error(err))
+			session.markError(err)
+		}
+	}
+}
+
+func (s *RuntimeSupervisor) checkIdleScrolls(backend ports.RuntimeIdleBackend) {
+	s.mu.Lock()
+	sessions := make([]*RuntimeSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.mu.Unlock()
+
+	for _, session := range sessions {
+		candidate, ok := session.idleCandidate()
+		if !ok {
+			continue
+		}
+		idle, known := s.proxyTraffic.commandIdle(candidate.id, candidate.command, time.Now())
+		if !known {
+			var err error
+			idle, err = backend.CommandIdle(candidate.root, candidate.serve, candidate.command, candidate.ports)
+			if err != nil {
+				logger.Log().Warn("Failed to check keepAliveTraffic", zap.String("scroll", candidate.id), zap.String("command", candidate.serve), zap.Error(err))
+				continue
+			}
+		}
+		if !idle {
+			continue
+		}
+		logger.Log().Info("Stopping idle serve command after keepAliveTraffic miss", zap.String("scroll", candidate.id), zap.String("command", candidate.serve), zap.Bool("proxy_traffic", known))
+		if err := session.scaleServeToZero(candidate.serve); err != nil {
+			logger.Log().Error("Failed to stop idle serve command", zap.String("scroll", candidate.id), zap.String("command", candidate.serve), zap.Error(err))
+			session.markError(err)
+			continue
+		}
+		s.proxyTraffic.forget(candidate.id)
+		if err := s.armWakeGate(session); err != nil {
+			logger.Log().Error("Failed to start idle wake listener; restarting serve", zap.String("scroll", candidate.id), zap.Error(err))
+			s.wake(candidate.id, session)
+		}
+	}
+}
+
+func (s *RuntimeSupervisor) armWakeGate(session *RuntimeSession) error {
+	wakePorts, err := session.wakePorts()
+	if err != nil {
+		return err
+	}
+	if len(wakePorts) == 0 {
+		return errors.New("serve command has no public ports to wake on")
+	}
+	session.mu.Lock()
+	id := session.runtimeScroll.ID
+	session.mu.Unlock()
+
+	s.mu.Lock()
+	parent := s.idleCtx
+	if parent == nil {
+		parent = context.Background()
+	}
+	if existing := s.wakeGates[id]; existing != nil {
+		s.mu.Unlock()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(parent)
+	coldStarter := coreservices.NewColdStarter(&idlePortService{ports: wakePorts}, nil, "")
+	gate := &idleWakeGate{coldStarter: coldStarter, cancel: cancel}
+	s.wakeGates[id] = gate
+	s.mu.Unlock()
+
+	finish := coldStarter.Start(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case port := <-finish:
+			if port != nil {
+				logger.Log().Info("Waking idle scroll", zap.String("scroll", id), zap.String("port_name", port.Name), zap.Int("port", port.Port.Port))
+			}
+			if wake := coldStarter.Wake(); wake != nil {
+				wake.Source = domain.RuntimeWakeSourceIdle
+				if _, err := s.RecordWakeEvent(id, *wake); err != nil {
+					logger.Log().Warn("Failed to record idle wake event", zap.String("scroll", id), zap.Error(err))
+				}
+			}
+			s.wake(id, session)
+		}
+	}()
+	return nil
+}
+
+// wake releases the wake listener before queueing serve so the serve
+// container can bind the same host ports again.
+func (s *RuntimeSupervisor) wake(id string, session *RuntimeSession) {
+	s.disarmWakeGate(id)
+	s.proxyTraffic.forget(id)
+	if err := session.wakeServe(); err != nil {
+		logger.Log().Error("Failed to wake idle scroll", zap.String("scroll", id), zap.Error(err))
+		session.markError(err)
+	}
+}
+
+func (s *RuntimeSupervisor) disarmWakeGate(id string) {
+	s.mu.Lock()
+	gate := s.wakeGates[id]
+	delete(s.wakeGates, id)
+	s.mu.Unlock()
+	if gate != nil {
+		gate.cancel()
+		gate.coldStarter.Stop()
+	}
+}
+
+func (s *RuntimeSupervisor) disarmAllWakeGates() {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.wakeGates))
+	for id := range s.wakeGates {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	for _, id := range ids {
+		s.disarmWakeGate(id)
+	}
+}
+
+type idleCandidate struct {
+	id      string
+	root    string
+	serve   string
+	command *domain.CommandInstructionSet
+	ports   []domain.Port
+}
+
+func (s *RuntimeSession) idleCandidate() (idleCandidate, bool) {
+	file := s.scrollService.GetFile()
+	serve := file.Serve
+	command := file.Commands[serve]
+	if serve == "" || command == nil {
+		return idleCandidate{}, false
+	}
+	s.mu.Lock()
+	runtimeScroll := *s.runtimeScroll
+	status, ok := deriveCommandStatus(s.runtimeScroll.Procedures[serve], serve, command)
+	routing := append([]domain.RuntimeRouteAssignment(nil), s.runtimeScroll.Routing...)
+	reservations := append([]domain.Port(nil), s.runtimeScroll.ReservedPorts...)
+	s.mu.Unlock()
+	if runtimeScroll.Status != domain.RuntimeScrollStatusRunning || !ok || status != domain.ScrollLockStatusRunning {
+		return idleCandidate{}, false
+	}
+	if wakePorts, err := s.wakePorts(); err != nil || len(wakePorts) == 0 {
+		return idleCandidate{}, false
+	}
+	merged, err := mergeRuntimePorts(file.Ports, reservations)
+	if err != nil {
+		return idleCandidate{}, false
+	}
+	resolved, err := resolveRuntimePorts(merged, routing, false)
+	if err != nil {
+		return idleCandidate{}, false
+	}
+	return idleCandidate{id: runtimeScroll.ID, root: runtimeScroll.Root, serve: serve, command: command, ports: resolved}, true
+}
+
+// wakePorts returns the routed host ports of the serve command's expected
+// ports, including those of a coldstarter proxy in front of the server.
+// Ports without a public assignment are not bound on the host and cannot
+// receive wake traffic.
+func (s *RuntimeSession) wakePorts() ([]*domain.AugmentedPort, error) {
+	file := s.scrollService.GetFile()
+	command := file.Commands[file.Serve]
+	if command == nil {
+		return nil, fmt.Errorf("serve command %q not found", file.Serve)
+	}
+	s.mu.Lock()
+	routing := append([]domain.RuntimeRouteAssignment(nil), s.runtimeScroll.Routing...)
+	s.mu.Unlock()
+	declared := make(map[string]domain.Port, len(file.Ports))
+	for _, port := range file.Ports {
+		declared[port.Name] = port
+	}
+
+	wakePorts := []*domain.AugmentedPort{}
+	seen := map[string]struct{}{}
+	for _, procedure := range command.Procedures {
+		if procedure == nil {
+			continue
+		}
+		for _, expectedPort := range procedure.ExpectedPorts {
+			if _, ok := seen[expectedPort.Name]; ok {
+				continue
+			}
+			assignment, ok := publicAssignment(expectedPort.Name, routing)
+			if !ok {
+				continue
+			}
+			seen[expectedPort.Name] = struct{}{}
+			protocol := strings.ToLower(assignment.Protocol)
+			if protocol == "" {
+				protocol = strings.ToLower(declared[expectedPort.Name].Protocol)
+			}
+			wakePorts = append(wakePorts, &domain.AugmentedPort{
+				Port: domain.Port{
+					Name:     expectedPort.Name,
+					Port:     assignment.PublicPort,
+					Protocol: protocol,
+				},
+				ColdstarterHandler: "generic",
+				InactiveSince:      time.Now(),
+			})
+		}
+	}
+	return wakePorts, nil
+}
+
+func publicAssignment(portName string, routing []domain.RuntimeRouteAssignment) (domain.RuntimeRouteAssignment, bool) {
+	for _, assignment := range routing {
+		name := assignment.PortName
+		if name == "" {
+			name = assignment.Name
+		}
+		if name == portName && assignment.PublicPort > 0 {
+			return assignment, true
+		}
+	}
+	return domain.RuntimeRouteAssignment{}, false
+}
+
+func (s *RuntimeSession) scaleServeToZero(serve string) error {
+	stopper, ok := s.runtimeBackend.(ports.RuntimeCommandStopper)
+	if !ok {
+		return fmt.Errorf("runtime backend %s cannot stop single commands", s.runtimeBackend.Name())
+	}
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	s.queueMu.Lock()
+	delete(s.queue, serve)
+	s.queueMu.Unlock()
+
+	s.mu.Lock()
+	root := s.runtimeScroll.Root
+	s.idleCommand = serve
+	s.mu.Unlock()
+
+	if err := stopper.StopCommand(root, serve); err != nil {
+		s.mu.Lock()
+		s.idleCommand = ""
+		s.mu.Unlock()
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.runtimeScroll.Procedures, serve)
+	s.runtimeScroll.Status = domain.RuntimeScrollStatusIdle
+	s.runtimeScroll.LastError = ""
+	return s.store.UpdateScroll(s.runtimeScroll)
+}
+
+func (s *RuntimeSession) wakeServe() error {
+	s.mu.Lock()
+	s.idleCommand = ""
+	s.runtimeScroll.Status = domain.RuntimeScrollStatusRunning
+	err := s.store.UpdateScroll(s.runtimeScroll)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.AutoStartServe()
+}
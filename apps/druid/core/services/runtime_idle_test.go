@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+)
+
+type fakeIdleBackend struct {
+	fakeWorkerBackend
+	idle        atomic.Bool
+	stopped     atomic.Int32
+	runs        atomic.Int32
+	stoppedRoot string
+}
+
+func (f *fakeIdleBackend) CommandIdle(root string, commandName string, command *domain.CommandInstructionSet, globalPorts []domain.Port) (bool, error) {
+	return f.idle.Load(), nil
+}
+
+func (f *fakeIdleBackend) StopCommand(root string, command string) error {
+	f.stoppedRoot = root
+	f.stopped.Add(1)
+	return nil
+}
+
+func (f *fakeIdleBackend) RunCommand(command ports.RuntimeCommand) (*int, error) {
+	f.runs.Add(1)
+	for idx, procedure := range command.Command.Procedures {
+		command.ObserveProcedureStatus(domain.ProcedureName(command.Name, idx, procedure), domain.ScrollLockStatusRunning, nil)
+	}
+	return nil, nil
+}
+
+func (f *fakeIdleBackend) ExpectedPorts(root string, commands map[string]*domain.CommandInstructionSet, globalPorts []domain.Port, reservedPorts []domain.Port) ([]domain.RuntimePortStatus, error) {
+	return []domain.RuntimePortStatus{{Name: "game", Procedure: "start.0", Port: 25565, Protocol: "tcp", KeepAliveTraffic: "1kb/5m", Source: "fake"}}, nil
+}
+
+func TestRuntimeSupervisorIdleControllerStopsServeAndWakesOnTraffic(t *testing.T) {
+	publicPort := freeTCPPort(t)
+	store := newTestStateStore(t)
+	runtimeScroll := &domain.RuntimeScroll{
+		ID:         "idle-scroll",
+		Artifact:   "local",
+		Root:       "runtime://idle-scroll",
+		ScrollName: "cached",
+		ScrollYAML: idleScrollYAML(),
+		Status:     domain.RuntimeScrollStatusRunning,
+		Routing: []domain.RuntimeRouteAssignment{
+			{Name: "game", PortName: "game", PublicPort: publicPort, Protocol: "tcp"},
+		},
+		Procedures: domain.ProcedureStatusMap{
+			"start": {"start.0": {Status: domain.ScrollLockStatusRunning}},
+		},
+	}
+	if err := store.CreateScroll(runtimeScroll); err != nil {
+		t.Fatal(err)
+	}
+	backend := &fakeIdleBackend{}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
+	session, err := supervisor.startSession(runtimeScroll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.stopDeploymentQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if !supervisor.StartIdleController(ctx, time.Hour) {
+		t.Fatal("idle controller did not start for backend with idle support")
+	}
+
+	supervisor.checkIdleScrolls(backend)
+	if backend.stopped.Load() != 0 {
+		t.Fatal("serve was stopped while traffic was above keepAliveTraffic")
+	}
+
+	backend.idle.Store(true)
+	supervisor.checkIdleScrolls(backend)
+	if backend.stopped.Load() != 1 || backend.stoppedRoot != "runtime://idle-scroll" {
+		t.Fatalf("StopCommand calls = %d root = %q, want one call for runtime root", backend.stopped.Load(), backend.stoppedRoot)
+	}
+	updated, err := store.GetScroll("idle-scroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != domain.RuntimeScrollStatusIdle {
+		t.Fatalf("status = %s, want idle", updated.Status)
+	}
+	if _, ok := updated.Procedures["start"]; ok {
+		t.Fatalf("procedures = %#v, want serve status cleared", updated.Procedures)
+	}
+
+	backend.idle.Store(false)
+	events := supervisor.SubscribeEvents()
+	defer supervisor.UnsubscribeEvents(events)
+	conn := dialEventually(t, publicPort)
+	_, _ = conn.Write([]byte("wake"))
+	waker := conn.LocalAddr().String()
+	conn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		updated, err = store.GetScroll("idle-scroll")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Status == domain.RuntimeScrollStatusRunning && backend.runs.Load() > 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if updated.Status != domain.RuntimeScrollStatusRunning || backend.runs.Load() == 0 {
+		t.Fatalf("status = %s runs = %d, want serve restarted after wake", updated.Status, backend.runs.Load())
+	}
+	supervisor.mu.Lock()
+	gates := len(supervisor.wakeGates)
+	supervisor.mu.Unlock()
+	if gates != 0 {
+		t.Fatalf("wake gates = %d, want listener released after wake", gates)
+	}
+	if len(updated.WakeEvents) != 1 {
+		t.Fatalf("wake events = %#v, want the idle wake recorded", updated.WakeEvents)
+	}
+	wake := updated.WakeEvents[0]
+	if wake.Source != domain.RuntimeWakeSourceIdle || wake.Port != "game" || wake.RemoteAddress != waker || wake.ListeningSince == nil || wake.WokeAt.IsZero() {
+		t.Fatalf("wake event = %#v, want idle wake on game from %s", wake, waker)
+	}
+	select {
+	case data := <-events:
+		var event domain.RuntimeEvent
+		if err := json.Unmarshal(*data, &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Type != domain.RuntimeEventTypeWake || event.ScrollID != "idle-scroll" || event.Wake == nil || event.Wake.ID != wake.ID {
+			t.Fatalf("streamed event = %#v, want the recorded wake", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wake event was not streamed")
+	}
+}
+
+func TestRuntimeSupervisorIdleControllerPrefersProxyTraffic(t *testing.T) {
+	store := newTestStateStore(t)
+	runtimeScroll := &domain.RuntimeScroll{
+		ID:         "proxy-scroll",
+		Artifact:   "local",
+		Root:       "runtime://proxy-scroll",
+		ScrollName: "cached",
+		ScrollYAML: idleScrollYAML(),
+		Status:     domain.RuntimeScrollStatusRunning,
+		Routing: []domain.RuntimeRouteAssignment{
+			{Name: "game", PortName: "game", PublicPort: freeTCPPort(t), Protocol: "tcp"},
+		},
+		Procedures: domain.ProcedureStatusMap{
+			"start": {"start.0": {Status: domain.ScrollLockStatusRunning}},
+		},
+	}
+	if err := store.CreateScroll(runtimeScroll); err != nil {
+		t.Fatal(err)
+	}
+	backend := &fakeIdleBackend{}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
+	session, err := supervisor.startSession(runtimeScroll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.stopDeploymentQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	supervisor.StartIdleController(ctx, time.Hour)
+
+	now := time.Now()
+	supervisor.proxyTraffic.record("proxy-scroll", []domain.RuntimePortTraffic{{Name: "game", RXBytes: 4096, ActiveSessions: 1, TotalSessions: 1}}, now.Add(-6*time.Minute))
+	if err := supervisor.RecordProxyTraffic("proxy-scroll", []domain.RuntimePortTraffic{{Name: "game", RXBytes: 4200, ActiveSessions: 1, TotalSessions: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor.checkIdleScrolls(backend)
+	if backend.stopped.Load() != 0 {
+		t.Fatal("serve was stopped while the proxy reported an active session")
+	}
+	statuses, err := supervisor.Ports("proxy-scroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Source != "coldstarter-proxy" || statuses[0].ActiveSessions == nil || *statuses[0].ActiveSessions != 1 {
+		t.Fatalf("port statuses = %#v, want proxy counters", statuses)
+	}
+
+	// The backend still sees container traffic, but the proxy knows the
+	// last player left and less than 1kb arrived in the window.
+	if err := supervisor.RecordProxyTraffic("proxy-scroll", []domain.RuntimePortTraffic{{Name: "game", RXBytes: 4300, TotalSessions: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor.checkIdleScrolls(backend)
+	if backend.stopped.Load() != 1 {
+		t.Fatalf("StopCommand calls = %d, want serve stopped from proxy traffic", backend.stopped.Load())
+	}
+	if _, ok := supervisor.proxyTraffic.port("proxy-scroll", "game", time.Now()); ok {
+		t.Fatal("proxy traffic was kept after scaling to zero")
+	}
+}
+
+func TestRuntimeSupervisorIdleControllerSkipsServeWithoutPublicPort(t *testing.T) {
+	session := newRuntimeSessionForTest(t, map[string]domain.LockStatus{
+		"start": {Status: domain.ScrollLockStatusRunning},
+	}, idleScrollYAML())
+	session.runtimeScroll.Status = domain.RuntimeScrollStatusRunning
+
+	if _, ok := session.idleCandidate(); ok {
+		t.Fatal("serve without a routed public port must not be scaled to zero")
+	}
+}
+
+func TestRuntimeSupervisorIdleControllerRequiresIdleBackend(t *testing.T) {
+	store := newTestStateStore(t)
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), &fakeWorkerBackend{})
+	if supervisor.StartIdleController(context.Background(), time.Second) {
+		t.Fatal("idle controller started for backend without idle support")
+	}
+}
+
+func idleScrollYAML() string {
+	return `name: cached
+desc: Cached scroll
+version: 0.1.0
+app_version: "1.0"
+ports:
+  - name: game
+    protocol: tcp
+    port: 25565
+serve: start
+commands:
+  start:
+    run: persistent
+    procedures:
+      - image: alpine:3.20
+        command: ["true"]
+        expectedPorts:
+          - name: game
+            keepAliveTraffic: 1kb/5m
+`
+}
+
+func freeTCPPort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func dialEventually(t *testing.T, port int) net.Conn {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(port))
+		if err == nil {
+			return conn
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wake listener did not accept connections: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
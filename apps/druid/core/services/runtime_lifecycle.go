@@ -7,6 +7,8 @@ func (s *RuntimeSupervisor) Delete(id string) error {
 }
 
 func (s *RuntimeSupervisor) DeleteWithPolicy(id string, purgeData bool) error {
+	s.disarmWakeGate(id)
+	s.proxyTraffic.forget(id)
 	s.mu.Lock()
 	session := s.sessions[id]
 	delete(s.sessions, id)
@@ -28,6 +30,12 @@ func (s *RuntimeSupervisor) DeleteWithPolicy(id string, purgeData bool) error {
 }
 
 func (s *RuntimeSupervisor) StartScroll(id string) (*domain.RuntimeScroll, error) {
+	release, err := s.admitCommand(id, "")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	s.disarmWakeGate(id)
 	session, err := s.sessionFor(id)
 	if err != nil {
 		return nil, err
@@ -52,6 +60,8 @@ func (s *RuntimeSupervisor) StartScroll(id string) (*domain.RuntimeScroll, error
 }
 
 func (s *RuntimeSupervisor) Stop(id string) (*domain.RuntimeScroll, error) {
+	s.disarmWakeGate(id)
+	s.proxyTraffic.forget(id)
 	session, err := s.detachSession(id)
 	if err != nil {
 		return nil, err
@@ -13,8 +13,11 @@ import (
 	"go.uber.org/zap"
 )
 
-func (s *RuntimeSupervisor) materializeNewScroll(ctx context.Context, runtimeService ports.RuntimeBackendInterface, artifact string, runtimeID string, namespace string, registryCredentials []domain.RegistryCredential) (*ports.RuntimeMaterialization, error) {
-	storage := resolveArtifactMinDisk(artifact, registryCredentials)
+func (s *RuntimeSupervisor) materializeNewScroll(ctx context.Context, runtimeService ports.RuntimeBackendInterface, artifact string, runtimeID string, namespace string, registryCredentials []domain.RegistryCredential, resources *domain.RuntimeResources) (*ports.RuntimeMaterialization, error) {
+	storage := ""
+	if resources != nil {
+		storage = resources.Disk
+	}
 	return s.runPullWorker(ctx, runtimeService, ports.RuntimeWorkerModeCreate, runtimeID, artifact, runtimeService.RootRef(runtimeID, namespace), registryCredentials, storage)
 }
 
@@ -37,7 +40,10 @@ func (s *RuntimeSupervisor) runPullWorker(ctx context.Context, runtimeService po
 		RootRef:             root,
 		MountPath:           "/scroll",
 		CallbackURL:         callbackURL,
+		CallbackCA:          s.workerCallbackCA,
 		RegistryCredentials: registryCredentials,
+		TrustPolicy:         s.trustPolicy,
+		RegistryConfig:      s.registryConfig,
 	}
 	if err := runtimeService.SpawnPullWorker(waitCtx, action); err != nil {
 		s.workerCallbacks.Cancel(runtimeID)
@@ -56,6 +62,7 @@ func (s *RuntimeSupervisor) runPullWorker(ctx context.Context, runtimeService po
 			ArtifactDigest: result.ArtifactDigest,
 			Root:           root,
 			ScrollYAML:     []byte(result.ScrollYAML),
+			ImageLock:      result.ImageLock,
 		}, nil
 	case <-waitCtx.Done():
 		s.workerCallbacks.Cancel(runtimeID)
@@ -63,30 +70,38 @@ func (s *RuntimeSupervisor) runPullWorker(ctx context.Context, runtimeService po
 	}
 }
 
-func resolveArtifactMinDisk(artifact string, registryCredentials []domain.RegistryCredential) string {
+func (s *RuntimeSupervisor) ociClient(registryCredentials []domain.RegistryCredential) *registry.OciClient {
+	oci := registry.NewOciClient(registry.NewCredentialStore(registryCredentials))
+	oci.SetRegistryConfig(s.registryConfig)
+	return oci
+}
+
+// resolveArtifactResources returns the resources artifact declares in its
+// annotations, or nil for local directories and unreachable artifacts.
+func (s *RuntimeSupervisor) resolveArtifactResources(artifact string, registryCredentials []domain.RegistryCredential) *domain.RuntimeResources {
 	if artifact == "" {
-		return ""
+		return nil
 	}
-	if _, err := os.Stat(artifact); err == nil {
-		return ""
+	if _, err := os.Stat(artifact); err == nil && !domain.IsScrollBundle(artifact) {
+		return nil
 	}
-	oci := registry.NewOciClient(registry.NewCredentialStore(registryCredentials))
+	oci := s.ociClient(registryCredentials)
 	info, err := oci.ResolveAnnotationInfo(artifact)
 	if err != nil {
-		logger.Log().Warn("Unable to resolve artifact min disk", zap.String("artifact", artifact), zap.Error(err))
-		return ""
+		logger.Log().Warn("Unable to resolve artifact resources", zap.String("artifact", artifact), zap.Error(err))
+		return nil
 	}
-	return info.MinDisk
+	return domain.RuntimeResourcesFromAnnotations(info)
 }
 
-func resolveArtifactDigest(artifact string, registryCredentials []domain.RegistryCredential) string {
+func (s *RuntimeSupervisor) resolveArtifactDigest(artifact string, registryCredentials []domain.RegistryCredential) string {
 	if artifact == "" {
 		return ""
 	}
-	if _, err := os.Stat(artifact); err == nil {
+	if _, err := os.Stat(artifact); err == nil && !domain.IsScrollBundle(artifact) {
 		return ""
 	}
-	oci := registry.NewOciClient(registry.NewCredentialStore(registryCredentials))
+	oci := s.ociClient(registryCredentials)
 	digest, err := oci.ResolveDigest(artifact)
 	if err != nil {
 		logger.Log().Warn("Unable to resolve artifact digest", zap.String("artifact", artifact), zap.Error(err))
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+// proxyTrafficStaleAfter is how long a proxy report stays authoritative.
+// Proxies report every few seconds; after that the backend's own traffic
+// numbers are used again.
+const proxyTrafficStaleAfter = 2 * time.Minute
+
+type proxyTrafficSample struct {
+	at time.Time
+	rx uint64
+}
+
+type proxyPortTraffic struct {
+	latest     domain.RuntimePortTraffic
+	reportedAt time.Time
+	samples    []proxyTrafficSample
+}
+
+// proxyTrafficStore keeps the reports coldstarter proxies send through the
+// callback listener, per scroll and port name.
+type proxyTrafficStore struct {
+	mu      sync.Mutex
+	scrolls map[string]map[string]*proxyPortTraffic
+}
+
+func newProxyTrafficStore() *proxyTrafficStore {
+	return &proxyTrafficStore{scrolls: map[string]map[string]*proxyPortTraffic{}}
+}
+
+// RecordProxyTraffic stores the counters a coldstarter proxy reported for a
+// scroll. Port statuses and the idle controller prefer them over container
+// stats while they are fresh.
+func (s *RuntimeSupervisor) RecordProxyTraffic(id string, reports []domain.RuntimePortTraffic) error {
+	for _, report := range reports {
+		if report.Name == "" {
+			return errors.New("traffic report port name is required")
+		}
+	}
+	if _, err := s.store.GetScroll(id); err != nil {
+		return err
+	}
+	s.proxyTraffic.record(id, reports, time.Now())
+	return nil
+}
+
+func (s *proxyTrafficStore) record(id string, reports []domain.RuntimePortTraffic, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ports := s.scrolls[id]
+	if ports == nil {
+		ports = map[string]*proxyPortTraffic{}
+		s.scrolls[id] = ports
+	}
+	cutoff := now.Add(-24 * time.Hour)
+	for _, report := range reports {
+		traffic := ports[report.Name]
+		if traffic == nil || report.RXBytes < traffic.latest.RXBytes {
+			// First report or a restarted proxy; old samples no longer line up.
+			traffic = &proxyPortTraffic{}
+			ports[report.Name] = traffic
+		}
+		traffic.latest = report
+		traffic.reportedAt = now
+		traffic.samples = append(traffic.samples, proxyTrafficSample{at: now, rx: report.RXBytes})
+		keepFrom := 0
+		for keepFrom < len(traffic.samples)-1 && traffic.samples[keepFrom].at.Before(cutoff) {
+			keepFrom++
+		}
+		traffic.samples = traffic.samples[keepFrom:]
+	}
+}
+
+func (s *proxyTrafficStore) forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.scrolls, id)
+}
+
+func (s *proxyTrafficStore) port(id string, name string, now time.Time) (proxyPortTraffic, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	traffic := s.scrolls[id][name]
+	if traffic == nil || now.Sub(traffic.reportedAt) > proxyTrafficStaleAfter {
+		return proxyPortTraffic{}, false
+	}
+	copied := *traffic
+	copied.samples = append([]proxyTrafficSample(nil), traffic.samples...)
+	return copied, true
+}
+
+// rxDelta returns the bytes received over window and whether the reports
+// already cover the full window.
+func (t proxyPortTraffic) rxDelta(window time.Duration, now time.Time) (uint64, bool) {
+	if len(t.samples) == 0 {
+		return 0, false
+	}
+	cutoff := now.Add(-window)
+	if t.samples[0].at.After(cutoff) {
+		return t.latest.RXBytes - t.samples[0].rx, false
+	}
+	base := t.samples[0]
+	for _, sample := range t.samples {
+		if sample.at.After(cutoff) {
+			break
+		}
+		base = sample
+	}
+	return t.latest.RXBytes - base.rx, true
+}
+
+func (t proxyPortTraffic) lastDelta() uint64 {
+	if len(t.samples) < 2 {
+		return 0
+	}
+	return t.samples[len(t.samples)-1].rx - t.samples[len(t.samples)-2].rx
+}
+
+// apply overlays fresh proxy counters onto the backend's port statuses.
+func (s *proxyTrafficStore) apply(id string, statuses []domain.RuntimePortStatus, now time.Time) {
+	for i := range statuses {
+		traffic, ok := s.port(id, statuses[i].Name, now)
+		if !ok {
+			continue
+		}
+		status := &statuses[i]
+		rx := traffic.latest.RXBytes
+		tx := traffic.latest.TXBytes
+		active := traffic.latest.ActiveSessions
+		total := traffic.latest.TotalSessions
+		status.RXBytes = &rx
+		status.TXBytes = &tx
+		status.ActiveSessions = &active
+		status.TotalSessions = &total
+		status.LastActivityAt = traffic.latest.LastActivityAt
+		status.Source = "coldstarter-proxy"
+		delta := traffic.lastDelta()
+		if status.KeepAliveTraffic != "" {
+			if threshold, err := domain.ParseKeepAliveTraffic(status.KeepAliveTraffic); err == nil {
+				delta, _ = traffic.rxDelta(threshold.Window, now)
+				trafficOK := active > 0 || delta >= threshold.Bytes
+				status.TrafficOK = &trafficOK
+				status.TrafficWindow = threshold.Window.String()
+			}
+		}
+		status.Traffic = active > 0 || delta > 0
+		status.TrafficBytes = &delta
+	}
+}
+
+// commandIdle decides keepAliveTraffic from proxy reports. known is false
+// when any port with a threshold has no fresh report; the backend decides
+// then.
+func (s *proxyTrafficStore) commandIdle(id string, command *domain.CommandInstructionSet, now time.Time) (idle bool, known bool) {
+	if command == nil {
+		return false, false
+	}
+	checked := 0
+	for _, procedure := range command.Procedures {
+		if procedure == nil {
+			continue
+		}
+		for _, expectedPort := range procedure.ExpectedPorts {
+			if expectedPort.KeepAliveTraffic == "" {
+				continue
+			}
+			threshold, err := domain.ParseKeepAliveTraffic(expectedPort.KeepAliveTraffic)
+			if err != nil {
+				return false, false
+			}
+			traffic, ok := s.port(id, expectedPort.Name, now)
+			if !ok {
+				return false, false
+			}
+			if traffic.latest.ActiveSessions > 0 {
+				return false, true
+			}
+			delta, ready := traffic.rxDelta(threshold.Window, now)
+			if !ready || delta >= threshold.Bytes {
+				return false, true
+			}
+			checked++
+		}
+	}
+	return checked > 0, checked > 0
+}
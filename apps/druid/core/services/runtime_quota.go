@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadQuotaPolicy reads the quota policy file at path.
+func LoadQuotaPolicy(path string) (*domain.QuotaPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read quota policy: %w", err)
+	}
+	policy := &domain.QuotaPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("parse quota policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("quota policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// SetQuotaPolicy limits what each owner may create and run. Without a
+// policy nothing is limited.
+func (s *RuntimeSupervisor) SetQuotaPolicy(policy *domain.QuotaPolicy) {
+	s.quotaPolicy = policy
+}
+
+// QuotaStatus returns the limits of owner and what it uses of them. Owners
+// without limits get zero limits.
+func (s *RuntimeSupervisor) QuotaStatus(owner string) (*domain.OwnerQuotaStatus, error) {
+	usage, err := s.quotaUsage(owner, "")
+	if err != nil {
+		return nil, err
+	}
+	status := &domain.OwnerQuotaStatus{Owner: owner, Usage: usage}
+	if limits := s.quotaPolicy.LimitsFor(owner); limits != nil {
+		status.Limits = *limits
+	}
+	return status, nil
+}
+
+// QuotaStatuses returns the quota of every owner with scrolls or an entry
+// in the policy, sorted by owner.
+func (s *RuntimeSupervisor) QuotaStatuses() ([]*domain.OwnerQuotaStatus, error) {
+	scrolls, err := s.store.ListScrolls()
+	if err != nil {
+		return nil, err
+	}
+	owners := map[string]bool{}
+	for _, runtimeScroll := range scrolls {
+		if runtimeScroll.OwnerID != "" {
+			owners[runtimeScroll.OwnerID] = true
+		}
+	}
+	if s.quotaPolicy != nil {
+		for _, owner := range s.quotaPolicy.Owners {
+			owners[owner.Owner] = true
+		}
+	}
+	sorted := make([]string, 0, len(owners))
+	for owner := range owners {
+		sorted = append(sorted, owner)
+	}
+	sort.Strings(sorted)
+	statuses := make([]*domain.OwnerQuotaStatus, 0, len(sorted))
+	for _, owner := range sorted {
+		status, err := s.QuotaStatus(owner)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// quotaUsage sums the scrolls of owner, leaving out except, whose usage is
+// about to be replaced.
+func (s *RuntimeSupervisor) quotaUsage(owner string, except string) (domain.QuotaUsage, error) {
+	usage := domain.QuotaUsage{}
+	scrolls, err := s.store.ListScrolls()
+	if err != nil {
+		return usage, err
+	}
+	for _, runtimeScroll := range scrolls {
+		if runtimeScroll.OwnerID != owner || runtimeScroll.ID == except {
+			continue
+		}
+		usage.Scrolls++
+		usage.RunningCommands += len(runningCommands(runtimeScroll.Procedures))
+		if runtimeScroll.Resources != nil {
+			// Usage is informational for values the policy cannot parse;
+			// they are rejected on admission when a limit applies.
+			usage.Resources, _ = domain.QuotaLimits{}.AdmitResources(usage.Resources, runtimeScroll.Resources)
+		}
+	}
+	return usage, nil
+}
+
+// admitArtifact checks that owner may deploy artifact with resources to the
+// runtime id. newScroll also counts the runtime against max_scrolls.
+func (s *RuntimeSupervisor) admitArtifact(owner string, id string, artifact string, resources *domain.RuntimeResources, newScroll bool) error {
+	limits := s.quotaPolicy.LimitsFor(owner)
+	if limits == nil {
+		return nil
+	}
+	repo, _, _ := utils.ParseArtifactRef(artifact)
+	if repo == "" {
+		repo = artifact
+	}
+	if !limits.AllowsRepository(repo) {
+		return fmt.Errorf("%w: repository %s is not allowed for %s", domain.ErrArtifactNotAdmitted, repo, owner)
+	}
+	usage, err := s.quotaUsage(owner, id)
+	if err != nil {
+		return err
+	}
+	if newScroll && limits.MaxScrolls > 0 && usage.Scrolls >= limits.MaxScrolls {
+		return fmt.Errorf("%w: %s already has %d of %d scrolls", domain.ErrQuotaExceeded, owner, usage.Scrolls, limits.MaxScrolls)
+	}
+	if _, err := limits.AdmitResources(usage.Resources, resources); err != nil {
+		return fmt.Errorf("%s: %w", owner, err)
+	}
+	return nil
+}
+
+// reserveArtifact admits artifact for the existing runtimeScroll and stores
+// its resources while holding the admission lock, so concurrent creates and
+// updates of one owner count each other.
+func (s *RuntimeSupervisor) reserveArtifact(runtimeScroll *domain.RuntimeScroll, artifact string, resources *domain.RuntimeResources) error {
+	s.admission.Lock()
+	defer s.admission.Unlock()
+	if err := s.admitArtifact(runtimeScroll.OwnerID, runtimeScroll.ID, artifact, resources, false); err != nil {
+		return err
+	}
+	runtimeScroll.Resources = resources
+	return s.store.UpdateScroll(runtimeScroll)
+}
+
+// admitCommand checks that running command in the runtime id stays within
+// max_running_commands of its owner. An empty command starts the runtime.
+// Commands that already run do not count again. The admitted command stays
+// reserved until the returned release is called, which callers do once the
+// command is queued and shows in the store.
+func (s *RuntimeSupervisor) admitCommand(id string, command string) (func(), error) {
+	release := func() {}
+	runtimeScroll, err := s.store.GetScroll(id)
+	if errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+		// Left to the session lookup, which reports it.
+		return release, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	owner := runtimeScroll.OwnerID
+	limits := s.quotaPolicy.LimitsFor(owner)
+	if limits == nil || limits.MaxRunningCommands == 0 {
+		return release, nil
+	}
+
+	s.admission.Lock()
+	defer s.admission.Unlock()
+	running, err := s.runningCommandKeys(owner)
+	if err != nil {
+		return nil, err
+	}
+	key := id + "/" + command
+	if running[key] || (command == "" && runsAny(running, id)) {
+		return release, nil
+	}
+	if len(running) >= limits.MaxRunningCommands {
+		return nil, fmt.Errorf("%w: %s already runs %d of %d commands", domain.ErrQuotaExceeded, owner, len(running), limits.MaxRunningCommands)
+	}
+	reservations := s.commandReservations[owner]
+	if reservations == nil {
+		reservations = map[string]int{}
+		s.commandReservations[owner] = reservations
+	}
+	reservations[key]++
+	return func() {
+		s.admission.Lock()
+		defer s.admission.Unlock()
+		if reservations[key]--; reservations[key] <= 0 {
+			delete(reservations, key)
+		}
+	}, nil
+}
+
+// runningCommandKeys returns the "<runtime id>/<command>" keys of the
+// commands owner runs or has reserved. A reserved start of a runtime that
+// already runs a command is not counted again. The caller holds admission.
+func (s *RuntimeSupervisor) runningCommandKeys(owner string) (map[string]bool, error) {
+	scrolls, err := s.store.ListScrolls()
+	if err != nil {
+		return nil, err
+	}
+	running := map[string]bool{}
+	for _, runtimeScroll := range scrolls {
+		if runtimeScroll.OwnerID != owner {
+			continue
+		}
+		for command := range runningCommands(runtimeScroll.Procedures) {
+			running[runtimeScroll.ID+"/"+command] = true
+		}
+	}
+	for key := range s.commandReservations[owner] {
+		if id, command, _ := strings.Cut(key, "/"); command == "" && runsAny(running, id) {
+			continue
+		}
+		running[key] = true
+	}
+	return running, nil
+}
+
+// runsAny reports whether running holds a command of the runtime id.
+func runsAny(running map[string]bool, id string) bool {
+	for key := range running {
+		if strings.HasPrefix(key, id+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// runningCommands returns the commands with a running or waiting procedure.
+func runningCommands(procedures domain.ProcedureStatusMap) map[string]bool {
+	running := map[string]bool{}
+	for command, statuses := range procedures {
+		for _, status := range statuses {
+			if status.Status == domain.ScrollLockStatusRunning || status.Status == domain.ScrollLockStatusWaiting {
+				running[command] = true
+				break
+			}
+		}
+	}
+	return running
+}
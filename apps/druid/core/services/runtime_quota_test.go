@@ -0,0 +1,247 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+)
+
+func newQuotaSupervisor(t *testing.T, policy *domain.QuotaPolicy) (*RuntimeSupervisor, string) {
+	t.Helper()
+	artifact := t.TempDir()
+	if err := os.WriteFile(filepath.Join(artifact, "scroll.yaml"), []byte(cachedScrollYAML("start")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := newTestStateStore(t)
+	callbacks := NewWorkerCallbackManager()
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), &fakeWorkerBackend{callbacks: callbacks, scrollYAML: cachedScrollYAML("start")})
+	supervisor.SetWorkerCallbacks(callbacks, "http://druid-cli:8083")
+	supervisor.SetQuotaPolicy(policy)
+	return supervisor, artifact
+}
+
+func TestRuntimeSupervisorCreateEnforcesMaxScrolls(t *testing.T) {
+	supervisor, artifact := newQuotaSupervisor(t, &domain.QuotaPolicy{
+		Default: domain.QuotaLimits{MaxScrolls: 1},
+		Owners:  []domain.OwnerQuota{{Owner: "bob", QuotaLimits: domain.QuotaLimits{MaxScrolls: 2}}},
+	})
+
+	if _, err := supervisor.CreateWithOwner(artifact, "alice-1", "alice", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := supervisor.CreateWithOwner(artifact, "alice-2", "alice", "", nil); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("second scroll of alice: err = %v, want quota exceeded", err)
+	}
+	if _, err := supervisor.Get("alice-2"); !errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+		t.Fatalf("a rejected create left a placeholder: %v", err)
+	}
+	for _, name := range []string{"bob-1", "bob-2"} {
+		if _, err := supervisor.CreateWithOwner(artifact, name, "bob", "", nil); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"local-1", "local-2"} {
+		if _, err := supervisor.Create(artifact, name, nil); err != nil {
+			t.Fatalf("scrolls without owner are not limited: %v", err)
+		}
+	}
+
+	status, err := supervisor.QuotaStatus("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Usage.Scrolls != 1 || status.Limits.MaxScrolls != 1 {
+		t.Fatalf("alice quota = %#v", status)
+	}
+	statuses, err := supervisor.QuotaStatuses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 || statuses[0].Owner != "alice" || statuses[1].Owner != "bob" || statuses[1].Usage.Scrolls != 2 {
+		t.Fatalf("statuses = %#v", statuses)
+	}
+}
+
+func TestRuntimeSupervisorCreateRejectsRepositoryOutsideAllowList(t *testing.T) {
+	supervisor, artifact := newQuotaSupervisor(t, &domain.QuotaPolicy{
+		Default: domain.QuotaLimits{AllowedRepositories: []string{"registry.example/scrolls/*"}},
+	})
+
+	if _, err := supervisor.CreateWithOwner(artifact, "local-dir", "alice", "", nil); !errors.Is(err, domain.ErrArtifactNotAdmitted) {
+		t.Fatalf("local directory: err = %v, want not admitted", err)
+	}
+	if err := supervisor.admitArtifact("alice", "game", "registry.example/other/game:1", nil, true); !errors.Is(err, domain.ErrArtifactNotAdmitted) {
+		t.Fatalf("other repository: err = %v, want not admitted", err)
+	}
+	if err := supervisor.admitArtifact("alice", "game", "registry.example/scrolls/minecraft@sha256:abc", nil, true); err != nil {
+		t.Fatalf("allowed repository: %v", err)
+	}
+}
+
+func TestRuntimeSupervisorAdmitsDeclaredResourcesAgainstOwnerTotal(t *testing.T) {
+	supervisor, _ := newQuotaSupervisor(t, &domain.QuotaPolicy{
+		Default: domain.QuotaLimits{MaxCPU: "2", MaxMemory: "4Gi"},
+	})
+	if err := supervisor.store.CreateScroll(&domain.RuntimeScroll{
+		ID:        "existing",
+		OwnerID:   "alice",
+		Status:    domain.RuntimeScrollStatusRunning,
+		Resources: &domain.RuntimeResources{CPU: "1500m", Memory: "3Gi"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := supervisor.admitArtifact("alice", "next", "registry.example/game:1", &domain.RuntimeResources{CPU: "500m", Memory: "1Gi"}, true); err != nil {
+		t.Fatalf("exactly at the limit: %v", err)
+	}
+	if err := supervisor.admitArtifact("alice", "next", "registry.example/game:1", &domain.RuntimeResources{CPU: "100m", Memory: "2Gi"}, true); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("over memory: err = %v, want quota exceeded", err)
+	}
+	if err := supervisor.admitArtifact("alice", "existing", "registry.example/game:2", &domain.RuntimeResources{CPU: "2", Memory: "4Gi"}, false); err != nil {
+		t.Fatalf("updating a scroll replaces its own usage: %v", err)
+	}
+	if err := supervisor.admitArtifact("alice", "next", "registry.example/game:1", &domain.RuntimeResources{CPU: "lots", Memory: "1Gi"}, true); !errors.Is(err, domain.ErrArtifactNotAdmitted) {
+		t.Fatalf("unparseable cpu: err = %v, want not admitted", err)
+	}
+	for name, resources := range map[string]*domain.RuntimeResources{
+		"unresolved":     nil,
+		"missing memory": {CPU: "100m"},
+	} {
+		if err := supervisor.admitArtifact("alice", "next", "registry.example/game:1", resources, true); !errors.Is(err, domain.ErrArtifactNotAdmitted) {
+			t.Fatalf("%s: err = %v, want not admitted", name, err)
+		}
+	}
+
+	status, err := supervisor.QuotaStatus("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Usage.Resources.CPU != "1500m" || status.Usage.Resources.Memory != "3Gi" {
+		t.Fatalf("usage = %#v", status.Usage)
+	}
+}
+
+func TestRuntimeSupervisorFailedUpdateReleasesReservedResources(t *testing.T) {
+	supervisor, artifact := newQuotaSupervisor(t, nil)
+	if err := supervisor.store.CreateScroll(&domain.RuntimeScroll{
+		ID:         "game",
+		OwnerID:    "alice",
+		Artifact:   artifact,
+		Root:       "runtime://game",
+		ScrollYAML: cachedScrollYAML("start"),
+		Status:     domain.RuntimeScrollStatusStopped,
+		Procedures: domain.ProcedureStatusMap{},
+		Resources:  &domain.RuntimeResources{CPU: "1", Memory: "2Gi"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	backend := supervisor.runtimeBackend.(*fakeWorkerBackend)
+	backend.workerErr = errors.New("pull failed")
+
+	if _, err := supervisor.Update("game", artifact, "local", nil); err == nil {
+		t.Fatal("update should fail")
+	}
+	runtimeScroll, err := supervisor.Get("game")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtimeScroll.Resources == nil || runtimeScroll.Resources.CPU != "1" || runtimeScroll.Resources.Memory != "2Gi" {
+		t.Fatalf("resources = %#v, want the deployed ones back", runtimeScroll.Resources)
+	}
+}
+
+func TestRuntimeSupervisorAdmitCommandCountsRunningCommandsOfOwner(t *testing.T) {
+	supervisor, _ := newQuotaSupervisor(t, &domain.QuotaPolicy{
+		Default: domain.QuotaLimits{MaxRunningCommands: 1},
+	})
+	running := domain.ProcedureStatusMap{"start": {"main": {Status: domain.ScrollLockStatusRunning}}}
+	for _, runtimeScroll := range []*domain.RuntimeScroll{
+		{ID: "first", OwnerID: "alice", Status: domain.RuntimeScrollStatusRunning, Procedures: running},
+		{ID: "second", OwnerID: "alice", Status: domain.RuntimeScrollStatusStopped, Procedures: domain.ProcedureStatusMap{}},
+		{ID: "local", Status: domain.RuntimeScrollStatusStopped, Procedures: domain.ProcedureStatusMap{}},
+	} {
+		if err := supervisor.store.CreateScroll(runtimeScroll); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := supervisor.admitCommand("first", "start"); err != nil {
+		t.Fatalf("re-running a running command: %v", err)
+	}
+	if _, err := supervisor.admitCommand("first", ""); err != nil {
+		t.Fatalf("starting a running scroll: %v", err)
+	}
+	if _, err := supervisor.admitCommand("first", "backup"); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("second command: err = %v, want quota exceeded", err)
+	}
+	if _, err := supervisor.admitCommand("second", ""); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("starting another scroll: err = %v, want quota exceeded", err)
+	}
+	if _, err := supervisor.admitCommand("local", "start"); err != nil {
+		t.Fatalf("scrolls without owner are not limited: %v", err)
+	}
+}
+
+func TestRuntimeSupervisorAdmitCommandCountsAdmittedCommandsUntilReleased(t *testing.T) {
+	supervisor, _ := newQuotaSupervisor(t, &domain.QuotaPolicy{
+		Default: domain.QuotaLimits{MaxRunningCommands: 1},
+	})
+	for _, id := range []string{"first", "second"} {
+		if err := supervisor.store.CreateScroll(&domain.RuntimeScroll{ID: id, OwnerID: "alice", Status: domain.RuntimeScrollStatusStopped, Procedures: domain.ProcedureStatusMap{}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	release, err := supervisor.admitCommand("first", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := supervisor.admitCommand("second", "start"); !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("command before the first is queued: err = %v, want quota exceeded", err)
+	}
+	again, err := supervisor.admitCommand("first", "")
+	if err != nil {
+		t.Fatalf("starting a scroll that is being started: %v", err)
+	}
+	again()
+	release()
+	if _, err := supervisor.admitCommand("second", "start"); err != nil {
+		t.Fatalf("after release: %v", err)
+	}
+}
+
+func TestLoadQuotaPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.yaml")
+	if err := os.WriteFile(path, []byte(`default:
+  max_scrolls: 3
+  max_running_commands: 2
+  max_memory: 8Gi
+  allowed_repositories: [registry.example/scrolls/*]
+owners:
+  - owner: bob
+    max_scrolls: 10
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadQuotaPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Default.MaxScrolls != 3 || policy.Default.MaxRunningCommands != 2 || policy.Default.MaxMemory != "8Gi" || len(policy.Default.AllowedRepositories) != 1 {
+		t.Fatalf("default = %#v", policy.Default)
+	}
+	if bob := policy.LimitsFor("bob"); bob.MaxScrolls != 10 || bob.MaxMemory != "" {
+		t.Fatalf("bob = %#v", bob)
+	}
+
+	if err := os.WriteFile(path, []byte("default:\n  max_scroll: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadQuotaPolicy(path); err == nil {
+		t.Fatal("a misspelled limit was accepted")
+	}
+}
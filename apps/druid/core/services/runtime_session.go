@@ -26,6 +26,17 @@ type RuntimeSession struct {
 	queueMu        sync.Mutex
 	runMu          sync.Mutex
 	started        bool
+	// idleCommand is the serve command scaled to zero by the idle controller.
+	// Status updates from its dying procedures are dropped.
+	idleCommand string
+	callbackURL string
+	// callbackTokens issues a fresh callback token every time a command
+	// starts, so a restart rotates the token of the coldstarter proxy.
+	callbackTokens *coreservices.RuntimeCallbackTokens
+	callbackCA     string
+	// admissionPolicy is checked before every command, once routing has
+	// assigned the host ports.
+	admissionPolicy *domain.ScrollAdmissionPolicy
 }
 
 func NewRuntimeSession(
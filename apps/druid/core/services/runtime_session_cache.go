@@ -50,6 +50,12 @@ func (s *RuntimeSupervisor) startSession(runtimeScroll *domain.RuntimeScroll) (*
 	if err != nil {
 		return nil, err
 	}
+	if s.workerCallbackURL != "" && s.callbackTokens != nil {
+		session.callbackURL = s.workerCallbackURL
+		session.callbackTokens = s.callbackTokens
+		session.callbackCA = s.workerCallbackCA
+	}
+	session.admissionPolicy = s.admissionPolicy
 	session.Start()
 
 	s.mu.Lock()
@@ -100,6 +100,9 @@ func (s *RuntimeSession) AutoStartServe() error {
 	if command == nil {
 		return nil
 	}
+	s.mu.Lock()
+	s.idleCommand = ""
+	s.mu.Unlock()
 	s.rememberDoneDependencies(command, map[string]bool{})
 	if command.Run == domain.RunModePersistent {
 		s.mu.Lock()
@@ -203,6 +206,9 @@ func (s *RuntimeSession) persistCommandStatus(command string, status domain.Scro
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if command == s.idleCommand {
+		return
+	}
 	commands := s.scrollService.GetFile().Commands
 	commandDefinition := commands[command]
 	if commandDefinition == nil {
@@ -246,6 +252,9 @@ func (s *RuntimeSession) SetCommandStatus(command string, status domain.ScrollLo
 func (s *RuntimeSession) persistProcedureStatus(command string, procedure string, status domain.ScrollLockStatus, exitCode *int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	if command == s.idleCommand {
+		return
+	}
 	commands := s.scrollService.GetFile().Commands
 	if commands[command] == nil {
 		return
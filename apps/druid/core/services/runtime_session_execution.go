@@ -28,7 +28,15 @@ func (s *RuntimeSession) runCommand(cmd string, extraEnv ...map[string]map[strin
 	routing := make([]domain.RuntimeRouteAssignment, len(s.runtimeScroll.Routing))
 	copy(routing, s.runtimeScroll.Routing)
 	reservations := append([]domain.Port(nil), s.runtimeScroll.ReservedPorts...)
+	imageLock := s.runtimeScroll.ImageLock
+	callbackURL := s.callbackURL
+	callbackTokens := s.callbackTokens
+	callbackCA := s.callbackCA
 	s.mu.Unlock()
+	callbackToken := ""
+	if callbackTokens != nil {
+		callbackToken = callbackTokens.Issue(scrollID)
+	}
 
 	if root == "" {
 		root = s.scrollService.GetCwd()
@@ -46,11 +54,18 @@ func (s *RuntimeSession) runCommand(cmd string, extraEnv ...map[string]map[strin
 	}
 	runtimeFile := *file
 	runtimeFile.Ports = runtimePorts
+	if err := s.admissionPolicy.Admit(&runtimeFile, imageLock); err != nil {
+		s.setCommandProcedureStatus(cmd, command, domain.ScrollLockStatusError, nil)
+		return err
+	}
 	procedureEnv, err := coreservices.BuildRuntimeProcedureEnv(&runtimeFile, cmd, command, coreservices.RuntimeEnvContext{
-		ScrollID:   scrollID,
-		ScrollName: scrollName,
-		Backend:    s.runtimeBackend.Name(),
-		Routing:    routing,
+		ScrollID:      scrollID,
+		ScrollName:    scrollName,
+		Backend:       s.runtimeBackend.Name(),
+		Routing:       routing,
+		CallbackURL:   callbackURL,
+		CallbackToken: callbackToken,
+		CallbackCA:    callbackCA,
 	})
 	if err != nil {
 		s.setCommandProcedureStatus(cmd, command, domain.ScrollLockStatusError, nil)
@@ -72,7 +87,7 @@ func (s *RuntimeSession) runCommand(cmd string, extraEnv ...map[string]map[strin
 	exitCode, err := s.runtimeBackend.RunCommand(ports.RuntimeCommand{
 		Name:          cmd,
 		ScrollID:      scrollID,
-		Command:       command,
+		Command:       domain.PinImages(command, imageLock),
 		Root:          root,
 		GlobalPorts:   runtimePorts,
 		ReservedPorts: reservations,
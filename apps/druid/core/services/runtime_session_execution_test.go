@@ -36,6 +36,33 @@ func TestRuntimeSessionRunCommandPassesCommandContextToRuntimeBackend(t *testing
 	}
 }
 
+func TestRuntimeSessionRunCommandRunsPinnedImages(t *testing.T) {
+	var seen ports.RuntimeCommand
+	session := newRuntimeSessionExecutionTest(t, executionScrollYAML(), &fakeWorkerBackend{
+		runCommand: func(command ports.RuntimeCommand) (*int, error) {
+			seen = command
+			return nil, nil
+		},
+	})
+	pinned := "docker.io/library/alpine@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	session.runtimeScroll.ImageLock = map[string]string{"alpine:3.20": pinned}
+
+	if err := session.runCommand("serve"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := seen.Command.Procedures[0].Image; got != pinned {
+		t.Fatalf("Image = %s, want %s", got, pinned)
+	}
+	command, err := session.scrollService.GetCommand("serve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command.Procedures[0].Image != "alpine:3.20" {
+		t.Fatalf("scroll command was changed to %s", command.Procedures[0].Image)
+	}
+}
+
 func TestRuntimeSessionPersistentCommandRemainsRunningAfterSetup(t *testing.T) {
 	session := newRuntimeSessionExecutionTest(t, executionScrollYAML(), &fakeWorkerBackend{
 		runCommand: func(command ports.RuntimeCommand) (*int, error) {
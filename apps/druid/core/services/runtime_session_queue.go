@@ -410,6 +410,16 @@ func (s *RuntimeSession) getQueueStatus(cmd string) domain.ScrollLockStatus {
 	return s.derivedQueueStatus(cmd)
 }
 
+// queueRestarts returns how often cmd was restarted right after exiting.
+func (s *RuntimeSession) queueRestarts(cmd string) uint {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+	if item, ok := s.queue[cmd]; ok {
+		return item.restartCount
+	}
+	return 0
+}
+
 func (s *RuntimeSession) derivedQueueStatus(cmd string) domain.ScrollLockStatus {
 	command, err := s.scrollService.GetCommand(cmd)
 	if err != nil {
@@ -175,6 +175,7 @@ func (s *RuntimeSession) ApplyRestore(materialized *ports.RuntimeMaterialization
 	}
 	s.runtimeScroll.Artifact = materialized.Artifact
 	s.runtimeScroll.ArtifactDigest = materialized.ArtifactDigest
+	s.runtimeScroll.ImageLock = materialized.ImageLock
 	s.runtimeScroll.Root = root
 	s.runtimeScroll.ScrollName = scrollService.GetCurrent().Name
 	s.runtimeScroll.ScrollYAML = string(scrollYAML)
@@ -23,10 +23,33 @@ type RuntimeSupervisor struct {
 	runtimeBackend    ports.RuntimeBackendInterface
 	workerCallbacks   *WorkerCallbackManager
 	workerCallbackURL string
+	workerCallbackCA  string
 	workerTimeout     time.Duration
+	callbackTokens    *coreservices.RuntimeCallbackTokens
+	trustPolicy       *domain.ScrollTrustPolicy
+	registryConfig    domain.RegistryConfig
+	quotaPolicy       *domain.QuotaPolicy
+	admissionPolicy   *domain.ScrollAdmissionPolicy
+	proxyTraffic      *proxyTrafficStore
+	events            *domain.BroadcastChannel
 
-	mu       sync.Mutex
-	sessions map[string]*RuntimeSession
+	registryCredentials []domain.RegistryCredential
+	updateWindow        *domain.MaintenanceWindow
+	updateHealthTimeout time.Duration
+	resolveUpdate       updateTargetResolver
+
+	// admission serializes quota checks with the placeholder or resources
+	// they store, so concurrent creates cannot both take the last scroll.
+	admission sync.Mutex
+	// commandReservations counts admitted commands per owner and
+	// "<runtime id>/<command>" until their run call returns, so commands
+	// that are not yet queued still count against max_running_commands.
+	commandReservations map[string]map[string]int
+
+	mu        sync.Mutex
+	sessions  map[string]*RuntimeSession
+	idleCtx   context.Context
+	wakeGates map[string]*idleWakeGate
 }
 
 type EnsureOptions struct {
@@ -35,6 +58,9 @@ type EnsureOptions struct {
 	OwnerID             string
 	Namespace           string
 	RegistryCredentials []domain.RegistryCredential
+	// Initiator is recorded in the update history when ensure moves the
+	// runtime to a new artifact.
+	Initiator string
 }
 
 // developerPorts are deployment-owned platform ports. They are allocated when
@@ -55,13 +81,22 @@ func NewRuntimeSupervisor(
 	manager *coreservices.RuntimeScrollManager,
 	runtimeBackend ports.RuntimeBackendInterface,
 ) *RuntimeSupervisor {
-	return &RuntimeSupervisor{
-		store:          store,
-		manager:        manager,
-		runtimeBackend: runtimeBackend,
-		workerTimeout:  20 * time.Minute,
-		sessions:       map[string]*RuntimeSession{},
+	events := domain.NewHub()
+	go events.Run()
+	s := &RuntimeSupervisor{
+		store:               store,
+		manager:             manager,
+		runtimeBackend:      runtimeBackend,
+		workerTimeout:       20 * time.Minute,
+		proxyTraffic:        newProxyTrafficStore(),
+		events:              events,
+		updateHealthTimeout: 2 * time.Minute,
+		sessions:            map[string]*RuntimeSession{},
+		wakeGates:           map[string]*idleWakeGate{},
+		commandReservations: map[string]map[string]int{},
 	}
+	s.resolveUpdate = s.resolveRegistryUpdateTarget
+	return s
 }
 
 func (s *RuntimeSupervisor) SetWorkerCallbacks(callbacks *WorkerCallbackManager, callbackURL string) {
@@ -69,6 +104,32 @@ func (s *RuntimeSupervisor) SetWorkerCallbacks(callbacks *WorkerCallbackManager,
 	s.workerCallbackURL = strings.TrimRight(callbackURL, "/")
 }
 
+// SetWorkerCallbackCA hands pull workers and procedures the PEM CAs to trust
+// when the callback listener serves HTTPS.
+func (s *RuntimeSupervisor) SetWorkerCallbackCA(caPEM string) {
+	s.workerCallbackCA = caPEM
+}
+
+// SetRuntimeCallbackTokens hands coldstarter procedures the worker callback
+// URL and a token scoped to their scroll, so they can report back to the
+// daemon.
+func (s *RuntimeSupervisor) SetRuntimeCallbackTokens(tokens *coreservices.RuntimeCallbackTokens) {
+	s.callbackTokens = tokens
+}
+
+// SetTrustPolicy makes pull workers reject scroll artifacts the policy does
+// not trust before their scroll.yaml is reported back.
+func (s *RuntimeSupervisor) SetTrustPolicy(policy *domain.ScrollTrustPolicy) {
+	s.trustPolicy = policy
+}
+
+// SetRegistryConfig sets the registry mirrors, rewrites and host settings
+// used to resolve artifacts and handed to pull workers. CA files must
+// already be inlined, see registry.ResolveRegistryConfig.
+func (s *RuntimeSupervisor) SetRegistryConfig(config domain.RegistryConfig) {
+	s.registryConfig = config
+}
+
 func (s *RuntimeSupervisor) SetWorkerTimeout(timeout time.Duration) {
 	if timeout <= 0 {
 		return
@@ -119,6 +180,7 @@ func (s *RuntimeSupervisor) createWithOwner(artifact string, name string, ownerI
 	} else if !errors.Is(err, domain.ErrRuntimeScrollNotFound) {
 		return nil, err
 	}
+	resources := s.resolveArtifactResources(artifact, registryCredentials)
 	placeholder := &domain.RuntimeScroll{
 		ID:            id,
 		OwnerID:       ownerID,
@@ -127,8 +189,16 @@ func (s *RuntimeSupervisor) createWithOwner(artifact string, name string, ownerI
 		Status:        domain.RuntimeScrollStatusCreated,
 		Procedures:    domain.ProcedureStatusMap{},
 		ReservedPorts: fixedDeveloperPorts(),
+		Resources:     resources,
 	}
-	if err := s.store.CreateScroll(placeholder); err != nil {
+	s.admission.Lock()
+	if err := s.admitArtifact(ownerID, id, artifact, resources, true); err != nil {
+		s.admission.Unlock()
+		return nil, err
+	}
+	err := s.store.CreateScroll(placeholder)
+	s.admission.Unlock()
+	if err != nil {
 		return nil, err
 	}
 	markPlaceholderError := func(cause error) {
@@ -137,7 +207,7 @@ func (s *RuntimeSupervisor) createWithOwner(artifact string, name string, ownerI
 		_ = s.store.UpdateScroll(placeholder)
 	}
 
-	materialized, err := s.materializeNewScroll(context.Background(), s.runtimeBackend, artifact, id, namespace, registryCredentials)
+	materialized, err := s.materializeNewScroll(context.Background(), s.runtimeBackend, artifact, id, namespace, registryCredentials, resources)
 	if err != nil {
 		markPlaceholderError(err)
 		return nil, err
@@ -149,6 +219,7 @@ func (s *RuntimeSupervisor) createWithOwner(artifact string, name string, ownerI
 	if err != nil {
 		return nil, err
 	}
+	s.rememberRegistryCredentials(id, registryCredentials)
 	return placeholder, nil
 }
 
@@ -169,7 +240,11 @@ func (s *RuntimeSupervisor) Ensure(options EnsureOptions) (*domain.RuntimeScroll
 				if artifact == "" {
 					artifact = runtimeScroll.Artifact
 				}
-				materialized, err := s.materializeNewScroll(context.Background(), s.runtimeBackend, artifact, id, options.Namespace, options.RegistryCredentials)
+				resources := s.resolveArtifactResources(artifact, options.RegistryCredentials)
+				if err := s.reserveArtifact(runtimeScroll, artifact, resources); err != nil {
+					return nil, err
+				}
+				materialized, err := s.materializeNewScroll(context.Background(), s.runtimeBackend, artifact, id, options.Namespace, options.RegistryCredentials, resources)
 				if err != nil {
 					runtimeScroll.Status = domain.RuntimeScrollStatusError
 					runtimeScroll.LastError = err.Error()
@@ -179,18 +254,28 @@ func (s *RuntimeSupervisor) Ensure(options EnsureOptions) (*domain.RuntimeScroll
 				if materialized.Artifact != "" {
 					artifact = materialized.Artifact
 				}
-				return s.applyMaterializedScroll(runtimeScroll, artifact, materialized)
+				runtimeScroll, err = s.applyMaterializedScroll(runtimeScroll, artifact, materialized)
+				if err != nil {
+					return nil, err
+				}
+				s.rememberRegistryCredentials(id, options.RegistryCredentials)
+				return runtimeScroll, nil
 			}
 			if runtimeScroll.Status == domain.RuntimeScrollStatusError && (options.Artifact == "" || options.Artifact == runtimeScroll.Artifact) {
 				return s.persistEnsureOptions(runtimeScroll, options)
 			}
 			if options.Artifact != "" {
-				nextDigest := resolveArtifactDigest(options.Artifact, options.RegistryCredentials)
+				nextDigest := s.resolveArtifactDigest(options.Artifact, options.RegistryCredentials)
 				artifactChanged := options.Artifact != runtimeScroll.Artifact
 				digestChanged := nextDigest != "" && nextDigest != runtimeScroll.ArtifactDigest
 				if artifactChanged || digestChanged {
 					applyEnsureOptions(runtimeScroll, options)
-					return s.updateExistingScroll(runtimeScroll, options.Artifact, nextDigest, options.RegistryCredentials, false)
+					runtimeScroll, err = s.updateExistingScroll(runtimeScroll, options.Artifact, nextDigest, options.RegistryCredentials, false, runtimeUpdate{action: domain.RuntimeUpdateActionUpdate, initiator: options.Initiator})
+					if err != nil {
+						return nil, err
+					}
+					s.rememberRegistryCredentials(id, options.RegistryCredentials)
+					return runtimeScroll, nil
 				}
 			}
 			return s.persistEnsureOptions(runtimeScroll, options)
@@ -252,8 +337,12 @@ func (s *RuntimeSupervisor) applyMaterializedScroll(runtimeScroll *domain.Runtim
 		return nil, err
 	}
 	scroll := scrollService.GetCurrent()
+	if err := s.admitScroll(runtimeScroll, artifact, scroll, materialized.ImageLock); err != nil {
+		return nil, err
+	}
 	runtimeScroll.Artifact = artifact
 	runtimeScroll.ArtifactDigest = materialized.ArtifactDigest
+	runtimeScroll.ImageLock = materialized.ImageLock
 	runtimeScroll.Root = materialized.Root
 	runtimeScroll.ScrollName = scroll.Name
 	runtimeScroll.ScrollYAML = string(materialized.ScrollYAML)
@@ -918,7 +918,7 @@ func TestRuntimeSupervisorUpdateRefreshesCurrentArtifactAndRestartsRunningScroll
 	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), backend)
 	supervisor.SetWorkerCallbacks(callbacks, "http://druid-cli:8083")
 
-	updated, err := supervisor.Update("refresh-worker", "", nil)
+	updated, err := supervisor.Update("refresh-worker", "", "local", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -940,6 +940,41 @@ func TestRuntimeSupervisorUpdateRefreshesCurrentArtifactAndRestartsRunningScroll
 	}
 }
 
+func TestRuntimeSupervisorRollbackRestoresPreviousDigest(t *testing.T) {
+	supervisor, store, backend := newAutoUpdateSupervisor(t, domain.RuntimeScrollStatusStopped, nil)
+
+	if _, err := supervisor.Update("auto", "registry.local/lab:2.0", "local", nil); err != nil {
+		t.Fatal(err)
+	}
+	rolledBack, err := supervisor.Rollback("auto", -1, "operator:druid/druid-operator", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backend.action.Mode != ports.RuntimeWorkerModeUpdate || backend.action.Artifact != "registry.local/lab@sha256:old" {
+		t.Fatalf("worker action = %#v", backend.action)
+	}
+	if rolledBack.Artifact != "registry.local/lab:1.0" || rolledBack.ArtifactDigest != "sha256:old" {
+		t.Fatalf("rolled back scroll = %s@%s", rolledBack.Artifact, rolledBack.ArtifactDigest)
+	}
+
+	persisted, err := store.GetScroll("auto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actions []string
+	for _, record := range persisted.UpdateHistory {
+		actions = append(actions, record.Action+":"+record.Digest+":"+record.Initiator)
+	}
+	want := []string{"create:sha256:old:", "update:sha256:new:local", "rollback:sha256:old:operator:druid/druid-operator"}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("update history = %v, want %v", actions, want)
+	}
+
+	if _, err := supervisor.Rollback("auto", len(persisted.UpdateHistory), "local", nil); !errors.Is(err, domain.ErrNoRollbackTarget) {
+		t.Fatalf("rollback past history error = %v, want ErrNoRollbackTarget", err)
+	}
+}
+
 func TestRuntimeSupervisorRestoreUsesPullWorkerResult(t *testing.T) {
 	store := newTestStateStore(t)
 	root := "runtime://restore-worker"
@@ -1435,3 +1470,63 @@ func newTestStateStore(t *testing.T) ports.RuntimeScrollStore {
 	}
 	return store
 }
+
+func TestRuntimeSupervisorRecordWakeEventUpdatesAndTrims(t *testing.T) {
+	store := newTestStateStore(t)
+	if err := store.CreateScroll(&domain.RuntimeScroll{
+		ID:         "woken",
+		Artifact:   "local",
+		Root:       "runtime://woken",
+		ScrollName: "cached",
+		ScrollYAML: idleScrollYAML(),
+		Status:     domain.RuntimeScrollStatusRunning,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	supervisor := NewRuntimeSupervisor(store, coreservices.NewRuntimeScrollManager(store), &fakeWorkerBackend{})
+	defer func() {
+		for _, session := range supervisor.sessions {
+			session.stopDeploymentQueue()
+		}
+	}()
+
+	wokeAt := time.Now().Add(-time.Minute)
+	first, err := supervisor.RecordWakeEvent("woken", domain.RuntimeWakeEvent{ID: "wake-1", Port: "game", RemoteAddress: "198.51.100.7:5000", WokeAt: wokeAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Source != domain.RuntimeWakeSourceColdstarter || first.ReceivedAt.IsZero() {
+		t.Fatalf("recorded = %#v, want coldstarter source and received_at", first)
+	}
+	readyAt := time.Now()
+	if _, err := supervisor.RecordWakeEvent("woken", domain.RuntimeWakeEvent{ID: "wake-1", Port: "game", RemoteAddress: "198.51.100.7:5000", WokeAt: wokeAt, ReadyAt: &readyAt}); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := store.GetScroll("woken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.WakeEvents) != 1 || updated.WakeEvents[0].ReadyAt == nil {
+		t.Fatalf("wake events = %#v, want one event updated with ready_at", updated.WakeEvents)
+	}
+
+	for i := 0; i < maxWakeEvents+5; i++ {
+		if _, err := supervisor.RecordWakeEvent("woken", domain.RuntimeWakeEvent{Port: "game", WokeAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := supervisor.Events("woken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != maxWakeEvents || events[0].Wake.ID == "wake-1" {
+		t.Fatalf("events = %d first = %s, want the newest %d", len(events), events[0].Wake.ID, maxWakeEvents)
+	}
+
+	if _, err := supervisor.RecordWakeEvent("woken", domain.RuntimeWakeEvent{WokeAt: time.Now()}); err == nil {
+		t.Fatal("wake event without port was accepted")
+	}
+	if _, err := supervisor.RecordWakeEvent("missing", domain.RuntimeWakeEvent{Port: "game", WokeAt: time.Now()}); !errors.Is(err, domain.ErrRuntimeScrollNotFound) {
+		t.Fatalf("missing scroll error = %v, want not found", err)
+	}
+}
@@ -3,15 +3,32 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 	coreservices "github.com/highcard-dev/daemon/internal/core/services"
+	"github.com/highcard-dev/daemon/internal/utils"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
 	"go.uber.org/zap"
 )
 
-func (s *RuntimeSupervisor) Update(id string, artifact string, registryCredentials []domain.RegistryCredential) (*domain.RuntimeScroll, error) {
+// maxUpdateHistory bounds RuntimeScroll.UpdateHistory; older deployments
+// are dropped first.
+const maxUpdateHistory = 20
+
+// runtimeUpdate describes why updateExistingScroll runs, for the update
+// history. artifact, when set, is stored as the runtime's artifact instead of
+// the reference that was pulled, so a rollback by digest keeps its tag.
+type runtimeUpdate struct {
+	action    string
+	initiator string
+	artifact  string
+}
+
+func (s *RuntimeSupervisor) Update(id string, artifact string, initiator string, registryCredentials []domain.RegistryCredential) (*domain.RuntimeScroll, error) {
 	runtimeScroll, err := s.store.GetScroll(id)
 	if err != nil {
 		return nil, err
@@ -19,12 +36,135 @@ func (s *RuntimeSupervisor) Update(id string, artifact string, registryCredentia
 	if artifact == "" {
 		artifact = runtimeScroll.Artifact
 	}
-	knownDigest := resolveArtifactDigest(artifact, registryCredentials)
-	return s.updateExistingScroll(runtimeScroll, artifact, knownDigest, registryCredentials, true)
+	knownDigest := s.resolveArtifactDigest(artifact, registryCredentials)
+	runtimeScroll, err = s.updateExistingScroll(runtimeScroll, artifact, knownDigest, registryCredentials, true, runtimeUpdate{action: domain.RuntimeUpdateActionUpdate, initiator: initiator})
+	if err != nil {
+		return nil, err
+	}
+	s.rememberRegistryCredentials(id, registryCredentials)
+	return runtimeScroll, nil
+}
+
+// Rollback re-materializes an earlier deployment from the update history by
+// its digest through the update worker, so skip_update chunks keep their
+// data. to is an index into UpdateHistory; a negative value picks the most
+// recent deployment with a different digest than the current one.
+func (s *RuntimeSupervisor) Rollback(id string, to int, initiator string, registryCredentials []domain.RegistryCredential) (*domain.RuntimeScroll, error) {
+	runtimeScroll, err := s.store.GetScroll(id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := rollbackTarget(runtimeScroll, to)
+	if err != nil {
+		return nil, err
+	}
+	repo, _, _ := utils.ParseArtifactRef(target.Artifact)
+	if repo == "" {
+		return nil, fmt.Errorf("%w: artifact %q is not a registry reference", domain.ErrNoRollbackTarget, target.Artifact)
+	}
+	logger.Log().Info("Rolling back runtime scroll",
+		zap.String("scroll", id),
+		zap.String("from", runtimeScroll.ArtifactDigest),
+		zap.String("to", target.Digest),
+	)
+	runtimeScroll, err = s.updateExistingScroll(runtimeScroll, repo+"@"+target.Digest, target.Digest, registryCredentials, true, runtimeUpdate{
+		action:    domain.RuntimeUpdateActionRollback,
+		initiator: initiator,
+		artifact:  target.Artifact,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.rememberRegistryCredentials(id, registryCredentials)
+	return runtimeScroll, nil
+}
+
+func rollbackTarget(runtimeScroll *domain.RuntimeScroll, to int) (domain.RuntimeUpdateRecord, error) {
+	history := runtimeScroll.UpdateHistory
+	if to >= 0 {
+		if to >= len(history) {
+			return domain.RuntimeUpdateRecord{}, fmt.Errorf("%w: update history has %d entries", domain.ErrNoRollbackTarget, len(history))
+		}
+		if history[to].Digest == "" {
+			return domain.RuntimeUpdateRecord{}, fmt.Errorf("%w: deployment %d has no recorded digest", domain.ErrNoRollbackTarget, to)
+		}
+		return history[to], nil
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Digest != "" && history[i].Digest != runtimeScroll.ArtifactDigest {
+			return history[i], nil
+		}
+	}
+	return domain.RuntimeUpdateRecord{}, domain.ErrNoRollbackTarget
+}
+
+// recordUpdate appends the deployment runtimeScroll now points at. Runtimes
+// created before the history existed get their previous deployment seeded
+// first so there is something to roll back to.
+func recordUpdate(runtimeScroll *domain.RuntimeScroll, previous domain.RuntimeUpdateRecord, update runtimeUpdate) {
+	if len(runtimeScroll.UpdateHistory) == 0 && previous.Artifact != "" {
+		runtimeScroll.UpdateHistory = append(runtimeScroll.UpdateHistory, previous)
+	}
+	runtimeScroll.UpdateHistory = append(runtimeScroll.UpdateHistory, domain.RuntimeUpdateRecord{
+		Artifact:  runtimeScroll.Artifact,
+		Digest:    runtimeScroll.ArtifactDigest,
+		At:        time.Now().UTC(),
+		Action:    update.action,
+		Initiator: update.initiator,
+	})
+	if excess := len(runtimeScroll.UpdateHistory) - maxUpdateHistory; excess > 0 {
+		runtimeScroll.UpdateHistory = append([]domain.RuntimeUpdateRecord(nil), runtimeScroll.UpdateHistory[excess:]...)
+	}
+}
+
+// pinnedArtifact returns the registry tag artifact pinned to digest, so the
+// admission checks and the pull worker see the same content. Digests, local
+// paths and unknown digests are returned as they are.
+func pinnedArtifact(artifact string, digest string) string {
+	if digest == "" {
+		return artifact
+	}
+	if _, err := os.Stat(artifact); err == nil {
+		return artifact
+	}
+	repo, _, kind := utils.ParseArtifactRef(artifact)
+	if kind != utils.ArtifactRefKindTag {
+		return artifact
+	}
+	return repo + "@" + digest
 }
 
-func (s *RuntimeSupervisor) updateExistingScroll(runtimeScroll *domain.RuntimeScroll, artifact string, knownDigest string, registryCredentials []domain.RegistryCredential, restartIfRunning bool) (*domain.RuntimeScroll, error) {
-	wasRunning := runtimeScroll.Status == domain.RuntimeScrollStatusRunning
+func (s *RuntimeSupervisor) updateExistingScroll(runtimeScroll *domain.RuntimeScroll, artifact string, knownDigest string, registryCredentials []domain.RegistryCredential, restartIfRunning bool, update runtimeUpdate) (*domain.RuntimeScroll, error) {
+	if pinned := pinnedArtifact(artifact, knownDigest); pinned != artifact {
+		if update.artifact == "" {
+			update.artifact = artifact
+		}
+		artifact = pinned
+	}
+	if err := s.admitTargetScroll(artifact, registryCredentials); err != nil {
+		return nil, err
+	}
+	previousResources := runtimeScroll.Resources
+	resources := s.resolveArtifactResources(artifact, registryCredentials)
+	if err := s.reserveArtifact(runtimeScroll, artifact, resources); err != nil {
+		return nil, err
+	}
+	// A failed update gives back the resources reserved for the artifact it
+	// did not deploy.
+	markUpdateError := func(cause error) {
+		runtimeScroll.Status = domain.RuntimeScrollStatusError
+		runtimeScroll.LastError = cause.Error()
+		runtimeScroll.Resources = previousResources
+		_ = s.store.UpdateScroll(runtimeScroll)
+	}
+	previous := domain.RuntimeUpdateRecord{
+		Artifact: runtimeScroll.Artifact,
+		Digest:   runtimeScroll.ArtifactDigest,
+		At:       runtimeScroll.CreatedAt,
+		Action:   domain.RuntimeUpdateActionCreate,
+	}
+	wasRunning := runtimeScroll.Status == domain.RuntimeScrollStatusRunning || runtimeScroll.Status == domain.RuntimeScrollStatusIdle
+	s.disarmWakeGate(runtimeScroll.ID)
 	existingRouting := make([]domain.RuntimeRouteAssignment, len(runtimeScroll.Routing))
 	copy(existingRouting, runtimeScroll.Routing)
 
@@ -38,56 +178,55 @@ func (s *RuntimeSupervisor) updateExistingScroll(runtimeScroll *domain.RuntimeSc
 
 	if wasRunning {
 		if err := s.runtimeBackend.StopRuntime(runtimeScroll.Root); err != nil {
-			runtimeScroll.Status = domain.RuntimeScrollStatusError
-			runtimeScroll.LastError = err.Error()
-			_ = s.store.UpdateScroll(runtimeScroll)
+			markUpdateError(err)
 			return nil, err
 		}
 	}
 
 	if s.workerCallbacks == nil || s.workerCallbackURL == "" {
 		err := errors.New("worker callback URL is required for daemon update")
-		runtimeScroll.Status = domain.RuntimeScrollStatusError
-		runtimeScroll.LastError = err.Error()
-		_ = s.store.UpdateScroll(runtimeScroll)
+		markUpdateError(err)
 		return nil, err
 	}
 	materialized, err := s.runPullWorker(context.Background(), s.runtimeBackend, ports.RuntimeWorkerModeUpdate, runtimeScroll.ID, artifact, runtimeScroll.Root, registryCredentials, "")
 	if err != nil {
-		runtimeScroll.Status = domain.RuntimeScrollStatusError
-		runtimeScroll.LastError = err.Error()
-		_ = s.store.UpdateScroll(runtimeScroll)
+		markUpdateError(err)
 		return nil, err
 	}
 	scrollService, err := coreservices.NewCachedScrollServiceWithPorts(materialized.Root, materialized.ScrollYAML, runtimeScroll.ReservedPorts)
 	if err != nil {
-		runtimeScroll.Status = domain.RuntimeScrollStatusError
-		runtimeScroll.LastError = err.Error()
-		_ = s.store.UpdateScroll(runtimeScroll)
+		markUpdateError(err)
 		return nil, err
 	}
 	scroll := scrollService.GetCurrent()
+	if err := s.checkScroll(artifact, scroll, materialized.ImageLock); err != nil {
+		markUpdateError(err)
+		return nil, err
+	}
 	runtimeScroll.Artifact = materialized.Artifact
 	if runtimeScroll.Artifact == "" {
 		runtimeScroll.Artifact = artifact
 	}
+	if update.artifact != "" {
+		runtimeScroll.Artifact = update.artifact
+	}
 	runtimeScroll.ArtifactDigest = materialized.ArtifactDigest
 	if runtimeScroll.ArtifactDigest == "" {
 		runtimeScroll.ArtifactDigest = knownDigest
 	}
+	runtimeScroll.ImageLock = materialized.ImageLock
 	runtimeScroll.Root = materialized.Root
 	runtimeScroll.ScrollName = scroll.Name
 	runtimeScroll.ScrollYAML = string(materialized.ScrollYAML)
 	runtimeScroll.Procedures = domain.ProcedureStatusMap{}
 	ports, err := mergeRuntimePorts(scroll.Ports, runtimeScroll.ReservedPorts)
 	if err != nil {
-		runtimeScroll.Status = domain.RuntimeScrollStatusError
-		runtimeScroll.LastError = err.Error()
-		_ = s.store.UpdateScroll(runtimeScroll)
+		markUpdateError(err)
 		return nil, err
 	}
 	runtimeScroll.Routing = preserveRoutingAssignments(existingRouting, ports)
 	runtimeScroll.LastError = ""
+	recordUpdate(runtimeScroll, previous, update)
 	if wasRunning || runtimeScroll.Status == domain.RuntimeScrollStatusStopped {
 		runtimeScroll.Status = domain.RuntimeScrollStatusStopped
 	} else {
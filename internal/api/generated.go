@@ -22,6 +22,13 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for AuditRecordResult.
+const (
+	AuditRecordResultDenied AuditRecordResult = "denied"
+	AuditRecordResultError  AuditRecordResult = "error"
+	AuditRecordResultOk     AuditRecordResult = "ok"
+)
+
 // Defines values for LockStatusStatus.
 const (
 	LockStatusStatusDone    LockStatusStatus = "done"
@@ -38,15 +45,49 @@ const (
 	Udp   PortProtocol = "udp"
 )
 
+// Defines values for RuntimeEventType.
+const (
+	Wake RuntimeEventType = "wake"
+)
+
+// Defines values for RuntimeRole.
+const (
+	ConsoleOnly RuntimeRole = "console-only"
+	Operator    RuntimeRole = "operator"
+	Owner       RuntimeRole = "owner"
+	Viewer      RuntimeRole = "viewer"
+)
+
 // Defines values for RuntimeScrollStatus.
 const (
 	RuntimeScrollStatusCreated RuntimeScrollStatus = "created"
 	RuntimeScrollStatusDeleted RuntimeScrollStatus = "deleted"
 	RuntimeScrollStatusError   RuntimeScrollStatus = "error"
+	RuntimeScrollStatusIdle    RuntimeScrollStatus = "idle"
 	RuntimeScrollStatusRunning RuntimeScrollStatus = "running"
 	RuntimeScrollStatusStopped RuntimeScrollStatus = "stopped"
 )
 
+// Defines values for RuntimeUpdatePolicyMode.
+const (
+	Channel RuntimeUpdatePolicyMode = "channel"
+	Pinned  RuntimeUpdatePolicyMode = "pinned"
+	Semver  RuntimeUpdatePolicyMode = "semver"
+)
+
+// Defines values for RuntimeUpdateRecordAction.
+const (
+	Create   RuntimeUpdateRecordAction = "create"
+	Rollback RuntimeUpdateRecordAction = "rollback"
+	Update   RuntimeUpdateRecordAction = "update"
+)
+
+// Defines values for RuntimeWakeEventSource.
+const (
+	RuntimeWakeEventSourceColdstarter RuntimeWakeEventSource = "coldstarter"
+	RuntimeWakeEventSourceIdle        RuntimeWakeEventSource = "idle"
+)
+
 // Defines values for PublishScrollUIPackageParamsScope.
 const (
 	Private PublishScrollUIPackageParamsScope = "private"
@@ -58,6 +99,39 @@ type ApplyRoutingRequest struct {
 	Assignments []RuntimeRouteAssignment `json:"assignments"`
 }
 
+// AuditRecord defines model for AuditRecord.
+type AuditRecord struct {
+	// Action What was done, such as scroll.start, scroll.command or console.input
+	Action string    `json:"action"`
+	At     time.Time `json:"at"`
+	Error  *string   `json:"error,omitempty"`
+	Id     string    `json:"id"`
+
+	// Params Request parameters with secrets redacted
+	Params   *map[string]string `json:"params,omitempty"`
+	Result   AuditRecordResult  `json:"result"`
+	ScrollId *string            `json:"scroll_id,omitempty"`
+	SourceIp *string            `json:"source_ip,omitempty"`
+
+	// Status HTTP status of the audited request
+	Status *int `json:"status,omitempty"`
+
+	// Subject Token subject, API key, operator or local caller that acted
+	Subject string `json:"subject"`
+}
+
+// AuditRecordResult defines model for AuditRecord.Result.
+type AuditRecordResult string
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	Commands  *[]string    `json:"commands,omitempty"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	Name      string       `json:"name"`
+	Role      *RuntimeRole `json:"role,omitempty"`
+	Scrolls   *[]string    `json:"scrolls,omitempty"`
+}
+
 // CreateScrollRequest defines model for CreateScrollRequest.
 type CreateScrollRequest struct {
 	// Artifact OCI artifact reference or local scroll path
@@ -77,6 +151,24 @@ type CreateScrollRequest struct {
 	RegistryCredentials *[]RegistryCredential `json:"registry_credentials,omitempty"`
 }
 
+// CreatedAPIKey defines model for CreatedAPIKey.
+type CreatedAPIKey struct {
+	// Commands Commands the key may run. Empty means all the role allows.
+	Commands  *[]string  `json:"commands,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Id        string     `json:"id"`
+
+	// Key The API key. It is only returned on creation.
+	Key  string      `json:"key"`
+	Name string      `json:"name"`
+	Role RuntimeRole `json:"role"`
+
+	// Scrolls Runtime scrolls the key may be used on. Empty means all.
+	Scrolls *[]string `json:"scrolls,omitempty"`
+}
+
 // DeletedScroll defines model for DeletedScroll.
 type DeletedScroll struct {
 	Id     string `json:"id"`
@@ -120,6 +212,14 @@ type LockStatus struct {
 // LockStatusStatus defines model for LockStatus.Status.
 type LockStatusStatus string
 
+// OwnerQuotaStatus defines model for OwnerQuotaStatus.
+type OwnerQuotaStatus struct {
+	// Limits Limits of an owner. Missing or zero limits are unlimited.
+	Limits QuotaLimits `json:"limits"`
+	Owner  string      `json:"owner"`
+	Usage  QuotaUsage  `json:"usage"`
+}
+
 // Port defines model for Port.
 type Port struct {
 	Description *string      `json:"description,omitempty"`
@@ -140,11 +240,59 @@ type PublishUIPackageRequest struct {
 	Path *string `json:"path,omitempty"`
 }
 
+// QuotaLimits Limits of an owner. Missing or zero limits are unlimited.
+type QuotaLimits struct {
+	// AllowedRepositories Repositories the owner may deploy from; entries ending in * match every repository below the prefix.
+	AllowedRepositories *[]string `json:"allowed_repositories,omitempty"`
+	MaxCpu              *string   `json:"max_cpu,omitempty"`
+	MaxDisk             *string   `json:"max_disk,omitempty"`
+	MaxMemory           *string   `json:"max_memory,omitempty"`
+	MaxRunningCommands  *int      `json:"max_running_commands,omitempty"`
+	MaxScrolls          *int      `json:"max_scrolls,omitempty"`
+}
+
+// QuotaUsage defines model for QuotaUsage.
+type QuotaUsage struct {
+	// Resources Resources as Kubernetes quantities, such as 500m, 2 or 4Gi
+	Resources       RuntimeResources `json:"resources"`
+	RunningCommands int              `json:"running_commands"`
+	Scrolls         int              `json:"scrolls"`
+}
+
 // RegistryCredential defines model for RegistryCredential.
 type RegistryCredential struct {
-	Host     string `json:"host"`
-	Password string `json:"password"`
-	Username string `json:"username"`
+	Host string `json:"host"`
+
+	// IdentityToken OAuth2 refresh token used instead of username and password.
+	IdentityToken *string `json:"identity_token,omitempty"`
+	Password      string  `json:"password"`
+
+	// RegistryToken Bearer token sent to the registry as is.
+	RegistryToken *string `json:"registry_token,omitempty"`
+	Username      string  `json:"username"`
+}
+
+// RollbackScrollRequest defines model for RollbackScrollRequest.
+type RollbackScrollRequest struct {
+	RegistryCredentials *[]RegistryCredential `json:"registry_credentials,omitempty"`
+
+	// To Index into update_history to roll back to. If omitted, the most recent deployment with a different digest is used.
+	To *int `json:"to,omitempty"`
+}
+
+// RuntimeAPIKey defines model for RuntimeAPIKey.
+type RuntimeAPIKey struct {
+	// Commands Commands the key may run. Empty means all the role allows.
+	Commands  *[]string   `json:"commands,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy *string     `json:"created_by,omitempty"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	Id        string      `json:"id"`
+	Name      string      `json:"name"`
+	Role      RuntimeRole `json:"role"`
+
+	// Scrolls Runtime scrolls the key may be used on. Empty means all.
+	Scrolls *[]string `json:"scrolls,omitempty"`
 }
 
 // RuntimeArtifactOperationRequest defines model for RuntimeArtifactOperationRequest.
@@ -154,8 +302,34 @@ type RuntimeArtifactOperationRequest struct {
 	Restart             *bool                 `json:"restart,omitempty"`
 }
 
+// RuntimeEvent defines model for RuntimeEvent.
+type RuntimeEvent struct {
+	At       time.Time         `json:"at"`
+	ScrollId string            `json:"scroll_id"`
+	Type     RuntimeEventType  `json:"type"`
+	Wake     *RuntimeWakeEvent `json:"wake,omitempty"`
+}
+
+// RuntimeEventType defines model for RuntimeEvent.Type.
+type RuntimeEventType string
+
+// RuntimeGrant defines model for RuntimeGrant.
+type RuntimeGrant struct {
+	// Permissions Permissions on top of the role, such as scroll:run:backup or scroll:console:attach.
+	Permissions *[]string    `json:"permissions,omitempty"`
+	Role        *RuntimeRole `json:"role,omitempty"`
+	Subject     string       `json:"subject"`
+}
+
+// RuntimeGrantRequest defines model for RuntimeGrantRequest.
+type RuntimeGrantRequest struct {
+	Permissions *[]string    `json:"permissions,omitempty"`
+	Role        *RuntimeRole `json:"role,omitempty"`
+}
+
 // RuntimePortStatus defines model for RuntimePortStatus.
 type RuntimePortStatus struct {
+	ActiveSessions   *int       `json:"active_sessions,omitempty"`
 	Bound            bool       `json:"bound"`
 	HostIp           *string    `json:"host_ip,omitempty"`
 	HostPort         *int       `json:"host_port,omitempty"`
@@ -167,6 +341,7 @@ type RuntimePortStatus struct {
 	Protocol         string     `json:"protocol"`
 	RxBytes          *int64     `json:"rx_bytes,omitempty"`
 	Source           string     `json:"source"`
+	TotalSessions    *int64     `json:"total_sessions,omitempty"`
 	Traffic          bool       `json:"traffic"`
 	TrafficBytes     *int64     `json:"traffic_bytes,omitempty"`
 	TrafficOk        *bool      `json:"traffic_ok,omitempty"`
@@ -174,6 +349,16 @@ type RuntimePortStatus struct {
 	TxBytes          *int64     `json:"tx_bytes,omitempty"`
 }
 
+// RuntimeResources Resources as Kubernetes quantities, such as 500m, 2 or 4Gi
+type RuntimeResources struct {
+	Cpu    *string `json:"cpu,omitempty"`
+	Disk   *string `json:"disk,omitempty"`
+	Memory *string `json:"memory,omitempty"`
+}
+
+// RuntimeRole defines model for RuntimeRole.
+type RuntimeRole string
+
 // RuntimeRouteAssignment defines model for RuntimeRouteAssignment.
 type RuntimeRouteAssignment struct {
 	ExternalIp *string `json:"external_ip,omitempty"`
@@ -199,19 +384,38 @@ type RuntimeRoutingTarget struct {
 
 // RuntimeScroll defines model for RuntimeScroll.
 type RuntimeScroll struct {
-	Artifact      string                    `json:"artifact"`
-	CreatedAt     time.Time                 `json:"created_at"`
-	Id            string                    `json:"id"`
-	LastError     *string                   `json:"last_error,omitempty"`
-	OwnerId       *string                   `json:"owner_id,omitempty"`
-	Procedures    *ProcedureStatusMap       `json:"procedures,omitempty"`
-	ReservedPorts *[]Port                   `json:"reserved_ports,omitempty"`
-	Root          string                    `json:"root"`
-	Routing       *[]RuntimeRouteAssignment `json:"routing,omitempty"`
-	ScrollName    string                    `json:"scroll_name"`
-	Status        RuntimeScrollStatus       `json:"status"`
-	UiPackages    *RuntimeUIPackages        `json:"ui_packages,omitempty"`
-	UpdatedAt     time.Time                 `json:"updated_at"`
+	Artifact string `json:"artifact"`
+
+	// ArtifactDigest Manifest digest the runtime was materialized from.
+	ArtifactDigest *string   `json:"artifact_digest,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Grants Token subjects other than the owner that may access this runtime.
+	Grants *[]RuntimeGrant `json:"grants,omitempty"`
+	Id     string          `json:"id"`
+
+	// ImageLock Digests the container procedures run, keyed by the image as written in scroll.yaml. Set when the artifact was pushed with images.lock.
+	ImageLock     *map[string]string  `json:"image_lock,omitempty"`
+	LastError     *string             `json:"last_error,omitempty"`
+	OwnerId       *string             `json:"owner_id,omitempty"`
+	Procedures    *ProcedureStatusMap `json:"procedures,omitempty"`
+	ReservedPorts *[]Port             `json:"reserved_ports,omitempty"`
+
+	// Resources Resources as Kubernetes quantities, such as 500m, 2 or 4Gi
+	Resources  *RuntimeResources         `json:"resources,omitempty"`
+	Root       string                    `json:"root"`
+	Routing    *[]RuntimeRouteAssignment `json:"routing,omitempty"`
+	ScrollName string                    `json:"scroll_name"`
+	Status     RuntimeScrollStatus       `json:"status"`
+	UiPackages *RuntimeUIPackages        `json:"ui_packages,omitempty"`
+
+	// UpdateHistory Deployments of this runtime, oldest first and capped at the 20 most recent.
+	UpdateHistory *[]RuntimeUpdateRecord `json:"update_history,omitempty"`
+	UpdatePolicy  *RuntimeUpdatePolicy   `json:"update_policy,omitempty"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// WakeEvents Most recent coldstart wakes, oldest first.
+	WakeEvents *[]RuntimeWakeEvent `json:"wake_events,omitempty"`
 }
 
 // RuntimeScrollStatus defines model for RuntimeScroll.Status.
@@ -227,6 +431,56 @@ type RuntimeUIPackage struct {
 // RuntimeUIPackages defines model for RuntimeUIPackages.
 type RuntimeUIPackages map[string]RuntimeUIPackage
 
+// RuntimeUpdatePolicy defines model for RuntimeUpdatePolicy.
+type RuntimeUpdatePolicy struct {
+	// Channel Name of a pull_channel entry in scroll.yaml for mode channel.
+	Channel *string `json:"channel,omitempty"`
+
+	// Constraint Semver range such as ~1.2 for mode semver.
+	Constraint *string `json:"constraint,omitempty"`
+
+	// HeldDigest Digest the daemon rolled back from; skipped until a different digest is published.
+	HeldDigest *string                 `json:"held_digest,omitempty"`
+	Mode       RuntimeUpdatePolicyMode `json:"mode"`
+
+	// Window Daily maintenance window as HH:MM-HH:MM in daemon local time. Defaults to the daemon's --update-window.
+	Window *string `json:"window,omitempty"`
+}
+
+// RuntimeUpdatePolicyMode defines model for RuntimeUpdatePolicy.Mode.
+type RuntimeUpdatePolicyMode string
+
+// RuntimeUpdateRecord defines model for RuntimeUpdateRecord.
+type RuntimeUpdateRecord struct {
+	Action   RuntimeUpdateRecordAction `json:"action"`
+	Artifact string                    `json:"artifact"`
+	At       time.Time                 `json:"at"`
+	Digest   *string                   `json:"digest,omitempty"`
+
+	// Initiator Who asked for the deployment, such as an operator service account, local or auto-update.
+	Initiator *string `json:"initiator,omitempty"`
+}
+
+// RuntimeUpdateRecordAction defines model for RuntimeUpdateRecord.Action.
+type RuntimeUpdateRecordAction string
+
+// RuntimeWakeEvent defines model for RuntimeWakeEvent.
+type RuntimeWakeEvent struct {
+	Handler        *string                `json:"handler,omitempty"`
+	Id             string                 `json:"id"`
+	ListeningSince *time.Time             `json:"listening_since,omitempty"`
+	Port           string                 `json:"port"`
+	Protocol       *string                `json:"protocol,omitempty"`
+	ReadyAt        *time.Time             `json:"ready_at,omitempty"`
+	ReceivedAt     time.Time              `json:"received_at"`
+	RemoteAddress  *string                `json:"remote_address,omitempty"`
+	Source         RuntimeWakeEventSource `json:"source"`
+	WokeAt         time.Time              `json:"woke_at"`
+}
+
+// RuntimeWakeEventSource defines model for RuntimeWakeEvent.Source.
+type RuntimeWakeEventSource string
+
 // ScrollLogMap defines model for ScrollLogMap.
 type ScrollLogMap map[string][]string
 
@@ -237,6 +491,23 @@ type UpdateScrollRequest struct {
 	RegistryCredentials *[]RegistryCredential `json:"registry_credentials,omitempty"`
 }
 
+// ListAuditParams defines parameters for ListAudit.
+type ListAuditParams struct {
+	Scroll  *string    `form:"scroll,omitempty" json:"scroll,omitempty"`
+	Subject *string    `form:"subject,omitempty" json:"subject,omitempty"`
+	Action  *string    `form:"action,omitempty" json:"action,omitempty"`
+	Since   *time.Time `form:"since,omitempty" json:"since,omitempty"`
+	Until   *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit Return only the newest records
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListQuotasParams defines parameters for ListQuotas.
+type ListQuotasParams struct {
+	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+}
+
 // RunScrollCommandParams defines parameters for RunScrollCommand.
 type RunScrollCommandParams struct {
 	// Sync Wait for the requested command to complete before responding.
@@ -246,6 +517,9 @@ type RunScrollCommandParams struct {
 // PublishScrollUIPackageParamsScope defines parameters for PublishScrollUIPackage.
 type PublishScrollUIPackageParamsScope string
 
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = CreateAPIKeyRequest
+
 // CreateScrollJSONRequestBody defines body for CreateScroll for application/json ContentType.
 type CreateScrollJSONRequestBody = CreateScrollRequest
 
@@ -255,9 +529,15 @@ type EnsureScrollJSONRequestBody = EnsureScrollRequest
 // BackupScrollJSONRequestBody defines body for BackupScroll for application/json ContentType.
 type BackupScrollJSONRequestBody = RuntimeArtifactOperationRequest
 
+// SetScrollGrantJSONRequestBody defines body for SetScrollGrant for application/json ContentType.
+type SetScrollGrantJSONRequestBody = RuntimeGrantRequest
+
 // RestoreScrollJSONRequestBody defines body for RestoreScroll for application/json ContentType.
 type RestoreScrollJSONRequestBody = RuntimeArtifactOperationRequest
 
+// RollbackScrollJSONRequestBody defines body for RollbackScroll for application/json ContentType.
+type RollbackScrollJSONRequestBody = RollbackScrollRequest
+
 // ApplyScrollRoutingJSONRequestBody defines body for ApplyScrollRouting for application/json ContentType.
 type ApplyScrollRoutingJSONRequestBody = ApplyRoutingRequest
 
@@ -267,6 +547,9 @@ type PublishScrollUIPackageJSONRequestBody = PublishUIPackageRequest
 // UpdateScrollJSONRequestBody defines body for UpdateScroll for application/json ContentType.
 type UpdateScrollJSONRequestBody = UpdateScrollRequest
 
+// SetScrollUpdatePolicyJSONRequestBody defines body for SetScrollUpdatePolicy for application/json ContentType.
+type SetScrollUpdatePolicyJSONRequestBody = RuntimeUpdatePolicy
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -340,9 +623,26 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAPIKeys request
+	ListAPIKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAPIKeyWithBody request with any body
+	CreateAPIKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAPIKey(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAPIKey request
+	DeleteAPIKey(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAudit request
+	ListAudit(ctx context.Context, params *ListAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealthAuth request
 	GetHealthAuth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListQuotas request
+	ListQuotas(ctx context.Context, params *ListQuotasParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListScrolls request
 	ListScrolls(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -376,6 +676,17 @@ type ClientInterface interface {
 	// GetScrollConsoles request
 	GetScrollConsoles(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetScrollEvents request
+	GetScrollEvents(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteScrollGrant request
+	DeleteScrollGrant(ctx context.Context, id string, subject string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetScrollGrantWithBody request with any body
+	SetScrollGrantWithBody(ctx context.Context, id string, subject string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetScrollGrant(ctx context.Context, id string, subject string, body SetScrollGrantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetScrollLogs request
 	GetScrollLogs(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -390,6 +701,11 @@ type ClientInterface interface {
 
 	RestoreScroll(ctx context.Context, id string, body RestoreScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RollbackScrollWithBody request with any body
+	RollbackScrollWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RollbackScroll(ctx context.Context, id string, body RollbackScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ApplyScrollRoutingWithBody request with any body
 	ApplyScrollRoutingWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -416,6 +732,74 @@ type ClientInterface interface {
 	UpdateScrollWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	UpdateScroll(ctx context.Context, id string, body UpdateScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteScrollUpdatePolicy request
+	DeleteScrollUpdatePolicy(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetScrollUpdatePolicyWithBody request with any body
+	SetScrollUpdatePolicyWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetScrollUpdatePolicy(ctx context.Context, id string, body SetScrollUpdatePolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListAPIKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAPIKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPIKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPIKeyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPIKeyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAPIKey(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAPIKeyRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAudit(ctx context.Context, params *ListAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAuditRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) GetHealthAuth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -430,6 +814,18 @@ func (c *Client) GetHealthAuth(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListQuotas(ctx context.Context, params *ListQuotasParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListQuotasRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListScrolls(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListScrollsRequest(c.Server)
 	if err != nil {
@@ -574,6 +970,54 @@ func (c *Client) GetScrollConsoles(ctx context.Context, id string, reqEditors ..
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetScrollEvents(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScrollEventsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteScrollGrant(ctx context.Context, id string, subject string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteScrollGrantRequest(c.Server, id, subject)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetScrollGrantWithBody(ctx context.Context, id string, subject string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScrollGrantRequestWithBody(c.Server, id, subject, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetScrollGrant(ctx context.Context, id string, subject string, body SetScrollGrantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScrollGrantRequest(c.Server, id, subject, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetScrollLogs(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetScrollLogsRequest(c.Server, id)
 	if err != nil {
@@ -634,6 +1078,30 @@ func (c *Client) RestoreScroll(ctx context.Context, id string, body RestoreScrol
 	return c.Client.Do(req)
 }
 
+func (c *Client) RollbackScrollWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRollbackScrollRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RollbackScroll(ctx context.Context, id string, body RollbackScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRollbackScrollRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ApplyScrollRoutingWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewApplyScrollRoutingRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
@@ -754,35 +1222,44 @@ func (c *Client) UpdateScroll(ctx context.Context, id string, body UpdateScrollJ
 	return c.Client.Do(req)
 }
 
-// NewGetHealthAuthRequest generates requests for GetHealthAuth
-func NewGetHealthAuthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteScrollUpdatePolicy(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteScrollUpdatePolicyRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/api/v1/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) SetScrollUpdatePolicyWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScrollUpdatePolicyRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) SetScrollUpdatePolicy(ctx context.Context, id string, body SetScrollUpdatePolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetScrollUpdatePolicyRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewListScrollsRequest generates requests for ListScrolls
-func NewListScrollsRequest(server string) (*http.Request, error) {
+// NewListAPIKeysRequest generates requests for ListAPIKeys
+func NewListAPIKeysRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -790,7 +1267,7 @@ func NewListScrollsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/scrolls")
+	operationPath := fmt.Sprintf("/api/v1/apikeys")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -808,19 +1285,325 @@ func NewListScrollsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewCreateScrollRequest calls the generic CreateScroll builder with application/json body
-func NewCreateScrollRequest(server string, body CreateScrollJSONRequestBody) (*http.Request, error) {
+// NewCreateAPIKeyRequest calls the generic CreateAPIKey builder with application/json body
+func NewCreateAPIKeyRequest(server string, body CreateAPIKeyJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewCreateScrollRequestWithBody(server, "application/json", bodyReader)
+	return NewCreateAPIKeyRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewCreateScrollRequestWithBody generates requests for CreateScroll with any type of body
-func NewCreateScrollRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewCreateAPIKeyRequestWithBody generates requests for CreateAPIKey with any type of body
+func NewCreateAPIKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/apikeys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAPIKeyRequest generates requests for DeleteAPIKey
+func NewDeleteAPIKeyRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/apikeys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListAuditRequest generates requests for ListAudit
+func NewListAuditRequest(server string, params *ListAuditParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/audit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Scroll != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scroll", runtime.ParamLocationQuery, *params.Scroll); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Subject != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "subject", runtime.ParamLocationQuery, *params.Subject); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Action != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "action", runtime.ParamLocationQuery, *params.Action); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthAuthRequest generates requests for GetHealthAuth
+func NewGetHealthAuthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListQuotasRequest generates requests for ListQuotas
+func NewListQuotasRequest(server string, params *ListQuotasParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/quotas")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Owner != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "owner", runtime.ParamLocationQuery, *params.Owner); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListScrollsRequest generates requests for ListScrolls
+func NewListScrollsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateScrollRequest calls the generic CreateScroll builder with application/json body
+func NewCreateScrollRequest(server string, body CreateScrollJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateScrollRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateScrollRequestWithBody generates requests for CreateScroll with any type of body
+func NewCreateScrollRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1134,6 +1917,135 @@ func NewGetScrollConsolesRequest(server string, id string) (*http.Request, error
 	return req, nil
 }
 
+// NewGetScrollEventsRequest generates requests for GetScrollEvents
+func NewGetScrollEventsRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteScrollGrantRequest generates requests for DeleteScrollGrant
+func NewDeleteScrollGrantRequest(server string, id string, subject string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subject", runtime.ParamLocationPath, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/grants/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetScrollGrantRequest calls the generic SetScrollGrant builder with application/json body
+func NewSetScrollGrantRequest(server string, id string, subject string, body SetScrollGrantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetScrollGrantRequestWithBody(server, id, subject, "application/json", bodyReader)
+}
+
+// NewSetScrollGrantRequestWithBody generates requests for SetScrollGrant with any type of body
+func NewSetScrollGrantRequestWithBody(server string, id string, subject string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "subject", runtime.ParamLocationPath, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/grants/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetScrollLogsRequest generates requests for GetScrollLogs
 func NewGetScrollLogsRequest(server string, id string) (*http.Request, error) {
 	var err error
@@ -1283,6 +2195,53 @@ func NewRestoreScrollRequestWithBody(server string, id string, contentType strin
 	return req, nil
 }
 
+// NewRollbackScrollRequest calls the generic RollbackScroll builder with application/json body
+func NewRollbackScrollRequest(server string, id string, body RollbackScrollJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRollbackScrollRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRollbackScrollRequestWithBody generates requests for RollbackScroll with any type of body
+func NewRollbackScrollRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/rollback", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewApplyScrollRoutingRequest calls the generic ApplyScrollRouting builder with application/json body
 func NewApplyScrollRoutingRequest(server string, id string, body ApplyScrollRoutingJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -1463,22 +2422,112 @@ func NewGetScrollUIPackagesRequest(server string, id string) (*http.Request, err
 		return nil, err
 	}
 
-	return req, nil
-}
-
-// NewPublishScrollUIPackageRequest calls the generic PublishScrollUIPackage builder with application/json body
-func NewPublishScrollUIPackageRequest(server string, id string, scope PublishScrollUIPackageParamsScope, body PublishScrollUIPackageJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+	return req, nil
+}
+
+// NewPublishScrollUIPackageRequest calls the generic PublishScrollUIPackage builder with application/json body
+func NewPublishScrollUIPackageRequest(server string, id string, scope PublishScrollUIPackageParamsScope, body PublishScrollUIPackageJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPublishScrollUIPackageRequestWithBody(server, id, scope, "application/json", bodyReader)
+}
+
+// NewPublishScrollUIPackageRequestWithBody generates requests for PublishScrollUIPackage with any type of body
+func NewPublishScrollUIPackageRequestWithBody(server string, id string, scope PublishScrollUIPackageParamsScope, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "scope", runtime.ParamLocationPath, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/ui/packages/%s/publish", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateScrollRequest calls the generic UpdateScroll builder with application/json body
+func NewUpdateScrollRequest(server string, id string, body UpdateScrollJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateScrollRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateScrollRequestWithBody generates requests for UpdateScroll with any type of body
+func NewUpdateScrollRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/update", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPublishScrollUIPackageRequestWithBody(server, id, scope, "application/json", bodyReader)
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// NewPublishScrollUIPackageRequestWithBody generates requests for PublishScrollUIPackage with any type of body
-func NewPublishScrollUIPackageRequestWithBody(server string, id string, scope PublishScrollUIPackageParamsScope, contentType string, body io.Reader) (*http.Request, error) {
+// NewDeleteScrollUpdatePolicyRequest generates requests for DeleteScrollUpdatePolicy
+func NewDeleteScrollUpdatePolicyRequest(server string, id string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1488,19 +2537,12 @@ func NewPublishScrollUIPackageRequestWithBody(server string, id string, scope Pu
 		return nil, err
 	}
 
-	var pathParam1 string
-
-	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "scope", runtime.ParamLocationPath, scope)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/ui/packages/%s/publish", pathParam0, pathParam1)
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/update-policy", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1510,29 +2552,27 @@ func NewPublishScrollUIPackageRequestWithBody(server string, id string, scope Pu
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewUpdateScrollRequest calls the generic UpdateScroll builder with application/json body
-func NewUpdateScrollRequest(server string, id string, body UpdateScrollJSONRequestBody) (*http.Request, error) {
+// NewSetScrollUpdatePolicyRequest calls the generic SetScrollUpdatePolicy builder with application/json body
+func NewSetScrollUpdatePolicyRequest(server string, id string, body SetScrollUpdatePolicyJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewUpdateScrollRequestWithBody(server, id, "application/json", bodyReader)
+	return NewSetScrollUpdatePolicyRequestWithBody(server, id, "application/json", bodyReader)
 }
 
-// NewUpdateScrollRequestWithBody generates requests for UpdateScroll with any type of body
-func NewUpdateScrollRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+// NewSetScrollUpdatePolicyRequestWithBody generates requests for SetScrollUpdatePolicy with any type of body
+func NewSetScrollUpdatePolicyRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1547,7 +2587,7 @@ func NewUpdateScrollRequestWithBody(server string, id string, contentType string
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/update", pathParam0)
+	operationPath := fmt.Sprintf("/api/v1/scrolls/%s/update-policy", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1557,7 +2597,7 @@ func NewUpdateScrollRequestWithBody(server string, id string, contentType string
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1610,9 +2650,26 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ListAPIKeysWithResponse request
+	ListAPIKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAPIKeysResponse, error)
+
+	// CreateAPIKeyWithBodyWithResponse request with any body
+	CreateAPIKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error)
+
+	CreateAPIKeyWithResponse(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error)
+
+	// DeleteAPIKeyWithResponse request
+	DeleteAPIKeyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAPIKeyResponse, error)
+
+	// ListAuditWithResponse request
+	ListAuditWithResponse(ctx context.Context, params *ListAuditParams, reqEditors ...RequestEditorFn) (*ListAuditResponse, error)
+
 	// GetHealthAuthWithResponse request
 	GetHealthAuthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthAuthResponse, error)
 
+	// ListQuotasWithResponse request
+	ListQuotasWithResponse(ctx context.Context, params *ListQuotasParams, reqEditors ...RequestEditorFn) (*ListQuotasResponse, error)
+
 	// ListScrollsWithResponse request
 	ListScrollsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListScrollsResponse, error)
 
@@ -1646,6 +2703,17 @@ type ClientWithResponsesInterface interface {
 	// GetScrollConsolesWithResponse request
 	GetScrollConsolesWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetScrollConsolesResponse, error)
 
+	// GetScrollEventsWithResponse request
+	GetScrollEventsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetScrollEventsResponse, error)
+
+	// DeleteScrollGrantWithResponse request
+	DeleteScrollGrantWithResponse(ctx context.Context, id string, subject string, reqEditors ...RequestEditorFn) (*DeleteScrollGrantResponse, error)
+
+	// SetScrollGrantWithBodyWithResponse request with any body
+	SetScrollGrantWithBodyWithResponse(ctx context.Context, id string, subject string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScrollGrantResponse, error)
+
+	SetScrollGrantWithResponse(ctx context.Context, id string, subject string, body SetScrollGrantJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScrollGrantResponse, error)
+
 	// GetScrollLogsWithResponse request
 	GetScrollLogsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetScrollLogsResponse, error)
 
@@ -1660,6 +2728,11 @@ type ClientWithResponsesInterface interface {
 
 	RestoreScrollWithResponse(ctx context.Context, id string, body RestoreScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*RestoreScrollResponse, error)
 
+	// RollbackScrollWithBodyWithResponse request with any body
+	RollbackScrollWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RollbackScrollResponse, error)
+
+	RollbackScrollWithResponse(ctx context.Context, id string, body RollbackScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*RollbackScrollResponse, error)
+
 	// ApplyScrollRoutingWithBodyWithResponse request with any body
 	ApplyScrollRoutingWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyScrollRoutingResponse, error)
 
@@ -1686,6 +2759,101 @@ type ClientWithResponsesInterface interface {
 	UpdateScrollWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateScrollResponse, error)
 
 	UpdateScrollWithResponse(ctx context.Context, id string, body UpdateScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateScrollResponse, error)
+
+	// DeleteScrollUpdatePolicyWithResponse request
+	DeleteScrollUpdatePolicyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteScrollUpdatePolicyResponse, error)
+
+	// SetScrollUpdatePolicyWithBodyWithResponse request with any body
+	SetScrollUpdatePolicyWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScrollUpdatePolicyResponse, error)
+
+	SetScrollUpdatePolicyWithResponse(ctx context.Context, id string, body SetScrollUpdatePolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScrollUpdatePolicyResponse, error)
+}
+
+type ListAPIKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RuntimeAPIKey
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAPIKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAPIKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreatedAPIKey
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AuditRecord
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type GetHealthAuthResponse struct {
@@ -1711,6 +2879,28 @@ func (r GetHealthAuthResponse) StatusCode() int {
 	return 0
 }
 
+type ListQuotasResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OwnerQuotaStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r ListQuotasResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListQuotasResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListScrollsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1909,6 +3099,72 @@ func (r GetScrollConsolesResponse) StatusCode() int {
 	return 0
 }
 
+type GetScrollEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]RuntimeEvent
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScrollEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScrollEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteScrollGrantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RuntimeScroll
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteScrollGrantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteScrollGrantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetScrollGrantResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RuntimeScroll
+}
+
+// Status returns HTTPResponse.Status
+func (r SetScrollGrantResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetScrollGrantResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetScrollLogsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1997,6 +3253,28 @@ func (r RestoreScrollResponse) StatusCode() int {
 	return 0
 }
 
+type RollbackScrollResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RuntimeScroll
+}
+
+// Status returns HTTPResponse.Status
+func (r RollbackScrollResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RollbackScrollResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ApplyScrollRoutingResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2129,14 +3407,58 @@ func (r PublishScrollUIPackageResponse) StatusCode() int {
 	return 0
 }
 
-type UpdateScrollResponse struct {
+type UpdateScrollResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RuntimeScroll
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateScrollResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateScrollResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteScrollUpdatePolicyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RuntimeScroll
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteScrollUpdatePolicyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteScrollUpdatePolicyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetScrollUpdatePolicyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *RuntimeScroll
 }
 
 // Status returns HTTPResponse.Status
-func (r UpdateScrollResponse) Status() string {
+func (r SetScrollUpdatePolicyResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2144,13 +3466,57 @@ func (r UpdateScrollResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r UpdateScrollResponse) StatusCode() int {
+func (r SetScrollUpdatePolicyResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
+// ListAPIKeysWithResponse request returning *ListAPIKeysResponse
+func (c *ClientWithResponses) ListAPIKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAPIKeysResponse, error) {
+	rsp, err := c.ListAPIKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAPIKeysResponse(rsp)
+}
+
+// CreateAPIKeyWithBodyWithResponse request with arbitrary body returning *CreateAPIKeyResponse
+func (c *ClientWithResponses) CreateAPIKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error) {
+	rsp, err := c.CreateAPIKeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPIKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAPIKeyWithResponse(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error) {
+	rsp, err := c.CreateAPIKey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPIKeyResponse(rsp)
+}
+
+// DeleteAPIKeyWithResponse request returning *DeleteAPIKeyResponse
+func (c *ClientWithResponses) DeleteAPIKeyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteAPIKeyResponse, error) {
+	rsp, err := c.DeleteAPIKey(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAPIKeyResponse(rsp)
+}
+
+// ListAuditWithResponse request returning *ListAuditResponse
+func (c *ClientWithResponses) ListAuditWithResponse(ctx context.Context, params *ListAuditParams, reqEditors ...RequestEditorFn) (*ListAuditResponse, error) {
+	rsp, err := c.ListAudit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAuditResponse(rsp)
+}
+
 // GetHealthAuthWithResponse request returning *GetHealthAuthResponse
 func (c *ClientWithResponses) GetHealthAuthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthAuthResponse, error) {
 	rsp, err := c.GetHealthAuth(ctx, reqEditors...)
@@ -2160,6 +3526,15 @@ func (c *ClientWithResponses) GetHealthAuthWithResponse(ctx context.Context, req
 	return ParseGetHealthAuthResponse(rsp)
 }
 
+// ListQuotasWithResponse request returning *ListQuotasResponse
+func (c *ClientWithResponses) ListQuotasWithResponse(ctx context.Context, params *ListQuotasParams, reqEditors ...RequestEditorFn) (*ListQuotasResponse, error) {
+	rsp, err := c.ListQuotas(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListQuotasResponse(rsp)
+}
+
 // ListScrollsWithResponse request returning *ListScrollsResponse
 func (c *ClientWithResponses) ListScrollsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListScrollsResponse, error) {
 	rsp, err := c.ListScrolls(ctx, reqEditors...)
@@ -2265,6 +3640,41 @@ func (c *ClientWithResponses) GetScrollConsolesWithResponse(ctx context.Context,
 	return ParseGetScrollConsolesResponse(rsp)
 }
 
+// GetScrollEventsWithResponse request returning *GetScrollEventsResponse
+func (c *ClientWithResponses) GetScrollEventsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetScrollEventsResponse, error) {
+	rsp, err := c.GetScrollEvents(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScrollEventsResponse(rsp)
+}
+
+// DeleteScrollGrantWithResponse request returning *DeleteScrollGrantResponse
+func (c *ClientWithResponses) DeleteScrollGrantWithResponse(ctx context.Context, id string, subject string, reqEditors ...RequestEditorFn) (*DeleteScrollGrantResponse, error) {
+	rsp, err := c.DeleteScrollGrant(ctx, id, subject, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteScrollGrantResponse(rsp)
+}
+
+// SetScrollGrantWithBodyWithResponse request with arbitrary body returning *SetScrollGrantResponse
+func (c *ClientWithResponses) SetScrollGrantWithBodyWithResponse(ctx context.Context, id string, subject string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScrollGrantResponse, error) {
+	rsp, err := c.SetScrollGrantWithBody(ctx, id, subject, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScrollGrantResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetScrollGrantWithResponse(ctx context.Context, id string, subject string, body SetScrollGrantJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScrollGrantResponse, error) {
+	rsp, err := c.SetScrollGrant(ctx, id, subject, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScrollGrantResponse(rsp)
+}
+
 // GetScrollLogsWithResponse request returning *GetScrollLogsResponse
 func (c *ClientWithResponses) GetScrollLogsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetScrollLogsResponse, error) {
 	rsp, err := c.GetScrollLogs(ctx, id, reqEditors...)
@@ -2309,6 +3719,23 @@ func (c *ClientWithResponses) RestoreScrollWithResponse(ctx context.Context, id
 	return ParseRestoreScrollResponse(rsp)
 }
 
+// RollbackScrollWithBodyWithResponse request with arbitrary body returning *RollbackScrollResponse
+func (c *ClientWithResponses) RollbackScrollWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RollbackScrollResponse, error) {
+	rsp, err := c.RollbackScrollWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRollbackScrollResponse(rsp)
+}
+
+func (c *ClientWithResponses) RollbackScrollWithResponse(ctx context.Context, id string, body RollbackScrollJSONRequestBody, reqEditors ...RequestEditorFn) (*RollbackScrollResponse, error) {
+	rsp, err := c.RollbackScroll(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRollbackScrollResponse(rsp)
+}
+
 // ApplyScrollRoutingWithBodyWithResponse request with arbitrary body returning *ApplyScrollRoutingResponse
 func (c *ClientWithResponses) ApplyScrollRoutingWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ApplyScrollRoutingResponse, error) {
 	rsp, err := c.ApplyScrollRoutingWithBody(ctx, id, contentType, body, reqEditors...)
@@ -2396,6 +3823,126 @@ func (c *ClientWithResponses) UpdateScrollWithResponse(ctx context.Context, id s
 	return ParseUpdateScrollResponse(rsp)
 }
 
+// DeleteScrollUpdatePolicyWithResponse request returning *DeleteScrollUpdatePolicyResponse
+func (c *ClientWithResponses) DeleteScrollUpdatePolicyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeleteScrollUpdatePolicyResponse, error) {
+	rsp, err := c.DeleteScrollUpdatePolicy(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteScrollUpdatePolicyResponse(rsp)
+}
+
+// SetScrollUpdatePolicyWithBodyWithResponse request with arbitrary body returning *SetScrollUpdatePolicyResponse
+func (c *ClientWithResponses) SetScrollUpdatePolicyWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetScrollUpdatePolicyResponse, error) {
+	rsp, err := c.SetScrollUpdatePolicyWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScrollUpdatePolicyResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetScrollUpdatePolicyWithResponse(ctx context.Context, id string, body SetScrollUpdatePolicyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetScrollUpdatePolicyResponse, error) {
+	rsp, err := c.SetScrollUpdatePolicy(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetScrollUpdatePolicyResponse(rsp)
+}
+
+// ParseListAPIKeysResponse parses an HTTP response from a ListAPIKeysWithResponse call
+func ParseListAPIKeysResponse(rsp *http.Response) (*ListAPIKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAPIKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []RuntimeAPIKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAPIKeyResponse parses an HTTP response from a CreateAPIKeyWithResponse call
+func ParseCreateAPIKeyResponse(rsp *http.Response) (*CreateAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreatedAPIKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAPIKeyResponse parses an HTTP response from a DeleteAPIKeyWithResponse call
+func ParseDeleteAPIKeyResponse(rsp *http.Response) (*DeleteAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListAuditResponse parses an HTTP response from a ListAuditWithResponse call
+func ParseListAuditResponse(rsp *http.Response) (*ListAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditRecord
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetHealthAuthResponse parses an HTTP response from a GetHealthAuthWithResponse call
 func ParseGetHealthAuthResponse(rsp *http.Response) (*GetHealthAuthResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2429,6 +3976,32 @@ func ParseGetHealthAuthResponse(rsp *http.Response) (*GetHealthAuthResponse, err
 	return response, nil
 }
 
+// ParseListQuotasResponse parses an HTTP response from a ListQuotasWithResponse call
+func ParseListQuotasResponse(rsp *http.Response) (*ListQuotasResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListQuotasResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OwnerQuotaStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseListScrollsResponse parses an HTTP response from a ListScrollsWithResponse call
 func ParseListScrollsResponse(rsp *http.Response) (*ListScrollsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2574,7 +4147,85 @@ func ParseBackupScrollResponse(rsp *http.Response) (*BackupScrollResponse, error
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest RuntimeScroll
+		var dest RuntimeScroll
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRunScrollCommandResponse parses an HTTP response from a RunScrollCommandWithResponse call
+func ParseRunScrollCommandResponse(rsp *http.Response) (*RunScrollCommandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RunScrollCommandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RuntimeScroll
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScrollConfigResponse parses an HTTP response from a GetScrollConfigWithResponse call
+func ParseGetScrollConfigResponse(rsp *http.Response) (*GetScrollConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScrollConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScrollConsolesResponse parses an HTTP response from a GetScrollConsolesWithResponse call
+func ParseGetScrollConsolesResponse(rsp *http.Response) (*GetScrollConsolesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScrollConsolesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2585,22 +4236,22 @@ func ParseBackupScrollResponse(rsp *http.Response) (*BackupScrollResponse, error
 	return response, nil
 }
 
-// ParseRunScrollCommandResponse parses an HTTP response from a RunScrollCommandWithResponse call
-func ParseRunScrollCommandResponse(rsp *http.Response) (*RunScrollCommandResponse, error) {
+// ParseGetScrollEventsResponse parses an HTTP response from a GetScrollEventsWithResponse call
+func ParseGetScrollEventsResponse(rsp *http.Response) (*GetScrollEventsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RunScrollCommandResponse{
+	response := &GetScrollEventsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest RuntimeScroll
+		var dest []RuntimeEvent
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2611,22 +4262,22 @@ func ParseRunScrollCommandResponse(rsp *http.Response) (*RunScrollCommandRespons
 	return response, nil
 }
 
-// ParseGetScrollConfigResponse parses an HTTP response from a GetScrollConfigWithResponse call
-func ParseGetScrollConfigResponse(rsp *http.Response) (*GetScrollConfigResponse, error) {
+// ParseDeleteScrollGrantResponse parses an HTTP response from a DeleteScrollGrantWithResponse call
+func ParseDeleteScrollGrantResponse(rsp *http.Response) (*DeleteScrollGrantResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetScrollConfigResponse{
+	response := &DeleteScrollGrantResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest RuntimeScroll
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2637,22 +4288,22 @@ func ParseGetScrollConfigResponse(rsp *http.Response) (*GetScrollConfigResponse,
 	return response, nil
 }
 
-// ParseGetScrollConsolesResponse parses an HTTP response from a GetScrollConsolesWithResponse call
-func ParseGetScrollConsolesResponse(rsp *http.Response) (*GetScrollConsolesResponse, error) {
+// ParseSetScrollGrantResponse parses an HTTP response from a SetScrollGrantWithResponse call
+func ParseSetScrollGrantResponse(rsp *http.Response) (*SetScrollGrantResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetScrollConsolesResponse{
+	response := &SetScrollGrantResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest RuntimeScroll
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2767,6 +4418,32 @@ func ParseRestoreScrollResponse(rsp *http.Response) (*RestoreScrollResponse, err
 	return response, nil
 }
 
+// ParseRollbackScrollResponse parses an HTTP response from a RollbackScrollWithResponse call
+func ParseRollbackScrollResponse(rsp *http.Response) (*RollbackScrollResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RollbackScrollResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RuntimeScroll
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseApplyScrollRoutingResponse parses an HTTP response from a ApplyScrollRoutingWithResponse call
 func ParseApplyScrollRoutingResponse(rsp *http.Response) (*ApplyScrollRoutingResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2949,11 +4626,78 @@ func ParseUpdateScrollResponse(rsp *http.Response) (*UpdateScrollResponse, error
 	return response, nil
 }
 
+// ParseDeleteScrollUpdatePolicyResponse parses an HTTP response from a DeleteScrollUpdatePolicyWithResponse call
+func ParseDeleteScrollUpdatePolicyResponse(rsp *http.Response) (*DeleteScrollUpdatePolicyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteScrollUpdatePolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RuntimeScroll
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetScrollUpdatePolicyResponse parses an HTTP response from a SetScrollUpdatePolicyWithResponse call
+func ParseSetScrollUpdatePolicyResponse(rsp *http.Response) (*SetScrollUpdatePolicyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetScrollUpdatePolicyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RuntimeScroll
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List API keys
+	// (GET /api/v1/apikeys)
+	ListAPIKeys(c *fiber.Ctx) error
+	// Create an API key
+	// (POST /api/v1/apikeys)
+	CreateAPIKey(c *fiber.Ctx) error
+	// Revoke an API key
+	// (DELETE /api/v1/apikeys/{id})
+	DeleteAPIKey(c *fiber.Ctx, id string) error
+	// List audit records
+	// (GET /api/v1/audit)
+	ListAudit(c *fiber.Ctx, params ListAuditParams) error
 	// Get health status
 	// (GET /api/v1/health)
 	GetHealthAuth(c *fiber.Ctx) error
+	// List owner quotas and usage
+	// (GET /api/v1/quotas)
+	ListQuotas(c *fiber.Ctx, params ListQuotasParams) error
 	// List runtime scrolls
 	// (GET /api/v1/scrolls)
 	ListScrolls(c *fiber.Ctx) error
@@ -2981,6 +4725,15 @@ type ServerInterface interface {
 	// Get scroll-scoped consoles
 	// (GET /api/v1/scrolls/{id}/consoles)
 	GetScrollConsoles(c *fiber.Ctx, id string) error
+	// Get recorded runtime events of a scroll
+	// (GET /api/v1/scrolls/{id}/events)
+	GetScrollEvents(c *fiber.Ctx, id string) error
+	// Revoke the grant of a token subject on a runtime scroll
+	// (DELETE /api/v1/scrolls/{id}/grants/{subject})
+	DeleteScrollGrant(c *fiber.Ctx, id string, subject string) error
+	// Grant a token subject a role or permissions on a runtime scroll
+	// (PUT /api/v1/scrolls/{id}/grants/{subject})
+	SetScrollGrant(c *fiber.Ctx, id string, subject string) error
 	// Get scroll-scoped logs
 	// (GET /api/v1/scrolls/{id}/logs)
 	GetScrollLogs(c *fiber.Ctx, id string) error
@@ -2993,6 +4746,9 @@ type ServerInterface interface {
 	// Execute runtime restore
 	// (POST /api/v1/scrolls/{id}/restore)
 	RestoreScroll(c *fiber.Ctx, id string) error
+	// Roll a runtime scroll back to an earlier artifact digest
+	// (POST /api/v1/scrolls/{id}/rollback)
+	RollbackScroll(c *fiber.Ctx, id string) error
 	// Persist operator-assigned public routing
 	// (POST /api/v1/scrolls/{id}/routing)
 	ApplyScrollRouting(c *fiber.Ctx, id string) error
@@ -3014,6 +4770,12 @@ type ServerInterface interface {
 	// Update runtime scroll files and state
 	// (POST /api/v1/scrolls/{id}/update)
 	UpdateScroll(c *fiber.Ctx, id string) error
+	// Remove the automatic update policy of a runtime scroll
+	// (DELETE /api/v1/scrolls/{id}/update-policy)
+	DeleteScrollUpdatePolicy(c *fiber.Ctx, id string) error
+	// Set the automatic update policy of a runtime scroll
+	// (PUT /api/v1/scrolls/{id}/update-policy)
+	SetScrollUpdatePolicy(c *fiber.Ctx, id string) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -3023,12 +4785,123 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// ListAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAPIKeys(c *fiber.Ctx) error {
+
+	return siw.Handler.ListAPIKeys(c)
+}
+
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(c *fiber.Ctx) error {
+
+	return siw.Handler.CreateAPIKey(c)
+}
+
+// DeleteAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIKey(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteAPIKey(c, id)
+}
+
+// ListAudit operation middleware
+func (siw *ServerInterfaceWrapper) ListAudit(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "scroll" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scroll", query, &params.Scroll)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter scroll: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "subject" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "subject", query, &params.Subject)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subject: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", query, &params.Action)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter action: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", query, &params.Since)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter since: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", query, &params.Until)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter until: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.ListAudit(c, params)
+}
+
 // GetHealthAuth operation middleware
 func (siw *ServerInterfaceWrapper) GetHealthAuth(c *fiber.Ctx) error {
 
 	return siw.Handler.GetHealthAuth(c)
 }
 
+// ListQuotas operation middleware
+func (siw *ServerInterfaceWrapper) ListQuotas(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListQuotasParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", query, &params.Owner)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter owner: %w", err).Error())
+	}
+
+	return siw.Handler.ListQuotas(c, params)
+}
+
 // ListScrolls operation middleware
 func (siw *ServerInterfaceWrapper) ListScrolls(c *fiber.Ctx) error {
 
@@ -3167,6 +5040,70 @@ func (siw *ServerInterfaceWrapper) GetScrollConsoles(c *fiber.Ctx) error {
 	return siw.Handler.GetScrollConsoles(c, id)
 }
 
+// GetScrollEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetScrollEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.GetScrollEvents(c, id)
+}
+
+// DeleteScrollGrant operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScrollGrant(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "subject" -------------
+	var subject string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subject", c.Params("subject"), &subject, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subject: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteScrollGrant(c, id, subject)
+}
+
+// SetScrollGrant operation middleware
+func (siw *ServerInterfaceWrapper) SetScrollGrant(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "subject" -------------
+	var subject string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subject", c.Params("subject"), &subject, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subject: %w", err).Error())
+	}
+
+	return siw.Handler.SetScrollGrant(c, id, subject)
+}
+
 // GetScrollLogs operation middleware
 func (siw *ServerInterfaceWrapper) GetScrollLogs(c *fiber.Ctx) error {
 
@@ -3231,6 +5168,22 @@ func (siw *ServerInterfaceWrapper) RestoreScroll(c *fiber.Ctx) error {
 	return siw.Handler.RestoreScroll(c, id)
 }
 
+// RollbackScroll operation middleware
+func (siw *ServerInterfaceWrapper) RollbackScroll(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.RollbackScroll(c, id)
+}
+
 // ApplyScrollRouting operation middleware
 func (siw *ServerInterfaceWrapper) ApplyScrollRouting(c *fiber.Ctx) error {
 
@@ -3351,6 +5304,38 @@ func (siw *ServerInterfaceWrapper) UpdateScroll(c *fiber.Ctx) error {
 	return siw.Handler.UpdateScroll(c, id)
 }
 
+// DeleteScrollUpdatePolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScrollUpdatePolicy(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteScrollUpdatePolicy(c, id)
+}
+
+// SetScrollUpdatePolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetScrollUpdatePolicy(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.SetScrollUpdatePolicy(c, id)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL     string
@@ -3372,8 +5357,18 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(fiber.Handler(m))
 	}
 
+	router.Get(options.BaseURL+"/api/v1/apikeys", wrapper.ListAPIKeys)
+
+	router.Post(options.BaseURL+"/api/v1/apikeys", wrapper.CreateAPIKey)
+
+	router.Delete(options.BaseURL+"/api/v1/apikeys/:id", wrapper.DeleteAPIKey)
+
+	router.Get(options.BaseURL+"/api/v1/audit", wrapper.ListAudit)
+
 	router.Get(options.BaseURL+"/api/v1/health", wrapper.GetHealthAuth)
 
+	router.Get(options.BaseURL+"/api/v1/quotas", wrapper.ListQuotas)
+
 	router.Get(options.BaseURL+"/api/v1/scrolls", wrapper.ListScrolls)
 
 	router.Post(options.BaseURL+"/api/v1/scrolls", wrapper.CreateScroll)
@@ -3392,6 +5387,12 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Get(options.BaseURL+"/api/v1/scrolls/:id/consoles", wrapper.GetScrollConsoles)
 
+	router.Get(options.BaseURL+"/api/v1/scrolls/:id/events", wrapper.GetScrollEvents)
+
+	router.Delete(options.BaseURL+"/api/v1/scrolls/:id/grants/:subject", wrapper.DeleteScrollGrant)
+
+	router.Put(options.BaseURL+"/api/v1/scrolls/:id/grants/:subject", wrapper.SetScrollGrant)
+
 	router.Get(options.BaseURL+"/api/v1/scrolls/:id/logs", wrapper.GetScrollLogs)
 
 	router.Get(options.BaseURL+"/api/v1/scrolls/:id/ports", wrapper.GetScrollPorts)
@@ -3400,6 +5401,8 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Post(options.BaseURL+"/api/v1/scrolls/:id/restore", wrapper.RestoreScroll)
 
+	router.Post(options.BaseURL+"/api/v1/scrolls/:id/rollback", wrapper.RollbackScroll)
+
 	router.Post(options.BaseURL+"/api/v1/scrolls/:id/routing", wrapper.ApplyScrollRouting)
 
 	router.Get(options.BaseURL+"/api/v1/scrolls/:id/routing/targets", wrapper.GetScrollRoutingTargets)
@@ -3414,60 +5417,102 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Post(options.BaseURL+"/api/v1/scrolls/:id/update", wrapper.UpdateScroll)
 
+	router.Delete(options.BaseURL+"/api/v1/scrolls/:id/update-policy", wrapper.DeleteScrollUpdatePolicy)
+
+	router.Put(options.BaseURL+"/api/v1/scrolls/:id/update-policy", wrapper.SetScrollUpdatePolicy)
+
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbS3MbNxL+KyjsVu1lRMqbOAftSZE3WSVOWSvZ5UPiYoFAk0Q4A8AARhJXxf++hccM",
-	"54Hhy1IspXKxRQJoNL5+otF8wFQWSgoQ1uCzB2zoAgri/zxXKl9dy9JyMb+GzyUY675WWirQloOfRIzh",
-	"c1FUy7mFwv/xdw0zfIb/Nt6QH0fa4+tSWF6AIw3n9Xq8zrBdKcBnmGhNVni9zrCGzyXXwPDZr62tPtVz",
-	"5fR3oH7xhQZi4YZqmefD/GrLZ4T6EQaGaq4slwKf4XcXl6gaRRpmoEFQQFKjXFKSI+MJI0XsAmcY7kmh",
-	"8sBsWGNGTJecjebzsQVj/T9n7h9c82qs5mLueOWsz8AbUBooscAQyTkxaCY1EqSAEXrn5zgmLJnmgHRA",
-	"EHE2DhMuZ0gW3FpgGbILQIxAIQWagwBNLBhEBOJs1GL8dzk1Kd4cxQQ8j8PCv8IYNyonK386ZCzPc0Rl",
-	"AQbNtCwi0qMVKfL9OTaK0ATbP5dT0ALc/vUsD2zFvwYjS03BjNDlXEgNDE1XSEhx0lg6JXQJgplRand5",
-	"J0BPUhKNio78DMQZKg0wvzstjZUF6JMZoVzMkXa2gEhpF1Lz/xG3PrmXhjk3Vq8mVAMDYTnJD7C7uPii",
-	"Xrvb5ipzSRncG8jBAgsW1ze1gEjvCMYSW/oJG8GyQKl/4g473E2JBFIc/VuYUh/iAnrcVYMTxudx9YDx",
-	"DtrNX+r5PNTzP0Byu7gGo6Qw0NeDQrKERC5KrUFYtPCrUVA25Oc2XZFcps6vtJxrMKZP9iqOIAWagrBk",
-	"HuScS8Icwo4xj6tzcDOpC2LxGZ7lkrj4UZB7XpQFPnt1eprhgovw6bRmQZTFFHQ0L20njNjE2T4uQDR9",
-	"s5/rza7e0S08cVqBMyzKPHe+Hp9ZXcIu2/QQpeTwVtLlTW30bRnAPbcTGgUxsB8XFubhcDkxdhJEMqEL",
-	"IuZ+Xc08F/a7b3FqYcPpCIfcr1iXQrhjZJhJ4WWrtdQ4w3eEu4yncZSBA0eaSa5SOFxJnfBGLQkd4lVU",
-	"JFfrxnevX3/zuqEdr1JAKC2tpDJvQrGwVuHM/+fDK3WfSqZ2Q+CZi6w0aCdPryUF5ryzB+oXorwvZoyH",
-	"vOKq7aMHvt/mPxp6tk4w0OeonObcLD5cXhG6JHMYDBg+5duSEPlwc6KltCcacmL5LaDRHTGFTxZH6A3M",
-	"SJlbg6xESvNbYmHMuLFjolSYJzVSjhva/n6UDIi9gyQcZ+8MCzkQzBQx5k7qdEgrDegBBexogqffWNAg",
-	"nNKGGHrOo/9+V3m/44L2UNhhAXh8NiO5gezxwpDb0jvPBjtTKXMg4rAYFXFwrmHIRU5lKVhqn8yDPuEq",
-	"iYkfq3xE3w8sAdR5zm/hvSazGadJGt6xEWr5LberCbEtZ9uMFId7raRnCg4iva7ht/ryv59MVzbAtU8w",
-	"8BlVkpLtodGAOw4etFe1Ri6307zjgsm7NE+HnG7AQdfY9p11FjVsc/gaoS0a2728JyK7da4g36afhwe8",
-	"yfDoNgUJznWLOZQ6T/u4befnYv6e6DkkTr/fXeAQ69h1+GNtx0AO1Eq9Ler2VbKLigF9yylM9gsWA1o5",
-	"GUgnOuS3aOXQVXRr9KC+bsQO8m8DV0DvMEMimRpuXsWGZbgzQiVSqRCRQN8C81q+/63LZ6V+OWHvRL7q",
-	"JN+bgCflQPANlvDo1b8Mh8RqWOv7SX0UJc4a6b2xUin/XZXhV9WGTwnBlnyiQjq470Hq/NGnnaViBypT",
-	"qsRR62vEvY1Ftrl6NHS3tfcWG6n5HU50+6gcfKotLrV5Wjcpw7GmeiD/R18UekCkPFpwJW/lfMdNpdb5",
-	"ISdZq3Nviw/+vMcXrKsLiPVBqC5eD5ZiNcw0mAUY/2WsMP3DIBpLHjWBr1Xa6SDk4wotNberG0co5sNA",
-	"NOjzMqhq+PRDpZE/fXzvDbyJ008f3yMrlyBCdZl7DuwKKS1vOQPtrcuRd5mZJ7c5v78aO87c+mrPNvmb",
-	"hdT2xKXSDH0uQa+qzaRGH2F6I+kSLKJSCKBVgYe7hX4yrhKesMVmZ6L4z+BgcdFGzKTbmEphgyqsu4d8",
-	"o0vO0MXbS5STUtCFr7czVBDhLAX5lVyAPvG1Qla9ZhClck5D4SlDOV/Cb2Lui/IulmiTIUYsmRIDJvME",
-	"72BajY1+8+xy6wtiNQM4w240sHU6ejU69aFPgSCK4zP8jf8qGL0X6JgoPr59NQ4VN/dNTKnaJ/wRbKXI",
-	"rdoc9sTD9fGShYmh9Ofl5QOjrwD6zf55elohGdPWBgTj302owgS93aXVnQKjF1Wb5zDDW//r02/+wI1v",
-	"QsKESkFuCQ9VNW9PZVEQvYpwdnG0ZG78ZT5IIsMBb/zJLa3EFDTHNOTUhv8tN/YmzvlC8A/JJ2Lq13cr",
-	"PWyqsnd1kDYujv26+m7qc1TQxJEmNi5hNQkgmu+ROIQ9MPZ7yVaPpgipJ891O8a6bG7dk8OrR2OhA/8u",
-	"uFGVorVRDwfp4L4d9r5KjsE///gYmpRI83noiSSSeoHaSyKnX00iAbWuRMJBOhJBcM+NDaFFxvQjX4V3",
-	"BHOwuB44Wwc/7/LxvrjC+2ItLkU0KcCCdls8hBgaM8cYQn3u3AY6a4DWTUU/PaEQ2m+ju4VQ3UnWGf72",
-	"9Nvht7o4XUiLZr5s05Za2PYgO8rSbvxHsC8T+QPV/0sRd3H0C92Ws4Oxy8tKNey7vvfjTyySx/eHuwr9",
-	"z803BpiRWxoNsu0V74GWDQOLUjtK4lQWBRHMjB/iX+th6V+XIvB8EaY+hQZkSSK03vAgSp2HZ8KtvxD5",
-	"i2cQPTAUaSMra8DRFGZS+74HJQXjYj4auC+ZlaC4yUX3taf3LvNVvU647LOur/hC73Ndim6I3gjsKJ0U",
-	"Mz4fzO3roHAR5j3D0NCtIfQEcUW02VyA44H7Pl2lph2LqZE5mL1QDTOfIa7p+lerVjyMeXUwtIRV6GDa",
-	"1P770McHdUOl8k6iBuUI8HM53wP4t27WC8tzWgXKBObuTMfgnQcsKqzjxwryYaTrZ4ftUF/JcFV4dlgf",
-	"UmxovNcfXHBADqiq5vLoyWeL+lEW87mEEnbL8b9+2guzmdTLWV9e/mgeQ9iC9+fGrA3QnyMsu+1Fg7Fy",
-	"W7XiOkz4K+V/6vtgwHnvnL8S3FHW1XgtTUvd/+oi1o/i3Jcj+tRPRp6buIcS8ZbMr0Abbmxsy5X6JPz4",
-	"BFjs00O6lk1fCXz/wk4VGIdnuz1CZqvX5MXHznbnzD7hMyxAFV6JBCb8JCW2wVeyqRccIaO6uS9tpDdu",
-	"+E9aHrsJTeHb7cNPepS6l7FSbQNaqj8tzr4vZRfOUnUzvDupl7kkzKC7Bc8BqdD54zSeEUuOE0PJx82+",
-	"l+0OqdGC8TKl0uzZSVQIQmM4MPThElWouEuUvyGlagWJBejD9VvzxbIYP/g91+O4xbClRKY7Avrj6oQB",
-	"m210qiat2AKPq7bM1M8Mnig/GWr5X8ck5Zm8yN1xu0CxG6qpUgVY4k28k6yEUyEiEMk1ELY6mZY8tyj0",
-	"ZHy4RB/Pb36pqBypk6r6SVFa/Zq9TC8oYU21YH1tZXiaSnGg2o0lM57HTqHuRTalG7HRt5LqUAfS+dUl",
-	"jv1/eIyd6CLRXutUYCI0KRW+BU1sXgXA37vczIaTiVA89ItdyFgNpHBh0K3WYDWHW5JvVvtSVn9tfFKJ",
-	"F/oNM5uF4VKfeNZItXeh8KKxBN/pFSncwdT4mQkqV1JbxEXoquRS1OIoGwRUaNN9SDYYIboAujTJhbGX",
-	"p7/0lzK3/CTqQaUWqdNXmtAn8Sa0Y+V8BnRF8/TyqD791T+45OWOWLqoZMbgFnKpvCbEX1xW+LlpCRrn",
-	"QkgbUHOqjAilYBqnJ/W4wetP6/8HAAD//xVi0p7uQAAA",
+	"H4sIAAAAAAAC/+w9a2/bOLZ/hdC9wAIXjp3OY4Gb/dRpZ2ey224zSYt+2BYGLR3bHFOkSlJxPUXub7/g",
+	"IaknZVtu0jaD/dLGFl/nyfOUPyWpzAspQBidXHxKdLqGnOKfT4uC765laZhYXcOHErSxXxdKFqAMAxxE",
+	"tWYrkYfpzECOf/y3gmVykfzXrF5+5teeXZfCsBzs0vC0mp/cTRKzKyC5SKhSdJfc3U0SBR9KpiBLLv7d",
+	"2up9NVYufocUJz8tM2auIZUqi5wzNUwK+1cGOlWscB+Tt2tqyJZqkkkBE6LLdE2oJjpVkvOpNlSZSfiU",
+	"yjynIiNSkVQKLTlMmShKk1SH0UYxsbKHoYirpVS5/SvJqIEzC3RsLCgllR3ee8Ky6NcFVdShmWYZs5BQ",
+	"ftUCtzelDbUnJ8GFwIDSZMvMmmhIFRhNFGQ0NZAlETQr0CVH6ECUuSWM3CR2A8FwgoPmfQROh8b5AFBa",
+	"liqFOSviTw01pe7T79fXr6+Ie0jkkpg1EGr5ADKiPM9WJ2HCwAoULlc6eHrrvZYbEMQ/npCnV5dkA7sJ",
+	"scilRipLfC5TyklKOQdFjGWgDrLCsTsMzOwQag8Utp8EvqzQGmPsZwqogadXl/+E3aAgeuZsS2EPjW0B",
+	"myTwsWAK9HwMuwqaQ3RxJTkcLfscapYYdegOUvE0w1i7wQ2G1ZcybEljjPDq2SUJT4mCJSgQKdTkdycn",
+	"BTXrxOKR5gV3p3Rz9DRTJcumq9XMgDb4z4Vp8WNXztsHeA6FgpRaTqacUU2WUhEL7ZS8KpzMW8ZfcCDK",
+	"IZWwbOYGXC6JzJkxkE1QJDIKuRRkBcJyMWhCBWHZtHXw3+VC7yN3Bz33c4S/uWdMF5zuEDqiDeOcpDIH",
+	"TZZK5kH77mjOjz+xLmgaOfY/ywUoAXb/ahQiNpxfgdNDekouV0IqyMhiR4QUZ42pC5puQGR6GttdbgWo",
+	"eYyinvcJjiAsI6WGDHdPS21kDupsSVMmVkTZq5HQ0qylYn9QOz+6l4IV00bt5qmCDIRhlI+4hv3kZ9Xc",
+	"w1dwEJdhgcucnkLh4vzVMrn491EawU+7m3RFdAO7PipfryHo5im5NIRpIgXfEQWmVAIyIgVJFQxhrgOW",
+	"3aIP0fu7SfIcOBjInBbpq4+hq6y6rGpmzdxKx10SfoEYln8WulRj1FrfNPEP5xlbgY6PGQBsUPX/R+S+",
+	"isj9CpSb9TXoQgoNfT7IZRahyLNSKRCGrHF2sJ5wbFO9olnXtz2VXCnQEVPsyj8hBagUhKErR2cuaWYx",
+	"7EwoJoVOJrWpseQSTaKcfmS5tSefnJ9PkpwJ9+m8OoIo84U33gxVZm5tlJg9D6J53+BYyJo7No0bUXJu",
+	"76/kwqgSDskmoihGhxcy3dxUQt+mAXxkZp56Qgzs17BMOdVm7kgyT9dUrKBlmTFh/vpD3KStlY63y1Up",
+	"hAVjkljnpjLNJ8mWMuvUJe8PAezXjJ4qhodXVsx+K6WhQ9jgLGfmoJzgEi/c0CDfUb1TarqCo1Z7gyO7",
+	"ALqVJ+FYYcEYbFdSRTRti/vGaMzCL1fx/V9//PH7Hxuc/yRG5EJJI1PJm2ReG1MkE/zPQmBS+6nMisPk",
+	"xcP5ozTWjkKvZAqZvXmQsC9psc/9HPp+H5UaMnQXOUD/ROWCM71+c3lF0w1dweBliCb6HgMWr9IzJaU5",
+	"U8CpYbdApluqczTup+Q5LGnJjSZGkkKxW2pgljFtZrQo3DipSGFPk7a/jxsePUCazN47pvveurZUuFts",
+	"Sl4yrVGhKvIHKEkc8xKqgJQCPwDa9h2bgHO5hWyuoJCaGaliHJxcN56iHnU3Z053JIOCyx3a5H8jIAwO",
+	"AYG6nQnyPySnJl0TuAVlDTG/zo4sgMstrlUoWLKP9mjHe6g5/ThPizI61j7LmN4MPswhl2o3+NgryHnT",
+	"d+7LnB3Z8FO7AwZJ+ibopjYdKnPnWFe5Gm+l96gT7z9tS8H7kZGVJ42TxjRCxJbpwbqWg/alnWJ2c2Pj",
+	"LRHpfFqa9XfW71ag1wRHOduNCW2AZlYiSg0KXUYblCuo1lupsqjRFh5Gz1JZdANn+QmoAuXPoEEYqwgs",
+	"O4eJNmDI4pZpOGJk4w4lEFWNCY1DR7EvObc28QFH4MGt1UliZB9llyKDj4QJI0lZWHtrvmYalYGRxB4Y",
+	"7XliZD9OkEttiILU4tlpnNz+ieFJSjK2xEiMIc55sY6fZYtp0rg5zydHCWnb8dwbUOvYz/4JnncDO9SO",
+	"qhRT8nNemB3JgQpNKOc4QEkOBJWvHqf5UudPj4rNhTmLuNI7Jdw31hX8vChg3H3zA1oIX4BTCLKP9zF4",
+	"jrnfXgARkhYd3u9hI++nvQpezmnO+ZDAZs4ISS6WlGuY3KcAK0AnqXGchZQcqBjni3o8/HwLIgb0CI7b",
+	"nydwX9Tm75ZuIJpuwAfHMeJbuvEH74KMqzaPhBH8PfD/omgM/gJUbs02KWKec/2QSEGMLEIuw7JgNyt1",
+	"oUpxYfVnWVgT0H/pM1IX1BiarsdpmlNEtk6gHHAh/cBDKBs239uYe0Co9lwS1vkbcmltCucW5hrqQ/ZN",
+	"soUsRRYTsQne/ENpL3wWXMX+qhuA4ilnt/Ba0eWSpdE10HfHQ1qD614yPcMnKoKfGJ/XcF97D9XH+WJn",
+	"QLfOtyfegbZpnBOkobxFkCPWMz0UNmjkH446YJgjN/vX3DKRyW0ckDEoGXDuK4L0Hf3AljXwFVr3iOt1",
+	"04Hpeo/+kdVWjejth5IKw6zA1Mrsx/PzfEK+syrsh19Yz10d8vmG/b0hX2+PWF9L3rpLQjgoZHuTSXLL",
+	"YIvfeQV7ZvMM0ftmoKwhEhA0oATl+2R+fCxpPvx0n9C5uMUeFVMqPhanWDHymqoVRKA/LoUwRuMcAv5U",
+	"faSBQ2qk2hfQGrqEaqxoULcshflxXuCA0M4HInWd5fcI7VAGa2ymqC3vL6lgS9CVN4Ymi9sQi2pyakAx",
+	"ytkfNuuiJIbEFNDslRWieMz9NMdnpagw+kA9hybSrF3NhmgEt7CEw7oUNE1BWy+D6QBGy5A6wpJw5t/d",
+	"IJSVdTJg3bKcrmDOZbr5jPKe50gN5y6lUhjKLJgVUyFwE+tIuVSbHYb7WrW8VcwYEDai10y8kxswZBuS",
+	"K1VhhCVyUeo1ZM5Dx2X01J5/D6lr7kTbZLj+qZnYGxbtg7SJBK+d3wPqFjJUfsdHRTAPcASBPy/OJ+WA",
+	"f+i0673X2lUuzqAm7eeXvJzWwUPMXMuiwO9Yxps5p5D/jt2cJZsXLoh/LDxV1B+x1Y4xRUtpfBzJF4nV",
+	"8j0hkmdWdS2Z0gYjiSm1EBDq1Nl3582Q1Fh18AZP5osSj2AaD0khOUt3o/a4clOqNcapT+srz+EWojr0",
+	"ZQ0/SSXPMFxA7BTdRt9Y7DTc7kOoiZbShbvLy0ubhyd19rJxobTQs+e+rBhsOJ/UZ+MTED9oXjWhtYMm",
+	"iS81G3n+k/NxPUTssflaLNiPpK6pEMD7jPUvG7y3qS1SlJzP/TjMLe06FxBWEtj8O/GjouH2VAptFGUi",
+	"YqvcQH4LiiibvK6ckP97Mv2uXlrjkOjKa+DZoBn0vLZ+fNmBPTdkLsrtEmZ6w1CtWITxgUh24VKaLpx9",
+	"0EwKxR1BHRdMCNS8DgzL9x7x0ehY5XV2YKGM2wArEwYEFSkQN9Ji69dfL16+PMN/LXk8rK4eEo2lVq60",
+	"RsdfNDk7c3x75lY7XJM1WGsR06x7yr3bl1UlPy68iymUKHr228UjBHxfkZVghlHvYXTLWCSheuNrlRCT",
+	"1R1Wu9BU1HXJ3hGwNqws7SBHFqlsTZP02D+M9oZOpY365D2EqJV4P/1HRcZhVGk7Z9oAJiI1Eykcj+eO",
+	"g3hszAloNi4mZu9BdjtSyyvIpYE5zbJQOjVQ/t7i2HDVggrmVFSQ5QZGHCZabuj2rvzLsGQb2hgPOLfy",
+	"hVwdKAg5Pmrb28IJ+ul13KHOw2BAonJdBiuUfc7Zlz94M/EvmqS+aq5a4GtVB3YwhDGGtFTM7G7sQg4h",
+	"C0xa2yx6/envgT3+8fZ10vUZ//H2tU9xY9F1SNBbr/GWZaAmqEoIFrXPQ+0tYVqXtRPpMIh2mD1IcuE3",
+	"rjGFtUoWBrtTOF3nnl5LZc5sUDsjH0pQu3AsqchbWNzIdAPWDhUC0lBNyOxEHByydxdui3pnWjCsL75D",
+	"xbuULsUrjGOavgtt4STPXlwSTkuRrrFgPSM5Fdamqr3qMyxMzUI7AC0KzlJX5TghnG3gnVhhVbt1NZWe",
+	"kIwauqDams52wS0swrPpOzwuMxyaB7AxSFDaHet8+mR6jp5xAYIWLLlIvsevnHmIpJ/Rgs1un9j/NrDD",
+	"r3wkrqrAvMywtEgbl/z2xR5YRIrjvzs/D/jxir0B2Ox37a5Wx7djXdGq0LvL2T0aeCZzvUmyRAOLqdCk",
+	"ZFf44fz7iMTbQvBwLzqyNTt2NIZ6HCEDH2snRmWeU7XzqKmfTRJDV9pXVHqt6ln9Pd48OoLdZtNO4nQu",
+	"aPOTzHajMLsPobG+oLu2grem412PuE/u+Qih5n+YhiTECpBo57GKkVvKWVYpFhXgeTgiu7NbI8oP2E/o",
+	"u0lXsmafWHbnjsbBFSO3ecB1D1Q8UHfbYVMEKi3v1Hmdhbdxm3qTBiW6V/n7HmV/6GOqxuit3EB2zxi1",
+	"i+3ZVEhDlphjauP+Gg9zEu5td19Dp3VCFaWhNj7mD4qlQ/b8Dh6fwiHYtdkJXZBXAfR3og+7BqgKG63H",
+	"8Tdi2oFlO6XSV3mpzTuxZNyAsncjDdeDWcOOrCUP98UFQmNLWFD3R/QzQhtnnc5951ZM9vHLZGBm1Y04",
+	"emrVvjh+UzTvmxOPs17jq6FXfdJq3QSmKZVwvUTWohGwBRf8kiobsjSw5DaGgzo1+/5LXLDNBuhjrlfk",
+	"vQDakFp45tpcc+qE2TpMLoDqWJfLVezupK21j5Vt15AyKNy/gAlGeqt1pSc5v4BxnTFoYX4m6vdhvNN/",
+	"E0GyG4Hmzo/n33/BjW98PKAU9JYy13TSJpRFZxePgU7u+wEyfSiloXqQTJ2Um1WdznCTW0FwbkPZkqiu",
+	"fSecsnX5Odea7ksA0Qtx8XEXKETHRApoDjZreCfc50pzDqnY3xw0R+nYUB8w5kp+AEHvtdkcIe0OTIee",
+	"4Mq6ZpfRko80jEm9w/gHt5MlrNvgWPFvlIEOOiw3dcH6l3JY3JbH4LhTrhpDkeoM+Qzf4iZc+A/nW7Sj",
+	"Ll/Yt+ig/xC6axcjZuW38T6WJWeAXbf2xHGKNLtyH4giscbfoyhy/tUo4rDWpYgDpEMRAh+Z9na09CE7",
+	"vnPtm3o0uY5zzCpyfQHH7P6I0G5JP0yEkHgfctU6w4c8NrftKDmaxNX4L2AeJ+ZHsv/nYtzaZ5+ptqwc",
+	"zFyp+LDu+gmfPzBJ7l8fHuq7+NZ0o0MzsVO9QLa14kdIy4aAeaqdRPHQujT75P+6G6b+dSncmX1X00Nw",
+	"wCS6SFptOGqlTqKUMlPlSD2bQUb82sTICuFkAUtp7x3kANu1Oh3w5/VOpC0jv9t802uT+apaxyXIMqLu",
+	"Vftcl6J7RdcEO4knxZKtBm376lJ45sZ9g1dDN+/WI8QVVbpOBXmA+zq9iA07FadactBHYdWN/AbxGs8Z",
+	"RwtG+zgPgNXVrHXtdB/1vtdfp7KAKhx8mnk5q6vlolGQf8GWuCHYmK+NApq7FxPNtnpgtWkyGaLgz267",
+	"b5B+Y3zpqtrvWFfa4aUdq78XwwpDkw2t6WmF1WifY225QvTZJx//OtoNcYXjX+zyrWP+j8S23nfLHQ5e",
+	"+byVI063BnjkjSmVW+dQcstaJG4gMlUrYWTVAB3pTBVlRMPfgHlc/PNgjkCri/RbM/73ce+ebPgqdJN8",
+	"aR4fVJ3Iz11mpu4lB1KRot3JTO/FgeVydYSJ88KOemQRhVb5XIRtLEynWDbc4SLg2n8MKB/GdNUJsx/V",
+	"V1I9flOk0dI9OrRPLKJC1uzewzyt1U+SmA8llHCYjr/hsEcmM7Fmrli6C0pAHMIefH9ojKoR/cGj5bC8",
+	"KNBG7ssLXLsB/wmuPXTk1eH56OhaINxJ0lU1DgyTvfV2psdE9+hrpe7u7r4udRvdNCMtqECrdkHhKG1t",
+	"J/xvxMGXBKjiDFTzPVWd91t1PQP7qGsUhbGEimrFqsHWt4+cyKdVr2icTfEXHjyp/djHw6qxn6d4LGZ/",
+	"iymuQGmsnvA1MWfuhy4g8y+VJKqiTZ8JsGPjIAvMXPPDEaZd6+0Nj97Ga0FzlJnnJpCAr4ih7d53799H",
+	"TVRnwgk0qt6+FRfSG/v4T5owvXFvZ94vHzjoXhxJbWSxD9Gy+NPiGbvyD+FZFp0RZCvVxr6/W5PtmnF8",
+	"gys2GooVNrKcRoaSzZrt/vsVUqOR+XFSpQFALGcUWn7Jm0sSsGKdffTkY9mjyATy5vqF/mxazD7hnncz",
+	"v8WwpPhDdwj0BYOPiJt961Q90e59zUl40VHsndgPZJ8MvZ/6qxvTHQm3BaH+nQJNlsrBUBTxjrHioLK2",
+	"KuXYPXu2KBk3xPWrvbkkb5/evAyrnMiTRXi3f5z9mh2hj8hgjTWyfm1meJjaAbdq9y5ZMu67KLsBl9G8",
+	"cVa/IOWY3Frr9RR/trv9gco/IJe34H9PzcicGpZ6NRGq7zGrdZ8prC9CpgeLlbVO/yfJP7UI/rksZd8g",
+	"dq/8dOdfrxc4ZKiD++mVbaLGN+0ks+TufbVor/XcAdDoHXRdg66WDDCGaEd2e+76ZWkv5MqXfFhT2c5W",
+	"YBSDW8rr2ZiW6c/1hXg+OF0fpp6ITyIzo+3xxNXBbUDoeoUtLDSOjKxiEyOECddAx6SoVHbZWKCQKjbX",
+	"9SWRdA3pRkcn+s6i/tSXJTfszPNQYIMY9P5ZZInn/oU0bAnpLuXx6Z59+rP/bh2crf0Ni0CzDG6BywI5",
+	"wf88UsCfHRZZ46kQ0jis2evOv1Gwnker59r+wNj/DwAatwS4fnUAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
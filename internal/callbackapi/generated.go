@@ -15,22 +15,61 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime"
 )
 
+// PortTraffic Cumulative counters of one proxied port since the proxy started.
+type PortTraffic struct {
+	ActiveSessions int        `json:"active_sessions"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	Name           string     `json:"name"`
+	RxBytes        int64      `json:"rx_bytes"`
+	TotalSessions  int64      `json:"total_sessions"`
+	TxBytes        int64      `json:"tx_bytes"`
+}
+
+// TrafficReport defines model for TrafficReport.
+type TrafficReport struct {
+	Ports []PortTraffic `json:"ports"`
+}
+
+// WakeReport defines model for WakeReport.
+type WakeReport struct {
+	Handler *string `json:"handler,omitempty"`
+
+	// Id Chosen by the coldstarter; stable across updates of one wake.
+	Id             string     `json:"id"`
+	ListeningSince *time.Time `json:"listening_since,omitempty"`
+	Port           string     `json:"port"`
+	Protocol       *string    `json:"protocol,omitempty"`
+	ReadyAt        *time.Time `json:"ready_at,omitempty"`
+	RemoteAddress  *string    `json:"remote_address,omitempty"`
+	WokeAt         time.Time  `json:"woke_at"`
+}
+
 // WorkerResult defines model for WorkerResult.
 type WorkerResult struct {
 	ArtifactDigest *string `json:"artifact_digest,omitempty"`
 	Error          *string `json:"error,omitempty"`
-	ScrollYaml     *string `json:"scroll_yaml,omitempty"`
+
+	// ImageLock Digest pins of the container images, keyed by the image as written in scroll.yaml.
+	ImageLock  *map[string]string `json:"image_lock,omitempty"`
+	ScrollYaml *string            `json:"scroll_yaml,omitempty"`
 }
 
 // Runtime defines model for Runtime.
 type Runtime = string
 
+// ReportRuntimeTrafficJSONRequestBody defines body for ReportRuntimeTraffic for application/json ContentType.
+type ReportRuntimeTrafficJSONRequestBody = TrafficReport
+
+// ReportRuntimeWakeJSONRequestBody defines body for ReportRuntimeWake for application/json ContentType.
+type ReportRuntimeWakeJSONRequestBody = WakeReport
+
 // CompleteWorkerJSONRequestBody defines body for CompleteWorker for application/json ContentType.
 type CompleteWorkerJSONRequestBody = WorkerResult
 
@@ -107,12 +146,70 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ReportRuntimeTrafficWithBody request with any body
+	ReportRuntimeTrafficWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReportRuntimeTraffic(ctx context.Context, runtimeId Runtime, body ReportRuntimeTrafficJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReportRuntimeWakeWithBody request with any body
+	ReportRuntimeWakeWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReportRuntimeWake(ctx context.Context, runtimeId Runtime, body ReportRuntimeWakeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// CompleteWorkerWithBody request with any body
 	CompleteWorkerWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	CompleteWorker(ctx context.Context, runtimeId Runtime, body CompleteWorkerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) ReportRuntimeTrafficWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportRuntimeTrafficRequestWithBody(c.Server, runtimeId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReportRuntimeTraffic(ctx context.Context, runtimeId Runtime, body ReportRuntimeTrafficJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportRuntimeTrafficRequest(c.Server, runtimeId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReportRuntimeWakeWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportRuntimeWakeRequestWithBody(c.Server, runtimeId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReportRuntimeWake(ctx context.Context, runtimeId Runtime, body ReportRuntimeWakeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportRuntimeWakeRequest(c.Server, runtimeId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) CompleteWorkerWithBody(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewCompleteWorkerRequestWithBody(c.Server, runtimeId, contentType, body)
 	if err != nil {
@@ -137,6 +234,100 @@ func (c *Client) CompleteWorker(ctx context.Context, runtimeId Runtime, body Com
 	return c.Client.Do(req)
 }
 
+// NewReportRuntimeTrafficRequest calls the generic ReportRuntimeTraffic builder with application/json body
+func NewReportRuntimeTrafficRequest(server string, runtimeId Runtime, body ReportRuntimeTrafficJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReportRuntimeTrafficRequestWithBody(server, runtimeId, "application/json", bodyReader)
+}
+
+// NewReportRuntimeTrafficRequestWithBody generates requests for ReportRuntimeTraffic with any type of body
+func NewReportRuntimeTrafficRequestWithBody(server string, runtimeId Runtime, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "runtime_id", runtime.ParamLocationPath, runtimeId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/runtimes/%s/traffic", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReportRuntimeWakeRequest calls the generic ReportRuntimeWake builder with application/json body
+func NewReportRuntimeWakeRequest(server string, runtimeId Runtime, body ReportRuntimeWakeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReportRuntimeWakeRequestWithBody(server, runtimeId, "application/json", bodyReader)
+}
+
+// NewReportRuntimeWakeRequestWithBody generates requests for ReportRuntimeWake with any type of body
+func NewReportRuntimeWakeRequestWithBody(server string, runtimeId Runtime, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "runtime_id", runtime.ParamLocationPath, runtimeId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/runtimes/%s/wake", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewCompleteWorkerRequest calls the generic CompleteWorker builder with application/json body
 func NewCompleteWorkerRequest(server string, runtimeId Runtime, body CompleteWorkerJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -227,12 +418,64 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ReportRuntimeTrafficWithBodyWithResponse request with any body
+	ReportRuntimeTrafficWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportRuntimeTrafficResponse, error)
+
+	ReportRuntimeTrafficWithResponse(ctx context.Context, runtimeId Runtime, body ReportRuntimeTrafficJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportRuntimeTrafficResponse, error)
+
+	// ReportRuntimeWakeWithBodyWithResponse request with any body
+	ReportRuntimeWakeWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportRuntimeWakeResponse, error)
+
+	ReportRuntimeWakeWithResponse(ctx context.Context, runtimeId Runtime, body ReportRuntimeWakeJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportRuntimeWakeResponse, error)
+
 	// CompleteWorkerWithBodyWithResponse request with any body
 	CompleteWorkerWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteWorkerResponse, error)
 
 	CompleteWorkerWithResponse(ctx context.Context, runtimeId Runtime, body CompleteWorkerJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteWorkerResponse, error)
 }
 
+type ReportRuntimeTrafficResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReportRuntimeTrafficResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReportRuntimeTrafficResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReportRuntimeWakeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReportRuntimeWakeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReportRuntimeWakeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type CompleteWorkerResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -254,6 +497,40 @@ func (r CompleteWorkerResponse) StatusCode() int {
 	return 0
 }
 
+// ReportRuntimeTrafficWithBodyWithResponse request with arbitrary body returning *ReportRuntimeTrafficResponse
+func (c *ClientWithResponses) ReportRuntimeTrafficWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportRuntimeTrafficResponse, error) {
+	rsp, err := c.ReportRuntimeTrafficWithBody(ctx, runtimeId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportRuntimeTrafficResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReportRuntimeTrafficWithResponse(ctx context.Context, runtimeId Runtime, body ReportRuntimeTrafficJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportRuntimeTrafficResponse, error) {
+	rsp, err := c.ReportRuntimeTraffic(ctx, runtimeId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportRuntimeTrafficResponse(rsp)
+}
+
+// ReportRuntimeWakeWithBodyWithResponse request with arbitrary body returning *ReportRuntimeWakeResponse
+func (c *ClientWithResponses) ReportRuntimeWakeWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportRuntimeWakeResponse, error) {
+	rsp, err := c.ReportRuntimeWakeWithBody(ctx, runtimeId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportRuntimeWakeResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReportRuntimeWakeWithResponse(ctx context.Context, runtimeId Runtime, body ReportRuntimeWakeJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportRuntimeWakeResponse, error) {
+	rsp, err := c.ReportRuntimeWake(ctx, runtimeId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportRuntimeWakeResponse(rsp)
+}
+
 // CompleteWorkerWithBodyWithResponse request with arbitrary body returning *CompleteWorkerResponse
 func (c *ClientWithResponses) CompleteWorkerWithBodyWithResponse(ctx context.Context, runtimeId Runtime, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteWorkerResponse, error) {
 	rsp, err := c.CompleteWorkerWithBody(ctx, runtimeId, contentType, body, reqEditors...)
@@ -271,6 +548,38 @@ func (c *ClientWithResponses) CompleteWorkerWithResponse(ctx context.Context, ru
 	return ParseCompleteWorkerResponse(rsp)
 }
 
+// ParseReportRuntimeTrafficResponse parses an HTTP response from a ReportRuntimeTrafficWithResponse call
+func ParseReportRuntimeTrafficResponse(rsp *http.Response) (*ReportRuntimeTrafficResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReportRuntimeTrafficResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseReportRuntimeWakeResponse parses an HTTP response from a ReportRuntimeWakeWithResponse call
+func ParseReportRuntimeWakeResponse(rsp *http.Response) (*ReportRuntimeWakeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReportRuntimeWakeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseCompleteWorkerResponse parses an HTTP response from a CompleteWorkerWithResponse call
 func ParseCompleteWorkerResponse(rsp *http.Response) (*CompleteWorkerResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -289,6 +598,12 @@ func ParseCompleteWorkerResponse(rsp *http.Response) (*CompleteWorkerResponse, e
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Report proxied port traffic of a running scroll
+	// (POST /internal/v1/runtimes/{runtime_id}/traffic)
+	ReportRuntimeTraffic(c *fiber.Ctx, runtimeId Runtime) error
+	// Report which traffic woke a coldstarted scroll
+	// (POST /internal/v1/runtimes/{runtime_id}/wake)
+	ReportRuntimeWake(c *fiber.Ctx, runtimeId Runtime) error
 	// Complete a pending worker action
 	// (POST /internal/v1/workers/{runtime_id}/complete)
 	CompleteWorker(c *fiber.Ctx, runtimeId Runtime) error
@@ -301,6 +616,38 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// ReportRuntimeTraffic operation middleware
+func (siw *ServerInterfaceWrapper) ReportRuntimeTraffic(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "runtime_id" -------------
+	var runtimeId Runtime
+
+	err = runtime.BindStyledParameterWithOptions("simple", "runtime_id", c.Params("runtime_id"), &runtimeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter runtime_id: %w", err).Error())
+	}
+
+	return siw.Handler.ReportRuntimeTraffic(c, runtimeId)
+}
+
+// ReportRuntimeWake operation middleware
+func (siw *ServerInterfaceWrapper) ReportRuntimeWake(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "runtime_id" -------------
+	var runtimeId Runtime
+
+	err = runtime.BindStyledParameterWithOptions("simple", "runtime_id", c.Params("runtime_id"), &runtimeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter runtime_id: %w", err).Error())
+	}
+
+	return siw.Handler.ReportRuntimeWake(c, runtimeId)
+}
+
 // CompleteWorker operation middleware
 func (siw *ServerInterfaceWrapper) CompleteWorker(c *fiber.Ctx) error {
 
@@ -338,6 +685,10 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(fiber.Handler(m))
 	}
 
+	router.Post(options.BaseURL+"/internal/v1/runtimes/:runtime_id/traffic", wrapper.ReportRuntimeTraffic)
+
+	router.Post(options.BaseURL+"/internal/v1/runtimes/:runtime_id/wake", wrapper.ReportRuntimeWake)
+
 	router.Post(options.BaseURL+"/internal/v1/workers/:runtime_id/complete", wrapper.CompleteWorker)
 
 }
@@ -345,14 +696,23 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/3xTsW7bMBD9FeLaUQidNpO2Nl2yFVk6FIZxJs8OE4pkjycXgqB/L0jKtYMa3ezj43u8",
-	"955mMHFIMVCQDP0MCRkHEuL673kM4gYqP12AHhLKC3QQsMyA2+nOWeiA6dfomCz0wiN1kM0LDVhuypQK",
-	"Ogu7cIRlWc6HVeJH5DfiZ8qjl/oAjolYHNVTZHEHNLKz7khZbtB1QMyRb55kw9H73YSDv/WQ7jyJ+1cy",
-	"0p7mwiEWsKVs2CVxsSz+FIQ4oFcGvd+jeVNfvj+pMZNV+0n9rjtkhcEqSyeViU/E+Q46ECe+SHzj0Vn1",
-	"l+bxigY6KOgmtLm7v9uUt8dEAZODHj7XUVfNr6Zot9Lo071etfV8SWPRJVNPUoNLsflWbMWyzpOFHh5X",
-	"RLO/sl+C/znDR6YD9PBBX+qhLxB9LsaybclTlq/RTkXHxCAUqiSm5J2povo1l/3mq17ckliLod+1ouby",
-	"vl91kFMMufXk0+bh39AaieLKotAYSkK2mPuw2dzK+ITe2TXN9VpD3/8f7SNa5SwFcTLVYuVxGJCnK6MV",
-	"qkTBunA8C6CpVB0IHovp0OawbZ9I61ANY2QPPejq9gqezx/hemnZLn8CAAD//+gup1vLAwAA",
+	"H4sIAAAAAAAC/8xXQW/jOA/9K4S+7+hNOjvFHrKn3c6lt6JYYA6DImAtJtFElrwUnTQI/N8XlJ3UbtxB",
+	"21NvikQ+UnxPZHw0ZazqGChIMoujqZGxIiHOv+6bIK4iXbpgFqZG2ZjCBNQ9w93p0llTGKZ/G8dkzUK4",
+	"ocKkckMVqqccarVOwi6sTdu2p8Mc4i6y/MO4WrlSf1pKJbtaXNR4N03VeBS3IyhjEzQtiCuIgaDm+OTI",
+	"Qh1ZILlQEsim2z5AEmQhOzOFqTnWxOIoR8NSwZaJUnIxpEF6LgitiU1bGI9JltnSyWGJolaryJWujEWh",
+	"33JRipc3OxXmeHnAT8vHg1AaQbkgf1ybYiIDiYJ+lOVbnN4Tox0y9qNLfJDmAK24KNpFfg9n/Pj4k0rR",
+	"bHpO70kJysoaEaG7eeGEqrz4P9PKLMz/5s+CnPc6mQ9F0p6DITMeLq7SIU+l9B239Fo+GwzWE0+S5+yE",
+	"MjcxUYDHQ1ZdGb3tNMd/qvgePQGWHFOCplbFnGW7xy3NpqTjXRIKLqyXWcxv19zpPpcHHCWW0U8eMqF9",
+	"n7aZqii0RGuZUpoE3cctvQPzBXG5jeTbPCNN0hh5S3xPqfETRCKLW2EpS+vWlKYrQ8zxFaorXNPSx3Kb",
+	"sax1yjf6u1GMC7exNr7lyFC7kGnvBBIEXSCGHCEVsKUD2ZN+8iZggj07EQrgAqSSo/ezA1Z+ZibK0J0v",
+	"9Xyqy1546JYLq3ip5VttrAE9lOj9I5Zb+OvuFprU5bfP5U6AwYKlHSTiHXHKOTnxGuIbN87CGeZmAGMK",
+	"o9ZdoKvZl9mV5h5rClg7szBf81aRJ0uu7dz1MPPdl3k/YdL8+Dxr2rk8j4s6dhQrO6jXubVmYbpH3g+v",
+	"U98oRrPtx3TDeTaZ9+6mfehkSkn+jvag0ZRNCjkw1rV3ZQ49/5n0lsfB6PtVTxs3yLZtX87QvJHqGFKn",
+	"ut+vri+561GAMwxgWVItZLXI11dXU1zv0DsLMvLrzL+8bq4i8BEtOEtBnBw6j6+XHt9fWoKNlCBEgQql",
+	"3ACf6qoabqoK+XCmbDzVTznGFaC6aXfsn4UpjOBaeTz9CzEPCvgG9WgHHkpnnH6XhwbSd5mwInAWcI0u",
+	"nFu5ntCOghSwigz0hFXtCSQCWgunzqoP5Bey1GH06TQ5mJAfFaRCdNV5uxj3Z59PI8T9xpWbswJ1HAEO",
+	"xrx9jw77DjqWoVbfk9DrXeymt+jm3efTynAMf1gtGQQ4o7xDMEO3j2hmRPmp0IBQU7D6+PsA+s83hgHJ",
+	"3b5yrAjdJMxkNOzNwsxztXvj4+k7qXdqi+OLLyfTPrT/DQBiIgqWgQ0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,5 @@
+package callbackapi
+
+// RenewedTokenHeader carries a replacement for a runtime callback token that
+// is about to expire. Reporters use it for their following requests.
+const RenewedTokenHeader = "Druid-Callback-Token"
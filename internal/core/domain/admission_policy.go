@@ -0,0 +1,332 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrScrollNotAdmitted rejects a scroll that breaks the admission policy.
+// Errors wrapping it are *ScrollAdmissionError and carry the violations.
+var ErrScrollNotAdmitted = errors.New("scroll not admitted")
+
+const (
+	PolicyRuleImageRegistry    = "image_registry"
+	PolicyRuleImageDigest      = "image_digest"
+	PolicyRuleForbiddenPort    = "forbidden_port"
+	PolicyRuleMaxExpectedPorts = "max_expected_ports"
+	PolicyRuleReadOnlyMount    = "read_only_mount"
+	PolicyRuleTTY              = "tty"
+)
+
+// ScrollAdmissionPolicy restricts what a scroll may run. The daemon checks
+// it when a scroll is materialized and again before every command, with the
+// host ports routing assigned by then. A nil policy admits everything.
+type ScrollAdmissionPolicy struct {
+	Images ImageAdmissionRules `json:"images" yaml:"images"`
+	Ports  PortAdmissionRules  `json:"ports" yaml:"ports"`
+	Mounts MountAdmissionRules `json:"mounts" yaml:"mounts"`
+	// DenyTTY rejects procedures with tty: true.
+	DenyTTY bool `json:"deny_tty,omitempty" yaml:"deny_tty"`
+}
+
+// ImageAdmissionRules restrict container images. AllowedRegistries entries
+// are registry hosts such as ghcr.io, or repositories; entries ending in "*"
+// match every repository below the prefix. Docker Hub images count as
+// docker.io/library/name. Images are checked as pinned by the image lock.
+type ImageAdmissionRules struct {
+	AllowedRegistries []string `json:"allowed_registries,omitempty" yaml:"allowed_registries"`
+	// AllowedDigests only admits images pinned to one of these digests.
+	AllowedDigests []string `json:"allowed_digests,omitempty" yaml:"allowed_digests"`
+	// RequireDigest only admits images pinned to a digest, in scroll.yaml or
+	// in the image lock.
+	RequireDigest bool `json:"require_digest,omitempty" yaml:"require_digest"`
+}
+
+// PortAdmissionRules restrict ports. Forbidden entries are ports such as
+// "22" or inclusive ranges such as "1-1023"; they apply to the ports of the
+// scroll and to the public ports routing assigns to them.
+type PortAdmissionRules struct {
+	Forbidden []string `json:"forbidden,omitempty" yaml:"forbidden"`
+	// MaxExpectedPorts limits the expectedPorts of a procedure; zero is
+	// unlimited.
+	MaxExpectedPorts int `json:"max_expected_ports,omitempty" yaml:"max_expected_ports"`
+}
+
+// MountAdmissionRules restrict mounts. ReadOnly lists container paths,
+// entries ending in "*" matching every path below the prefix, that may only
+// be mounted with read_only: true.
+type MountAdmissionRules struct {
+	ReadOnly []string `json:"read_only,omitempty" yaml:"read_only"`
+}
+
+// PolicyViolation is one broken rule. Command and Procedure are empty for
+// violations of the scroll as a whole, such as its ports.
+type PolicyViolation struct {
+	Rule      string `json:"rule"`
+	Command   string `json:"command,omitempty"`
+	Procedure string `json:"procedure,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (v PolicyViolation) String() string {
+	if v.Procedure != "" {
+		return fmt.Sprintf("%s: %s (%s)", v.Procedure, v.Message, v.Rule)
+	}
+	return fmt.Sprintf("%s (%s)", v.Message, v.Rule)
+}
+
+type ScrollAdmissionError struct {
+	Violations []PolicyViolation
+}
+
+func (e *ScrollAdmissionError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrScrollNotAdmitted, strings.Join(messages, "; "))
+}
+
+func (e *ScrollAdmissionError) Unwrap() error {
+	return ErrScrollNotAdmitted
+}
+
+type portRange struct {
+	from, to int
+}
+
+func (p *ScrollAdmissionPolicy) Validate() error {
+	if _, err := parsePortRanges(p.Ports.Forbidden); err != nil {
+		return fmt.Errorf("ports.forbidden: %w", err)
+	}
+	if p.Ports.MaxExpectedPorts < 0 {
+		return fmt.Errorf("ports.max_expected_ports must not be negative")
+	}
+	for _, digest := range p.Images.AllowedDigests {
+		if !strings.Contains(digest, ":") {
+			return fmt.Errorf("images.allowed_digests: %q is not a digest", digest)
+		}
+	}
+	return nil
+}
+
+func parsePortRanges(values []string) ([]portRange, error) {
+	ranges := make([]portRange, 0, len(values))
+	for _, value := range values {
+		fromValue, toValue, isRange := strings.Cut(strings.TrimSpace(value), "-")
+		if !isRange {
+			toValue = fromValue
+		}
+		from, err := strconv.Atoi(strings.TrimSpace(fromValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", value)
+		}
+		to, err := strconv.Atoi(strings.TrimSpace(toValue))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", value)
+		}
+		if from < 1 || to > 65535 || from > to {
+			return nil, fmt.Errorf("invalid port range %q", value)
+		}
+		ranges = append(ranges, portRange{from, to})
+	}
+	return ranges, nil
+}
+
+// Evaluate returns the violations of file, with images pinned by imageLock,
+// ordered by command and procedure. The policy must be valid.
+func (p *ScrollAdmissionPolicy) Evaluate(file *File, imageLock map[string]string) []PolicyViolation {
+	if p == nil || file == nil {
+		return nil
+	}
+	violations := p.evaluatePorts(file.Ports)
+	commands := make([]string, 0, len(file.Commands))
+	for name := range file.Commands {
+		commands = append(commands, name)
+	}
+	sort.Strings(commands)
+	for _, commandName := range commands {
+		command := file.Commands[commandName]
+		if command == nil {
+			continue
+		}
+		for idx, procedure := range command.Procedures {
+			if procedure == nil || !procedure.IsContainer() {
+				continue
+			}
+			name := ProcedureName(commandName, idx, procedure)
+			for _, violation := range p.evaluateProcedure(procedure, imageLock) {
+				violation.Command = commandName
+				violation.Procedure = name
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations
+}
+
+// Admit returns a *ScrollAdmissionError when file has violations.
+func (p *ScrollAdmissionPolicy) Admit(file *File, imageLock map[string]string) error {
+	if violations := p.Evaluate(file, imageLock); len(violations) > 0 {
+		return &ScrollAdmissionError{Violations: violations}
+	}
+	return nil
+}
+
+// AdmitRouting returns a *ScrollAdmissionError when routing assigns a
+// forbidden public port.
+func (p *ScrollAdmissionPolicy) AdmitRouting(assignments []RuntimeRouteAssignment) error {
+	if p == nil {
+		return nil
+	}
+	var violations []PolicyViolation
+	for _, assignment := range assignments {
+		if assignment.PublicPort > 0 && p.portForbidden(assignment.PublicPort) {
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleForbiddenPort,
+				Message: fmt.Sprintf("route %s assigns forbidden public port %d", assignment.Name, assignment.PublicPort),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return &ScrollAdmissionError{Violations: violations}
+	}
+	return nil
+}
+
+func (p *ScrollAdmissionPolicy) evaluatePorts(ports []Port) []PolicyViolation {
+	var violations []PolicyViolation
+	for _, port := range ports {
+		if port.Port > 0 && p.portForbidden(port.Port) {
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleForbiddenPort,
+				Message: fmt.Sprintf("port %s uses forbidden port %d", port.Name, port.Port),
+			})
+		}
+	}
+	return violations
+}
+
+func (p *ScrollAdmissionPolicy) portForbidden(port int) bool {
+	ranges, _ := parsePortRanges(p.Ports.Forbidden)
+	for _, r := range ranges {
+		if port >= r.from && port <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ScrollAdmissionPolicy) evaluateProcedure(procedure *Procedure, imageLock map[string]string) []PolicyViolation {
+	var violations []PolicyViolation
+	image := procedure.Image
+	if ref := imageLock[image]; ref != "" {
+		image = ref
+	}
+	if image != "" {
+		repo, digest := splitImageRef(image)
+		if len(p.Images.AllowedRegistries) > 0 && !imageRegistryAllowed(p.Images.AllowedRegistries, repo) {
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleImageRegistry,
+				Message: fmt.Sprintf("image %s is not from an allowed registry", image),
+			})
+		}
+		switch {
+		case len(p.Images.AllowedDigests) > 0 && !slices.Contains(p.Images.AllowedDigests, digest):
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleImageDigest,
+				Message: fmt.Sprintf("image %s is not pinned to an allowed digest", image),
+			})
+		case p.Images.RequireDigest && digest == "":
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleImageDigest,
+				Message: fmt.Sprintf("image %s is not pinned to a digest", image),
+			})
+		}
+	}
+	if p.Ports.MaxExpectedPorts > 0 && len(procedure.ExpectedPorts) > p.Ports.MaxExpectedPorts {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleMaxExpectedPorts,
+			Message: fmt.Sprintf("expects %d ports, at most %d are allowed", len(procedure.ExpectedPorts), p.Ports.MaxExpectedPorts),
+		})
+	}
+	for _, mount := range procedure.Mounts {
+		if !mount.ReadOnly && pathMatchesAny(p.Mounts.ReadOnly, mount.Path) {
+			violations = append(violations, PolicyViolation{
+				Rule:    PolicyRuleReadOnlyMount,
+				Message: fmt.Sprintf("mount %s must be read_only", mount.Path),
+			})
+		}
+	}
+	if p.DenyTTY && procedure.TTY {
+		violations = append(violations, PolicyViolation{
+			Rule:    PolicyRuleTTY,
+			Message: "tty is not allowed",
+		})
+	}
+	return violations
+}
+
+// splitImageRef returns the repository of image, expanded the way docker
+// pull expands it, and its digest, if any.
+func splitImageRef(image string) (string, string) {
+	name, digest, _ := strings.Cut(strings.TrimSpace(image), "@")
+	if slash := strings.LastIndex(name, "/"); strings.LastIndex(name, ":") > slash {
+		name = name[:strings.LastIndex(name, ":")]
+	}
+	first, _, found := strings.Cut(name, "/")
+	if !found {
+		name = "docker.io/library/" + name
+	} else if !strings.ContainsAny(first, ".:") && first != "localhost" {
+		name = "docker.io/" + name
+	}
+	return name, digest
+}
+
+func imageRegistryAllowed(allowed []string, repo string) bool {
+	host, _, _ := strings.Cut(repo, "/")
+	for _, entry := range allowed {
+		pattern := strings.TrimRight(entry, "/")
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(repo, prefix) {
+				return true
+			}
+		} else if pattern == host || pattern == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// pathMatchesAny reports whether mountPath matches one of patterns. Both
+// are cleaned first, so /etc/. or //etc cannot slip past a rule for /etc.
+// A pattern ending in /* matches the directory and everything below it.
+func pathMatchesAny(patterns []string, mountPath string) bool {
+	mountPath = path.Clean(mountPath)
+	for _, pattern := range patterns {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if !ok {
+			if path.Clean(pattern) == mountPath {
+				return true
+			}
+			continue
+		}
+		if prefix == "" {
+			return true
+		}
+		if strings.HasSuffix(prefix, "/") {
+			dir := strings.TrimSuffix(path.Clean(prefix), "/")
+			if mountPath == dir || strings.HasPrefix(mountPath, dir+"/") {
+				return true
+			}
+		} else if strings.HasPrefix(mountPath, path.Clean(prefix)) {
+			return true
+		}
+	}
+	return false
+}
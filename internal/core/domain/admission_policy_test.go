@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func admissionTestFile() *File {
+	id := "server"
+	return &File{
+		Ports: []Port{{Name: "ssh", Port: 22}, {Name: "game", Port: 25565}},
+		Commands: map[string]*CommandInstructionSet{
+			"start": {Procedures: []*Procedure{
+				{
+					Id:            &id,
+					Image:         "itzg/minecraft-server:latest",
+					ExpectedPorts: []ExpectedPort{{Name: "game"}, {Name: "ssh"}},
+					Mounts:        []Mount{{Path: "/etc/server"}, {Path: "/data"}},
+					TTY:           true,
+				},
+				{Type: ProcedureTypeSignal, Signal: "SIGTERM"},
+			}},
+			"install": {Procedures: []*Procedure{
+				{Image: "ghcr.io/highcard-dev/installer@sha256:abc"},
+			}},
+		},
+	}
+}
+
+func TestScrollAdmissionPolicyEvaluate(t *testing.T) {
+	policy := &ScrollAdmissionPolicy{
+		Images:  ImageAdmissionRules{AllowedRegistries: []string{"ghcr.io/highcard-dev/*"}, RequireDigest: true},
+		Ports:   PortAdmissionRules{Forbidden: []string{"1-1023"}, MaxExpectedPorts: 1},
+		Mounts:  MountAdmissionRules{ReadOnly: []string{"/etc/*"}},
+		DenyTTY: true,
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	violations := policy.Evaluate(admissionTestFile(), nil)
+	want := []struct{ rule, procedure string }{
+		{PolicyRuleForbiddenPort, ""},
+		{PolicyRuleImageRegistry, "server"},
+		{PolicyRuleImageDigest, "server"},
+		{PolicyRuleMaxExpectedPorts, "server"},
+		{PolicyRuleReadOnlyMount, "server"},
+		{PolicyRuleTTY, "server"},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("violations = %#v", violations)
+	}
+	for i, w := range want {
+		if violations[i].Rule != w.rule || violations[i].Procedure != w.procedure {
+			t.Fatalf("violation %d = %#v, want %s of %q", i, violations[i], w.rule, w.procedure)
+		}
+	}
+
+	err := policy.Admit(admissionTestFile(), nil)
+	var admissionErr *ScrollAdmissionError
+	if !errors.As(err, &admissionErr) || !errors.Is(err, ErrScrollNotAdmitted) || len(admissionErr.Violations) != len(want) {
+		t.Fatalf("admit = %v", err)
+	}
+}
+
+func TestScrollAdmissionPolicyChecksImagesAsLocked(t *testing.T) {
+	policy := &ScrollAdmissionPolicy{Images: ImageAdmissionRules{
+		AllowedRegistries: []string{"docker.io/itzg/*", "ghcr.io"},
+		AllowedDigests:    []string{"sha256:abc", "sha256:def"},
+	}}
+	lock := map[string]string{"itzg/minecraft-server:latest": "docker.io/itzg/minecraft-server@sha256:def"}
+
+	if violations := policy.Evaluate(admissionTestFile(), lock); len(violations) != 0 {
+		t.Fatalf("violations = %#v", violations)
+	}
+	lock["itzg/minecraft-server:latest"] = "docker.io/itzg/minecraft-server@sha256:123"
+	violations := policy.Evaluate(admissionTestFile(), lock)
+	if len(violations) != 1 || violations[0].Rule != PolicyRuleImageDigest {
+		t.Fatalf("violations = %#v", violations)
+	}
+	var nilPolicy *ScrollAdmissionPolicy
+	if err := nilPolicy.Admit(admissionTestFile(), nil); err != nil {
+		t.Fatalf("a nil policy rejected the scroll: %v", err)
+	}
+}
+
+func TestPathMatchesAnyCleansPaths(t *testing.T) {
+	for _, tc := range []struct {
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{[]string{"/etc"}, "/etc/.", true},
+		{[]string{"/etc"}, "//etc", true},
+		{[]string{"/etc/"}, "/var/../etc", true},
+		{[]string{"/etc"}, "/etc/server", false},
+		{[]string{"/etc/*"}, "/etc", true},
+		{[]string{"/etc/*"}, "//etc//server", true},
+		{[]string{"/etc/*"}, "/etcetera", false},
+		{[]string{"/*"}, "/data", true},
+		{[]string{"/var/lib*"}, "/var/./library", true},
+	} {
+		if got := pathMatchesAny(tc.patterns, tc.path); got != tc.want {
+			t.Errorf("pathMatchesAny(%q, %q) = %v, want %v", tc.patterns, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestSplitImageRef(t *testing.T) {
+	for image, want := range map[string][2]string{
+		"alpine":                              {"docker.io/library/alpine", ""},
+		"alpine:3.20":                         {"docker.io/library/alpine", ""},
+		"itzg/minecraft-server":               {"docker.io/itzg/minecraft-server", ""},
+		"localhost:5000/game:1@sha256:abc":    {"localhost:5000/game", "sha256:abc"},
+		"ghcr.io/highcard-dev/druid@sha256:1": {"ghcr.io/highcard-dev/druid", "sha256:1"},
+	} {
+		repo, digest := splitImageRef(image)
+		if repo != want[0] || digest != want[1] {
+			t.Errorf("splitImageRef(%q) = %q, %q; want %q, %q", image, repo, digest, want[0], want[1])
+		}
+	}
+}
+
+func TestScrollAdmissionPolicyAdmitRouting(t *testing.T) {
+	policy := &ScrollAdmissionPolicy{Ports: PortAdmissionRules{Forbidden: []string{"22", "8000-8100"}}}
+	if err := policy.AdmitRouting([]RuntimeRouteAssignment{{Name: "web", PublicPort: 8080}}); !errors.Is(err, ErrScrollNotAdmitted) {
+		t.Fatalf("err = %v, want not admitted", err)
+	}
+	if err := policy.AdmitRouting([]RuntimeRouteAssignment{{Name: "game", PublicPort: 25565}, {Name: "web", Host: "game.example"}}); err != nil {
+		t.Fatal(err)
+	}
+}
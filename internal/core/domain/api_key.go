@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RuntimeAPIKeyPrefix starts every daemon-issued API key, so the management
+// API can tell them apart from workload tokens.
+const RuntimeAPIKeyPrefix = "druid_"
+
+var ErrRuntimeAPIKeyNotFound = errors.New("api key not found")
+
+// RuntimeAPIKey is a long-lived management API credential. Only Hash, the
+// SHA-256 of the key, is stored; the key itself is shown once on creation.
+// Role sets what the key may do and defaults to operator; Scrolls and
+// Commands narrow it to some runtimes and commands. Empty lists mean all of
+// them.
+type RuntimeAPIKey struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Hash      string      `json:"-"`
+	Role      RuntimeRole `json:"role"`
+	Scrolls   []string    `json:"scrolls,omitempty"`
+	Commands  []string    `json:"commands,omitempty"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	CreatedBy string      `json:"created_by,omitempty"`
+}
+
+func (k *RuntimeAPIKey) Validate() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return fmt.Errorf("api key needs a name")
+	}
+	if _, ok := runtimeRolePermissions[k.Role]; k.Role != "" && !ok {
+		return fmt.Errorf("unknown role %q", k.Role)
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("api key expiry %s is in the past", k.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (k *RuntimeAPIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// AllowsScroll reports whether the key may be used on runtime id at all.
+func (k *RuntimeAPIKey) AllowsScroll(id string) bool {
+	return len(k.Scrolls) == 0 || slices.Contains(k.Scrolls, id)
+}
+
+// Allows reports whether the key holds permission on runtime id.
+func (k *RuntimeAPIKey) Allows(id string, permission string) bool {
+	if !k.AllowsScroll(id) {
+		return false
+	}
+	if command, ok := strings.CutPrefix(permission, permissionScrollRunPrefix); ok && len(k.Commands) > 0 && !slices.Contains(k.Commands, command) {
+		return false
+	}
+	return permissionsAllow(runtimeRolePermissions[k.Role], permission)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	AuditResultOK     = "ok"
+	AuditResultDenied = "denied"
+	AuditResultError  = "error"
+
+	AuditActionConsoleAttach = "console.attach"
+	AuditActionConsoleInput  = "console.input"
+
+	auditRedacted = "[redacted]"
+)
+
+// AuditRecord is one entry of the daemon audit log: who did what to which
+// runtime, from where, and how it ended.
+type AuditRecord struct {
+	ID       string            `json:"id"`
+	At       time.Time         `json:"at"`
+	Subject  string            `json:"subject"`
+	SourceIP string            `json:"source_ip,omitempty"`
+	ScrollID string            `json:"scroll_id,omitempty"`
+	Action   string            `json:"action"`
+	Params   map[string]string `json:"params,omitempty"`
+	Result   string            `json:"result"`
+	Status   int               `json:"status,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// AuditFilter selects audit records. Zero fields match everything; Limit
+// keeps the newest records.
+type AuditFilter struct {
+	ScrollID string
+	Subject  string
+	Action   string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+func (f AuditFilter) Matches(record *AuditRecord) bool {
+	if f.ScrollID != "" && record.ScrollID != f.ScrollID {
+		return false
+	}
+	if f.Subject != "" && record.Subject != f.Subject {
+		return false
+	}
+	if f.Action != "" && record.Action != f.Action {
+		return false
+	}
+	if !f.Since.IsZero() && record.At.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !record.At.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+var auditSecretWords = []string{"password", "secret", "token", "key", "credential", "auth"}
+
+// RedactAuditParams blanks the values of parameters whose name looks like
+// it holds a secret, such as registry passwords or identity tokens.
+func RedactAuditParams(params map[string]string) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(params))
+	for name, value := range params {
+		lower := strings.ToLower(name)
+		redacted[name] = value
+		for _, word := range auditSecretWords {
+			if strings.Contains(lower, word) {
+				redacted[name] = auditRedacted
+				break
+			}
+		}
+	}
+	return redacted
+}
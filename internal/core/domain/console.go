@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type ConsoleType string
 
 const (
@@ -27,3 +29,27 @@ type StreamItem struct {
 func (c *Console) MarkExited(exitCode int) {
 	c.Exit = &exitCode
 }
+
+// ConsoleLimits protect console websockets from abusive clients. Zero
+// disables a limit.
+type ConsoleLimits struct {
+	// MaxInputBytes is the largest input message a connection may send.
+	MaxInputBytes int
+	// InputRate is the sustained number of input messages per second of a
+	// connection; InputBurst how many it may send at once.
+	InputRate  int
+	InputBurst int
+	// MaxViewers caps the connections attached to one console.
+	MaxViewers int
+	// IdleTimeout disconnects connections that sent no input for this long.
+	IdleTimeout time.Duration
+}
+
+func DefaultConsoleLimits() ConsoleLimits {
+	return ConsoleLimits{
+		MaxInputBytes: 64 * 1024,
+		InputRate:     50,
+		InputBurst:    200,
+		MaxViewers:    20,
+	}
+}
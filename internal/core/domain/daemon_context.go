@@ -0,0 +1,87 @@
+package domain
+
+import "fmt"
+
+type DaemonAuthMethod string
+
+const (
+	// DaemonAuthAuto sends the cached druid auth login of the daemon if
+	// there is one.
+	DaemonAuthAuto  DaemonAuthMethod = ""
+	DaemonAuthNone  DaemonAuthMethod = "none"
+	DaemonAuthToken DaemonAuthMethod = "token"
+	DaemonAuthOIDC  DaemonAuthMethod = "oidc"
+)
+
+// DaemonContext is a named daemon the CLI can switch to. Contexts are kept
+// under contexts in ~/.druid.yaml, the active one under current_context.
+type DaemonContext struct {
+	Name string `json:"name" mapstructure:"name" yaml:"name"`
+	// Socket or URL is the daemon. With neither the default socket is used.
+	Socket string           `json:"socket,omitempty" mapstructure:"socket" yaml:"socket,omitempty"`
+	URL    string           `json:"url,omitempty" mapstructure:"url" yaml:"url,omitempty"`
+	Auth   DaemonAuthMethod `json:"auth,omitempty" mapstructure:"auth" yaml:"auth,omitempty"`
+	// Token is sent with auth token. TokenEnv names an environment variable
+	// holding it instead, so the secret stays out of the config file.
+	Token    string `json:"token,omitempty" mapstructure:"token" yaml:"token,omitempty"`
+	TokenEnv string `json:"token_env,omitempty" mapstructure:"token_env" yaml:"token_env,omitempty"`
+	CA       string `json:"ca,omitempty" mapstructure:"ca" yaml:"ca,omitempty"`
+	Cert     string `json:"cert,omitempty" mapstructure:"cert" yaml:"cert,omitempty"`
+	Key      string `json:"key,omitempty" mapstructure:"key" yaml:"key,omitempty"`
+	// Namespace is the Kubernetes namespace new scrolls are created in.
+	Namespace string `json:"namespace,omitempty" mapstructure:"namespace" yaml:"namespace,omitempty"`
+	// Output is the format of list commands: table or json.
+	Output string `json:"output,omitempty" mapstructure:"output" yaml:"output,omitempty"`
+}
+
+func (c DaemonContext) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("context needs a name")
+	}
+	if c.Socket != "" && c.URL != "" {
+		return fmt.Errorf("context %s: set either a socket or a URL", c.Name)
+	}
+	switch c.Auth {
+	case DaemonAuthAuto, DaemonAuthNone, DaemonAuthOIDC:
+	case DaemonAuthToken:
+		if c.Token == "" && c.TokenEnv == "" {
+			return fmt.Errorf("context %s: auth token needs a token or token_env", c.Name)
+		}
+	default:
+		return fmt.Errorf("context %s: unknown auth %q; use token, oidc or none", c.Name, c.Auth)
+	}
+	if (c.Cert == "") != (c.Key == "") {
+		return fmt.Errorf("context %s: a client certificate needs both cert and key", c.Name)
+	}
+	if err := ValidateOutputFormat(c.Output); err != nil {
+		return fmt.Errorf("context %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Target is the daemon URL, or the socket as unix:// URL.
+func (c DaemonContext) Target() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return "unix://" + c.Socket
+}
+
+// ValidateOutputFormat accepts table, json and empty for the default.
+func ValidateOutputFormat(output string) error {
+	switch output {
+	case "", "table", "json":
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q; use table or json", output)
+}
+
+// FindDaemonContext returns the context called name, or nil.
+func FindDaemonContext(contexts []DaemonContext, name string) *DaemonContext {
+	for i := range contexts {
+		if contexts[i].Name == name {
+			return &contexts[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package domain
+
+// DaemonDiscovery is served at /.well-known/druid-configuration so clients
+// can find out how to log in to a daemon before they hold a token.
+type DaemonDiscovery struct {
+	Version string `json:"version,omitempty"`
+	// OIDCIssuer is the issuer whose ID tokens the management API accepts.
+	OIDCIssuer   string   `json:"oidc_issuer,omitempty"`
+	OIDCClientID string   `json:"oidc_client_id,omitempty"`
+	OIDCScopes   []string `json:"oidc_scopes,omitempty"`
+}
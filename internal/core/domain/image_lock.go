@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ImageLockFile sits next to scroll.yaml and is pushed with it.
+const ImageLockFile = "images.lock"
+
+// ImageLock pins the container images of a scroll to digests. Keys are the
+// images as written in scroll.yaml, values are digest references.
+type ImageLock struct {
+	Images map[string]string `yaml:"images" json:"images"`
+}
+
+// ReadImageLock reads the image lock of the scroll in dir. A scroll without
+// one returns nil.
+func ReadImageLock(dir string) (*ImageLock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ImageLockFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseImageLock(data)
+}
+
+// ParseImageLock parses the contents of an images.lock file.
+func ParseImageLock(data []byte) (*ImageLock, error) {
+	var lock ImageLock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ImageLockFile, err)
+	}
+	return &lock, nil
+}
+
+func (l *ImageLock) Write(dir string) error {
+	images := make([]string, 0, len(l.Images))
+	for image := range l.Images {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	out := []byte("# Written by druid lock. Image digests the runtime backends run.\nimages:\n")
+	for _, image := range images {
+		line, err := yaml.Marshal(map[string]string{image: l.Images[image]})
+		if err != nil {
+			return err
+		}
+		out = append(out, "  "...)
+		out = append(out, line...)
+	}
+	return os.WriteFile(filepath.Join(dir, ImageLockFile), out, 0644)
+}
+
+// PinImages returns command with the image of every locked container
+// procedure replaced by its pinned digest. command itself is not changed.
+func PinImages(command *CommandInstructionSet, pins map[string]string) *CommandInstructionSet {
+	if command == nil || len(pins) == 0 {
+		return command
+	}
+	pinned := *command
+	pinned.Procedures = make([]*Procedure, len(command.Procedures))
+	for idx, procedure := range command.Procedures {
+		pinned.Procedures[idx] = procedure
+		if procedure == nil || !procedure.IsContainer() {
+			continue
+		}
+		if ref, ok := pins[procedure.Image]; ok && ref != "" {
+			copied := *procedure
+			copied.Image = ref
+			pinned.Procedures[idx] = &copied
+		}
+	}
+	return &pinned
+}
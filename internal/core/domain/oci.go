@@ -1,6 +1,13 @@
 package domain
 
-import "sync/atomic"
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
 
 type ArtifactType string
 
@@ -29,11 +36,82 @@ func NewSnapshotProgress() *SnapshotProgress {
 	return sp
 }
 
+// Manifest annotations druid push writes to describe a scroll.
+const (
+	AnnotationScrollName       = "gg.druid.scroll.name"
+	AnnotationScrollVersion    = "gg.druid.scroll.version"
+	AnnotationScrollAppVersion = "gg.druid.scroll.appVersion"
+	AnnotationScrollMinRam     = "gg.druid.scroll.minRam"
+	AnnotationScrollMinDisk    = "gg.druid.scroll.minDisk"
+	AnnotationScrollMinCpu     = "gg.druid.scroll.minCpu"
+	AnnotationScrollImage      = "gg.druid.scroll.image"
+	AnnotationScrollSmart      = "gg.druid.scroll.smart"
+	AnnotationScrollCategory   = "gg.druid.scroll.category"
+	// AnnotationScrollPortPrefix is followed by the port name.
+	AnnotationScrollPortPrefix = "gg.druid.scroll.port."
+)
+
 type AnnotationInfo struct {
-	MinRam  string
-	MinDisk string
-	MinCpu  string
-	Image   string
-	Smart   bool
-	Ports   map[string]string
+	Name       string            `json:"name,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	AppVersion string            `json:"app_version,omitempty"`
+	MinRam     string            `json:"min_ram,omitempty"`
+	MinDisk    string            `json:"min_disk,omitempty"`
+	MinCpu     string            `json:"min_cpu,omitempty"`
+	Image      string            `json:"image,omitempty"`
+	Smart      bool              `json:"smart,omitempty"`
+	Category   string            `json:"category,omitempty"`
+	Ports      map[string]string `json:"ports,omitempty"`
+}
+
+func NewAnnotationInfo(annotations map[string]string) AnnotationInfo {
+	info := AnnotationInfo{
+		Name:       annotations[AnnotationScrollName],
+		Version:    annotations[AnnotationScrollVersion],
+		AppVersion: annotations[AnnotationScrollAppVersion],
+		MinRam:     annotations[AnnotationScrollMinRam],
+		MinDisk:    annotations[AnnotationScrollMinDisk],
+		MinCpu:     annotations[AnnotationScrollMinCpu],
+		Image:      annotations[AnnotationScrollImage],
+		Smart:      annotations[AnnotationScrollSmart] == "true",
+		Category:   annotations[AnnotationScrollCategory],
+	}
+	for key, value := range annotations {
+		if name, ok := strings.CutPrefix(key, AnnotationScrollPortPrefix); ok && name != "" {
+			if info.Ports == nil {
+				info.Ports = map[string]string{}
+			}
+			info.Ports[name] = value
+		}
+	}
+	return info
+}
+
+// PortList renders Ports as sorted name=port pairs.
+func (a AnnotationInfo) PortList() string {
+	ports := make([]string, 0, len(a.Ports))
+	for name, port := range a.Ports {
+		ports = append(ports, name+"="+port)
+	}
+	sort.Strings(ports)
+	return strings.Join(ports, ",")
+}
+
+// ScrollBundleExt is the extension of the OCI image layout tarballs written
+// by druid bundle export.
+const ScrollBundleExt = ".tar"
+
+// ErrBundleImagesMissing means a scroll from a bundle runs container images
+// the runtime backend does not have. Bundled images are not loaded into the
+// backend, and pulling them would need the network the bundle replaces.
+var ErrBundleImagesMissing = errors.New("bundle images are not loaded into the runtime backend")
+
+// IsScrollBundle reports whether artifact is a scroll bundle on disk rather
+// than a registry reference or a scroll directory.
+func IsScrollBundle(artifact string) bool {
+	if !strings.EqualFold(filepath.Ext(artifact), ScrollBundleExt) {
+		return false
+	}
+	info, err := os.Stat(artifact)
+	return err == nil && info.Mode().IsRegular()
 }
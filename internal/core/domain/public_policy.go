@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PublicRoutes names the /:id/api/v1/* routes of the public listener by
+// their path below /api/v1.
+var PublicRoutes = []string{
+	"health",
+	"token",
+	"scroll",
+	"scroll/commands",
+	"command",
+	"queue",
+	"consoles",
+	"logs",
+	"ports",
+	"ui/packages",
+	"ui/packages/publish",
+}
+
+// PublicAPIPolicy shapes the public listener: which browsers may call it,
+// how often, and which routes exist at all.
+type PublicAPIPolicy struct {
+	CORS      PublicCORSPolicy `json:"cors" yaml:"cors"`
+	RateLimit PublicRateLimit  `json:"rate_limit" yaml:"rate_limit"`
+	// Routes enables only these PublicRoutes; empty enables all of them.
+	Routes []string `json:"routes,omitempty" yaml:"routes"`
+}
+
+type PublicCORSPolicy struct {
+	AllowOrigins []string `json:"allow_origins,omitempty" yaml:"allow_origins"`
+	AllowHeaders []string `json:"allow_headers,omitempty" yaml:"allow_headers"`
+	// AllowCredentials defaults to true.
+	AllowCredentials *bool    `json:"allow_credentials,omitempty" yaml:"allow_credentials"`
+	ExposeHeaders    []string `json:"expose_headers,omitempty" yaml:"expose_headers"`
+}
+
+// PublicRateLimit allows PerIP requests per client IP and PerSubject
+// requests per token subject in each Window. Zero disables a limit.
+type PublicRateLimit struct {
+	PerIP      int           `json:"per_ip,omitempty" yaml:"per_ip"`
+	PerSubject int           `json:"per_subject,omitempty" yaml:"per_subject"`
+	Window     time.Duration `json:"window,omitempty" yaml:"window"`
+}
+
+// DefaultPublicAPIPolicy is used without a policy file and fills in what a
+// policy file leaves out: the druid.gg dashboard and a local development
+// server may call every route, without rate limits.
+func DefaultPublicAPIPolicy() PublicAPIPolicy {
+	return PublicAPIPolicy{
+		CORS: PublicCORSPolicy{
+			AllowOrigins:  []string{"https://app.druid.gg", "http://localhost:3000", "http://127.0.0.1:3000"},
+			AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "Cache-Control", "DNT", "Keep-Alive", "User-Agent", "If-Modified-Since", "Depth", "Destination", "Overwrite", "If", "Lock-Token", "Timeout", "Dav"},
+			ExposeHeaders: []string{"Druid-Version"},
+		},
+		RateLimit: PublicRateLimit{Window: time.Minute},
+	}
+}
+
+// WithDefaults fills empty fields from DefaultPublicAPIPolicy.
+func (p PublicAPIPolicy) WithDefaults() PublicAPIPolicy {
+	defaults := DefaultPublicAPIPolicy()
+	if len(p.CORS.AllowOrigins) == 0 {
+		p.CORS.AllowOrigins = defaults.CORS.AllowOrigins
+	}
+	if len(p.CORS.AllowHeaders) == 0 {
+		p.CORS.AllowHeaders = defaults.CORS.AllowHeaders
+	}
+	if len(p.CORS.ExposeHeaders) == 0 {
+		p.CORS.ExposeHeaders = defaults.CORS.ExposeHeaders
+	}
+	if p.RateLimit.Window == 0 {
+		p.RateLimit.Window = defaults.RateLimit.Window
+	}
+	return p
+}
+
+func (p PublicAPIPolicy) CredentialsAllowed() bool {
+	return p.CORS.AllowCredentials == nil || *p.CORS.AllowCredentials
+}
+
+func (p PublicAPIPolicy) Validate() error {
+	if p.CredentialsAllowed() && slices.Contains(p.CORS.AllowOrigins, "*") {
+		return fmt.Errorf("cors: allow_origins * needs allow_credentials: false")
+	}
+	if p.RateLimit.PerIP < 0 || p.RateLimit.PerSubject < 0 || p.RateLimit.Window < 0 {
+		return fmt.Errorf("rate_limit must not be negative")
+	}
+	for _, route := range p.Routes {
+		if !slices.Contains(PublicRoutes, route) {
+			return fmt.Errorf("unknown route %q", route)
+		}
+	}
+	return nil
+}
+
+// RouteEnabled reports whether the public route is served.
+func (p PublicAPIPolicy) RouteEnabled(route string) bool {
+	return len(p.Routes) == 0 || slices.Contains(p.Routes, route)
+}
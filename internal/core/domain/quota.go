@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	// ErrQuotaExceeded rejects a request that would take an owner past one
+	// of its quota limits.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrArtifactNotAdmitted rejects an artifact the owner may not deploy:
+	// its repository is not allowed, or it declares resources that cannot
+	// be parsed.
+	ErrArtifactNotAdmitted = errors.New("artifact not admitted")
+)
+
+// RuntimeResources are the resources an artifact declares through its
+// gg.druid.scroll.min* annotations, as Kubernetes quantities.
+type RuntimeResources struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	Disk   string `json:"disk,omitempty"`
+}
+
+func RuntimeResourcesFromAnnotations(info AnnotationInfo) *RuntimeResources {
+	if info.MinCpu == "" && info.MinRam == "" && info.MinDisk == "" {
+		return nil
+	}
+	return &RuntimeResources{CPU: info.MinCpu, Memory: info.MinRam, Disk: info.MinDisk}
+}
+
+// QuotaPolicy is the admission policy of the daemon. Default applies to
+// every owner without an entry in Owners. Scrolls without an owner, created
+// over the local socket or by an operator, are never limited.
+type QuotaPolicy struct {
+	Default QuotaLimits  `json:"default" yaml:"default"`
+	Owners  []OwnerQuota `json:"owners,omitempty" yaml:"owners"`
+}
+
+type OwnerQuota struct {
+	Owner       string `json:"owner" yaml:"owner"`
+	QuotaLimits `yaml:",inline"`
+}
+
+// QuotaLimits are the limits of one owner. Zero values are unlimited.
+// MaxCPU, MaxMemory and MaxDisk are Kubernetes quantities such as 2, 500m or
+// 4Gi. AllowedRepositories entries ending in "*" match every repository
+// below the prefix.
+type QuotaLimits struct {
+	MaxScrolls          int      `json:"max_scrolls,omitempty" yaml:"max_scrolls"`
+	MaxRunningCommands  int      `json:"max_running_commands,omitempty" yaml:"max_running_commands"`
+	MaxCPU              string   `json:"max_cpu,omitempty" yaml:"max_cpu"`
+	MaxMemory           string   `json:"max_memory,omitempty" yaml:"max_memory"`
+	MaxDisk             string   `json:"max_disk,omitempty" yaml:"max_disk"`
+	AllowedRepositories []string `json:"allowed_repositories,omitempty" yaml:"allowed_repositories"`
+}
+
+// QuotaUsage is what an owner currently holds against its limits.
+type QuotaUsage struct {
+	Scrolls         int              `json:"scrolls"`
+	RunningCommands int              `json:"running_commands"`
+	Resources       RuntimeResources `json:"resources"`
+}
+
+type OwnerQuotaStatus struct {
+	Owner  string      `json:"owner"`
+	Limits QuotaLimits `json:"limits"`
+	Usage  QuotaUsage  `json:"usage"`
+}
+
+func (p *QuotaPolicy) Validate() error {
+	if err := p.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	seen := map[string]bool{}
+	for i, owner := range p.Owners {
+		if strings.TrimSpace(owner.Owner) == "" {
+			return fmt.Errorf("owners[%d] has no owner", i)
+		}
+		if seen[owner.Owner] {
+			return fmt.Errorf("owner %s is listed twice", owner.Owner)
+		}
+		seen[owner.Owner] = true
+		if err := owner.validate(); err != nil {
+			return fmt.Errorf("owner %s: %w", owner.Owner, err)
+		}
+	}
+	return nil
+}
+
+func (l QuotaLimits) validate() error {
+	if l.MaxScrolls < 0 || l.MaxRunningCommands < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	for name, value := range map[string]string{"max_cpu": l.MaxCPU, "max_memory": l.MaxMemory, "max_disk": l.MaxDisk} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("%s %q: %w", name, value, err)
+		}
+	}
+	return nil
+}
+
+// LimitsFor returns the limits of owner; nil when owner is not limited.
+func (p *QuotaPolicy) LimitsFor(owner string) *QuotaLimits {
+	if p == nil || owner == "" {
+		return nil
+	}
+	for i := range p.Owners {
+		if p.Owners[i].Owner == owner {
+			return &p.Owners[i].QuotaLimits
+		}
+	}
+	return &p.Default
+}
+
+// AllowsRepository reports whether repo may be deployed. An empty list
+// allows every repository.
+func (l QuotaLimits) AllowsRepository(repo string) bool {
+	if len(l.AllowedRepositories) == 0 {
+		return true
+	}
+	repo = strings.TrimRight(strings.TrimPrefix(strings.TrimPrefix(repo, "https://"), "http://"), "/")
+	if repo == "" {
+		return false
+	}
+	for _, allowed := range l.AllowedRepositories {
+		pattern := strings.TrimRight(allowed, "/")
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(repo, prefix) {
+				return true
+			}
+		} else if pattern == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// AdmitResources checks that usage plus requested stays within the
+// resource limits and returns the new total. A resource that is limited
+// must be declared, so artifacts cannot skip a limit by leaving it out.
+func (l QuotaLimits) AdmitResources(usage RuntimeResources, requested *RuntimeResources) (RuntimeResources, error) {
+	if requested == nil {
+		requested = &RuntimeResources{}
+	}
+	total := usage
+	checks := []struct {
+		name      string
+		limit     string
+		requested string
+		total     *string
+	}{
+		{"cpu", l.MaxCPU, requested.CPU, &total.CPU},
+		{"memory", l.MaxMemory, requested.Memory, &total.Memory},
+		{"disk", l.MaxDisk, requested.Disk, &total.Disk},
+	}
+	for _, check := range checks {
+		if check.requested == "" {
+			if check.limit != "" {
+				return usage, fmt.Errorf("%w: artifact declares no %s, required by max_%s", ErrArtifactNotAdmitted, check.name, check.name)
+			}
+			continue
+		}
+		sum, err := AddQuantities(*check.total, check.requested)
+		if err != nil {
+			if check.limit == "" {
+				continue
+			}
+			return usage, fmt.Errorf("%w: declared %s %q is not a quantity", ErrArtifactNotAdmitted, check.name, check.requested)
+		}
+		*check.total = sum
+		if check.limit == "" {
+			continue
+		}
+		limit, summed := resource.MustParse(check.limit), resource.MustParse(sum)
+		if summed.Cmp(limit) > 0 {
+			return usage, fmt.Errorf("%w: %s would be %s of %s", ErrQuotaExceeded, check.name, sum, check.limit)
+		}
+	}
+	return total, nil
+}
+
+// AddQuantities adds two Kubernetes quantities; an empty one counts as zero.
+func AddQuantities(a string, b string) (string, error) {
+	var sum resource.Quantity
+	for _, value := range []string{a, b} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return "", err
+		}
+		sum.Add(quantity)
+	}
+	return sum.String(), nil
+}
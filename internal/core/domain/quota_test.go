@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestQuotaPolicyLimitsFor(t *testing.T) {
+	policy := &QuotaPolicy{
+		Default: QuotaLimits{MaxScrolls: 1},
+		Owners:  []OwnerQuota{{Owner: "bob", QuotaLimits: QuotaLimits{MaxScrolls: 5}}},
+	}
+	if limits := policy.LimitsFor(""); limits != nil {
+		t.Fatalf("scrolls without owner got limits %#v", limits)
+	}
+	if limits := policy.LimitsFor("alice"); limits == nil || limits.MaxScrolls != 1 {
+		t.Fatalf("alice = %#v, want the default", limits)
+	}
+	if limits := policy.LimitsFor("bob"); limits == nil || limits.MaxScrolls != 5 {
+		t.Fatalf("bob = %#v", limits)
+	}
+	var none *QuotaPolicy
+	if limits := none.LimitsFor("alice"); limits != nil {
+		t.Fatalf("no policy got limits %#v", limits)
+	}
+}
+
+func TestQuotaLimitsAllowsRepository(t *testing.T) {
+	limits := QuotaLimits{AllowedRepositories: []string{"registry.example/scrolls/*", "ghcr.io/acme/game"}}
+	for repo, want := range map[string]bool{
+		"registry.example/scrolls/minecraft": true,
+		"https://ghcr.io/acme/game":          true,
+		"ghcr.io/acme/game-other":            false,
+		"registry.example/other":             false,
+		"":                                   false,
+	} {
+		if got := limits.AllowsRepository(repo); got != want {
+			t.Errorf("AllowsRepository(%q) = %v, want %v", repo, got, want)
+		}
+	}
+	if !(QuotaLimits{}).AllowsRepository("anything") {
+		t.Fatal("an empty allow list should allow every repository")
+	}
+}
+
+func TestQuotaPolicyValidate(t *testing.T) {
+	for name, policy := range map[string]QuotaPolicy{
+		"bad quantity":   {Default: QuotaLimits{MaxMemory: "four gigs"}},
+		"negative":       {Default: QuotaLimits{MaxScrolls: -1}},
+		"missing owner":  {Owners: []OwnerQuota{{}}},
+		"repeated owner": {Owners: []OwnerQuota{{Owner: "bob"}, {Owner: "bob"}}},
+	} {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("%s: policy was accepted", name)
+		}
+	}
+	valid := QuotaPolicy{Default: QuotaLimits{MaxCPU: "500m", MaxMemory: "4Gi", MaxDisk: "20G"}}
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrRuntimeGrantNotFound = errors.New("runtime grant not found")
+
+type RuntimeRole string
+
+const (
+	// RuntimeRoleOwner may do everything, including deleting the runtime and
+	// managing its grants.
+	RuntimeRoleOwner RuntimeRole = "owner"
+	// RuntimeRoleOperator runs and maintains the runtime but cannot delete it
+	// or change who has access.
+	RuntimeRoleOperator    RuntimeRole = "operator"
+	RuntimeRoleViewer      RuntimeRole = "viewer"
+	RuntimeRoleConsoleOnly RuntimeRole = "console-only"
+)
+
+const (
+	PermissionScrollRead          = "scroll:read"
+	PermissionScrollStart         = "scroll:start"
+	PermissionScrollStop          = "scroll:stop"
+	PermissionScrollUpdate        = "scroll:update"
+	PermissionScrollCommands      = "scroll:commands"
+	PermissionScrollRouting       = "scroll:routing"
+	PermissionScrollUIPublish     = "scroll:ui:publish"
+	PermissionScrollConsoleAttach = "scroll:console:attach"
+	PermissionScrollBackup        = "scroll:backup"
+	PermissionScrollRestore       = "scroll:restore"
+	PermissionScrollDelete        = "scroll:delete"
+	PermissionScrollGrants        = "scroll:grants"
+	PermissionScrollCreate        = "scroll:create"
+	PermissionScrollAudit         = "scroll:audit"
+
+	permissionScrollRunPrefix = "scroll:run:"
+)
+
+// PermissionScrollRun is the permission to run command. A grant of
+// "scroll:run:*" covers every command.
+func PermissionScrollRun(command string) string {
+	return permissionScrollRunPrefix + command
+}
+
+var runtimeRolePermissions = map[RuntimeRole][]string{
+	RuntimeRoleOwner: {"scroll:*"},
+	RuntimeRoleOperator: {
+		PermissionScrollRead,
+		PermissionScrollStart,
+		PermissionScrollStop,
+		PermissionScrollUpdate,
+		PermissionScrollCommands,
+		PermissionScrollRouting,
+		PermissionScrollUIPublish,
+		PermissionScrollConsoleAttach,
+		PermissionScrollBackup,
+		PermissionScrollRestore,
+		PermissionScrollRun("*"),
+	},
+	RuntimeRoleViewer:      {PermissionScrollRead},
+	RuntimeRoleConsoleOnly: {PermissionScrollConsoleAttach},
+}
+
+// grantablePermissions are the permissions a grant may list besides
+// "scroll:run:<command>". scroll:create is left out: it is not tied to a
+// runtime, so only API keys hold it.
+var grantablePermissions = []string{
+	PermissionScrollRead,
+	PermissionScrollStart,
+	PermissionScrollStop,
+	PermissionScrollUpdate,
+	PermissionScrollCommands,
+	PermissionScrollRouting,
+	PermissionScrollUIPublish,
+	PermissionScrollConsoleAttach,
+	PermissionScrollBackup,
+	PermissionScrollRestore,
+	PermissionScrollDelete,
+	PermissionScrollGrants,
+	PermissionScrollAudit,
+}
+
+// RuntimeGrant gives Subject, a token subject, access to one runtime scroll.
+// Permissions add to those of Role, so a viewer can be allowed a single
+// command with "scroll:run:backup".
+type RuntimeGrant struct {
+	Subject     string      `json:"subject"`
+	Role        RuntimeRole `json:"role,omitempty"`
+	Permissions []string    `json:"permissions,omitempty"`
+}
+
+func (g *RuntimeGrant) Validate() error {
+	if strings.TrimSpace(g.Subject) == "" {
+		return fmt.Errorf("grant needs a subject")
+	}
+	if g.Role == "" && len(g.Permissions) == 0 {
+		return fmt.Errorf("grant for %s needs a role or permissions", g.Subject)
+	}
+	if _, ok := runtimeRolePermissions[g.Role]; g.Role != "" && !ok {
+		return fmt.Errorf("unknown role %q", g.Role)
+	}
+	for _, permission := range g.Permissions {
+		if !grantablePermission(permission) {
+			return fmt.Errorf("invalid permission %q", permission)
+		}
+	}
+	return nil
+}
+
+// GrantedPermissions returns what the grant gives: the permissions of its
+// role and its own.
+func (g *RuntimeGrant) GrantedPermissions() []string {
+	permissions := append([]string(nil), runtimeRolePermissions[g.Role]...)
+	return append(permissions, g.Permissions...)
+}
+
+// grantablePermission reports whether a grant may list permission. The only
+// wildcard it accepts is "scroll:run:*".
+func grantablePermission(permission string) bool {
+	if command, ok := strings.CutPrefix(permission, permissionScrollRunPrefix); ok {
+		return command == "*" || (command != "" && !strings.Contains(command, "*"))
+	}
+	return slices.Contains(grantablePermissions, permission)
+}
+
+// PermissionsFor returns what subject may do on the runtime. The owner, and
+// anyone while the runtime has no owner, may do everything.
+func (r *RuntimeScroll) PermissionsFor(subject string) []string {
+	if r.OwnerID == "" || r.OwnerID == subject {
+		return runtimeRolePermissions[RuntimeRoleOwner]
+	}
+	var permissions []string
+	for _, grant := range r.Grants {
+		if grant.Subject != subject {
+			continue
+		}
+		permissions = append(permissions, grant.GrantedPermissions()...)
+	}
+	return permissions
+}
+
+// Allows reports whether subject holds permission on the runtime.
+func (r *RuntimeScroll) Allows(subject string, permission string) bool {
+	return permissionsAllow(r.PermissionsFor(subject), permission)
+}
+
+func permissionsAllow(permissions []string, permission string) bool {
+	for _, granted := range permissions {
+		if PermissionMatches(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionMatches reports whether granted covers permission. A trailing
+// "*" in granted matches everything below it.
+func PermissionMatches(granted string, permission string) bool {
+	if prefix, ok := strings.CutSuffix(granted, "*"); ok {
+		return strings.HasPrefix(permission, prefix)
+	}
+	return granted == permission
+}
@@ -1,7 +1,200 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 type RegistryCredential struct {
 	Host     string `json:"host" mapstructure:"host" yaml:"host"`
 	Username string `json:"username" mapstructure:"username" yaml:"username"`
 	Password string `json:"password" mapstructure:"password" yaml:"password"`
+	// IdentityToken is an OAuth2 refresh token exchanged for access tokens.
+	IdentityToken string `json:"identity_token,omitempty" mapstructure:"identity_token" yaml:"identity_token,omitempty"`
+	// RegistryToken is a bearer token sent to the registry as is.
+	RegistryToken string `json:"registry_token,omitempty" mapstructure:"registry_token" yaml:"registry_token,omitempty"`
+	// CredentialHelper names a docker-credential-<helper> executable holding
+	// the secret for Host; the fields above stay empty.
+	CredentialHelper string `json:"credential_helper,omitempty" mapstructure:"credential_helper" yaml:"credential_helper,omitempty"`
+}
+
+// ScrollTrustPolicy decides which signatures a scroll artifact needs before a
+// pull worker materializes it. Keys are PEM encoded public keys.
+type ScrollTrustPolicy struct {
+	// Keys are trusted for every repository without a matching rule.
+	Keys  []string          `json:"keys,omitempty" mapstructure:"keys" yaml:"keys"`
+	Rules []ScrollTrustRule `json:"rules,omitempty" mapstructure:"rules" yaml:"rules"`
+}
+
+// ScrollTrustRule applies to one repository, or to every repository below a
+// prefix when Repo ends with "*".
+type ScrollTrustRule struct {
+	Repo          string   `json:"repo" mapstructure:"repo" yaml:"repo"`
+	Keys          []string `json:"keys,omitempty" mapstructure:"keys" yaml:"keys"`
+	AllowUnsigned bool     `json:"allow_unsigned,omitempty" mapstructure:"allow_unsigned" yaml:"allow_unsigned"`
+}
+
+func (p *ScrollTrustPolicy) Enabled() bool {
+	return p != nil && (len(p.Keys) > 0 || len(p.Rules) > 0)
+}
+
+// RuleFor returns the most specific rule for repo. Repositories without a
+// rule fall back to the policy keys; nil means nothing from repo is trusted.
+func (p *ScrollTrustPolicy) RuleFor(repo string) *ScrollTrustRule {
+	repo = NormalizeTrustRepo(repo)
+	var best *ScrollTrustRule
+	bestLen := -1
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		pattern := strings.TrimRight(rule.Repo, "/")
+		matched := pattern == repo
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			pattern = prefix
+			matched = strings.HasPrefix(repo, prefix)
+		}
+		if matched && len(pattern) > bestLen {
+			best = rule
+			bestLen = len(pattern)
+		}
+	}
+	if best != nil {
+		return best
+	}
+	if len(p.Keys) == 0 {
+		return nil
+	}
+	return &ScrollTrustRule{Repo: repo, Keys: p.Keys}
+}
+
+// NormalizeTrustRepo strips the scheme and trailing slashes trust rules and
+// signature identities are compared without.
+func NormalizeTrustRepo(repo string) string {
+	return strings.TrimRight(strings.TrimPrefix(strings.TrimPrefix(repo, "https://"), "http://"), "/")
+}
+
+// RegistryConfig changes where and how registries are reached. Its fields
+// use the same keys as the druid config file.
+type RegistryConfig struct {
+	Hosts    []RegistryHost    `json:"registry_hosts,omitempty" mapstructure:"registry_hosts" yaml:"registry_hosts,omitempty"`
+	Mirrors  []RegistryMirror  `json:"registry_mirrors,omitempty" mapstructure:"registry_mirrors" yaml:"registry_mirrors,omitempty"`
+	Rewrites []RegistryRewrite `json:"registry_rewrites,omitempty" mapstructure:"registry_rewrites" yaml:"registry_rewrites,omitempty"`
+}
+
+// RegistryHost holds the connection settings of one registry host, with
+// port if it has one.
+type RegistryHost struct {
+	Host      string `json:"host" mapstructure:"host" yaml:"host"`
+	PlainHTTP bool   `json:"plain_http,omitempty" mapstructure:"plain_http" yaml:"plain_http,omitempty"`
+	// Insecure skips TLS certificate verification.
+	Insecure bool `json:"insecure,omitempty" mapstructure:"insecure" yaml:"insecure,omitempty"`
+	// CAFile and CA add a PEM encoded CA to the system roots. Workers only
+	// get CA, so CAFile is read before the config is handed to them.
+	CAFile string `json:"ca_file,omitempty" mapstructure:"ca_file" yaml:"ca_file,omitempty"`
+	CA     string `json:"ca,omitempty" mapstructure:"ca" yaml:"ca,omitempty"`
+}
+
+// RegistryMirror lists endpoints that serve the content of Registry, a host
+// or repository prefix. Pulls try them in order before Registry itself.
+type RegistryMirror struct {
+	Registry  string   `json:"registry" mapstructure:"registry" yaml:"registry"`
+	Endpoints []string `json:"endpoints" mapstructure:"endpoints" yaml:"endpoints"`
+}
+
+// RegistryRewrite replaces repository From with To for every registry
+// operation. With a trailing "*" on both sides everything below From is
+// moved below To.
+type RegistryRewrite struct {
+	From string `json:"from" mapstructure:"from" yaml:"from"`
+	To   string `json:"to" mapstructure:"to" yaml:"to"`
+}
+
+func (c RegistryConfig) Empty() bool {
+	return len(c.Hosts) == 0 && len(c.Mirrors) == 0 && len(c.Rewrites) == 0
+}
+
+func (c RegistryConfig) Validate() error {
+	for _, host := range c.Hosts {
+		if trimRegistryRef(host.Host) == "" {
+			return fmt.Errorf("registry host without host")
+		}
+		if host.PlainHTTP && (host.Insecure || host.CAFile != "" || host.CA != "") {
+			return fmt.Errorf("registry host %s: plain_http cannot be combined with TLS settings", host.Host)
+		}
+	}
+	for _, mirror := range c.Mirrors {
+		if trimRegistryRef(mirror.Registry) == "" || len(mirror.Endpoints) == 0 {
+			return fmt.Errorf("registry mirror needs a registry and at least one endpoint")
+		}
+	}
+	for _, rewrite := range c.Rewrites {
+		from, fromPrefix := strings.CutSuffix(trimRegistryRef(rewrite.From), "*")
+		to, toPrefix := strings.CutSuffix(trimRegistryRef(rewrite.To), "*")
+		if from == "" || to == "" {
+			return fmt.Errorf("registry rewrite needs from and to")
+		}
+		if fromPrefix != toPrefix {
+			return fmt.Errorf("registry rewrite %s -> %s: both sides or neither must end in *", rewrite.From, rewrite.To)
+		}
+	}
+	return nil
+}
+
+// Rewrite applies the most specific matching rewrite rule to repo.
+func (c RegistryConfig) Rewrite(repo string) string {
+	repo = trimRegistryRef(repo)
+	best := ""
+	bestLen := -1
+	for _, rewrite := range c.Rewrites {
+		from := trimRegistryRef(rewrite.From)
+		to := trimRegistryRef(rewrite.To)
+		if prefix, ok := strings.CutSuffix(from, "*"); ok {
+			if strings.HasPrefix(repo, prefix) && len(prefix) > bestLen {
+				best = strings.TrimSuffix(to, "*") + strings.TrimPrefix(repo, prefix)
+				bestLen = len(prefix)
+			}
+		} else if repo == from && len(from) > bestLen {
+			best = to
+			bestLen = len(from)
+		}
+	}
+	if bestLen < 0 {
+		return repo
+	}
+	return best
+}
+
+// MirrorsFor returns the mirror repositories for repo in the order they
+// should be tried.
+func (c RegistryConfig) MirrorsFor(repo string) []string {
+	repo = trimRegistryRef(repo)
+	var mirrors []string
+	for _, mirror := range c.Mirrors {
+		registry := trimRegistryRef(mirror.Registry)
+		rest, ok := strings.CutPrefix(repo, registry)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			continue
+		}
+		for _, endpoint := range mirror.Endpoints {
+			mirrors = append(mirrors, trimRegistryRef(endpoint)+rest)
+		}
+	}
+	return mirrors
+}
+
+// HostFor returns the settings for host, or nil if it has none.
+func (c RegistryConfig) HostFor(host string) *RegistryHost {
+	host = trimRegistryRef(host)
+	for i := range c.Hosts {
+		if trimRegistryRef(c.Hosts[i].Host) == host {
+			return &c.Hosts[i]
+		}
+	}
+	return nil
+}
+
+func trimRegistryRef(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "https://")
+	value = strings.TrimPrefix(value, "http://")
+	return strings.TrimRight(value, "/")
 }
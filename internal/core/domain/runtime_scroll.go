@@ -8,6 +8,7 @@ import (
 var (
 	ErrRuntimeScrollNotFound      = errors.New("runtime scroll not found")
 	ErrRuntimeScrollAlreadyExists = errors.New("runtime scroll already exists")
+	ErrNoRollbackTarget           = errors.New("no deployment to roll back to")
 )
 
 type RuntimeScrollStatus string
@@ -16,6 +17,9 @@ const (
 	RuntimeScrollStatusCreated RuntimeScrollStatus = "created"
 	RuntimeScrollStatusRunning RuntimeScrollStatus = "running"
 	RuntimeScrollStatusStopped RuntimeScrollStatus = "stopped"
+	// RuntimeScrollStatusIdle means the serve command was stopped for missing
+	// its keepAliveTraffic budget and a coldstarter holds its public ports.
+	RuntimeScrollStatusIdle    RuntimeScrollStatus = "idle"
 	RuntimeScrollStatusError   RuntimeScrollStatus = "error"
 	RuntimeScrollStatusDeleted RuntimeScrollStatus = "deleted"
 )
@@ -36,6 +40,62 @@ type RuntimeScroll struct {
 	UpdatedAt      time.Time                `json:"updated_at"`
 	Procedures     ProcedureStatusMap       `json:"procedures,omitempty"`
 	ReservedPorts  []Port                   `json:"reserved_ports,omitempty"`
+	WakeEvents     []RuntimeWakeEvent       `json:"wake_events,omitempty"`
+	UpdatePolicy   *RuntimeUpdatePolicy     `json:"update_policy,omitempty"`
+	UpdateHistory  []RuntimeUpdateRecord    `json:"update_history,omitempty"`
+	ImageLock      map[string]string        `json:"image_lock,omitempty"`
+	Grants         []RuntimeGrant           `json:"grants,omitempty"`
+	// Resources are declared by the artifact and count against the owner's
+	// quota.
+	Resources *RuntimeResources `json:"resources,omitempty"`
+}
+
+const (
+	RuntimeUpdateActionCreate   = "create"
+	RuntimeUpdateActionUpdate   = "update"
+	RuntimeUpdateActionRollback = "rollback"
+)
+
+// RuntimeUpdateRecord is one deployment of a runtime, oldest first in
+// RuntimeScroll.UpdateHistory. Initiator names who asked for it: an API
+// caller, ensure, or the auto-updater.
+type RuntimeUpdateRecord struct {
+	Artifact  string    `json:"artifact"`
+	Digest    string    `json:"digest,omitempty"`
+	At        time.Time `json:"at"`
+	Action    string    `json:"action"`
+	Initiator string    `json:"initiator,omitempty"`
+}
+
+const (
+	RuntimeWakeSourceColdstarter = "coldstarter"
+	RuntimeWakeSourceIdle        = "idle"
+)
+
+// RuntimeWakeEvent records which traffic ended a coldstart gate. Source is
+// coldstarter for a druid-coldstarter procedure and idle for the daemon's own
+// wake listener. ReadyAt is set once the woken server accepted traffic.
+type RuntimeWakeEvent struct {
+	ID             string     `json:"id"`
+	Source         string     `json:"source"`
+	Port           string     `json:"port"`
+	Protocol       string     `json:"protocol,omitempty"`
+	Handler        string     `json:"handler,omitempty"`
+	RemoteAddress  string     `json:"remote_address,omitempty"`
+	ListeningSince *time.Time `json:"listening_since,omitempty"`
+	WokeAt         time.Time  `json:"woke_at"`
+	ReadyAt        *time.Time `json:"ready_at,omitempty"`
+	ReceivedAt     time.Time  `json:"received_at"`
+}
+
+const RuntimeEventTypeWake = "wake"
+
+// RuntimeEvent is one entry of the daemon's scroll event stream.
+type RuntimeEvent struct {
+	Type     string            `json:"type"`
+	ScrollID string            `json:"scroll_id"`
+	At       time.Time         `json:"at"`
+	Wake     *RuntimeWakeEvent `json:"wake,omitempty"`
 }
 
 type RuntimeState struct {
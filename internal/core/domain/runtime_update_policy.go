@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	semver "github.com/Masterminds/semver/v3"
+)
+
+type RuntimeUpdatePolicyMode string
+
+const (
+	// RuntimeUpdatePolicyPinned never updates on its own; it is the same as
+	// having no policy.
+	RuntimeUpdatePolicyPinned RuntimeUpdatePolicyMode = "pinned"
+	// RuntimeUpdatePolicySemver follows the highest tag matching Constraint.
+	RuntimeUpdatePolicySemver RuntimeUpdatePolicyMode = "semver"
+	// RuntimeUpdatePolicyChannel follows the pull_channel entry named Channel
+	// in the runtime's current scroll.yaml.
+	RuntimeUpdatePolicyChannel RuntimeUpdatePolicyMode = "channel"
+)
+
+// RuntimeUpdatePolicy tells the daemon's auto-updater which new artifact
+// versions a runtime may move to and when. HeldDigest is maintained by the
+// daemon: it is the digest last rolled back from, which is skipped until a
+// different digest shows up.
+type RuntimeUpdatePolicy struct {
+	Mode       RuntimeUpdatePolicyMode `json:"mode"`
+	Constraint string                  `json:"constraint,omitempty"`
+	Channel    string                  `json:"channel,omitempty"`
+	Window     string                  `json:"window,omitempty"`
+	HeldDigest string                  `json:"held_digest,omitempty"`
+}
+
+func (p *RuntimeUpdatePolicy) Validate() error {
+	switch p.Mode {
+	case RuntimeUpdatePolicyPinned:
+	case RuntimeUpdatePolicySemver:
+		if _, err := semver.NewConstraint(p.Constraint); err != nil {
+			return fmt.Errorf("invalid semver constraint %q: %w", p.Constraint, err)
+		}
+	case RuntimeUpdatePolicyChannel:
+		if strings.TrimSpace(p.Channel) == "" {
+			return fmt.Errorf("update policy mode channel requires a channel")
+		}
+	default:
+		return fmt.Errorf("unknown update policy mode %q", p.Mode)
+	}
+	if p.Window != "" {
+		if _, err := ParseMaintenanceWindow(p.Window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AutoUpdates reports whether the auto-updater considers the runtime.
+func (p *RuntimeUpdatePolicy) AutoUpdates() bool {
+	return p != nil && p.Mode != "" && p.Mode != RuntimeUpdatePolicyPinned
+}
+
+// MaintenanceWindow is a daily time range in the daemon's local time. End
+// before Start wraps past midnight, so "22:00-04:00" covers the night.
+type MaintenanceWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseMaintenanceWindow parses "HH:MM-HH:MM".
+func ParseMaintenanceWindow(value string) (MaintenanceWindow, error) {
+	startRaw, endRaw, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return MaintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, want HH:MM-HH:MM", value)
+	}
+	start, err := parseClock(startRaw)
+	if err != nil {
+		return MaintenanceWindow{}, fmt.Errorf("invalid maintenance window %q: %w", value, err)
+	}
+	end, err := parseClock(endRaw)
+	if err != nil {
+		return MaintenanceWindow{}, fmt.Errorf("invalid maintenance window %q: %w", value, err)
+	}
+	if start == end {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q is empty", value)
+	}
+	return MaintenanceWindow{Start: start, End: end}, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+func (w MaintenanceWindow) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return clock(w.Start) + "-" + clock(w.End)
+}
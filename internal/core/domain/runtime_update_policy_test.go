@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	at := func(clock string) time.Time {
+		parsed, err := time.Parse("15:04", clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(2024, 5, 1, parsed.Hour(), parsed.Minute(), 0, 0, time.Local)
+	}
+	tests := []struct {
+		window string
+		clock  string
+		want   bool
+	}{
+		{"03:00-05:00", "03:00", true},
+		{"03:00-05:00", "04:59", true},
+		{"03:00-05:00", "05:00", false},
+		{"22:00-04:00", "23:30", true},
+		{"22:00-04:00", "01:00", true},
+		{"22:00-04:00", "12:00", false},
+	}
+	for _, test := range tests {
+		window, err := ParseMaintenanceWindow(test.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := window.Contains(at(test.clock)); got != test.want {
+			t.Errorf("%s contains %s = %v, want %v", test.window, test.clock, got, test.want)
+		}
+	}
+	for _, invalid := range []string{"", "03:00", "3am-5am", "04:00-04:00", "25:00-01:00"} {
+		if _, err := ParseMaintenanceWindow(invalid); err == nil {
+			t.Errorf("ParseMaintenanceWindow(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestRuntimeUpdatePolicyValidate(t *testing.T) {
+	valid := []RuntimeUpdatePolicy{
+		{Mode: RuntimeUpdatePolicyPinned},
+		{Mode: RuntimeUpdatePolicySemver, Constraint: "~1.2", Window: "02:00-06:00"},
+		{Mode: RuntimeUpdatePolicyChannel, Channel: "stable"},
+	}
+	for _, policy := range valid {
+		if err := policy.Validate(); err != nil {
+			t.Errorf("%+v: %v", policy, err)
+		}
+	}
+	invalid := []RuntimeUpdatePolicy{
+		{},
+		{Mode: RuntimeUpdatePolicySemver, Constraint: "stable"},
+		{Mode: RuntimeUpdatePolicyChannel},
+		{Mode: RuntimeUpdatePolicyPinned, Window: "night"},
+	}
+	for _, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("%+v should be invalid", policy)
+		}
+	}
+	var none *RuntimeUpdatePolicy
+	if none.AutoUpdates() || (&RuntimeUpdatePolicy{Mode: RuntimeUpdatePolicyPinned}).AutoUpdates() {
+		t.Fatal("missing and pinned policies must not auto-update")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -124,6 +125,23 @@ func ProcedureName(commandName string, idx int, procedure *Procedure) string {
 	return name
 }
 
+// IsColdstarterProcedure reports whether a procedure is a coldstart gate.
+// Gates are never stopped for idleness; they are what waits for traffic.
+func IsColdstarterProcedure(procedureName string, procedure *Procedure) bool {
+	if strings.EqualFold(procedureName, "coldstart") {
+		return true
+	}
+	if procedure == nil {
+		return false
+	}
+	for _, part := range procedure.Command {
+		if strings.Contains(part, "druid-coldstarter") {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Procedure) hasContainerFields() bool {
 	return p.Image != "" ||
 		len(p.Command) > 0 ||
@@ -165,10 +183,23 @@ type RuntimePortStatus struct {
 	KeepAliveTraffic string     `json:"keepAliveTraffic,omitempty"`
 	TrafficWindow    string     `json:"traffic_window,omitempty"`
 	TrafficOK        *bool      `json:"traffic_ok,omitempty"`
+	ActiveSessions   *int       `json:"active_sessions,omitempty"`
+	TotalSessions    *uint64    `json:"total_sessions,omitempty"`
 	LastActivityAt   *time.Time `json:"last_activity_at,omitempty"`
 	Source           string     `json:"source"`
 }
 
+// RuntimePortTraffic is what a coldstarter proxy reports for one port. The
+// counters are cumulative since the proxy process started.
+type RuntimePortTraffic struct {
+	Name           string
+	RXBytes        uint64
+	TXBytes        uint64
+	ActiveSessions int
+	TotalSessions  uint64
+	LastActivityAt *time.Time
+}
+
 var trafficThresholdPattern = regexp.MustCompile(`(?i)^([0-9]+)(b|kb|mb|gb)/(.+)$`)
 
 func ParseKeepAliveTraffic(value string) (*TrafficThreshold, error) {
@@ -431,6 +462,27 @@ func (sc *Scroll) GetColdStartPorts() []Port {
 	return sc.Ports
 }
 
+// ContainerImages returns the distinct images of all container procedures,
+// sorted.
+func (f *File) ContainerImages() []string {
+	seen := map[string]bool{}
+	images := []string{}
+	for _, cis := range f.Commands {
+		if cis == nil {
+			continue
+		}
+		for _, procedure := range cis.Procedures {
+			if procedure == nil || !procedure.IsContainer() || procedure.Image == "" || seen[procedure.Image] {
+				continue
+			}
+			seen[procedure.Image] = true
+			images = append(images, procedure.Image)
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
 const ScrollDataDir = "data"
 
 // DataLoadedMarkerFile is created under the scroll data directory after a successful
@@ -446,4 +498,5 @@ var ScrollFiles = map[string]ArtifactType{
 	"scroll-config.yml.scroll_template": ArtifactTypeScrollFs,
 	"data":                              ArtifactTypeScrollData,
 	".meta":                             ArtifactTypeScrollFs,
+	ImageLockFile:                       ArtifactTypeScrollFs,
 }
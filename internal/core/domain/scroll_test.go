@@ -171,3 +171,33 @@ func testScroll(t *testing.T, procedure *Procedure) *Scroll {
 		scrollDir: t.TempDir(),
 	}
 }
+
+func TestImageLockRoundTripAndPinImages(t *testing.T) {
+	dir := t.TempDir()
+	if lock, err := ReadImageLock(dir); err != nil || lock != nil {
+		t.Fatalf("ReadImageLock without lock file = %#v, %v", lock, err)
+	}
+	pinned := "docker.io/library/alpine@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	if err := (&ImageLock{Images: map[string]string{"alpine:3.20": pinned}}).Write(dir); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := ReadImageLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Images["alpine:3.20"] != pinned {
+		t.Fatalf("lock = %#v", lock.Images)
+	}
+
+	command := &CommandInstructionSet{Procedures: []*Procedure{
+		{Image: "alpine:3.20"},
+		{Image: "busybox"},
+	}}
+	got := PinImages(command, lock.Images)
+	if got.Procedures[0].Image != pinned || got.Procedures[1].Image != "busybox" {
+		t.Fatalf("pinned procedures = %s, %s", got.Procedures[0].Image, got.Procedures[1].Image)
+	}
+	if command.Procedures[0].Image != "alpine:3.20" {
+		t.Fatalf("PinImages changed its input to %s", command.Procedures[0].Image)
+	}
+}
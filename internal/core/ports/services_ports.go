@@ -13,13 +13,18 @@ import (
 type AuthorizerServiceInterface interface {
 	CheckHeader(r *fiber.Ctx) (*AuthContext, error)
 	CheckQuery(runtimeID string, token string) (*AuthContext, error)
-	GenerateQueryToken(runtimeID string, ownerID string) string
+	// GenerateQueryToken issues a query token for runtimeID. With a console
+	// the token only attaches to that console.
+	GenerateQueryToken(runtimeID string, ownerID string, console string) string
 }
 
 type AuthContext struct {
 	Subject   string
 	RuntimeID string
 	ExpiresAt *time.Time
+	// TokenID and Console are the jti and console claims of query tokens.
+	TokenID string
+	Console string
 }
 
 type ScrollServiceInterface interface {
@@ -56,9 +61,18 @@ type RuntimeCommandStopper interface {
 	StopCommand(root string, command string) error
 }
 
+// RuntimeIdleBackend is implemented by backends whose workloads are not
+// stopped by the platform when their keepAliveTraffic budget is missed. The
+// daemon then polls CommandIdle and scales the command to zero itself.
+type RuntimeIdleBackend interface {
+	CommandIdle(root string, commandName string, command *domain.CommandInstructionSet, globalPorts []domain.Port) (bool, error)
+}
+
 type RuntimeWorkerCallbackConfig struct {
 	Listen string
 	URL    string
+	// TLS is set when the callback listener serves HTTPS.
+	TLS bool
 }
 
 type RuntimeWorkerCallbackBackend interface {
@@ -75,12 +89,26 @@ type RuntimeWorkloadIdentity struct {
 	PodUID         string
 	RuntimeID      string
 	Kind           string
+	// ExpiresAt is when the token of the identity expires, if it does.
+	ExpiresAt time.Time
+}
+
+// RuntimeImageInspector is implemented by backends that can tell which
+// container images they already have without pulling them.
+type RuntimeImageInspector interface {
+	MissingImages(ctx context.Context, images []string) ([]string, error)
 }
 
 type RuntimeWorkloadAuthenticator interface {
 	AuthenticateWorkload(ctx context.Context, token string) (RuntimeWorkloadIdentity, error)
 }
 
+// RuntimeUserAuthenticator verifies end-user tokens, such as OIDC ID tokens,
+// and returns their subject.
+type RuntimeUserAuthenticator interface {
+	AuthenticateUser(ctx context.Context, token string) (string, error)
+}
+
 type RuntimeScrollStore interface {
 	StateDir() string
 	Root(id string) string
@@ -91,6 +119,33 @@ type RuntimeScrollStore interface {
 	DeleteScroll(id string) error
 }
 
+// RuntimeAPIKeyStore keeps daemon-issued API keys next to the runtime state.
+type RuntimeAPIKeyStore interface {
+	CreateAPIKey(key *domain.RuntimeAPIKey) error
+	ListAPIKeys() ([]*domain.RuntimeAPIKey, error)
+	GetAPIKey(id string) (*domain.RuntimeAPIKey, error)
+	DeleteAPIKey(id string) error
+}
+
+// RuntimeRegistryCredentialStore keeps the registry credentials a runtime was
+// last deployed with, so the daemon can pull for it on its own later. They
+// are deleted with the runtime.
+type RuntimeRegistryCredentialStore interface {
+	// SetRegistryCredentials replaces the credentials of runtime id; none
+	// removes them.
+	SetRegistryCredentials(id string, credentials []domain.RegistryCredential) error
+	// GetRegistryCredentials returns nil for runtimes without credentials.
+	GetRegistryCredentials(id string) ([]domain.RegistryCredential, error)
+}
+
+// RuntimeAuditStore keeps the daemon audit log next to the runtime state.
+type RuntimeAuditStore interface {
+	AppendAudit(record *domain.AuditRecord) error
+	ListAudit(filter domain.AuditFilter) ([]*domain.AuditRecord, error)
+	// PruneAudit deletes records older than before and returns how many.
+	PruneAudit(before time.Time) (int, error)
+}
+
 type RuntimeCommand struct {
 	Name                    string
 	ScrollID                string
@@ -128,6 +183,8 @@ type RuntimeMaterialization struct {
 	ArtifactDigest string
 	Root           string
 	ScrollYAML     []byte
+	// ImageLock are the container image pins of the artifact, if it has any.
+	ImageLock map[string]string
 }
 
 type RuntimeWorkerMode string
@@ -139,21 +196,28 @@ const (
 )
 
 type RuntimeWorkerAction struct {
-	Mode                RuntimeWorkerMode
-	RuntimeID           string
-	Artifact            string
-	Storage             string
-	RootRef             string
-	MountPath           string
-	CallbackURL         string
+	Mode        RuntimeWorkerMode
+	RuntimeID   string
+	Artifact    string
+	Storage     string
+	RootRef     string
+	MountPath   string
+	CallbackURL string
+	// CallbackCA holds PEM CAs the worker trusts for an https CallbackURL.
+	CallbackCA          string
 	TokenFile           string
 	RegistryCredentials []domain.RegistryCredential
+	TrustPolicy         *domain.ScrollTrustPolicy
+	// RegistryConfig carries mirrors, rewrites and host settings, with CA
+	// files already inlined.
+	RegistryConfig domain.RegistryConfig
 }
 
 type RuntimeWorkerResult struct {
-	ScrollYAML     string `json:"scroll_yaml,omitempty"`
-	ArtifactDigest string `json:"artifact_digest,omitempty"`
-	Error          string `json:"error,omitempty"`
+	ScrollYAML     string            `json:"scroll_yaml,omitempty"`
+	ArtifactDigest string            `json:"artifact_digest,omitempty"`
+	ImageLock      map[string]string `json:"image_lock,omitempty"`
+	Error          string            `json:"error,omitempty"`
 }
 
 type BroadcastChannelInterface interface {
@@ -204,7 +268,7 @@ type ColdStarterInterface interface {
 }
 
 type ColdStarterServerInterface interface {
-	Start(port int, onFinish func()) error
+	Start(port int, onFinish func(remote string)) error
 	Close() error
 }
 
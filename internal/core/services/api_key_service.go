@@ -0,0 +1,94 @@
+package services
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/utils"
+)
+
+// APIKeyService issues and checks management API keys. A key reads
+// druid_<id>.<secret>; the id finds the stored record and the hash of the
+// whole key proves the secret.
+type APIKeyService struct {
+	store ports.RuntimeAPIKeyStore
+	now   func() time.Time
+}
+
+func NewAPIKeyService(store ports.RuntimeAPIKeyStore) *APIKeyService {
+	return &APIKeyService{store: store, now: time.Now}
+}
+
+// Create stores key and returns it with the secret API key, which cannot be
+// recovered later.
+func (s *APIKeyService) Create(key domain.RuntimeAPIKey) (*domain.RuntimeAPIKey, string, error) {
+	if key.Role == "" {
+		key.Role = domain.RuntimeRoleOperator
+	}
+	if err := key.Validate(); err != nil {
+		return nil, "", err
+	}
+	id, err := utils.GenerateRandomBytes(8)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := utils.GenerateRandomBytes(32)
+	if err != nil {
+		return nil, "", err
+	}
+	key.ID = hex.EncodeToString(id)
+	token := domain.RuntimeAPIKeyPrefix + key.ID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	key.Hash = hashAPIKey(token)
+	key.CreatedAt = s.now().UTC()
+	if err := s.store.CreateAPIKey(&key); err != nil {
+		return nil, "", err
+	}
+	return &key, token, nil
+}
+
+func (s *APIKeyService) List() ([]*domain.RuntimeAPIKey, error) {
+	return s.store.ListAPIKeys()
+}
+
+func (s *APIKeyService) Revoke(id string) error {
+	return s.store.DeleteAPIKey(id)
+}
+
+// Authenticate returns the key token stands for, if it exists and has not
+// expired.
+func (s *APIKeyService) Authenticate(token string) (*domain.RuntimeAPIKey, error) {
+	rest, ok := strings.CutPrefix(token, domain.RuntimeAPIKeyPrefix)
+	if !ok {
+		return nil, errors.New("not an api key")
+	}
+	id, _, ok := strings.Cut(rest, ".")
+	if !ok || id == "" {
+		return nil, errors.New("malformed api key")
+	}
+	key, err := s.store.GetAPIKey(id)
+	if errors.Is(err, domain.ErrRuntimeAPIKeyNotFound) {
+		return nil, errors.New("invalid api key")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashAPIKey(token))) != 1 {
+		return nil, errors.New("invalid api key")
+	}
+	if key.Expired(s.now()) {
+		return nil, errors.New("api key expired")
+	}
+	return key, nil
+}
+
+func hashAPIKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
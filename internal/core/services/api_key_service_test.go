@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+type memoryAPIKeyStore map[string]*domain.RuntimeAPIKey
+
+func (s memoryAPIKeyStore) CreateAPIKey(key *domain.RuntimeAPIKey) error {
+	copied := *key
+	s[key.ID] = &copied
+	return nil
+}
+
+func (s memoryAPIKeyStore) ListAPIKeys() ([]*domain.RuntimeAPIKey, error) {
+	keys := []*domain.RuntimeAPIKey{}
+	for _, key := range s {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s memoryAPIKeyStore) GetAPIKey(id string) (*domain.RuntimeAPIKey, error) {
+	key, ok := s[id]
+	if !ok {
+		return nil, domain.ErrRuntimeAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (s memoryAPIKeyStore) DeleteAPIKey(id string) error {
+	if _, ok := s[id]; !ok {
+		return domain.ErrRuntimeAPIKeyNotFound
+	}
+	delete(s, id)
+	return nil
+}
+
+func TestAPIKeyServiceAuthenticatesIssuedKeys(t *testing.T) {
+	store := memoryAPIKeyStore{}
+	service := NewAPIKeyService(store)
+	expires := time.Now().Add(time.Hour)
+	created, token, err := service.Create(domain.RuntimeAPIKey{Name: "ci", Scrolls: []string{"scroll-1"}, ExpiresAt: &expires})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Role != domain.RuntimeRoleOperator {
+		t.Fatalf("role = %q, want operator", created.Role)
+	}
+	if store[created.ID].Hash == "" || store[created.ID].Hash == token {
+		t.Fatalf("stored hash %q does not hide the key", store[created.ID].Hash)
+	}
+
+	key, err := service.Authenticate(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != created.ID {
+		t.Fatalf("authenticated key %q, want %q", key.ID, created.ID)
+	}
+	for _, bad := range []string{token + "x", domain.RuntimeAPIKeyPrefix + created.ID, "not-a-key"} {
+		if _, err := service.Authenticate(bad); err == nil {
+			t.Fatalf("Authenticate(%q) succeeded", bad)
+		}
+	}
+
+	service.now = func() time.Time { return expires.Add(time.Second) }
+	if _, err := service.Authenticate(token); err == nil {
+		t.Fatal("expired key authenticated")
+	}
+
+	if err := service.Revoke(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	service.now = time.Now
+	if _, err := service.Authenticate(token); err == nil {
+		t.Fatal("revoked key authenticated")
+	}
+}
+
+func TestRuntimeAPIKeyAllowsOnlyItsScrollsAndCommands(t *testing.T) {
+	key := domain.RuntimeAPIKey{Role: domain.RuntimeRoleOperator, Scrolls: []string{"scroll-1"}, Commands: []string{"backup"}}
+	for _, tc := range []struct {
+		id         string
+		permission string
+		want       bool
+	}{
+		{"scroll-1", domain.PermissionScrollStart, true},
+		{"scroll-1", domain.PermissionScrollRun("backup"), true},
+		{"scroll-1", domain.PermissionScrollRun("restart"), false},
+		{"scroll-1", domain.PermissionScrollDelete, false},
+		{"scroll-2", domain.PermissionScrollRead, false},
+	} {
+		if got := key.Allows(tc.id, tc.permission); got != tc.want {
+			t.Errorf("Allows(%q, %q) = %v, want %v", tc.id, tc.permission, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// AuditLog writes audit records to the state store and drops them once they
+// are older than the retention. A zero retention keeps records forever.
+type AuditLog struct {
+	store     ports.RuntimeAuditStore
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewAuditLog(store ports.RuntimeAuditStore, retention time.Duration) *AuditLog {
+	return &AuditLog{store: store, retention: retention, now: time.Now}
+}
+
+// Record stores record with secrets in its parameters redacted. A failing
+// store is logged rather than failing the audited request.
+func (a *AuditLog) Record(record domain.AuditRecord) {
+	if record.ID == "" {
+		record.ID = uuid.NewString()
+	}
+	if record.At.IsZero() {
+		record.At = a.now().UTC()
+	}
+	record.Params = domain.RedactAuditParams(record.Params)
+	if err := a.store.AppendAudit(&record); err != nil {
+		logger.Log().Warn("Failed to write audit record", zap.String("action", record.Action), zap.String("scroll", record.ScrollID), zap.Error(err))
+	}
+}
+
+// List returns matching records, oldest first.
+func (a *AuditLog) List(filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	return a.store.ListAudit(filter)
+}
+
+// Prune deletes records past the retention.
+func (a *AuditLog) Prune() (int, error) {
+	if a.retention <= 0 {
+		return 0, nil
+	}
+	return a.store.PruneAudit(a.now().Add(-a.retention))
+}
+
+// StartPruner prunes once and then every interval until ctx ends. It returns
+// false when records are kept forever.
+func (a *AuditLog) StartPruner(ctx context.Context, interval time.Duration) bool {
+	if a.retention <= 0 || interval <= 0 {
+		return false
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if pruned, err := a.Prune(); err != nil {
+				logger.Log().Warn("Failed to prune audit log", zap.Error(err))
+			} else if pruned > 0 {
+				logger.Log().Info("Pruned audit log", zap.Int("records", pruned), zap.Duration("retention", a.retention))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return true
+}
@@ -183,19 +183,30 @@ func (auth *AuthorizerService) CheckQuery(runtimeID string, tokenString string)
 	}
 	subject, _ := claims["sub"].(string)
 	claimRuntimeID, _ := claims["runtime_id"].(string)
-	return &ports.AuthContext{Subject: subject, RuntimeID: claimRuntimeID, ExpiresAt: &expires}, nil
+	tokenID, _ := claims["jti"].(string)
+	console, _ := claims["console"].(string)
+	return &ports.AuthContext{Subject: subject, RuntimeID: claimRuntimeID, ExpiresAt: &expires, TokenID: tokenID, Console: console}, nil
 }
 
-func (auth *AuthorizerService) GenerateQueryToken(runtimeID string, ownerID string) string {
+func (auth *AuthorizerService) GenerateQueryToken(runtimeID string, ownerID string, console string) string {
 	auth.ensureRuntimeKey()
+	tokenID, err := utils.GenerateRandomStringURLSafe(16)
+	if err != nil {
+		logger.Log().Error("failed to generate runtime query token id", zap.Error(err))
+		return ""
+	}
 	expires := time.Now().Add(queryTokenTTL)
 	claims := jwt.MapClaims{
 		"sub":        ownerID,
 		"runtime_id": runtimeID,
 		"scope":      "runtime",
+		"jti":        tokenID,
 		"exp":        expires.Unix(),
 		"iat":        time.Now().Unix(),
 	}
+	if console != "" {
+		claims["console"] = console
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	token.Header["kid"] = auth.keyID
 	signed, err := token.SignedString(auth.runtimeKey)
@@ -76,6 +76,33 @@ func TestAuthorizerService_CheckHeaderRejectsWrongSub(t *testing.T) {
 	}
 }
 
+func TestAuthorizerService_QueryTokenCarriesIDAndConsole(t *testing.T) {
+	authorizer, err := NewAuthorizer(nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := authorizer.GenerateQueryToken("game", "alice", "serve")
+	second := authorizer.GenerateQueryToken("game", "alice", "")
+	auth, err := authorizer.CheckQuery("game", first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.Subject != "alice" || auth.Console != "serve" || auth.TokenID == "" {
+		t.Fatalf("auth = %#v", auth)
+	}
+	other, err := authorizer.CheckQuery("game", second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Console != "" || other.TokenID == "" || other.TokenID == auth.TokenID {
+		t.Fatalf("second token = %#v, want its own id and no console", other)
+	}
+	if _, err := authorizer.CheckQuery("other", first); err == nil {
+		t.Fatal("a token of another runtime was accepted")
+	}
+}
+
 func checkAuthorizationHeader(authorizer interface {
 	CheckHeader(*fiber.Ctx) (*ports.AuthContext, error)
 }, token string) error {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/capture"
 	lua "github.com/highcard-dev/daemon/internal/core/services/coldstarter/handler"
 	"github.com/highcard-dev/daemon/internal/core/services/coldstarter/servers"
 	"github.com/highcard-dev/daemon/internal/utils/logger"
@@ -26,6 +27,11 @@ type ColdStarter struct {
 	queueManager ports.QueueManagerInterface
 	handlerMu    sync.Mutex
 	progress     *domain.SnapshotProgress
+	recorder     *capture.Recorder
+	udpConfig    servers.UDPConfig
+	startedAt    time.Time
+	wakeMu       sync.Mutex
+	wake         *domain.RuntimeWakeEvent
 }
 
 func NewColdStarter(
@@ -46,8 +52,37 @@ func NewColdStarter(
 	}
 }
 
+// SetRecorder records all traffic of the listeners started afterwards.
+func (c *ColdStarter) SetRecorder(recorder *capture.Recorder) {
+	c.recorder = recorder
+}
+
+// SetUDPConfig applies to the UDP listeners started afterwards.
+func (c *ColdStarter) SetUDPConfig(config servers.UDPConfig) {
+	c.udpConfig = config
+}
+
+// QueuedDatagrams returns the datagrams each UDP port queued before Finish,
+// keyed by port name. It is empty unless UDPConfig.QueueLimit is set.
+func (c *ColdStarter) QueuedDatagrams() map[string][]servers.Datagram {
+	c.handlerMu.Lock()
+	defer c.handlerMu.Unlock()
+	queued := map[string][]servers.Datagram{}
+	for name, server := range c.handler {
+		udp, ok := server.(*servers.UDP)
+		if !ok {
+			continue
+		}
+		if datagrams := udp.Queued(); len(datagrams) > 0 {
+			queued[name] = datagrams
+		}
+	}
+	return queued
+}
+
 func (c *ColdStarter) Start(ctx context.Context) chan *domain.AugmentedPort {
 	c.finishChan = make(chan *domain.AugmentedPort)
+	c.startedAt = time.Now()
 
 	go c.Serve(ctx)
 
@@ -85,17 +120,21 @@ func (c *ColdStarter) Serve(ctx context.Context) {
 			handler = lua.NewLuaHandler(c.queueManager, path, c.dir, port.ColdstarterVars, augmentedPortMap, c.progress)
 		}
 
+		if c.recorder != nil {
+			handler = capture.NewRecordingHandler(handler, c.recorder, port.Name)
+		}
+
 		c.chandlers = append(c.chandlers, handler)
 
-		finishFunc := func() {
-			c.Finish(port)
+		finishFunc := func(remote string) {
+			c.finish(port, remote)
 		}
 
 		var server ports.ColdStarterServerInterface
 		switch port.Protocol {
 		case "udp":
 			logger.Log().Info("Starting UDP coldstarter listener", zap.Int("port", port.Port.Port), zap.String("handler", port.ColdstarterHandler), zap.String("port_name", port.Name))
-			server = servers.NewUDP(handler)
+			server = servers.NewUDPWithConfig(handler, c.udpConfig)
 		case "tcp", "http", "https", "":
 			logger.Log().Info("Starting TCP coldstarter listener", zap.Int("port", port.Port.Port), zap.String("handler", port.ColdstarterHandler), zap.String("port_name", port.Name))
 			server = servers.NewTCP(handler)
@@ -134,17 +173,50 @@ func (c *ColdStarter) Stop() {
 	}
 }
 
+// Wake describes the traffic that finished the gate, or nil before Finish or
+// when Finish was not triggered by a port. ID and Source are left to the
+// caller.
+func (c *ColdStarter) Wake() *domain.RuntimeWakeEvent {
+	c.wakeMu.Lock()
+	defer c.wakeMu.Unlock()
+	if c.wake == nil {
+		return nil
+	}
+	wake := *c.wake
+	return &wake
+}
+
 func (c *ColdStarter) Finish(port *domain.AugmentedPort) {
+	c.finish(port, "")
+}
+
+func (c *ColdStarter) finish(port *domain.AugmentedPort, remote string) {
 	c.finishOnce.Do(func() {
 		now := time.Now()
 		c.finishTime = &now
+		if port != nil {
+			wake := &domain.RuntimeWakeEvent{
+				Port:          port.Name,
+				Protocol:      port.Protocol,
+				Handler:       port.ColdstarterHandler,
+				RemoteAddress: remote,
+				WokeAt:        now,
+			}
+			if !c.startedAt.IsZero() {
+				startedAt := c.startedAt
+				wake.ListeningSince = &startedAt
+			}
+			c.wakeMu.Lock()
+			c.wake = wake
+			c.wakeMu.Unlock()
+		}
 		for _, handler := range c.chandlers {
 			handler.SetFinishedAt(c.finishTime)
 		}
 		if port == nil {
 			logger.Log().Info("Received coldstarter finish signal")
 		} else {
-			logger.Log().Info("Coldstarter wake signal accepted", zap.Int("port", port.Port.Port), zap.String("protocol", port.Protocol), zap.String("port_name", port.Name), zap.String("handler", port.ColdstarterHandler))
+			logger.Log().Info("Coldstarter wake signal accepted", zap.Int("port", port.Port.Port), zap.String("protocol", port.Protocol), zap.String("port_name", port.Name), zap.String("handler", port.ColdstarterHandler), zap.String("address", remote))
 		}
 		c.finishChan <- port
 	})
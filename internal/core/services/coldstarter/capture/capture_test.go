@@ -0,0 +1,118 @@
+package capture
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	lua "github.com/highcard-dev/daemon/internal/core/services/coldstarter/handler"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+const echoHandler = `
+function handle(ctx, data)
+	if data == "wake" then
+		finish()
+		return
+	end
+	ctx.sendData("pong:" .. data)
+end
+`
+
+func recordSession(t *testing.T, root string, packets ...string) []byte {
+	t.Helper()
+	port := &domain.AugmentedPort{
+		Port:               domain.Port{Name: "game", Port: 27015, Protocol: "udp"},
+		ColdstarterHandler: "handler.lua",
+	}
+	var buf bytes.Buffer
+	recorder, err := NewRecorder(nopWriteCloser{&buf}, []*domain.AugmentedPort{port})
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	inner := lua.NewLuaHandler(nil, filepath.Join(root, "handler.lua"), root, nil, map[string]int{"game": 27015}, nil)
+	handler := NewRecordingHandler(inner, recorder, "game")
+	defer handler.Close()
+
+	funcs := map[string]func(data ...string){
+		"sendData": func(data ...string) {},
+		"finish":   func(data ...string) {},
+		"close":    func(data ...string) {},
+	}
+	for _, packet := range packets {
+		packetHandler, err := handler.GetHandler(funcs)
+		if err != nil {
+			t.Fatalf("GetHandler returned error: %v", err)
+		}
+		if err := packetHandler.Handle([]byte(packet), map[string]func(data ...string){"sendData": funcs["sendData"]}); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReplayMatchesRecordedOutputs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "handler.lua"), []byte(echoHandler), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data := recordSession(t, root, "ping", "wake")
+
+	report, err := Replay(bytes.NewReader(data), NewRootHandlerFactory(root))
+	if err != nil {
+		t.Fatalf("Replay returned error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("expected no mismatches, got %v", report.Mismatches)
+	}
+	if report.Sessions != 2 || report.Packets != 2 || report.Outputs != 2 {
+		t.Fatalf("unexpected report counts: %+v", report)
+	}
+}
+
+func TestReplayReportsChangedHandlerOutput(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "handler.lua")
+	if err := os.WriteFile(path, []byte(echoHandler), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data := recordSession(t, root, "ping")
+
+	changed := `
+function handle(ctx, data)
+	ctx.sendData("PONG:" .. data)
+end
+`
+	if err := os.WriteFile(path, []byte(changed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := Replay(bytes.NewReader(data), NewRootHandlerFactory(root))
+	if err != nil {
+		t.Fatalf("Replay returned error: %v", err)
+	}
+	if len(report.Mismatches) != 1 {
+		t.Fatalf("expected one mismatch, got %v", report.Mismatches)
+	}
+	mismatch := report.Mismatches[0]
+	if mismatch.Expected.Data != "pong:ping" || mismatch.Actual.Data != "PONG:ping" {
+		t.Fatalf("unexpected mismatch: %s", mismatch)
+	}
+}
+
+func TestReplayRejectsHandlerOutsideRoot(t *testing.T) {
+	header := `{"format":"druid-coldstarter-capture","version":1,"ports":[{"name":"game","port":1,"protocol":"tcp","handler":"../evil.lua"}]}` + "\n"
+	if _, err := Replay(bytes.NewBufferString(header), NewRootHandlerFactory(t.TempDir())); err == nil {
+		t.Fatal("expected handler path outside root to be rejected")
+	}
+}
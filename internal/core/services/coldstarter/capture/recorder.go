@@ -0,0 +1,132 @@
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const (
+	Format  = "druid-coldstarter-capture"
+	Version = 1
+)
+
+type Kind string
+
+const (
+	KindOpen   Kind = "open"
+	KindIn     Kind = "in"
+	KindOut    Kind = "out"
+	KindFinish Kind = "finish"
+	KindClose  Kind = "close"
+)
+
+// Header is the first line of a capture file. It carries enough of the
+// coldstarter port configuration to rebuild the same handlers offline.
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	StartedAt time.Time `json:"started_at"`
+	Ports     []Port    `json:"ports"`
+}
+
+type Port struct {
+	Name     string            `json:"name"`
+	Port     int               `json:"port"`
+	Protocol string            `json:"protocol"`
+	Handler  string            `json:"handler"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
+// Record is one captured packet or handler event. Session numbers are
+// assigned per port each time the server asks the handler for a packet
+// handler, which is once per TCP connection and once per UDP remote address
+// until its session times out.
+type Record struct {
+	Time    time.Time `json:"ts"`
+	Port    string    `json:"port"`
+	Session uint64    `json:"session"`
+	Kind    Kind      `json:"kind"`
+	Data    []byte    `json:"data,omitempty"`
+}
+
+// Recorder appends coldstarter traffic to a JSON lines capture file.
+type Recorder struct {
+	mu     sync.Mutex
+	out    io.WriteCloser
+	enc    *json.Encoder
+	closed bool
+}
+
+func NewRecorder(out io.WriteCloser, ports []*domain.AugmentedPort) (*Recorder, error) {
+	recorder := &Recorder{out: out, enc: json.NewEncoder(out)}
+	header := Header{
+		Format:    Format,
+		Version:   Version,
+		StartedAt: time.Now().UTC(),
+		Ports:     headerPorts(ports),
+	}
+	if err := recorder.enc.Encode(header); err != nil {
+		return nil, fmt.Errorf("write capture header: %w", err)
+	}
+	return recorder, nil
+}
+
+func CreateRecorder(path string, ports []*domain.AugmentedPort) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open capture file: %w", err)
+	}
+	recorder, err := NewRecorder(file, ports)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func headerPorts(ports []*domain.AugmentedPort) []Port {
+	out := make([]Port, 0, len(ports))
+	for _, port := range ports {
+		out = append(out, Port{
+			Name:     port.Name,
+			Port:     port.Port.Port,
+			Protocol: port.Protocol,
+			Handler:  port.ColdstarterHandler,
+			Vars:     port.ColdstarterVars,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (r *Recorder) Record(port string, session uint64, kind Kind, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return fmt.Errorf("capture recorder is closed")
+	}
+	return r.enc.Encode(Record{
+		Time:    time.Now().UTC(),
+		Port:    port,
+		Session: session,
+		Kind:    kind,
+		Data:    append([]byte(nil), data...),
+	})
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.out.Close()
+}
@@ -0,0 +1,81 @@
+package capture
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// RecordingHandler wraps a coldstarter handler and records every inbound
+// packet and every function the handler calls back into the server.
+type RecordingHandler struct {
+	inner    ports.ColdStarterHandlerInterface
+	recorder *Recorder
+	port     string
+	sessions atomic.Uint64
+}
+
+type recordingPacketHandler struct {
+	parent  *RecordingHandler
+	inner   ports.ColdStarterPacketHandlerInterface
+	session uint64
+}
+
+func NewRecordingHandler(inner ports.ColdStarterHandlerInterface, recorder *Recorder, port string) *RecordingHandler {
+	return &RecordingHandler{inner: inner, recorder: recorder, port: port}
+}
+
+func (h *RecordingHandler) GetHandler(funcs map[string]func(data ...string)) (ports.ColdStarterPacketHandlerInterface, error) {
+	session := h.sessions.Add(1)
+	h.record(session, KindOpen, nil)
+	inner, err := h.inner.GetHandler(h.wrap(session, funcs))
+	if err != nil {
+		return nil, err
+	}
+	return &recordingPacketHandler{parent: h, inner: inner, session: session}, nil
+}
+
+func (h *RecordingHandler) SetFinishedAt(finishedAt *time.Time) {
+	h.inner.SetFinishedAt(finishedAt)
+}
+
+func (h *RecordingHandler) Close() error {
+	return h.inner.Close()
+}
+
+func (h *RecordingHandler) wrap(session uint64, funcs map[string]func(data ...string)) map[string]func(data ...string) {
+	wrapped := make(map[string]func(data ...string), len(funcs))
+	for name, fn := range funcs {
+		fn := fn
+		kind := KindOut
+		switch name {
+		case "finish":
+			kind = KindFinish
+		case "close":
+			kind = KindClose
+		}
+		wrapped[name] = func(data ...string) {
+			var payload []byte
+			if len(data) > 0 {
+				payload = []byte(data[0])
+			}
+			h.record(session, kind, payload)
+			fn(data...)
+		}
+	}
+	return wrapped
+}
+
+func (h *RecordingHandler) record(session uint64, kind Kind, data []byte) {
+	if err := h.recorder.Record(h.port, session, kind, data); err != nil {
+		logger.Log().Warn("Failed to record coldstarter packet", zap.String("port_name", h.port), zap.Error(err))
+	}
+}
+
+func (p *recordingPacketHandler) Handle(data []byte, funcs map[string]func(data ...string)) error {
+	p.parent.record(p.session, KindIn, data)
+	return p.inner.Handle(data, p.parent.wrap(p.session, funcs))
+}
@@ -0,0 +1,248 @@
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	lua "github.com/highcard-dev/daemon/internal/core/services/coldstarter/handler"
+)
+
+// HandlerFactory builds the handler for one captured port. Replay uses
+// NewRootHandlerFactory by default; tests can substitute their own.
+type HandlerFactory func(port Port, portMap map[string]int) (ports.ColdStarterHandlerInterface, error)
+
+type Output struct {
+	Kind Kind   `json:"kind"`
+	Data string `json:"data,omitempty"`
+}
+
+func (o Output) String() string {
+	if o.Data == "" {
+		return string(o.Kind)
+	}
+	return fmt.Sprintf("%s %q", o.Kind, o.Data)
+}
+
+type Mismatch struct {
+	Port     string  `json:"port"`
+	Session  uint64  `json:"session"`
+	Index    int     `json:"index"`
+	Expected *Output `json:"expected,omitempty"`
+	Actual   *Output `json:"actual,omitempty"`
+}
+
+func (m Mismatch) String() string {
+	expected, actual := "<none>", "<none>"
+	if m.Expected != nil {
+		expected = m.Expected.String()
+	}
+	if m.Actual != nil {
+		actual = m.Actual.String()
+	}
+	return fmt.Sprintf("%s session %d output %d: expected %s, got %s", m.Port, m.Session, m.Index, expected, actual)
+}
+
+type Report struct {
+	Sessions   int        `json:"sessions"`
+	Packets    int        `json:"packets"`
+	Outputs    int        `json:"outputs"`
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+}
+
+func (r *Report) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// NewRootHandlerFactory resolves captured handlers the same way the
+// coldstarter does: "generic" or a Lua file below root.
+func NewRootHandlerFactory(root string) HandlerFactory {
+	return func(port Port, portMap map[string]int) (ports.ColdStarterHandlerInterface, error) {
+		if port.Handler == "generic" {
+			return lua.NewGenericReturnHandler(), nil
+		}
+		path := filepath.Join(root, filepath.Clean(port.Handler))
+		if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || filepath.IsAbs(rel) || strings.HasPrefix(rel, "../") {
+			return nil, fmt.Errorf("handler %s for port %s must be generic or a path below root", port.Handler, port.Name)
+		}
+		return lua.NewLuaHandler(nil, path, root, port.Vars, portMap, nil), nil
+	}
+}
+
+func ReadHeader(r *bufio.Reader) (Header, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return Header{}, fmt.Errorf("read capture header: %w", err)
+	}
+	var header Header
+	if err := json.Unmarshal(line, &header); err != nil {
+		return Header{}, fmt.Errorf("parse capture header: %w", err)
+	}
+	if header.Format != Format {
+		return Header{}, fmt.Errorf("unsupported capture format %q", header.Format)
+	}
+	if header.Version != Version {
+		return Header{}, fmt.Errorf("unsupported capture version %d", header.Version)
+	}
+	return header, nil
+}
+
+func ReplayFile(path string, root string) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Replay(file, NewRootHandlerFactory(root))
+}
+
+type replaySession struct {
+	port     string
+	id       uint64
+	handler  ports.ColdStarterPacketHandlerInterface
+	expected []Output
+	actual   []Output
+}
+
+// Replay feeds every captured inbound packet through freshly built handlers,
+// in capture order, and compares what the handlers send back with what was
+// recorded. Timing is not reproduced; packets are replayed back to back.
+func Replay(r io.Reader, factory HandlerFactory) (*Report, error) {
+	reader := bufio.NewReader(r)
+	header, err := ReadHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	portMap := make(map[string]int, len(header.Ports))
+	for _, port := range header.Ports {
+		portMap[port.Name] = port.Port
+	}
+	handlers := make(map[string]ports.ColdStarterHandlerInterface, len(header.Ports))
+	defer func() {
+		for _, handler := range handlers {
+			_ = handler.Close()
+		}
+	}()
+	for _, port := range header.Ports {
+		handler, err := factory(port, portMap)
+		if err != nil {
+			return nil, err
+		}
+		handlers[port.Name] = handler
+	}
+
+	report := &Report{}
+	sessions := map[string]*replaySession{}
+	order := []*replaySession{}
+	finished := false
+	collect := func(session *replaySession, kind Kind) func(data ...string) {
+		return func(data ...string) {
+			output := Output{Kind: kind}
+			if len(data) > 0 {
+				output.Data = data[0]
+			}
+			session.actual = append(session.actual, output)
+			if kind == KindFinish && !finished {
+				finished = true
+				now := time.Now()
+				for _, handler := range handlers {
+					handler.SetFinishedAt(&now)
+				}
+			}
+		}
+	}
+	funcs := func(session *replaySession) map[string]func(data ...string) {
+		return map[string]func(data ...string){
+			"sendData": collect(session, KindOut),
+			"finish":   collect(session, KindFinish),
+			"close":    collect(session, KindClose),
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var record Record
+		if err := decoder.Decode(&record); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("parse capture record: %w", err)
+		}
+		key := fmt.Sprintf("%s/%d", record.Port, record.Session)
+		session := sessions[key]
+		switch record.Kind {
+		case KindOpen:
+			handler, ok := handlers[record.Port]
+			if !ok {
+				return nil, fmt.Errorf("capture references unknown port %s", record.Port)
+			}
+			session = &replaySession{port: record.Port, id: record.Session}
+			packetHandler, err := handler.GetHandler(funcs(session))
+			if err != nil {
+				return nil, fmt.Errorf("%s session %d: %w", record.Port, record.Session, err)
+			}
+			session.handler = packetHandler
+			sessions[key] = session
+			order = append(order, session)
+			report.Sessions++
+		case KindIn:
+			if session == nil {
+				return nil, fmt.Errorf("%s session %d: packet before open", record.Port, record.Session)
+			}
+			report.Packets++
+			if err := session.handler.Handle(record.Data, map[string]func(data ...string){
+				"sendData": collect(session, KindOut),
+			}); err != nil {
+				return nil, fmt.Errorf("%s session %d: %w", record.Port, record.Session, err)
+			}
+		case KindOut, KindFinish, KindClose:
+			if session == nil {
+				return nil, fmt.Errorf("%s session %d: output before open", record.Port, record.Session)
+			}
+			session.expected = append(session.expected, Output{Kind: record.Kind, Data: string(record.Data)})
+			report.Outputs++
+		default:
+			return nil, fmt.Errorf("unsupported capture record kind %q", record.Kind)
+		}
+	}
+
+	for _, session := range order {
+		report.Mismatches = append(report.Mismatches, compareOutputs(session)...)
+	}
+	return report, nil
+}
+
+func compareOutputs(session *replaySession) []Mismatch {
+	var mismatches []Mismatch
+	count := len(session.expected)
+	if len(session.actual) > count {
+		count = len(session.actual)
+	}
+	for i := 0; i < count; i++ {
+		var expected, actual *Output
+		if i < len(session.expected) {
+			expected = &session.expected[i]
+		}
+		if i < len(session.actual) {
+			actual = &session.actual[i]
+		}
+		if expected != nil && actual != nil && *expected == *actual {
+			continue
+		}
+		mismatches = append(mismatches, Mismatch{
+			Port:     session.port,
+			Session:  session.id,
+			Index:    i,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+	return mismatches
+}
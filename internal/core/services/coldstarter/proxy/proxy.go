@@ -0,0 +1,24 @@
+// Package proxy keeps the coldstarter in front of the real server. Each
+// listener forwards to an internal target and counts bytes and sessions so
+// the daemon sees player traffic instead of container-level estimates.
+package proxy
+
+import "fmt"
+
+type Server interface {
+	Name() string
+	Start(port int) error
+	Stats() Snapshot
+	Close() error
+}
+
+func New(name string, protocol string, target string) (Server, error) {
+	switch protocol {
+	case "udp":
+		return NewUDP(name, target), nil
+	case "tcp", "http", "https", "":
+		return NewTCP(name, target), nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy protocol %q for port %s", protocol, name)
+	}
+}
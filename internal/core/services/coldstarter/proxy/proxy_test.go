@@ -0,0 +1,200 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTCPProxyForwardsAndCountsSessions(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	go func() {
+		for {
+			conn, err := backend.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	port := freePort(t, "tcp")
+	server := NewTCP("game", backend.Addr().String())
+	if err := server.Start(port); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	reply := make([]byte, 4)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		t.Fatal(err)
+	}
+	if string(reply) != "ping" {
+		t.Fatalf("reply = %q, want echoed ping", reply)
+	}
+	stats := server.Stats()
+	if stats.ActiveSessions != 1 || stats.TotalSessions != 1 || stats.RXBytes != 4 || stats.TXBytes != 4 || stats.LastActivityAt == nil {
+		t.Fatalf("stats with open connection = %+v", stats)
+	}
+
+	conn.Close()
+	waitFor(t, func() bool { return server.Stats().ActiveSessions == 0 })
+	if stats := server.Stats(); stats.TotalSessions != 1 {
+		t.Fatalf("total sessions = %d, want 1", stats.TotalSessions)
+	}
+}
+
+func TestUDPProxyRoutesRepliesPerClient(t *testing.T) {
+	backend, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := backend.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			_, _ = backend.WriteToUDP(append([]byte("re:"), buf[:n]...), addr)
+		}
+	}()
+
+	port := freePort(t, "udp")
+	server := NewUDP("query", backend.LocalAddr().String())
+	server.sessionTimeout = 200 * time.Millisecond
+	if err := server.Start(port); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	for _, payload := range []string{"a", "b"} {
+		client, err := net.Dial("udp", "127.0.0.1:"+strconv.Itoa(port))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		if _, err := client.Write([]byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+		reply := make([]byte, 16)
+		_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := client.Read(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(reply[:n]) != "re:"+payload {
+			t.Fatalf("reply = %q, want re:%s", reply[:n], payload)
+		}
+	}
+	if stats := server.Stats(); stats.ActiveSessions != 2 || stats.RXBytes != 2 || stats.TXBytes != 8 {
+		t.Fatalf("stats = %+v, want two sessions with 2 bytes in and 8 out", stats)
+	}
+	waitFor(t, func() bool { return server.Stats().ActiveSessions == 0 })
+}
+
+func TestUDPProxyDropsClientsBeyondSessionLimit(t *testing.T) {
+	backend, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	server := NewUDP("query", backend.LocalAddr().String())
+	server.maxSessions = 1
+	if _, err := server.session(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40001}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.session(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40001}); err != nil {
+		t.Fatalf("known client was dropped: %v", err)
+	}
+	if _, err := server.session(&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40002}); !errors.Is(err, errUDPSessionLimit) {
+		t.Fatalf("err = %v, want session limit", err)
+	}
+	_ = server.Close()
+}
+
+func TestUDPProxyDeliverWaitsForTarget(t *testing.T) {
+	targetPort := freePort(t, "udp")
+	server := NewUDP("query", "127.0.0.1:"+strconv.Itoa(targetPort))
+	if err := server.Start(freePort(t, "udp")); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	client := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 40000}
+	server.Deliver(client, [][]byte{[]byte("wake"), []byte("join")})
+
+	// The target binds only after the first attempts were refused.
+	time.Sleep(600 * time.Millisecond)
+	backend, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: targetPort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	received := []string{}
+	buf := make([]byte, 64)
+	_ = backend.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for len(received) == 0 || received[len(received)-1] != "join" {
+		n, _, err := backend.ReadFromUDP(buf)
+		if err != nil {
+			t.Fatalf("received %q before error: %v", received, err)
+		}
+		received = append(received, string(buf[:n]))
+	}
+	if received[0] != "wake" {
+		t.Fatalf("received = %q, want wake before join", received)
+	}
+	waitFor(t, func() bool { return server.Stats().RXBytes == 8 })
+}
+
+func freePort(t *testing.T, network string) int {
+	t.Helper()
+	switch network {
+	case "udp":
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		return conn.LocalAddr().(*net.UDPAddr).Port
+	default:
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer listener.Close()
+		return listener.Addr().(*net.TCPAddr).Port
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached before deadline")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,87 @@
+package proxy
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Snapshot is a copy of the counters of one proxied port. Byte counters are
+// cumulative; RX is client to server, TX is server to client.
+type Snapshot struct {
+	RXBytes        uint64
+	TXBytes        uint64
+	ActiveSessions int
+	TotalSessions  uint64
+	LastActivityAt *time.Time
+}
+
+type Stats struct {
+	mu           sync.Mutex
+	rxBytes      uint64
+	txBytes      uint64
+	active       int
+	total        uint64
+	lastActivity time.Time
+}
+
+func (s *Stats) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := Snapshot{
+		RXBytes:        s.rxBytes,
+		TXBytes:        s.txBytes,
+		ActiveSessions: s.active,
+		TotalSessions:  s.total,
+	}
+	if !s.lastActivity.IsZero() {
+		last := s.lastActivity
+		snapshot.LastActivityAt = &last
+	}
+	return snapshot
+}
+
+func (s *Stats) open() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active++
+	s.total++
+	s.lastActivity = time.Now()
+}
+
+func (s *Stats) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active > 0 {
+		s.active--
+	}
+}
+
+func (s *Stats) addRX(n int) {
+	s.add(&s.rxBytes, n)
+}
+
+func (s *Stats) addTX(n int) {
+	s.add(&s.txBytes, n)
+}
+
+func (s *Stats) add(counter *uint64, n int) {
+	if n <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*counter += uint64(n)
+	s.lastActivity = time.Now()
+}
+
+type countingWriter struct {
+	conn  io.Writer
+	count func(int)
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	n, err := w.conn.Write(p)
+	w.count(n)
+	return n, err
+}
@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const dialTimeout = 5 * time.Second
+
+// TCP accepts connections on the public port and pipes each one to target.
+// When the target refuses, the client connection is closed so it retries as
+// it would against a server that is still starting.
+type TCP struct {
+	name     string
+	target   string
+	stats    Stats
+	listener net.Listener
+	mu       sync.Mutex
+	conns    map[net.Conn]struct{}
+}
+
+func NewTCP(name string, target string) *TCP {
+	return &TCP{
+		name:   name,
+		target: target,
+		conns:  map[net.Conn]struct{}{},
+	}
+}
+
+func (t *TCP) Name() string {
+	return t.name
+}
+
+func (t *TCP) Stats() Snapshot {
+	return t.stats.Snapshot()
+}
+
+func (t *TCP) Start(port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to bind [%v]", err)
+	}
+	t.listener = listener
+	go t.accept(listener)
+	return nil
+}
+
+func (t *TCP) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				logger.Log().Info("TCP proxy stopped", zap.String("port_name", t.name))
+				return
+			}
+			logger.Log().Warn("Error accepting TCP proxy connection", zap.String("port_name", t.name), zap.Error(err))
+			continue
+		}
+		go t.forward(conn)
+	}
+}
+
+func (t *TCP) forward(client net.Conn) {
+	defer client.Close()
+	upstream, err := net.DialTimeout("tcp", t.target, dialTimeout)
+	if err != nil {
+		logger.Log().Warn("TCP proxy target unavailable", zap.String("port_name", t.name), zap.String("target", t.target), zap.Error(err))
+		return
+	}
+	defer upstream.Close()
+
+	t.track(client, upstream)
+	defer t.untrack(client, upstream)
+	t.stats.open()
+	defer t.stats.close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(countingWriter{conn: upstream, count: t.stats.addRX}, client)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(countingWriter{conn: client, count: t.stats.addTX}, upstream)
+		done <- struct{}{}
+	}()
+	// Either side hanging up ends the session; closing both unblocks the
+	// other copy.
+	<-done
+	client.Close()
+	upstream.Close()
+	<-done
+}
+
+func (t *TCP) track(conns ...net.Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, conn := range conns {
+		t.conns[conn] = struct{}{}
+	}
+}
+
+func (t *TCP) untrack(conns ...net.Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, conn := range conns {
+		delete(t.conns, conn)
+	}
+}
+
+func (t *TCP) Close() error {
+	var err error
+	if t.listener != nil {
+		if closeErr := t.listener.Close(); closeErr != nil && !errors.Is(closeErr, net.ErrClosed) {
+			err = fmt.Errorf("failed to close listener [%v]", closeErr)
+		}
+	}
+	t.mu.Lock()
+	for conn := range t.conns {
+		_ = conn.Close()
+	}
+	t.mu.Unlock()
+	return err
+}
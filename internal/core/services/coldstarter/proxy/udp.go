@@ -0,0 +1,245 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	udpSessionTimeout = 60 * time.Second
+	udpBufferSize     = 64 * 1024
+	// udpMaxSessions bounds the upstream sockets of one proxy. Source
+	// addresses are spoofable, so new clients are dropped beyond it.
+	udpMaxSessions = 4096
+	// deliverTimeout bounds how long Deliver waits for the target to bind.
+	deliverTimeout    = 2 * time.Minute
+	deliverProbeDelay = 250 * time.Millisecond
+)
+
+var errUDPSessionLimit = errors.New("too many UDP sessions")
+
+// UDP forwards datagrams per client address. Every client gets its own
+// upstream socket so replies can be routed back; a client counts as one
+// active session until it has been silent for the session timeout.
+type UDP struct {
+	name           string
+	target         string
+	stats          Stats
+	conn           *net.UDPConn
+	sessionTimeout time.Duration
+	maxSessions    int
+	mu             sync.Mutex
+	sessions       map[string]*udpSession
+}
+
+type udpSession struct {
+	client   *net.UDPAddr
+	upstream *net.UDPConn
+	mu       sync.Mutex
+	lastSeen time.Time
+	refused  atomic.Bool
+}
+
+func NewUDP(name string, target string) *UDP {
+	return &UDP{
+		name:           name,
+		target:         target,
+		sessionTimeout: udpSessionTimeout,
+		maxSessions:    udpMaxSessions,
+		sessions:       map[string]*udpSession{},
+	}
+}
+
+func (u *UDP) Name() string {
+	return u.name
+}
+
+func (u *UDP) Stats() Snapshot {
+	return u.stats.Snapshot()
+}
+
+func (u *UDP) Start(port int) error {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port, IP: net.IPv4zero})
+	if err != nil {
+		return fmt.Errorf("failed to bind [%v]", err)
+	}
+	u.conn = conn
+	go u.read(conn)
+	return nil
+}
+
+func (u *UDP) read(conn *net.UDPConn) {
+	buf := make([]byte, udpBufferSize)
+	for {
+		n, remoteAddr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				logger.Log().Info("UDP proxy stopped", zap.String("port_name", u.name))
+				return
+			}
+			logger.Log().Warn("Error reading from UDP proxy", zap.String("port_name", u.name), zap.Error(err))
+			continue
+		}
+		session, err := u.session(remoteAddr)
+		if errors.Is(err, errUDPSessionLimit) {
+			logger.Log().Debug("Dropping UDP datagram; session limit reached", zap.String("port_name", u.name), zap.String("address", remoteAddr.String()))
+			continue
+		}
+		if err != nil {
+			logger.Log().Warn("UDP proxy target unavailable", zap.String("port_name", u.name), zap.String("target", u.target), zap.Error(err))
+			continue
+		}
+		session.touch()
+		written, err := session.upstream.Write(buf[:n])
+		if err != nil {
+			logger.Log().Debug("UDP proxy write failed", zap.String("port_name", u.name), zap.Error(err))
+			continue
+		}
+		u.stats.addRX(written)
+	}
+}
+
+func (u *UDP) session(client *net.UDPAddr) (*udpSession, error) {
+	key := client.String()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if session := u.sessions[key]; session != nil {
+		return session, nil
+	}
+	if u.maxSessions > 0 && len(u.sessions) >= u.maxSessions {
+		return nil, errUDPSessionLimit
+	}
+	target, err := net.ResolveUDPAddr("udp", u.target)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := net.DialUDP("udp", nil, target)
+	if err != nil {
+		return nil, err
+	}
+	session := &udpSession{client: client, upstream: upstream, lastSeen: time.Now()}
+	u.sessions[key] = session
+	u.stats.open()
+	go u.reply(key, session)
+	return session, nil
+}
+
+// reply copies datagrams from the upstream socket back to the client until
+// the session expires or the proxy closes.
+func (u *UDP) reply(key string, session *udpSession) {
+	defer u.expire(key, session)
+	buf := make([]byte, udpBufferSize)
+	for {
+		_ = session.upstream.SetReadDeadline(time.Now().Add(u.sessionTimeout))
+		n, err := session.upstream.Read(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				if session.idleFor() < u.sessionTimeout {
+					continue
+				}
+				return
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			// ICMP port unreachable while the target starts; keep the session.
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				session.refused.Store(true)
+			}
+			continue
+		}
+		session.touch()
+		written, err := u.conn.WriteToUDP(buf[:n], session.client)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		u.stats.addTX(written)
+	}
+}
+
+// Deliver forwards datagrams client sent before the proxy started, for
+// example ones queued by the coldstart gate. The first datagram is resent
+// while the target answers with port unreachable, so nothing is lost while
+// the server is still binding.
+func (u *UDP) Deliver(client *net.UDPAddr, datagrams [][]byte) {
+	if len(datagrams) == 0 {
+		return
+	}
+	go func() {
+		deadline := time.Now().Add(deliverTimeout)
+		for {
+			session, err := u.session(client)
+			if err == nil {
+				session.touch()
+				session.refused.Store(false)
+				if _, err = session.upstream.Write(datagrams[0]); err == nil {
+					time.Sleep(deliverProbeDelay)
+					if !session.refused.Load() {
+						u.stats.addRX(len(datagrams[0]))
+						for _, data := range datagrams[1:] {
+							if written, err := session.upstream.Write(data); err == nil {
+								u.stats.addRX(written)
+							}
+						}
+						logger.Log().Info("Forwarded queued UDP datagrams", zap.String("port_name", u.name), zap.String("address", client.String()), zap.Int("datagrams", len(datagrams)))
+						return
+					}
+				}
+			}
+			if time.Now().After(deadline) {
+				logger.Log().Warn("Dropping queued UDP datagrams; proxy target did not come up", zap.String("port_name", u.name), zap.String("target", u.target), zap.Int("datagrams", len(datagrams)))
+				return
+			}
+			time.Sleep(deliverProbeDelay)
+		}
+	}()
+}
+
+func (u *UDP) expire(key string, session *udpSession) {
+	u.mu.Lock()
+	if u.sessions[key] == session {
+		delete(u.sessions, key)
+	}
+	u.mu.Unlock()
+	_ = session.upstream.Close()
+	u.stats.close()
+}
+
+func (s *udpSession) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSeen = time.Now()
+}
+
+func (s *udpSession) idleFor() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Since(s.lastSeen)
+}
+
+func (u *UDP) Close() error {
+	var err error
+	if u.conn != nil {
+		if closeErr := u.conn.Close(); closeErr != nil && !errors.Is(closeErr, net.ErrClosed) {
+			err = fmt.Errorf("failed to close UDP connection: %v", closeErr)
+		}
+	}
+	u.mu.Lock()
+	for _, session := range u.sessions {
+		_ = session.upstream.Close()
+	}
+	u.mu.Unlock()
+	return err
+}
@@ -19,7 +19,7 @@ type TCPServer interface {
 type TCP struct {
 	handler  ports.ColdStarterHandlerInterface
 	listener net.Listener
-	onFinish func()
+	onFinish func(remote string)
 }
 
 func NewTCP(handler ports.ColdStarterHandlerInterface) *TCP {
@@ -28,7 +28,7 @@ func NewTCP(handler ports.ColdStarterHandlerInterface) *TCP {
 	}
 }
 
-func (t *TCP) Start(port int, onFinish func()) error {
+func (t *TCP) Start(port int, onFinish func(remote string)) error {
 	ser, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return fmt.Errorf("failed to resolve address [%v]", err)
@@ -77,7 +77,7 @@ func (t *TCP) handleConnection(conn net.Conn) {
 		"finish": func(data ...string) {
 			logger.Log().Info("TCP coldstarter finish requested", zap.Strings("data", data), zap.String("address", conn.RemoteAddr().String()))
 			<-time.After(time.Second)
-			t.onFinish()
+			t.onFinish(conn.RemoteAddr().String())
 			<-time.After(time.Second)
 			conn.Close()
 		},
@@ -1,8 +1,10 @@
 package servers
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/highcard-dev/daemon/internal/core/ports"
@@ -10,23 +12,100 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	DefaultUDPMaxDatagramSize = 65535
+	DefaultUDPSessionTimeout  = time.Minute
+	DefaultUDPMaxSessions     = 1024
+	DefaultUDPQueueTotalLimit = 4096
+	// udpSessionBacklog bounds datagrams waiting for a busy session handler.
+	udpSessionBacklog = 64
+)
+
 type UDPServer interface {
 	Start(port int)
 }
 
+// UDPConfig tunes the UDP coldstarter. Zero values use the defaults; a zero
+// QueueLimit disables queueing. UDP source addresses are spoofable, so the
+// sessions and the queue are also bounded across all remote addresses.
+type UDPConfig struct {
+	// MaxDatagramSize is the largest datagram handed to the handler. Larger
+	// datagrams are dropped instead of truncated.
+	MaxDatagramSize int
+	// SessionTimeout ends a remote address's session after this much silence.
+	SessionTimeout time.Duration
+	// QueueLimit keeps up to this many datagrams per remote address received
+	// before Finish, so they can be forwarded once the server is up.
+	QueueLimit int
+	// QueueTotalLimit caps the queued datagrams of all remote addresses.
+	QueueTotalLimit int
+	// MaxSessions caps concurrent sessions. Datagrams from new remote
+	// addresses are dropped while it is reached.
+	MaxSessions int
+}
+
+func (c UDPConfig) withDefaults() UDPConfig {
+	if c.MaxDatagramSize <= 0 {
+		c.MaxDatagramSize = DefaultUDPMaxDatagramSize
+	}
+	if c.SessionTimeout <= 0 {
+		c.SessionTimeout = DefaultUDPSessionTimeout
+	}
+	if c.QueueLimit < 0 {
+		c.QueueLimit = 0
+	}
+	if c.QueueTotalLimit <= 0 {
+		c.QueueTotalLimit = DefaultUDPQueueTotalLimit
+	}
+	if c.MaxSessions <= 0 {
+		c.MaxSessions = DefaultUDPMaxSessions
+	}
+	return c
+}
+
+// Datagram is a queued packet from Remote, in arrival order.
+type Datagram struct {
+	Remote *net.UDPAddr
+	Data   []byte
+}
+
+// UDP runs one handler session per remote address. Datagrams of a session
+// are handled one at a time in arrival order; sessions run concurrently.
 type UDP struct {
 	handler  ports.ColdStarterHandlerInterface
+	config   UDPConfig
 	conn     *net.UDPConn
-	onFinish func()
+	onFinish func(remote string)
+	mu       sync.Mutex
+	sessions map[string]*udpSession
+	finished bool
+	queued   []Datagram
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+type udpSession struct {
+	remote   *net.UDPAddr
+	packets  chan []byte
+	done     chan struct{}
+	lastSeen time.Time
+	queued   int
 }
 
 func NewUDP(handler ports.ColdStarterHandlerInterface) *UDP {
+	return NewUDPWithConfig(handler, UDPConfig{})
+}
+
+func NewUDPWithConfig(handler ports.ColdStarterHandlerInterface, config UDPConfig) *UDP {
 	return &UDP{
-		handler: handler,
+		handler:  handler,
+		config:   config.withDefaults(),
+		sessions: map[string]*udpSession{},
+		done:     make(chan struct{}),
 	}
 }
 
-func (u *UDP) Start(port int, onFinish func()) error {
+func (u *UDP) Start(port int, onFinish func(remote string)) error {
 	addr := net.UDPAddr{
 		Port: port,
 		IP:   net.IPv4zero,
@@ -38,65 +117,155 @@ func (u *UDP) Start(port int, onFinish func()) error {
 	u.conn = conn
 	u.onFinish = onFinish
 
-	go func() {
-		buf := make([]byte, 1024)
-		for {
-			n, remoteAddr, err := u.conn.ReadFromUDP(buf)
-			if err != nil {
-				if opErr, ok := err.(*net.OpError); ok && opErr.Err.Error() == "use of closed network connection" {
-					logger.Log().Info("UDP Server stopped", zap.Error(err))
-					return
-				}
-				logger.Log().Warn("Error reading from UDP connection", zap.Error(err))
-				continue
-			}
+	go u.read()
+	go u.expireSessions()
+	return nil
+}
 
-			logger.Log().Info("UDP coldstarter packet received", zap.Int("bytes", n), zap.String("address", remoteAddr.String()))
-			go u.handleConnection(buf[:n], remoteAddr)
+func (u *UDP) read() {
+	// One spare byte tells oversized datagrams apart from ones that fit.
+	buf := make([]byte, u.config.MaxDatagramSize+1)
+	for {
+		n, remoteAddr, err := u.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				logger.Log().Info("UDP Server stopped")
+				return
+			}
+			logger.Log().Warn("Error reading from UDP connection", zap.Error(err))
+			continue
 		}
-	}()
+		if n > u.config.MaxDatagramSize {
+			logger.Log().Warn("Dropping oversized UDP coldstarter datagram", zap.Int("max_bytes", u.config.MaxDatagramSize), zap.String("address", remoteAddr.String()))
+			continue
+		}
+		logger.Log().Debug("UDP coldstarter packet received", zap.Int("bytes", n), zap.String("address", remoteAddr.String()))
+		u.dispatch(remoteAddr, append([]byte(nil), buf[:n]...))
+	}
+}
 
-	return nil
+func (u *UDP) dispatch(remoteAddr *net.UDPAddr, data []byte) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	key := remoteAddr.String()
+	session := u.sessions[key]
+	if session == nil {
+		if len(u.sessions) >= u.config.MaxSessions {
+			logger.Log().Debug("UDP coldstarter session limit reached; dropping datagram", zap.String("address", key))
+			return
+		}
+		session = &udpSession{
+			remote:  remoteAddr,
+			packets: make(chan []byte, udpSessionBacklog),
+			done:    make(chan struct{}),
+		}
+		u.sessions[key] = session
+		logger.Log().Info("UDP coldstarter session started", zap.String("address", key))
+		go u.serve(session)
+	}
+	session.lastSeen = time.Now()
+	if !u.finished && session.queued < u.config.QueueLimit && len(u.queued) < u.config.QueueTotalLimit {
+		u.queued = append(u.queued, Datagram{Remote: remoteAddr, Data: data})
+		session.queued++
+	}
+	select {
+	case session.packets <- data:
+	default:
+		logger.Log().Warn("UDP coldstarter session backlog full; dropping datagram", zap.String("address", key))
+	}
 }
 
-func (u *UDP) handleConnection(data []byte, remoteAddr *net.UDPAddr) {
+func (u *UDP) serve(session *udpSession) {
+	remote := session.remote
 	sendFunc := func(data ...string) {
 		if len(data) == 0 {
 			return
 		}
-		u.conn.WriteToUDP([]byte(data[0]), remoteAddr)
+		if _, err := u.conn.WriteToUDP([]byte(data[0]), remote); err != nil && !errors.Is(err, net.ErrClosed) {
+			logger.Log().Error("Error sending data", zap.Error(err))
+		}
 	}
-
 	handler, err := u.handler.GetHandler(map[string]func(data ...string){
+		"sendData": sendFunc,
 		"finish": func(data ...string) {
-			logger.Log().Info("UDP coldstarter finish requested", zap.Strings("data", data), zap.String("address", remoteAddr.String()))
+			logger.Log().Info("UDP coldstarter finish requested", zap.Strings("data", data), zap.String("address", remote.String()))
+			u.mu.Lock()
+			u.finished = true
+			u.mu.Unlock()
 			<-time.After(time.Second)
-			u.onFinish()
-			//closing connection here is not smart most likely
-			//<-time.After(time.Second)
-			//u.conn.Close()
-
+			u.onFinish(remote.String())
+		},
+		"close": func(data ...string) {
+			sendFunc(data...)
+			u.endSession(remote.String(), session)
 		},
 	})
-
 	if err != nil {
 		logger.Log().Error("Error getting handler", zap.Error(err))
+		u.endSession(remote.String(), session)
 		return
 	}
 
-	err = handler.Handle(data, map[string]func(data ...string){
-		"sendData": sendFunc,
-	})
+	for {
+		select {
+		case <-u.done:
+			return
+		case <-session.done:
+			return
+		case data := <-session.packets:
+			if err := handler.Handle(data, map[string]func(data ...string){
+				"sendData": sendFunc,
+			}); err != nil {
+				logger.Log().Error("Error handling packet", zap.Error(err))
+			}
+		}
+	}
+}
 
-	if err != nil {
-		logger.Log().Error("Error handling packet", zap.Error(err))
+func (u *UDP) expireSessions() {
+	ticker := time.NewTicker(u.config.SessionTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-u.done:
+			return
+		case now := <-ticker.C:
+			u.mu.Lock()
+			for key, session := range u.sessions {
+				if now.Sub(session.lastSeen) >= u.config.SessionTimeout {
+					delete(u.sessions, key)
+					close(session.done)
+					logger.Log().Debug("UDP coldstarter session expired", zap.String("address", key))
+				}
+			}
+			u.mu.Unlock()
+		}
 	}
 }
 
+func (u *UDP) endSession(key string, session *udpSession) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.sessions[key] == session {
+		delete(u.sessions, key)
+		close(session.done)
+	}
+}
+
+// Queued returns the datagrams received before Finish, in arrival order.
+func (u *UDP) Queued() []Datagram {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]Datagram(nil), u.queued...)
+}
+
 func (u *UDP) Close() error {
+	u.doneOnce.Do(func() {
+		close(u.done)
+	})
 	if u.conn != nil {
 		err := u.conn.Close()
-		if err != nil {
+		if err != nil && !errors.Is(err, net.ErrClosed) {
 			return fmt.Errorf("failed to close UDP connection: %v", err)
 		}
 
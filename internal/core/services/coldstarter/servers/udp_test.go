@@ -0,0 +1,229 @@
+package servers
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/ports"
+)
+
+// recordingHandler echoes every datagram and remembers what each session saw.
+type recordingHandler struct {
+	mu       sync.Mutex
+	sessions int
+	packets  [][]byte
+	funcs    []map[string]func(data ...string)
+}
+
+type recordingPacketHandler struct {
+	parent *recordingHandler
+}
+
+func (h *recordingHandler) GetHandler(funcs map[string]func(data ...string)) (ports.ColdStarterPacketHandlerInterface, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions++
+	h.funcs = append(h.funcs, funcs)
+	return &recordingPacketHandler{parent: h}, nil
+}
+
+func (h *recordingHandler) SetFinishedAt(*time.Time) {}
+
+func (h *recordingHandler) Close() error { return nil }
+
+func (h *recordingHandler) sessionCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sessions
+}
+
+func (h *recordingHandler) received() [][]byte {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([][]byte(nil), h.packets...)
+}
+
+func (p *recordingPacketHandler) Handle(data []byte, funcs map[string]func(data ...string)) error {
+	p.parent.mu.Lock()
+	p.parent.packets = append(p.parent.packets, data)
+	p.parent.mu.Unlock()
+	// A slow handler would expose reordering if packets ran concurrently.
+	time.Sleep(5 * time.Millisecond)
+	funcs["sendData"](string(data))
+	return nil
+}
+
+func startUDP(t *testing.T, handler *recordingHandler, config UDPConfig, onFinish func(string)) (*UDP, int) {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	conn.Close()
+	server := NewUDPWithConfig(handler, config)
+	if onFinish == nil {
+		onFinish = func(string) {}
+	}
+	if err := server.Start(port, onFinish); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	return server, port
+}
+
+func dialUDP(t *testing.T, port int) net.Conn {
+	t.Helper()
+	client, err := net.Dial("udp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached before deadline")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUDPHandlesLargeDatagramsInOrderPerSession(t *testing.T) {
+	handler := &recordingHandler{}
+	_, port := startUDP(t, handler, UDPConfig{}, nil)
+	client := dialUDP(t, port)
+
+	large := bytes.Repeat([]byte("x"), 4000)
+	payloads := [][]byte{large, []byte("1"), []byte("2"), []byte("3")}
+	for _, payload := range payloads {
+		if _, err := client.Write(payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reply := make([]byte, 8192)
+	for _, want := range payloads {
+		_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, err := client.Read(reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(reply[:n], want) {
+			t.Fatalf("reply = %d bytes %q..., want %d bytes", n, reply[:min(n, 8)], len(want))
+		}
+	}
+	if sessions := handler.sessionCount(); sessions != 1 {
+		t.Fatalf("sessions = %d, want one handler for one client", sessions)
+	}
+}
+
+func TestUDPDropsOversizedDatagrams(t *testing.T) {
+	handler := &recordingHandler{}
+	_, port := startUDP(t, handler, UDPConfig{MaxDatagramSize: 16}, nil)
+	client := dialUDP(t, port)
+
+	if _, err := client.Write(bytes.Repeat([]byte("x"), 17)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Write([]byte("fits")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(handler.received()) == 1 })
+	time.Sleep(50 * time.Millisecond)
+	if received := handler.received(); len(received) != 1 || string(received[0]) != "fits" {
+		t.Fatalf("received = %q, want only the datagram within the limit", received)
+	}
+}
+
+func TestUDPSessionsExpireAfterSilence(t *testing.T) {
+	handler := &recordingHandler{}
+	server, port := startUDP(t, handler, UDPConfig{SessionTimeout: 100 * time.Millisecond}, nil)
+	client := dialUDP(t, port)
+
+	if _, err := client.Write([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return handler.sessionCount() == 1 })
+	waitFor(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.sessions) == 0
+	})
+	if _, err := client.Write([]byte("b")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return handler.sessionCount() == 2 })
+}
+
+func TestUDPQueuesDatagramsUntilFinish(t *testing.T) {
+	handler := &recordingHandler{}
+	finished := make(chan string, 1)
+	server, port := startUDP(t, handler, UDPConfig{QueueLimit: 2}, func(remote string) { finished <- remote })
+	client := dialUDP(t, port)
+
+	for _, payload := range []string{"a", "b", "c"} {
+		if _, err := client.Write([]byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, func() bool { return len(handler.received()) == 3 })
+
+	handler.mu.Lock()
+	finish := handler.funcs[0]["finish"]
+	handler.mu.Unlock()
+	go finish()
+	waitFor(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return server.finished
+	})
+	if _, err := client.Write([]byte("d")); err != nil {
+		t.Fatal(err)
+	}
+	if remote := <-finished; remote != client.LocalAddr().String() {
+		t.Fatalf("finished by %s, want %s", remote, client.LocalAddr())
+	}
+
+	queued := server.Queued()
+	if len(queued) != 2 || string(queued[0].Data) != "a" || string(queued[1].Data) != "b" {
+		t.Fatalf("queued = %+v, want the first two datagrams before finish", queued)
+	}
+	if queued[0].Remote.String() != client.LocalAddr().String() {
+		t.Fatalf("queued remote = %s, want %s", queued[0].Remote, client.LocalAddr())
+	}
+}
+
+func TestUDPBoundsSessionsAndQueueAcrossRemotes(t *testing.T) {
+	handler := &recordingHandler{}
+	server, port := startUDP(t, handler, UDPConfig{QueueLimit: 2, QueueTotalLimit: 3, MaxSessions: 2}, nil)
+	clients := []net.Conn{dialUDP(t, port), dialUDP(t, port), dialUDP(t, port)}
+
+	for _, client := range clients[:2] {
+		for _, payload := range []string{"a", "b"} {
+			if _, err := client.Write([]byte(payload)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	waitFor(t, func() bool { return len(handler.received()) == 4 })
+	if _, err := clients[2].Write([]byte("c")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if sessions := handler.sessionCount(); sessions != 2 {
+		t.Fatalf("sessions = %d, want the third remote dropped", sessions)
+	}
+	if queued := server.Queued(); len(queued) != 3 {
+		t.Fatalf("queued = %d datagrams, want the total limit of 3", len(queued))
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// OIDCProvider is the part of an issuer's openid-configuration document the
+// daemon and the CLI use.
+type OIDCProvider struct {
+	Issuer                      string `json:"issuer"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	JWKSURI                     string `json:"jwks_uri"`
+}
+
+// DiscoverOIDCProvider fetches <issuer>/.well-known/openid-configuration and
+// checks that it describes issuer.
+func DiscoverOIDCProvider(ctx context.Context, client *http.Client, issuer string) (*OIDCProvider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	issuer = strings.TrimRight(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery for %s failed with status %d", issuer, res.StatusCode)
+	}
+	var provider OIDCProvider
+	if err := json.NewDecoder(res.Body).Decode(&provider); err != nil {
+		return nil, fmt.Errorf("OIDC discovery for %s: %w", issuer, err)
+	}
+	if strings.TrimRight(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery for %s returned issuer %q", issuer, provider.Issuer)
+	}
+	return &provider, nil
+}
+
+// OIDCAuthenticator verifies ID tokens of one issuer for one client.
+type OIDCAuthenticator struct {
+	issuer   string
+	clientID string
+	jwks     *keyfunc.JWKS
+}
+
+func NewOIDCAuthenticator(ctx context.Context, issuer string, clientID string) (*OIDCAuthenticator, error) {
+	provider, err := DiscoverOIDCProvider(ctx, nil, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if provider.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC issuer %s has no jwks_uri", issuer)
+	}
+	jwks, err := keyfunc.Get(provider.JWKSURI, keyfunc.Options{
+		Ctx:               ctx,
+		RefreshInterval:   time.Hour,
+		RefreshUnknownKID: true,
+		RefreshRateLimit:  time.Minute,
+		RefreshErrorHandler: func(err error) {
+			logger.Log().Error("Failed to refresh the OIDC JWKS", zap.String("issuer", issuer), zap.Error(err))
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWKS from %q: %w", provider.JWKSURI, err)
+	}
+	return &OIDCAuthenticator{issuer: provider.Issuer, clientID: clientID, jwks: jwks}, nil
+}
+
+// AuthenticateUser returns the subject of a valid ID token issued to the
+// configured client.
+func (a *OIDCAuthenticator) AuthenticateUser(ctx context.Context, token string) (string, error) {
+	parsed, err := jwt.Parse(token, a.jwks.Keyfunc)
+	if err != nil {
+		return "", fmt.Errorf("invalid OIDC token: %w", err)
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return "", errors.New("invalid OIDC token")
+	}
+	if !claims.VerifyIssuer(a.issuer, true) {
+		return "", errors.New("OIDC token was issued by another issuer")
+	}
+	if _, ok := claims["exp"]; !ok {
+		return "", errors.New("OIDC token has no expiry")
+	}
+	if a.clientID != "" && !claims.VerifyAudience(a.clientID, true) {
+		return "", errors.New("OIDC token was issued to another client")
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return "", errors.New("OIDC token has no subject")
+	}
+	return subject, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestOIDCAuthenticatorChecksIssuerAndClient(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var issuer *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer.URL, "jwks_uri": issuer.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{{
+			"kty": "RSA",
+			"kid": "k1",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		}}})
+	})
+	issuer = httptest.NewServer(mux)
+	defer issuer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	authenticator, err := NewOIDCAuthenticator(ctx, issuer.URL, "druid-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	subject, err := authenticator.AuthenticateUser(ctx, sign(jwt.MapClaims{"iss": issuer.URL, "aud": "druid-cli", "sub": "alice", "exp": exp}))
+	if err != nil || subject != "alice" {
+		t.Fatalf("subject = %q, err = %v", subject, err)
+	}
+	if subject, err := authenticator.AuthenticateUser(ctx, sign(jwt.MapClaims{"iss": issuer.URL, "aud": []string{"dashboard", "druid-cli"}, "azp": "dashboard", "sub": "bob", "exp": exp})); err != nil || subject != "bob" {
+		t.Fatalf("audience list subject = %q, err = %v", subject, err)
+	}
+	for name, claims := range map[string]jwt.MapClaims{
+		"other issuer": {"iss": "https://evil.example", "aud": "druid-cli", "sub": "alice", "exp": exp},
+		"other client": {"iss": issuer.URL, "aud": "dashboard", "sub": "alice", "exp": exp},
+		"azp only":     {"iss": issuer.URL, "aud": "dashboard", "azp": "druid-cli", "sub": "alice", "exp": exp},
+		"expired":      {"iss": issuer.URL, "aud": "druid-cli", "sub": "alice", "exp": time.Now().Add(-time.Minute).Unix()},
+		"no expiry":    {"iss": issuer.URL, "aud": "druid-cli", "sub": "alice"},
+		"no subject":   {"iss": issuer.URL, "aud": "druid-cli", "exp": exp},
+		"malformed":    nil,
+	} {
+		token := "not.a.jwt"
+		if claims != nil {
+			token = sign(claims)
+		}
+		if _, err := authenticator.AuthenticateUser(ctx, token); err == nil {
+			t.Errorf("%s: token was accepted", name)
+		}
+	}
+}
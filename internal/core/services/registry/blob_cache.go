@@ -0,0 +1,509 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	ocidigest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// DefaultBlobCacheSize caps the blob cache when no size is configured.
+	DefaultBlobCacheSize int64 = 10 << 30
+	// blobDownloadAttempts bounds how often one pull resumes a blob.
+	blobDownloadAttempts = 3
+	// partialMaxAge is how long an interrupted download is kept to resume.
+	partialMaxAge = 24 * time.Hour
+)
+
+// BlobCache is a content-addressed store of registry blobs shared by every
+// pull on the host, including Docker pull workers that mount it. Blobs are
+// named by digest and checked against it whenever they are opened, so a
+// corrupted or planted blob is evicted instead of served; the least recently
+// used ones are evicted once the cache grows past maxBytes. Interrupted
+// downloads stay as partial files and resume with HTTP Range; they count
+// against maxBytes and are removed once they are older than partialMaxAge.
+//
+// Everything in the cache belongs to the owner of its directory. A root
+// process, such as the daemon sharing the cache with its pull workers, hands
+// what it creates over to that owner instead of making it world writable.
+type BlobCache struct {
+	dir      string
+	maxBytes int64
+	evictMu  sync.Mutex
+	// uid and gid own new cache entries; -1 keeps the creating user.
+	uid, gid int
+}
+
+func NewBlobCache(dir string, maxBytes int64) (*BlobCache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("blob cache directory is required")
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBlobCacheSize
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	cache := &BlobCache{dir: dir, maxBytes: maxBytes, uid: -1, gid: -1}
+	if uid, gid, ok := fileOwner(dir); ok && os.Geteuid() == 0 && uid != 0 {
+		cache.uid, cache.gid = uid, gid
+	}
+	for _, sub := range []string{"blobs", "partial"} {
+		if err := cache.mkdirAll(filepath.Join(dir, sub)); err != nil {
+			return nil, err
+		}
+	}
+	return cache, nil
+}
+
+// mkdirAll creates path and its missing parents below the cache directory,
+// owned by the cache owner.
+func (c *BlobCache) mkdirAll(path string) error {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return nil
+	}
+	if parent := filepath.Dir(path); parent != path && parent != c.dir {
+		if err := c.mkdirAll(parent); err != nil {
+			return err
+		}
+	}
+	if err := os.Mkdir(path, 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return c.own(path)
+}
+
+// own hands path to the cache owner.
+func (c *BlobCache) own(path string) error {
+	if c.uid < 0 {
+		return nil
+	}
+	return os.Lchown(path, c.uid, c.gid)
+}
+
+// BlobCacheFromEnv configures the cache from DRUID_BLOB_CACHE_DIR and
+// DRUID_BLOB_CACHE_SIZE (a quantity like 20Gi). The directory defaults to
+// blob-cache under the runtime state dir; "off" disables caching.
+func BlobCacheFromEnv() (*BlobCache, error) {
+	dir := strings.TrimSpace(os.Getenv("DRUID_BLOB_CACHE_DIR"))
+	if dir == "off" {
+		return nil, nil
+	}
+	if dir == "" {
+		stateDir, err := utils.DefaultRuntimeStateDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(stateDir, "blob-cache")
+	}
+	size, err := ParseBlobCacheSize(os.Getenv("DRUID_BLOB_CACHE_SIZE"))
+	if err != nil {
+		return nil, err
+	}
+	return NewBlobCache(dir, size)
+}
+
+// ParseBlobCacheSize parses a Kubernetes style quantity. Empty means the
+// default size.
+func ParseBlobCacheSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultBlobCacheSize, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid blob cache size %q: %w", value, err)
+	}
+	if quantity.Value() <= 0 {
+		return 0, fmt.Errorf("blob cache size must be positive: %s", value)
+	}
+	return quantity.Value(), nil
+}
+
+func (c *BlobCache) Dir() string {
+	return c.dir
+}
+
+func (c *BlobCache) blobPath(digest ocidigest.Digest) string {
+	return filepath.Join(c.dir, "blobs", digest.Algorithm().String(), digest.Encoded())
+}
+
+func (c *BlobCache) partialPath(digest ocidigest.Digest) string {
+	return filepath.Join(c.dir, "partial", digest.Algorithm().String(), digest.Encoded())
+}
+
+// Open returns the cached blob, or nil when it is not cached. A blob whose
+// size or digest does not match desc is removed and reported as not cached.
+// Opening a blob marks it as recently used.
+func (c *BlobCache) Open(desc v1.Descriptor) (*os.File, error) {
+	return c.open(desc, true)
+}
+
+func (c *BlobCache) open(desc v1.Descriptor, verify bool) (*os.File, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, err
+	}
+	path := c.blobPath(desc.Digest)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil || info.Size() != desc.Size {
+		file.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if verify {
+		verifier := desc.Digest.Verifier()
+		_, err := io.Copy(verifier, file)
+		if err == nil {
+			_, err = file.Seek(0, io.SeekStart)
+		}
+		if err != nil || !verifier.Verified() {
+			file.Close()
+			logger.Log().Warn("Evicting cached blob that does not match its digest", zap.String("digest", desc.Digest.String()), zap.Error(err))
+			if removeErr := os.Remove(path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+				return nil, removeErr
+			}
+			return nil, nil
+		}
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return file, nil
+}
+
+// Fetch returns desc from the cache, downloading it from fetcher first when
+// needed. Concurrent fetches of one blob, also across processes, download it
+// once.
+func (c *BlobCache) Fetch(ctx context.Context, fetcher content.Fetcher, desc v1.Descriptor) (io.ReadCloser, error) {
+	if file, err := c.Open(desc); file != nil || err != nil {
+		return file, err
+	}
+	unlock, err := c.lock(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if file, err := c.Open(desc); file != nil || err != nil {
+		return file, err
+	}
+	var lastErr error
+	for attempt := 1; attempt <= blobDownloadAttempts; attempt++ {
+		if lastErr = c.download(ctx, fetcher, desc); lastErr == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return nil, lastErr
+		}
+		logger.Log().Warn("Blob download interrupted",
+			zap.String("digest", desc.Digest.String()),
+			zap.Int("attempt", attempt),
+			zap.Error(lastErr),
+		)
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	c.evict(desc.Digest)
+	// download verified the digest before moving the blob into place.
+	file, err := c.open(desc, false)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("blob %s vanished from cache", desc.Digest)
+	}
+	return file, nil
+}
+
+// download appends to the partial file of desc, seeking the registry stream
+// past what is already on disk, and moves the blob into place once its
+// digest checks out.
+func (c *BlobCache) download(ctx context.Context, fetcher content.Fetcher, desc v1.Descriptor) error {
+	partial := c.partialPath(desc.Digest)
+	if err := c.mkdirAll(filepath.Dir(partial)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := c.own(partial); err != nil {
+		return err
+	}
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if offset >= desc.Size {
+		offset = 0
+	}
+
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if offset > 0 {
+		seeker, ok := rc.(io.Seeker)
+		if ok {
+			_, err = seeker.Seek(offset, io.SeekStart)
+		}
+		if !ok || err != nil {
+			// oras only returns a seeker when the registry advertises
+			// Accept-Ranges, and Seek fails if the range is refused.
+			logger.Log().Info("Registry does not support range requests; restarting blob download", zap.String("digest", desc.Digest.String()))
+			offset = 0
+		} else {
+			logger.Log().Info("Resuming blob download",
+				zap.String("digest", desc.Digest.String()),
+				zap.Int64("offset", offset),
+				zap.Int64("size", desc.Size),
+			)
+		}
+	}
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+
+	verifier := desc.Digest.Verifier()
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(verifier, file, offset); err != nil {
+		return err
+	}
+	written, err := io.Copy(io.MultiWriter(file, verifier), io.LimitReader(rc, desc.Size-offset))
+	if err != nil {
+		return err
+	}
+	if offset+written != desc.Size {
+		return fmt.Errorf("blob %s: got %d of %d bytes", desc.Digest, offset+written, desc.Size)
+	}
+	if !verifier.Verified() {
+		_ = os.Remove(partial)
+		return fmt.Errorf("blob %s: %w", desc.Digest, content.ErrMismatchedDigest)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	target := c.blobPath(desc.Digest)
+	if err := c.mkdirAll(filepath.Dir(target)); err != nil {
+		return err
+	}
+	return os.Rename(partial, target)
+}
+
+// lock serializes downloads of one digest across goroutines and processes.
+func (c *BlobCache) lock(digest ocidigest.Digest) (func(), error) {
+	unlock, _, err := c.lockDigest(digest, true)
+	return unlock, err
+}
+
+// lockDigest takes the lock file of digest, waiting for it when wait is set
+// and otherwise reporting false when someone else holds it. Lock files are
+// removed together with abandoned partial downloads, so a lock taken on a
+// file that was removed meanwhile is retried on the new one.
+func (c *BlobCache) lockDigest(digest ocidigest.Digest, wait bool) (func(), bool, error) {
+	path := c.partialPath(digest) + ".lock"
+	if err := c.mkdirAll(filepath.Dir(path)); err != nil {
+		return nil, false, err
+	}
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := c.own(path); err != nil {
+			file.Close()
+			return nil, false, err
+		}
+		locked := true
+		if wait {
+			err = lockFile(file)
+		} else {
+			locked, err = tryLockFile(file)
+		}
+		if err != nil || !locked {
+			file.Close()
+			return nil, false, err
+		}
+		held, err := file.Stat()
+		current, statErr := os.Stat(path)
+		if err == nil && statErr == nil && os.SameFile(held, current) {
+			return func() {
+				_ = unlockFile(file)
+				file.Close()
+			}, true, nil
+		}
+		_ = unlockFile(file)
+		file.Close()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+}
+
+// removePartial removes the partial download of digest and its lock file
+// unless a download of it is running.
+func (c *BlobCache) removePartial(digest ocidigest.Digest) bool {
+	unlock, locked, err := c.lockDigest(digest, false)
+	if err != nil || !locked {
+		return false
+	}
+	defer unlock()
+	partial := c.partialPath(digest)
+	for _, path := range []string{partial, partial + ".lock"} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Log().Warn("Failed to remove partial blob", zap.String("path", path), zap.Error(err))
+			return false
+		}
+	}
+	return true
+}
+
+// evict removes partial downloads older than partialMaxAge, then the least
+// recently used blobs and partial downloads until the cache fits maxBytes.
+// keep is never evicted, so a blob larger than the cap still serves the
+// pull that fetched it. Partial downloads that are running are skipped.
+func (c *BlobCache) evict(keep ocidigest.Digest) {
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+		// partial is the digest of a partial download, removed with its
+		// lock file.
+		partial ocidigest.Digest
+	}
+	var entries []entry
+	var total int64
+	keepPath := c.blobPath(keep)
+	_ = filepath.WalkDir(filepath.Join(c.dir, "blobs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		if path != keepPath {
+			entries = append(entries, entry{path: path, size: info.Size(), modTime: info.ModTime()})
+		}
+		return nil
+	})
+	partials := map[ocidigest.Digest]*entry{}
+	_ = filepath.WalkDir(filepath.Join(c.dir, "partial"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		algorithm := ocidigest.Algorithm(filepath.Base(filepath.Dir(path)))
+		digest := ocidigest.NewDigestFromEncoded(algorithm, strings.TrimSuffix(d.Name(), ".lock"))
+		total += info.Size()
+		if digest == keep {
+			return nil
+		}
+		e := partials[digest]
+		if e == nil {
+			e = &entry{path: c.partialPath(digest), partial: digest}
+			partials[digest] = e
+		}
+		e.size += info.Size()
+		if info.ModTime().After(e.modTime) {
+			e.modTime = info.ModTime()
+		}
+		return nil
+	})
+	for _, e := range partials {
+		if time.Since(e.modTime) > partialMaxAge && c.removePartial(e.partial) {
+			total -= e.size
+			logger.Log().Debug("Removed abandoned partial blob", zap.String("path", e.path), zap.Int64("size", e.size))
+			continue
+		}
+		entries = append(entries, *e)
+	}
+	if total <= c.maxBytes {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.Before(entries[j].modTime) })
+	for _, e := range entries {
+		if total <= c.maxBytes {
+			break
+		}
+		if e.partial != "" {
+			if !c.removePartial(e.partial) {
+				continue
+			}
+		} else if err := os.Remove(e.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Log().Warn("Failed to evict cached blob", zap.String("path", e.path), zap.Error(err))
+			continue
+		}
+		total -= e.size
+		logger.Log().Debug("Evicted cached blob", zap.String("path", e.path), zap.Int64("size", e.size))
+	}
+}
+
+// cachedSource serves the blobs of a pull from the cache. Manifests always
+// come from the registry so tags resolve to their current content.
+type cachedSource struct {
+	oras.ReadOnlyTarget
+	cache *BlobCache
+}
+
+// Fetch serves desc from the cache once the source confirms it has the
+// blob. The cache is shared by every pull on the host, so without asking
+// the registry with this pull's credentials, a manifest naming the digest
+// of another tenant's private layer would be enough to read it.
+func (s cachedSource) Fetch(ctx context.Context, desc v1.Descriptor) (io.ReadCloser, error) {
+	if isManifestMediaType(desc.MediaType) {
+		return s.ReadOnlyTarget.Fetch(ctx, desc)
+	}
+	exists, err := s.ReadOnlyTarget.Exists(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", desc.Digest, errdef.ErrNotFound)
+	}
+	return s.cache.Fetch(ctx, s.ReadOnlyTarget, desc)
+}
+
+func isManifestMediaType(mediaType string) bool {
+	switch mediaType {
+	case v1.MediaTypeImageManifest, v1.MediaTypeImageIndex,
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json":
+		return true
+	}
+	return false
+}
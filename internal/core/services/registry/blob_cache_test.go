@@ -0,0 +1,326 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ocidigest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// blobServer serves a single blob and records the Range headers it sees.
+// With ranges disabled it neither advertises nor honors them.
+func blobServer(t *testing.T, data []byte, ranges bool) (*remote.Repository, v1.Descriptor, *[]string) {
+	t.Helper()
+	desc := v1.Descriptor{MediaType: "application/octet-stream", Digest: ocidigest.FromBytes(data), Size: int64(len(data))}
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/blobs/"+desc.Digest.String()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		seen = append(seen, r.Header.Get("Range"))
+		if ranges {
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	repo, err := remote.NewRepository(strings.TrimPrefix(srv.URL, "http://") + "/test/blobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.PlainHTTP = true
+	return repo, desc, &seen
+}
+
+func readCached(t *testing.T, cache *BlobCache, repo *remote.Repository, desc v1.Descriptor) []byte {
+	t.Helper()
+	rc, err := cache.Fetch(context.Background(), repo.Blobs(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBlobCacheResumesPartialDownload(t *testing.T) {
+	data := bytes.Repeat([]byte("druid-layer-"), 1000)
+	repo, desc, seen := blobServer(t, data, true)
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partial := cache.partialPath(desc.Digest)
+	if err := os.MkdirAll(filepath.Dir(partial), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial, data[:4000], 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readCached(t, cache, repo, desc); !bytes.Equal(got, data) {
+		t.Fatal("resumed blob does not match")
+	}
+	if len(*seen) != 2 || (*seen)[1] != "bytes=4000-11999" {
+		t.Fatalf("range requests = %q", *seen)
+	}
+	if _, err := os.Stat(partial); !os.IsNotExist(err) {
+		t.Fatalf("partial file should be moved into place, stat err = %v", err)
+	}
+}
+
+func TestBlobCacheRestartsWithoutRangeSupport(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 2048)
+	repo, desc, _ := blobServer(t, data, false)
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partial := cache.partialPath(desc.Digest)
+	if err := os.MkdirAll(filepath.Dir(partial), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial, []byte("stale"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readCached(t, cache, repo, desc); !bytes.Equal(got, data) {
+		t.Fatal("restarted blob does not match")
+	}
+}
+
+type failingFetcher struct{}
+
+func (failingFetcher) Fetch(context.Context, v1.Descriptor) (io.ReadCloser, error) {
+	return nil, errors.New("registry unreachable")
+}
+
+func TestBlobCacheServesCachedBlobsOffline(t *testing.T) {
+	data := []byte("cached layer")
+	repo, desc, _ := blobServer(t, data, true)
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readCached(t, cache, repo, desc)
+
+	rc, err := cache.Fetch(context.Background(), failingFetcher{}, desc)
+	if err != nil {
+		t.Fatalf("cached blob should not hit the registry: %v", err)
+	}
+	defer rc.Close()
+	if got, _ := io.ReadAll(rc); !bytes.Equal(got, data) {
+		t.Fatalf("cached blob = %q", got)
+	}
+}
+
+func TestBlobCacheEvictsPlantedBlob(t *testing.T) {
+	data := []byte("genuine layer")
+	repo, desc, seen := blobServer(t, data, true)
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := cache.blobPath(desc.Digest)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("planted layer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if file, err := cache.Open(desc); file != nil || err != nil {
+		t.Fatalf("Open served a blob that does not match its digest: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("planted blob was not evicted, stat err = %v", err)
+	}
+	if got := readCached(t, cache, repo, desc); !bytes.Equal(got, data) || len(*seen) != 1 {
+		t.Fatalf("refetched blob = %q after %d requests", got, len(*seen))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Fatalf("cached blob mode = %v, want 0644", info.Mode().Perm())
+	}
+}
+
+func TestBlobCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := NewBlobCache(t.TempDir(), 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descs := make([]v1.Descriptor, 3)
+	for i := range descs {
+		data := bytes.Repeat([]byte{byte('a' + i)}, 100)
+		repo, desc, _ := blobServer(t, data, true)
+		descs[i] = desc
+		readCached(t, cache, repo, desc)
+		// mtime resolution differs between filesystems.
+		old := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if err := os.Chtimes(cache.blobPath(desc.Digest), old, old); err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 {
+			// Touch the first blob so the second one is the oldest.
+			file, err := cache.Open(descs[0])
+			if err != nil || file == nil {
+				t.Fatalf("open first blob: %v", err)
+			}
+			file.Close()
+		}
+	}
+
+	for i, want := range []bool{true, false, true} {
+		_, err := os.Stat(cache.blobPath(descs[i].Digest))
+		if got := err == nil; got != want {
+			t.Fatalf("blob %d cached = %v, want %v", i, got, want)
+		}
+	}
+}
+
+type countingTransport struct {
+	blobGets atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/blobs/") {
+		c.blobGets.Add(1)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestPullSelectiveUsesBlobCache(t *testing.T) {
+	srv := fakeRegistry(t)
+	repo := strings.TrimPrefix(srv.URL, "http://") + "/test/cached"
+	transport := &countingTransport{}
+	client := &OciClient{
+		credentialStore: NewCredentialStore(nil),
+		httpClient:      &http.Client{Transport: transport},
+		plainHTTP:       true,
+		pullConcurrency: 2,
+	}
+	pushTestScroll(t, client, repo, "1.0.0")
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBlobCache(cache)
+
+	if err := client.PullSelective(filepath.Join(t.TempDir(), "first"), repo+":1.0.0", true, nil); err != nil {
+		t.Fatal(err)
+	}
+	firstGets := transport.blobGets.Load()
+	if firstGets == 0 {
+		t.Fatal("first pull should download blobs")
+	}
+	second := filepath.Join(t.TempDir(), "second")
+	if err := client.PullSelective(second, repo+":1.0.0", true, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := transport.blobGets.Load(); got != firstGets {
+		t.Fatalf("second pull downloaded %d blobs, want 0", got-firstGets)
+	}
+	if _, err := os.Stat(filepath.Join(second, "scroll.yaml")); err != nil {
+		t.Fatalf("scroll.yaml not materialized from cache: %v", err)
+	}
+}
+
+func TestCachedSourceRefusesBlobsTheRepositoryDoesNotHave(t *testing.T) {
+	data := []byte("private layer")
+	private, desc, _ := blobServer(t, data, true)
+	cache, err := NewBlobCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readCached(t, cache, private, desc)
+	other, _, _ := blobServer(t, []byte("other layer"), true)
+
+	if _, err := (cachedSource{ReadOnlyTarget: other, cache: cache}).Fetch(context.Background(), desc); !errors.Is(err, errdef.ErrNotFound) {
+		t.Fatalf("err = %v, want not found for a repository without the blob", err)
+	}
+	rc, err := (cachedSource{ReadOnlyTarget: private, cache: cache}).Fetch(context.Background(), desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if got, _ := io.ReadAll(rc); !bytes.Equal(got, data) {
+		t.Fatalf("cached blob = %q", got)
+	}
+}
+
+func TestBlobCacheEvictsAbandonedPartialDownloads(t *testing.T) {
+	cache, err := NewBlobCache(t.TempDir(), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	abandoned := ocidigest.FromString("abandoned")
+	recent := ocidigest.FromString("recent")
+	unrelated := ocidigest.FromString("unrelated")
+	for _, digest := range []ocidigest.Digest{abandoned, recent} {
+		partial := cache.partialPath(digest)
+		if err := cache.mkdirAll(filepath.Dir(partial)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(partial, bytes.Repeat([]byte("x"), 400), 0644); err != nil {
+			t.Fatal(err)
+		}
+		unlock, err := cache.lock(digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unlock()
+	}
+	old := time.Now().Add(-2 * partialMaxAge)
+	for _, path := range []string{cache.partialPath(abandoned), cache.partialPath(abandoned) + ".lock"} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache.evict(unrelated)
+	for _, path := range []string{cache.partialPath(abandoned), cache.partialPath(abandoned) + ".lock"} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("%s was not removed, stat err = %v", path, err)
+		}
+	}
+	if _, err := os.Stat(cache.partialPath(recent)); err != nil {
+		t.Fatalf("a recent partial download was removed: %v", err)
+	}
+
+	// A running download keeps its partial file even past the cap.
+	unlock, err := cache.lock(recent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.maxBytes = 100
+	cache.evict(unrelated)
+	if _, err := os.Stat(cache.partialPath(recent)); err != nil {
+		t.Fatalf("a running download was evicted: %v", err)
+	}
+	unlock()
+	cache.evict(unrelated)
+	if _, err := os.Stat(cache.partialPath(recent)); !os.IsNotExist(err) {
+		t.Fatalf("partial downloads should count against the cap, stat err = %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.uber.org/zap"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// BundleScrollRef is the reference the scroll manifest is tagged with inside
+// a bundle. Container images are tagged with their normalized reference.
+const BundleScrollRef = "scroll"
+
+type BundleOptions struct {
+	// Images also exports the container images of the scroll's container
+	// procedures.
+	Images bool
+	// Platform picks one manifest of multi-platform images, as os/arch or
+	// os/arch/variant.
+	Platform string
+}
+
+// Bundle describes an exported bundle.
+type Bundle struct {
+	Artifact string   `json:"artifact"`
+	Digest   string   `json:"digest"`
+	Images   []string `json:"images,omitempty"`
+}
+
+// ExportBundle copies artifact with all of its layers, including data, into
+// an OCI image layout and writes it to output as an uncompressed tarball.
+// The tarball can be used wherever an artifact reference is accepted.
+func (c *OciClient) ExportBundle(artifact string, output string, options BundleOptions) (Bundle, error) {
+	ctx := context.Background()
+	if domain.IsScrollBundle(artifact) {
+		return Bundle{}, fmt.Errorf("%s is already a bundle", artifact)
+	}
+	var platform *v1.Platform
+	if options.Images {
+		parsed, err := parsePlatform(options.Platform)
+		if err != nil {
+			return Bundle{}, err
+		}
+		platform = parsed
+	}
+	source, ref, err := c.openArtifact(ctx, artifact)
+	if err != nil {
+		return Bundle{}, err
+	}
+	layoutDir, err := os.MkdirTemp("", "druid-bundle-*")
+	if err != nil {
+		return Bundle{}, err
+	}
+	defer os.RemoveAll(layoutDir)
+	store, err := oci.New(layoutDir)
+	if err != nil {
+		return Bundle{}, err
+	}
+
+	copyOpts := oras.CopyOptions{CopyGraphOptions: oras.CopyGraphOptions{Concurrency: c.pullConcurrency}}
+	desc, err := oras.Copy(ctx, source, ref, store, BundleScrollRef, copyOpts)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("failed to copy %s: %w", artifact, err)
+	}
+	bundle := Bundle{Artifact: artifact, Digest: desc.Digest.String()}
+
+	if options.Images {
+		scrollYAML, err := fetchFileFromOCI(ctx, store, desc, "scroll.yaml")
+		if err != nil {
+			return Bundle{}, fmt.Errorf("failed to read scroll.yaml of %s: %w", artifact, err)
+		}
+		scroll, err := domain.NewScrollFromBytes("", scrollYAML)
+		if err != nil {
+			return Bundle{}, err
+		}
+		for _, image := range scroll.ContainerImages() {
+			imageRef, err := c.exportImage(ctx, store, image, platform)
+			if err != nil {
+				return Bundle{}, err
+			}
+			bundle.Images = append(bundle.Images, imageRef)
+		}
+	}
+
+	if err := writeLayoutTar(layoutDir, output); err != nil {
+		return Bundle{}, err
+	}
+	logger.Log().Info("Exported bundle",
+		zap.String("artifact", artifact),
+		zap.String("digest", bundle.Digest),
+		zap.Int("images", len(bundle.Images)),
+		zap.String("output", output),
+	)
+	return bundle, nil
+}
+
+func (c *OciClient) exportImage(ctx context.Context, store *oci.Store, image string, platform *v1.Platform) (string, error) {
+	imageRef := NormalizeImageRef(image)
+	repo, ref, _ := utils.ParseArtifactRef(imageRef)
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return "", err
+	}
+	copyOpts := oras.CopyOptions{CopyGraphOptions: oras.CopyGraphOptions{Concurrency: c.pullConcurrency}}
+	copyOpts.WithTargetPlatform(platform)
+	logger.Log().Info("Exporting container image", zap.String("image", imageRef))
+	if _, err := oras.Copy(ctx, repoInstance, ref, store, imageRef, copyOpts); err != nil {
+		return "", fmt.Errorf("failed to copy image %s: %w", imageRef, err)
+	}
+	return imageRef, nil
+}
+
+// NormalizeImageRef expands a container image reference the way docker pull
+// does: Docker Hub images get docker.io and library/, and a missing tag
+// becomes latest.
+func NormalizeImageRef(image string) string {
+	name := strings.TrimSpace(image)
+	first, _, found := strings.Cut(name, "/")
+	if !found {
+		name = "docker.io/library/" + name
+	} else if !strings.ContainsAny(first, ".:") && first != "localhost" {
+		name = "docker.io/" + name
+	}
+	last := name[strings.LastIndex(name, "/")+1:]
+	if !strings.ContainsAny(last, ":@") {
+		name += ":latest"
+	}
+	return name
+}
+
+// dockerHubEndpoint rewrites docker.io repositories to the registry host
+// Docker Hub actually serves. GetRepo applies it after the registry rewrites,
+// so rewrite rules can match docker.io.
+func dockerHubEndpoint(repo string) string {
+	if rest, ok := strings.CutPrefix(repo, "docker.io/"); ok {
+		return "registry-1.docker.io/" + rest
+	}
+	return repo
+}
+
+func parsePlatform(value string) (*v1.Platform, error) {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid platform %q, want os/arch[/variant]", value)
+	}
+	platform := &v1.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		platform.Variant = parts[2]
+	}
+	return platform, nil
+}
+
+// writeLayoutTar tars the OCI layout in dir to output. It writes next to
+// output first so an interrupted export leaves no partial bundle behind.
+func writeLayoutTar(dir string, output string) error {
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	tw := tar.NewWriter(tmp)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write bundle %s: %w", output, err)
+	}
+	return os.Rename(tmp.Name(), output)
+}
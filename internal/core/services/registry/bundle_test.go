@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+func TestExportBundleCanBePulledWithoutRegistry(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	srv := fakeRegistry(t)
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+
+	folder := filepath.Join("scrolls", "bundle")
+	if err := os.MkdirAll(filepath.Join(folder, "data"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	scrollYAML := []byte("name: test\nversion: 0.1.0\napp_version: \"1.0\"\n")
+	if err := os.WriteFile(filepath.Join(folder, "scroll.yaml"), scrollYAML, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "data", "world.dat"), []byte("world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &OciClient{
+		credentialStore: NewCredentialStore([]domain.RegistryCredential{}),
+		plainHTTP:       true,
+	}
+	repoRef := registryHost + "/test/scroll"
+	pushed, err := client.Push(folder, repoRef, "1.0", map[string]string{}, false, nil)
+	if err != nil {
+		t.Fatalf("Push failed unexpectedly: %v", err)
+	}
+
+	output := filepath.Join(tmpDir, "bundle.tar")
+	bundle, err := client.ExportBundle(repoRef+":1.0", output, BundleOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Digest != pushed.Digest.String() {
+		t.Fatalf("bundle digest = %s, want %s", bundle.Digest, pushed.Digest)
+	}
+	srv.Close()
+
+	if !domain.IsScrollBundle(output) {
+		t.Fatalf("%s is not recognized as a bundle", output)
+	}
+	digest, err := client.ResolveDigest(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != bundle.Digest {
+		t.Fatalf("bundle resolves to %s, want %s", digest, bundle.Digest)
+	}
+	pullDir := filepath.Join(tmpDir, "pulled")
+	if err := client.PullSelective(pullDir, output, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(pullDir, "data", "world.dat"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "world" {
+		t.Fatalf("data/world.dat = %q", data)
+	}
+}
+
+func TestNormalizeImageRef(t *testing.T) {
+	for image, want := range map[string]string{
+		"alpine":                          "docker.io/library/alpine:latest",
+		"alpine:3.20":                     "docker.io/library/alpine:3.20",
+		"itzg/minecraft-server:java21":    "docker.io/itzg/minecraft-server:java21",
+		"ghcr.io/org/app":                 "ghcr.io/org/app:latest",
+		"localhost/app:1":                 "localhost/app:1",
+		"registry.local:5000/app":         "registry.local:5000/app:latest",
+		"ghcr.io/org/app@sha256:abcd1234": "ghcr.io/org/app@sha256:abcd1234",
+	} {
+		if got := NormalizeImageRef(image); got != want {
+			t.Errorf("NormalizeImageRef(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+)
+
+// referrersTagPattern matches the tags registries without a referrers API
+// use to index signatures and other referrers.
+var referrersTagPattern = regexp.MustCompile(`^sha256-[0-9a-f]{64}(\..*)?$`)
+
+// CatalogEntry describes one tagged scroll artifact.
+type CatalogEntry struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest"`
+	domain.AnnotationInfo
+}
+
+// ListRepositories returns the repositories of a registry through the
+// distribution catalog API. prefix is a registry host, optionally followed by
+// a namespace that repositories must live under. Results carry the host.
+func (c *OciClient) ListRepositories(prefix string) ([]string, error) {
+	prefix = normalizeRegistryPrefix(prefix)
+	host := extractHost(prefix)
+	namespace := strings.TrimPrefix(strings.TrimPrefix(prefix, host), "/")
+
+	reg, err := c.GetRegistry(prefix)
+	if err != nil {
+		return nil, err
+	}
+	repos := []string{}
+	err = reg.Repositories(context.Background(), "", func(page []string) error {
+		for _, repo := range page {
+			if namespace == "" || repo == namespace || strings.HasPrefix(repo, namespace+"/") {
+				repos = append(repos, host+"/"+repo)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list repositories of %s: %w", host, err)
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// ListTags returns the tags of repo sorted with SortTags, leaving out
+// referrers index tags.
+func (c *OciClient) ListTags(repo string) ([]string, error) {
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{}
+	err = repoInstance.Tags(context.Background(), "", func(page []string) error {
+		for _, tag := range page {
+			if !referrersTagPattern.MatchString(tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tags of %s: %w", repo, err)
+	}
+	SortTags(tags)
+	return tags, nil
+}
+
+// DescribeTag reads the scroll annotations of repo:tag. Artifacts pushed
+// before name and versions were annotated fall back to their scroll.yaml.
+func (c *OciClient) DescribeTag(repo string, tag string) (CatalogEntry, error) {
+	ctx := context.Background()
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return CatalogEntry{}, err
+	}
+	desc, err := oras.Resolve(ctx, repoInstance, tag, oras.DefaultResolveOptions)
+	if err != nil {
+		return CatalogEntry{}, fmt.Errorf("failed to resolve %s:%s: %w", repo, tag, err)
+	}
+	data, err := content.FetchAll(ctx, repoInstance, desc)
+	if err != nil {
+		return CatalogEntry{}, fmt.Errorf("failed to fetch manifest for %s:%s: %w", repo, tag, err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return CatalogEntry{}, fmt.Errorf("failed to parse manifest for %s:%s: %w", repo, tag, err)
+	}
+	entry := CatalogEntry{
+		Repository:     repo,
+		Tag:            tag,
+		Digest:         desc.Digest.String(),
+		AnnotationInfo: domain.NewAnnotationInfo(manifest.Annotations),
+	}
+	if entry.Name == "" || entry.AppVersion == "" {
+		if scrollYAML, err := fetchFileFromOCI(ctx, repoInstance, desc, "scroll.yaml"); err == nil {
+			if scroll, err := domain.NewScrollFromBytes("", scrollYAML); err == nil {
+				entry.fillFromScroll(&scroll.File)
+			}
+		}
+	}
+	return entry, nil
+}
+
+func (e *CatalogEntry) fillFromScroll(file *domain.File) {
+	if e.Name == "" {
+		e.Name = file.Name
+	}
+	if e.AppVersion == "" {
+		e.AppVersion = file.AppVersion
+	}
+	if e.Version == "" && file.Version != nil {
+		e.Version = file.Version.String()
+	}
+	if len(e.Ports) == 0 && len(file.Ports) > 0 {
+		e.Ports = map[string]string{}
+		for _, port := range file.Ports {
+			e.Ports[port.Name] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+		}
+	}
+}
+
+// SortTags orders semver tags newest first, followed by other tags
+// alphabetically.
+func SortTags(tags []string) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, errA := semver.NewVersion(tags[i])
+		b, errB := semver.NewVersion(tags[j])
+		switch {
+		case errA == nil && errB == nil:
+			return a.GreaterThan(b)
+		case errA == nil:
+			return true
+		case errB == nil:
+			return false
+		}
+		return tags[i] < tags[j]
+	})
+}
+
+// HighestMatchingTag returns the newest semver tag satisfying constraint, or
+// "" when no tag does.
+func HighestMatchingTag(tags []string, constraint string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver constraint %q: %w", constraint, err)
+	}
+	var entry Entry
+	best := ""
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !c.Check(version) {
+			continue
+		}
+		if entry.Latest == nil {
+			entry, best = NewRegistryEntry(version), tag
+			continue
+		}
+		if next, newer := entry.Refresh(version); newer && !version.Equal(entry.Latest) {
+			entry, best = next, tag
+		}
+	}
+	return best, nil
+}
+
+// LatestTag picks the tag a catalog shows for a repository: "latest" when
+// present, otherwise the first tag in SortTags order.
+func LatestTag(tags []string) string {
+	for _, tag := range tags {
+		if tag == "latest" {
+			return tag
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	sorted := append([]string(nil), tags...)
+	SortTags(sorted)
+	return sorted[0]
+}
@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+func TestSortTagsOrdersSemverNewestFirst(t *testing.T) {
+	tags := []string{"beta", "1.2.0", "latest", "1.10.0", "0.9.1"}
+	SortTags(tags)
+	want := []string{"1.10.0", "1.2.0", "0.9.1", "beta", "latest"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Fatalf("tags = %v, want %v", tags, want)
+	}
+	if got := LatestTag([]string{"1.0.0", "latest"}); got != "latest" {
+		t.Fatalf("LatestTag = %q, want latest", got)
+	}
+	if got := LatestTag([]string{"1.0.0", "2.0.0"}); got != "2.0.0" {
+		t.Fatalf("LatestTag = %q, want 2.0.0", got)
+	}
+}
+
+func TestHighestMatchingTag(t *testing.T) {
+	tags := []string{"latest", "1.2.0", "1.2.9", "1.3.0", "1.2.10-rc.1", "2.0.0"}
+	for constraint, want := range map[string]string{
+		"~1.2":   "1.2.9",
+		"^1":     "1.3.0",
+		">=2":    "2.0.0",
+		"~3.0.0": "",
+	} {
+		got, err := HighestMatchingTag(tags, constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("HighestMatchingTag(%q) = %q, want %q", constraint, got, want)
+		}
+	}
+	if _, err := HighestMatchingTag(tags, "stable"); err == nil {
+		t.Fatal("expected invalid constraint error")
+	}
+}
+
+func TestCatalogListsRepositoriesAndDescribesTags(t *testing.T) {
+	srv := fakeRegistry(t)
+	host := strings.TrimPrefix(srv.URL, "http://")
+	client := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+
+	folder := filepath.Join(t.TempDir(), "scroll")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "scroll.yaml"), []byte("name: minecraft\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := &domain.File{Name: "minecraft", AppVersion: "1.21", Version: semver.MustParse("2.1.0")}
+	overrides := map[string]string{
+		domain.AnnotationScrollMinRam:              "4Gi",
+		domain.AnnotationScrollCategory:            "sandbox",
+		domain.AnnotationScrollPortPrefix + "main": "25565",
+	}
+	if _, err := client.Push(folder, host+"/games/minecraft", "2.1.0", overrides, false, file); err != nil {
+		t.Fatal(err)
+	}
+	pushTestScroll(t, client, host+"/games/terraria", "1.0.0")
+	pushTestScroll(t, client, host+"/tools/backup", "1.0.0")
+
+	repos, err := client.ListRepositories(host + "/games")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{host + "/games/minecraft", host + "/games/terraria"}; !reflect.DeepEqual(repos, want) {
+		t.Fatalf("repos = %v, want %v", repos, want)
+	}
+
+	tags, err := client.ListTags(host + "/games/minecraft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tags, []string{"2.1.0"}) {
+		t.Fatalf("tags = %v", tags)
+	}
+	entry, err := client.DescribeTag(host+"/games/minecraft", "2.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Name != "minecraft" || entry.AppVersion != "1.21" || entry.Version != "2.1.0" ||
+		entry.MinRam != "4Gi" || entry.Category != "sandbox" || entry.PortList() != "main=25565" {
+		t.Fatalf("entry = %+v", entry)
+	}
+
+	// Older artifacts without name annotations fall back to scroll.yaml.
+	legacy, err := client.DescribeTag(host+"/games/terraria", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Name != "test" || legacy.AppVersion != "1.0.0" {
+		t.Fatalf("legacy entry = %+v", legacy)
+	}
+}
@@ -0,0 +1,250 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// credentialHelperTokenUsername is the username a docker credential helper
+// returns when the secret is an identity token instead of a password.
+const credentialHelperTokenUsername = "<token>"
+
+// dockerHubServerURL is the key Docker uses for Docker Hub credentials.
+const dockerHubServerURL = "https://index.docker.io/v1/"
+
+// DockerConfig is the credential part of ~/.docker/config.json.
+type DockerConfig struct {
+	Auths       map[string]DockerAuthConfig `json:"auths"`
+	CredsStore  string                      `json:"credsStore,omitempty"`
+	CredHelpers map[string]string           `json:"credHelpers,omitempty"`
+}
+
+type DockerAuthConfig struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+type credentialHelperPayload struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// DefaultDockerConfigPath follows the docker CLI: $DOCKER_CONFIG/config.json,
+// else ~/.docker/config.json.
+func DefaultDockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// LoadDockerConfig reads a docker config file. A missing file is not an
+// error and yields nil.
+func LoadDockerConfig(path string) (*DockerConfig, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := &DockerConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse docker config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// HelperFor returns the credential helper docker would use for host.
+func (c *DockerConfig) HelperFor(host string) string {
+	if c == nil {
+		return ""
+	}
+	for _, key := range dockerConfigKeys(host) {
+		for configured, helper := range c.CredHelpers {
+			if normalizeRegistryPrefix(configured) == key {
+				return helper
+			}
+		}
+	}
+	return c.CredsStore
+}
+
+func (c *DockerConfig) credentialFor(host string) (auth.Credential, error) {
+	if c == nil {
+		return auth.EmptyCredential, nil
+	}
+	for _, key := range dockerConfigKeys(host) {
+		for configured, entry := range c.Auths {
+			if normalizeRegistryPrefix(configured) != key {
+				continue
+			}
+			cred, err := entry.credential()
+			if err != nil || cred != auth.EmptyCredential {
+				return cred, err
+			}
+		}
+	}
+	helper := c.HelperFor(host)
+	if helper == "" {
+		return auth.EmptyCredential, nil
+	}
+	serverURL := normalizeDockerHost(host)
+	if isDockerHub(serverURL) {
+		serverURL = dockerHubServerURL
+	}
+	return credentialHelperGet(helper, serverURL)
+}
+
+// hosts lists every registry host the config has credentials for.
+func (c *DockerConfig) hosts() []string {
+	if c == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(c.Auths)+len(c.CredHelpers))
+	for key := range c.Auths {
+		keys = append(keys, key)
+	}
+	for key := range c.CredHelpers {
+		keys = append(keys, key)
+	}
+	seen := map[string]bool{}
+	hosts := []string{}
+	for _, key := range keys {
+		host := normalizeDockerHost(key)
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+func (e DockerAuthConfig) credential() (auth.Credential, error) {
+	if e.IdentityToken != "" {
+		return auth.Credential{RefreshToken: e.IdentityToken}, nil
+	}
+	if e.RegistryToken != "" {
+		return auth.Credential{AccessToken: e.RegistryToken}, nil
+	}
+	username, password := e.Username, e.Password
+	if e.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(e.Auth)
+		if err != nil {
+			return auth.EmptyCredential, fmt.Errorf("decode docker auth: %w", err)
+		}
+		var ok bool
+		username, password, ok = strings.Cut(string(decoded), ":")
+		if !ok {
+			return auth.EmptyCredential, fmt.Errorf("docker auth is not user:password")
+		}
+	}
+	if username == "" || password == "" {
+		return auth.EmptyCredential, nil
+	}
+	return auth.Credential{Username: username, Password: password}, nil
+}
+
+func dockerConfigKeys(host string) []string {
+	host = normalizeDockerHost(host)
+	if isDockerHub(host) {
+		return []string{normalizeRegistryPrefix(dockerHubServerURL), "index.docker.io", "docker.io", "registry-1.docker.io"}
+	}
+	return []string{host}
+}
+
+func normalizeDockerHost(value string) string {
+	value = normalizeRegistryPrefix(value)
+	if isDockerHub(value) || strings.HasPrefix(value, "index.docker.io/") {
+		return "docker.io"
+	}
+	if idx := strings.Index(value, "/"); idx > 0 {
+		value = value[:idx]
+	}
+	return value
+}
+
+func isDockerHub(host string) bool {
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io", normalizeRegistryPrefix(dockerHubServerURL):
+		return true
+	}
+	return false
+}
+
+// credentialHelperGet runs "docker-credential-<helper> get". A helper that
+// has nothing stored for serverURL yields an empty credential.
+func credentialHelperGet(helper string, serverURL string) (auth.Credential, error) {
+	out, err := runCredentialHelper(helper, "get", serverURL)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "credentials not found") {
+			return auth.EmptyCredential, nil
+		}
+		return auth.EmptyCredential, err
+	}
+	var payload credentialHelperPayload
+	if err := json.Unmarshal(out, &payload); err != nil {
+		return auth.EmptyCredential, fmt.Errorf("credential helper %s returned invalid JSON: %w", helper, err)
+	}
+	if payload.Username == credentialHelperTokenUsername {
+		return auth.Credential{RefreshToken: payload.Secret}, nil
+	}
+	if payload.Username == "" || payload.Secret == "" {
+		return auth.EmptyCredential, nil
+	}
+	return auth.Credential{Username: payload.Username, Password: payload.Secret}, nil
+}
+
+// StoreWithCredentialHelper saves a login in the helper's backing store, such
+// as the OS keychain. An identity token is stored under the "<token>" user.
+func StoreWithCredentialHelper(helper string, serverURL string, cred auth.Credential) error {
+	payload := credentialHelperPayload{ServerURL: normalizeRegistryPrefix(serverURL), Username: cred.Username, Secret: cred.Password}
+	if cred.RefreshToken != "" {
+		payload.Username = credentialHelperTokenUsername
+		payload.Secret = cred.RefreshToken
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = runCredentialHelper(helper, "store", string(data))
+	return err
+}
+
+func runCredentialHelper(helper string, action string, input string) ([]byte, error) {
+	name := "docker-credential-" + helper
+	cmd := exec.Command(name, action)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s: %s", name, action, message)
+	}
+	return stdout.Bytes(), nil
+}
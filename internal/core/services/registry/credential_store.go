@@ -4,17 +4,27 @@ import (
 	"strings"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
 	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
 type CredentialStore struct {
 	registries []domain.RegistryCredential
+	// dockerConfig is consulted for repos without a configured registry.
+	dockerConfig *DockerConfig
 }
 
 func NewCredentialStore(registries []domain.RegistryCredential) *CredentialStore {
 	return &CredentialStore{registries: registries}
 }
 
+// SetDockerConfig falls back to docker's auths, credHelpers and credsStore
+// for registries druid has no credentials for.
+func (s *CredentialStore) SetDockerConfig(config *DockerConfig) {
+	s.dockerConfig = config
+}
+
 func (s *CredentialStore) CredentialForRepo(repoURL string) (auth.Credential, error) {
 	repoURL = normalizeRegistryPrefix(repoURL)
 
@@ -30,13 +40,26 @@ func (s *CredentialStore) CredentialForRepo(repoURL string) (auth.Credential, er
 		}
 	}
 
-	if bestMatch == nil || bestMatch.Username == "" || bestMatch.Password == "" {
-		return auth.EmptyCredential, nil
+	if bestMatch == nil {
+		return s.dockerConfig.credentialFor(repoURL)
 	}
+	return resolveRegistryCredential(*bestMatch)
+}
 
+func resolveRegistryCredential(reg domain.RegistryCredential) (auth.Credential, error) {
+	switch {
+	case reg.CredentialHelper != "":
+		return credentialHelperGet(reg.CredentialHelper, normalizeRegistryPrefix(reg.Host))
+	case reg.IdentityToken != "":
+		return auth.Credential{RefreshToken: reg.IdentityToken}, nil
+	case reg.RegistryToken != "":
+		return auth.Credential{AccessToken: reg.RegistryToken}, nil
+	case reg.Username == "" || reg.Password == "":
+		return auth.EmptyCredential, nil
+	}
 	return auth.Credential{
-		Username: bestMatch.Username,
-		Password: bestMatch.Password,
+		Username: reg.Username,
+		Password: reg.Password,
 	}, nil
 }
 
@@ -50,6 +73,87 @@ func (s *CredentialStore) Credentials() []domain.RegistryCredential {
 	return out
 }
 
+// ResolvedCredentials returns every known credential with helper secrets
+// filled in, for handing to a daemon that cannot run the local helpers.
+// Entries whose helper fails or has nothing stored are skipped.
+func (s *CredentialStore) ResolvedCredentials() []domain.RegistryCredential {
+	out := make([]domain.RegistryCredential, 0, len(s.registries))
+	known := map[string]bool{}
+	add := func(host string, cred auth.Credential, err error) {
+		if err != nil {
+			logger.Log().Warn("Failed to resolve registry credentials", zap.String("host", host), zap.Error(err))
+			return
+		}
+		if cred == auth.EmptyCredential {
+			return
+		}
+		out = append(out, domain.RegistryCredential{
+			Host:          host,
+			Username:      cred.Username,
+			Password:      cred.Password,
+			IdentityToken: cred.RefreshToken,
+			RegistryToken: cred.AccessToken,
+		})
+	}
+	for _, reg := range s.registries {
+		known[normalizeRegistryPrefix(reg.Host)] = true
+		cred, err := resolveRegistryCredential(reg)
+		add(reg.Host, cred, err)
+	}
+	for _, host := range s.dockerConfig.hosts() {
+		if known[host] {
+			continue
+		}
+		cred, err := s.dockerConfig.credentialFor(host)
+		add(host, cred, err)
+	}
+	return out
+}
+
+// ResolvedCredentialsFor returns the credential CredentialForRepo picks for
+// each of repos, with helper secrets filled in. Unlike ResolvedCredentials it
+// leaves out every other registry, so a remote daemon only learns the
+// secrets it needs for the pull.
+func (s *CredentialStore) ResolvedCredentialsFor(repos ...string) []domain.RegistryCredential {
+	out := []domain.RegistryCredential{}
+	seen := map[string]bool{}
+	for _, repo := range repos {
+		repo = normalizeRegistryPrefix(repo)
+		if repo == "" {
+			continue
+		}
+		host := normalizeDockerHost(repo)
+		bestLen := 0
+		for _, reg := range s.registries {
+			prefix := normalizeRegistryPrefix(reg.Host)
+			if strings.HasPrefix(repo, prefix) && len(prefix) > bestLen {
+				host = prefix
+				bestLen = len(prefix)
+			}
+		}
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		cred, err := s.CredentialForRepo(repo)
+		if err != nil {
+			logger.Log().Warn("Failed to resolve registry credentials", zap.String("host", host), zap.Error(err))
+			continue
+		}
+		if cred == auth.EmptyCredential {
+			continue
+		}
+		out = append(out, domain.RegistryCredential{
+			Host:          host,
+			Username:      cred.Username,
+			Password:      cred.Password,
+			IdentityToken: cred.RefreshToken,
+			RegistryToken: cred.AccessToken,
+		})
+	}
+	return out
+}
+
 func normalizeRegistryPrefix(value string) string {
 	value = strings.TrimSpace(value)
 	value = strings.TrimPrefix(value, "https://")
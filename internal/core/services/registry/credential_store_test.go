@@ -1,9 +1,14 @@
 package registry
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
+	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
 func TestCredentialForRepoNormalizesRegistryHost(t *testing.T) {
@@ -36,3 +41,119 @@ func TestCredentialForRepoUsesMostSpecificNormalizedMatch(t *testing.T) {
 		t.Fatalf("CredentialForRepo returned %#v, want most specific credential", cred)
 	}
 }
+
+// installCredentialHelper puts a docker-credential-<name> script on PATH that
+// answers get from a fixed payload and records store requests in a file.
+func installCredentialHelper(t *testing.T, name string, getPayload string) string {
+	t.Helper()
+	dir := t.TempDir()
+	stored := filepath.Join(dir, "stored.json")
+	script := "#!/bin/sh\n" +
+		"case \"$1\" in\n" +
+		"get) read server; if [ \"$server\" = \"" + "artifacts.druid.gg/user" + "\" ] || [ \"$server\" = \"ghcr.io\" ]; then printf '%s' '" + getPayload + "'; else echo 'credentials not found in native keychain'; exit 1; fi ;;\n" +
+		"store) cat > " + stored + " ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker-credential-"+name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return stored
+}
+
+func TestCredentialForRepoUsesCredentialHelper(t *testing.T) {
+	installCredentialHelper(t, "druidtest", `{"ServerURL":"artifacts.druid.gg/user","Username":"robot","Secret":"from-keychain"}`)
+	store := NewCredentialStore([]domain.RegistryCredential{{Host: "artifacts.druid.gg/user", CredentialHelper: "druidtest"}})
+
+	cred, err := store.CredentialForRepo("artifacts.druid.gg/user/scroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred.Username != "robot" || cred.Password != "from-keychain" {
+		t.Fatalf("CredentialForRepo returned %#v, want helper credential", cred)
+	}
+}
+
+func TestCredentialForRepoTreatsHelperTokenUserAsIdentityToken(t *testing.T) {
+	installCredentialHelper(t, "druidtest", `{"ServerURL":"ghcr.io","Username":"<token>","Secret":"refresh"}`)
+	store := NewCredentialStore(nil)
+	store.SetDockerConfig(&DockerConfig{CredHelpers: map[string]string{"ghcr.io": "druidtest"}})
+
+	cred, err := store.CredentialForRepo("ghcr.io/druid/scroll")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cred != (auth.Credential{RefreshToken: "refresh"}) {
+		t.Fatalf("CredentialForRepo returned %#v, want refresh token", cred)
+	}
+
+	cred, err = store.CredentialForRepo("quay.io/druid/scroll")
+	if err != nil || cred != auth.EmptyCredential {
+		t.Fatalf("unknown registry = %#v, %v; want anonymous", cred, err)
+	}
+}
+
+func TestCredentialForRepoReadsDockerConfigAuths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{"auths":{
+		"https://index.docker.io/v1/":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("hub-user:hub-pass")) + `"},
+		"registry.example.com":{"identitytoken":"id-token"}
+	}}`
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dockerConfig, err := LoadDockerConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewCredentialStore([]domain.RegistryCredential{{Host: "registry.example.com/team", RegistryToken: "bearer"}})
+	store.SetDockerConfig(dockerConfig)
+
+	for repo, want := range map[string]auth.Credential{
+		"docker.io/library/scroll":            {Username: "hub-user", Password: "hub-pass"},
+		"registry.example.com/other/scroll":   {RefreshToken: "id-token"},
+		"registry.example.com/team/my-scroll": {AccessToken: "bearer"},
+	} {
+		cred, err := store.CredentialForRepo(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cred != want {
+			t.Fatalf("CredentialForRepo(%s) = %#v, want %#v", repo, cred, want)
+		}
+	}
+
+	resolved := map[string]domain.RegistryCredential{}
+	for _, credential := range store.ResolvedCredentials() {
+		resolved[credential.Host] = credential
+	}
+	if resolved["docker.io"].Password != "hub-pass" || resolved["registry.example.com"].IdentityToken != "id-token" || resolved["registry.example.com/team"].RegistryToken != "bearer" {
+		t.Fatalf("ResolvedCredentials = %#v", resolved)
+	}
+
+	scoped := store.ResolvedCredentialsFor("registry.example.com/team/my-scroll", "registry.example.com/team/other")
+	if len(scoped) != 1 || scoped[0].Host != "registry.example.com/team" || scoped[0].RegistryToken != "bearer" {
+		t.Fatalf("ResolvedCredentialsFor(team) = %#v, want only the team credential", scoped)
+	}
+	scoped = store.ResolvedCredentialsFor("registry.example.com/other/scroll", "ghcr.io/unknown/scroll")
+	if len(scoped) != 1 || scoped[0].Host != "registry.example.com" || scoped[0].IdentityToken != "id-token" {
+		t.Fatalf("ResolvedCredentialsFor(other) = %#v, want only the docker config credential", scoped)
+	}
+}
+
+func TestStoreWithCredentialHelperSendsTokenUser(t *testing.T) {
+	stored := installCredentialHelper(t, "druidtest", `{}`)
+	if err := StoreWithCredentialHelper("druidtest", "https://artifacts.druid.gg/user/", auth.Credential{RefreshToken: "refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload credentialHelperPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload != (credentialHelperPayload{ServerURL: "artifacts.druid.gg/user", Username: "<token>", Secret: "refresh"}) {
+		t.Fatalf("stored = %s", data)
+	}
+}
@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+	"oras.land/oras-go/v2"
+)
+
+// PinImage resolves a container image to a digest reference. For
+// multi-platform images the digest is the one of the index, so the pin holds
+// on every platform. Images already pinned by digest are returned as is.
+func (c *OciClient) PinImage(image string) (string, error) {
+	imageRef := NormalizeImageRef(image)
+	repo, ref, _ := utils.ParseArtifactRef(imageRef)
+	if strings.HasPrefix(ref, "sha256:") {
+		return repo + "@" + ref, nil
+	}
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return "", err
+	}
+	desc, err := oras.Resolve(context.Background(), repoInstance, ref, oras.DefaultResolveOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image %s: %w", imageRef, err)
+	}
+	return repo + "@" + desc.Digest.String(), nil
+}
+
+// LockImages pins every container image of file.
+func (c *OciClient) LockImages(file *domain.File) (*domain.ImageLock, error) {
+	lock := &domain.ImageLock{Images: map[string]string{}}
+	for _, image := range file.ContainerImages() {
+		pinned, err := c.PinImage(image)
+		if err != nil {
+			return nil, err
+		}
+		logger.Log().Info("Pinned container image", zap.String("image", image), zap.String("digest", pinned))
+		lock.Images[image] = pinned
+	}
+	return lock, nil
+}
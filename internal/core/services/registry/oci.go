@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -32,6 +34,7 @@ import (
 
 const annotationDruidFileMode = "gg.druid.file.mode"
 const chmodModeMask = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+const defaultPullConcurrency = 4
 
 type OciClient struct {
 	credentialStore *CredentialStore
@@ -42,15 +45,36 @@ type OciClient struct {
 	plainHTTP bool
 	// disableTarReproducible opts out of reproducible tar layers for pushes.
 	disableTarReproducible bool
+	// blobCache serves pulled blobs that were fetched before. When
+	// blobCacheFromEnv is set it is configured on first pull.
+	blobCache        *BlobCache
+	blobCacheFromEnv bool
+	blobCacheOnce    sync.Once
+	// pullConcurrency bounds parallel layer downloads; zero uses the oras default.
+	pullConcurrency int
+	// registryConfig holds mirrors, rewrites and per-host connection settings.
+	registryConfig domain.RegistryConfig
+	hostClientsMu  sync.Mutex
+	hostClients    map[string]*http.Client
 }
 
 func NewOciClient(credentialStore *CredentialStore) *OciClient {
 	return &OciClient{
-		credentialStore: credentialStore,
-		plainHTTP:       plainHTTPFromEnv(),
+		credentialStore:  credentialStore,
+		plainHTTP:        plainHTTPFromEnv(),
+		blobCacheFromEnv: true,
+		pullConcurrency:  pullConcurrencyFromEnv(),
 	}
 }
 
+func pullConcurrencyFromEnv() int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv("DRUID_PULL_CONCURRENCY")))
+	if err != nil || value <= 0 {
+		return defaultPullConcurrency
+	}
+	return value
+}
+
 func plainHTTPFromEnv() bool {
 	value := strings.ToLower(strings.TrimSpace(os.Getenv("DRUID_REGISTRY_PLAIN_HTTP")))
 	return value == "1" || value == "true" || value == "yes"
@@ -61,6 +85,33 @@ func (c *OciClient) DisableTarReproducible() {
 	c.disableTarReproducible = true
 }
 
+// SetBlobCache replaces the environment configured blob cache. nil disables
+// caching.
+func (c *OciClient) SetBlobCache(cache *BlobCache) {
+	c.blobCache = cache
+	c.blobCacheFromEnv = false
+}
+
+// SetPullConcurrency sets how many layers a pull downloads at once.
+func (c *OciClient) SetPullConcurrency(n int) {
+	c.pullConcurrency = n
+}
+
+func (c *OciClient) cache() *BlobCache {
+	c.blobCacheOnce.Do(func() {
+		if !c.blobCacheFromEnv {
+			return
+		}
+		cache, err := BlobCacheFromEnv()
+		if err != nil {
+			logger.Log().Warn("Blob cache disabled", zap.Error(err))
+			return
+		}
+		c.blobCache = cache
+	})
+	return c.blobCache
+}
+
 func (c *OciClient) newFileStore(root string) (*file.Store, error) {
 	fs, err := file.New(root)
 	if err != nil {
@@ -70,38 +121,79 @@ func (c *OciClient) newFileStore(root string) (*file.Store, error) {
 	return fs, nil
 }
 
+// GetRepo returns a client for repoUrl after applying the registry rewrites.
 func (c *OciClient) GetRepo(repoUrl string) (*remote.Repository, error) {
+	repoUrl = dockerHubEndpoint(c.registryConfig.Rewrite(repoUrl))
 	repo, err := remote.NewRepository(repoUrl)
 	if err != nil {
 		return nil, err
 	}
 
-	repo.PlainHTTP = c.plainHTTP
+	repo.PlainHTTP = c.plainHTTPFor(repoUrl)
+	client, err := c.remoteClient(repoUrl)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		repo.Client = client
+	}
+
+	return repo, nil
+}
+
+// GetRegistry returns a client for the registry hosting prefix, using the
+// credentials that match prefix.
+func (c *OciClient) GetRegistry(prefix string) (*remote.Registry, error) {
+	prefix = c.registryConfig.Rewrite(prefix)
+	reg, err := remote.NewRegistry(extractHost(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	reg.PlainHTTP = c.plainHTTPFor(prefix)
+	client, err := c.remoteClient(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		reg.Client = client
+	}
+
+	return reg, nil
+}
 
-	httpClient := retry.DefaultClient
-	if c.httpClient != nil {
-		httpClient = c.httpClient
+// remoteClient returns the authenticating client for repoUrl, or nil when
+// the oras default client should be used.
+func (c *OciClient) remoteClient(repoUrl string) (remote.Client, error) {
+	httpClient, err := c.hostClient(extractHost(repoUrl))
+	if err != nil {
+		return nil, err
+	}
+	custom := httpClient != nil
+	if !custom {
+		httpClient = retry.DefaultClient
 	}
 
-	cred, _ := c.credentialStore.CredentialForRepo(repoUrl)
-	if cred.Username == "" || cred.Password == "" {
+	cred, err := c.credentialStore.CredentialForRepo(repoUrl)
+	if err != nil {
+		logger.Log().Warn("Failed to resolve registry credentials for "+repoUrl, zap.Error(err))
+	}
+	if cred == auth.EmptyCredential {
 		logger.Log().Warn("No registry credentials found for " + repoUrl + ". Trying to pull anonymously")
-		if c.httpClient != nil {
-			repo.Client = &auth.Client{
+		if custom {
+			return &auth.Client{
 				Client: httpClient,
 				Cache:  auth.DefaultCache,
-			}
-		}
-	} else {
-		host := extractHost(repoUrl)
-		repo.Client = &auth.Client{
-			Client:     httpClient,
-			Cache:      auth.DefaultCache,
-			Credential: auth.StaticCredential(host, cred),
+			}, nil
 		}
+		return nil, nil
 	}
-
-	return repo, nil
+	host := extractHost(repoUrl)
+	return &auth.Client{
+		Client:     httpClient,
+		Cache:      auth.DefaultCache,
+		Credential: auth.StaticCredential(host, cred),
+	}, nil
 }
 
 // checkPushAccess triggers the OCI auth challenge-response flow against
@@ -132,7 +224,7 @@ func extractHost(repoUrl string) string {
 	return repoUrl
 }
 
-func ValidateCredentials(host, username, password string) error {
+func ValidateCredentials(host string, cred auth.Credential) error {
 	registryHost := extractHost(host)
 
 	reg, err := remote.NewRegistry(registryHost)
@@ -142,12 +234,9 @@ func ValidateCredentials(host, username, password string) error {
 
 	reg.PlainHTTP = plainHTTPFromEnv()
 	reg.Client = &auth.Client{
-		Client: retry.DefaultClient,
-		Cache:  auth.DefaultCache,
-		Credential: auth.StaticCredential(registryHost, auth.Credential{
-			Username: username,
-			Password: password,
-		}),
+		Client:     retry.DefaultClient,
+		Cache:      auth.DefaultCache,
+		Credential: auth.StaticCredential(registryHost, cred),
 	}
 
 	if err := reg.Ping(context.Background()); err != nil {
@@ -161,26 +250,42 @@ func (c *OciClient) Pull(dir string, artifact string) error {
 	return c.PullSelective(dir, artifact, true, nil)
 }
 
-func (c *OciClient) PullSelective(dir string, artifact string, includeData bool, progress *domain.SnapshotProgress) error {
-
+// openArtifact returns the target holding artifact and the reference to
+// resolve in it: the registry repository, or the OCI layout inside a scroll
+// bundle.
+func (c *OciClient) openArtifact(ctx context.Context, artifact string) (oras.ReadOnlyTarget, string, error) {
+	if domain.IsScrollBundle(artifact) {
+		bundle, err := oci.NewFromTar(ctx, artifact)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open bundle %s: %w", artifact, err)
+		}
+		return bundle, BundleScrollRef, nil
+	}
 	repo, ref, _ := utils.ParseArtifactRef(artifact)
 	if repo == "" || ref == "" {
-		return fmt.Errorf("reference (tag or digest) must be set")
+		return nil, "", fmt.Errorf("reference (tag or digest) must be set")
 	}
+	repoInstance, err := c.readRepo(ctx, repo, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	return repoInstance, ref, nil
+}
 
-	logger.Log().Info("Starting pull from registry",
-		zap.String("repo", repo),
-		zap.String("ref", ref),
-		zap.Bool("includeData", includeData),
-	)
-
+func (c *OciClient) PullSelective(dir string, artifact string, includeData bool, progress *domain.SnapshotProgress) error {
 	ctx := context.Background()
 
-	repoInstance, err := c.GetRepo(repo)
+	target, ref, err := c.openArtifact(ctx, artifact)
 	if err != nil {
 		return err
 	}
 
+	logger.Log().Info("Starting pull",
+		zap.String("artifact", artifact),
+		zap.String("ref", ref),
+		zap.Bool("includeData", includeData),
+	)
+
 	err = os.MkdirAll(dir, 0755)
 	if err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
@@ -203,8 +308,16 @@ func (c *OciClient) PullSelective(dir string, artifact string, includeData bool,
 		progress.Percentage.Store(0)
 	}
 
+	source := target
+	if _, remote := target.(*remote.Repository); remote {
+		if cache := c.cache(); cache != nil {
+			source = cachedSource{ReadOnlyTarget: target, cache: cache}
+		}
+	}
+
 	copyOpts := oras.CopyOptions{
 		CopyGraphOptions: oras.CopyGraphOptions{
+			Concurrency: c.pullConcurrency,
 			FindSuccessors: func(ctx context.Context, fetcher content.Fetcher, desc v1.Descriptor) ([]v1.Descriptor, error) {
 				successors, err := content.Successors(ctx, fetcher, desc)
 				if err != nil {
@@ -300,7 +413,7 @@ func (c *OciClient) PullSelective(dir string, artifact string, includeData bool,
 	// Use a constant destination reference for the local file store so digest references
 	// (which contain ':' and other characters) don't become a tag key.
 	const dstRef = "root"
-	manifestDescriptor, err := oras.Copy(ctx, repoInstance, ref, fs, dstRef, copyOpts)
+	manifestDescriptor, err := oras.Copy(ctx, source, ref, fs, dstRef, copyOpts)
 	stopProgress()
 	if err != nil {
 		if progress != nil {
@@ -321,7 +434,7 @@ func (c *OciClient) PullSelective(dir string, artifact string, includeData bool,
 		return fmt.Errorf("failed to marshal manifest descriptor: %w", err)
 	}
 
-	bs, err := content.FetchAll(ctx, repoInstance, manifestDescriptor)
+	bs, err := content.FetchAll(ctx, target, manifestDescriptor)
 	if err != nil {
 		return fmt.Errorf("failed to fetch manifest descriptor: %w", err)
 	}
@@ -352,18 +465,18 @@ func (c *OciClient) PullSelective(dir string, artifact string, includeData bool,
 	return nil
 }
 
+// ErrFileNotInArtifact is returned by FetchFile when the artifact has no
+// file at the path.
+var ErrFileNotInArtifact = errors.New("not found in artifact")
+
 func (c *OciClient) FetchFile(artifact string, filePath string) ([]byte, error) {
-	repo, ref, _ := utils.ParseArtifactRef(artifact)
-	if repo == "" || ref == "" {
-		return nil, fmt.Errorf("reference (tag or digest) must be set")
-	}
 	filePath = cleanOCIFilePath(filePath)
 	if filePath == "" {
 		return nil, fmt.Errorf("file path is required")
 	}
 
 	ctx := context.Background()
-	repoInstance, err := c.GetRepo(repo)
+	repoInstance, ref, err := c.openArtifact(ctx, artifact)
 	if err != nil {
 		return nil, err
 	}
@@ -379,11 +492,7 @@ func (c *OciClient) FetchFile(artifact string, filePath string) ([]byte, error)
 }
 
 func (c *OciClient) ResolveDigest(artifact string) (string, error) {
-	repo, ref, _ := utils.ParseArtifactRef(artifact)
-	if repo == "" || ref == "" {
-		return "", fmt.Errorf("reference (tag or digest) must be set")
-	}
-	repoInstance, err := c.GetRepo(repo)
+	repoInstance, ref, err := c.openArtifact(context.Background(), artifact)
 	if err != nil {
 		return "", err
 	}
@@ -395,11 +504,7 @@ func (c *OciClient) ResolveDigest(artifact string) (string, error) {
 }
 
 func (c *OciClient) ResolveAnnotationInfo(artifact string) (domain.AnnotationInfo, error) {
-	repo, ref, _ := utils.ParseArtifactRef(artifact)
-	if repo == "" || ref == "" {
-		return domain.AnnotationInfo{}, fmt.Errorf("reference (tag or digest) must be set")
-	}
-	repoInstance, err := c.GetRepo(repo)
+	repoInstance, ref, err := c.openArtifact(context.Background(), artifact)
 	if err != nil {
 		return domain.AnnotationInfo{}, err
 	}
@@ -415,14 +520,34 @@ func (c *OciClient) ResolveAnnotationInfo(artifact string) (domain.AnnotationInf
 	if err := json.Unmarshal(manifest, &fullDesc); err != nil {
 		return domain.AnnotationInfo{}, fmt.Errorf("failed to parse manifest for %s: %w", ref, err)
 	}
-	annotations := fullDesc.Annotations
-	return domain.AnnotationInfo{
-		MinRam:  annotations["gg.druid.scroll.minRam"],
-		MinDisk: annotations["gg.druid.scroll.minDisk"],
-		MinCpu:  annotations["gg.druid.scroll.minCpu"],
-		Image:   annotations["gg.druid.scroll.image"],
-		Smart:   annotations["gg.druid.scroll.smart"] == "true",
-	}, nil
+	return domain.NewAnnotationInfo(fullDesc.Annotations), nil
+}
+
+// FetchManifest resolves artifact and returns its manifest descriptor and
+// image manifest.
+func (c *OciClient) FetchManifest(artifact string) (v1.Descriptor, v1.Manifest, error) {
+	repo, ref, _ := utils.ParseArtifactRef(artifact)
+	if repo == "" || ref == "" {
+		return v1.Descriptor{}, v1.Manifest{}, fmt.Errorf("reference (tag or digest) must be set")
+	}
+	ctx := context.Background()
+	repoInstance, err := c.readRepo(ctx, repo, ref)
+	if err != nil {
+		return v1.Descriptor{}, v1.Manifest{}, err
+	}
+	desc, err := oras.Resolve(ctx, repoInstance, ref, oras.DefaultResolveOptions)
+	if err != nil {
+		return v1.Descriptor{}, v1.Manifest{}, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	data, err := content.FetchAll(ctx, repoInstance, desc)
+	if err != nil {
+		return v1.Descriptor{}, v1.Manifest{}, fmt.Errorf("failed to fetch manifest for %s: %w", ref, err)
+	}
+	var manifest v1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return v1.Descriptor{}, v1.Manifest{}, fmt.Errorf("failed to parse manifest for %s: %w", ref, err)
+	}
+	return desc, manifest, nil
 }
 
 func fetchFileFromOCI(ctx context.Context, fetcher content.Fetcher, rootDesc v1.Descriptor, filePath string) ([]byte, error) {
@@ -453,7 +578,7 @@ func fetchFileFromOCI(ctx context.Context, fetcher content.Fetcher, rootDesc v1.
 			queue = append(queue, desc)
 		}
 	}
-	return nil, fmt.Errorf("%s not found in artifact", filePath)
+	return nil, fmt.Errorf("%s %w", filePath, ErrFileNotInArtifact)
 }
 
 func descriptorMatchesPath(desc v1.Descriptor, want string) bool {
@@ -863,6 +988,18 @@ func (c *OciClient) Push(folder string, repo string, tag string, overrides map[s
 	} else if !os.IsNotExist(err) {
 		logger.Log().Info("No annotations.json found, skipping")
 	}
+	// Describe the scroll in the manifest so catalogs need not fetch scroll.yaml.
+	if scrollFile != nil {
+		if scrollFile.Name != "" {
+			annotations[domain.AnnotationScrollName] = scrollFile.Name
+		}
+		if scrollFile.AppVersion != "" {
+			annotations[domain.AnnotationScrollAppVersion] = scrollFile.AppVersion
+		}
+		if scrollFile.Version != nil {
+			annotations[domain.AnnotationScrollVersion] = scrollFile.Version.String()
+		}
+	}
 	for k, v := range overrides {
 		annotations[k] = v
 	}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	ocidigest "github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
 // fakeRegistry returns a plain-HTTP httptest server that implements the bare
@@ -20,12 +23,32 @@ func fakeRegistry(t *testing.T) *httptest.Server {
 	blobs := map[string][]byte{}
 	manifests := map[string][]byte{}
 	manifestTypes := map[string]string{}
+	repoTags := map[string][]string{}
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /v2/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/referrers/") {
+			// No referrers API; clients fall back to the tag schema.
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/v2/_catalog" {
+			repos := []string{}
+			for repo := range repoTags {
+				repos = append(repos, repo)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string][]string{"repositories": repos})
+			return
+		}
 		if strings.HasSuffix(r.URL.Path, "/tags/list") {
+			repo := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/"), "/tags/list")
+			tags := repoTags[repo]
+			if tags == nil {
+				tags = []string{}
+			}
 			w.Header().Set("Content-Type", "application/json")
-			w.Write([]byte(`{"tags":[]}`))
+			json.NewEncoder(w).Encode(map[string][]string{"tags": tags})
 			return
 		}
 		if strings.Contains(r.URL.Path, "/blobs/") {
@@ -104,6 +127,10 @@ func fakeRegistry(t *testing.T) *httptest.Server {
 			manifests[bodyDigest] = body
 			manifestTypes[ref] = r.Header.Get("Content-Type")
 			manifestTypes[bodyDigest] = r.Header.Get("Content-Type")
+			if !strings.HasPrefix(ref, "sha256:") {
+				repo := strings.TrimPrefix(strings.Split(r.URL.Path, "/manifests/")[0], "/v2/")
+				repoTags[repo] = append(repoTags[repo], ref)
+			}
 			w.WriteHeader(http.StatusCreated)
 			return
 		}
@@ -123,7 +150,7 @@ func TestValidateCredentialsUsesPlainHTTPEnv(t *testing.T) {
 	srv := fakeRegistry(t)
 	registryHost := strings.TrimPrefix(srv.URL, "http://")
 
-	if err := ValidateCredentials(registryHost, "admin", "admin"); err != nil {
+	if err := ValidateCredentials(registryHost, auth.Credential{Username: "admin", Password: "admin"}); err != nil {
 		t.Fatalf("ValidateCredentials failed: %v", err)
 	}
 }
@@ -326,7 +353,7 @@ func TestFetchFileReadsScrollYAMLDescriptor(t *testing.T) {
 	if string(got) != string(scrollYAML) {
 		t.Fatalf("scroll.yaml = %q, want %q", got, scrollYAML)
 	}
-	if _, err := client.FetchFile(repoRef+":1.0", "missing.txt"); err == nil || !strings.Contains(err.Error(), "missing.txt not found") {
+	if _, err := client.FetchFile(repoRef+":1.0", "missing.txt"); !errors.Is(err, ErrFileNotInArtifact) || !strings.Contains(err.Error(), "missing.txt not found") {
 		t.Fatalf("missing error = %v, want clear not found", err)
 	}
 }
@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// SetRegistryConfig applies registry mirrors, rewrites and per-host
+// connection settings to every later request.
+func (c *OciClient) SetRegistryConfig(config domain.RegistryConfig) {
+	c.hostClientsMu.Lock()
+	defer c.hostClientsMu.Unlock()
+	c.registryConfig = config
+	c.hostClients = nil
+}
+
+// ResolveRegistryConfig inlines the CA files of config so it can be handed
+// to workers that do not share the host's filesystem.
+func ResolveRegistryConfig(config domain.RegistryConfig) (domain.RegistryConfig, error) {
+	if err := config.Validate(); err != nil {
+		return domain.RegistryConfig{}, err
+	}
+	hosts := make([]domain.RegistryHost, len(config.Hosts))
+	for i, host := range config.Hosts {
+		if host.CAFile != "" {
+			pem, err := os.ReadFile(host.CAFile)
+			if err != nil {
+				return domain.RegistryConfig{}, fmt.Errorf("registry host %s: %w", host.Host, err)
+			}
+			host.CA = string(pem)
+			host.CAFile = ""
+		}
+		hosts[i] = host
+	}
+	config.Hosts = hosts
+	return config, nil
+}
+
+func (c *OciClient) plainHTTPFor(repoUrl string) bool {
+	if c.plainHTTP {
+		return true
+	}
+	host := c.registryConfig.HostFor(extractHost(repoUrl))
+	return host != nil && host.PlainHTTP
+}
+
+// hostClient returns the HTTP client for host, or nil when the default
+// client is fine. Clients with custom TLS settings are built once per host.
+func (c *OciClient) hostClient(host string) (*http.Client, error) {
+	settings := c.registryConfig.HostFor(host)
+	if settings == nil || (!settings.Insecure && settings.CAFile == "" && settings.CA == "") {
+		return c.httpClient, nil
+	}
+	c.hostClientsMu.Lock()
+	defer c.hostClientsMu.Unlock()
+	if client := c.hostClients[host]; client != nil {
+		return client, nil
+	}
+	tlsConfig, err := registryTLSConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if c.httpClient != nil {
+		if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+			base = transport.Clone()
+		}
+	}
+	base.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: retry.NewTransport(base)}
+	if c.hostClients == nil {
+		c.hostClients = map[string]*http.Client{}
+	}
+	c.hostClients[host] = client
+	return client, nil
+}
+
+func registryTLSConfig(settings *domain.RegistryHost) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: settings.Insecure}
+	pem := []byte(settings.CA)
+	if settings.CAFile != "" {
+		data, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("registry host %s: %w", settings.Host, err)
+		}
+		pem = append(pem, '\n')
+		pem = append(pem, data...)
+	}
+	if len(pem) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("registry host %s: no certificates found in CA", settings.Host)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// readRepo returns the first mirror of repo that has ref, or repo itself
+// when no mirror is configured or none of them answers.
+func (c *OciClient) readRepo(ctx context.Context, repo string, ref string) (*remote.Repository, error) {
+	for _, mirror := range c.registryConfig.MirrorsFor(repo) {
+		mirrorRepo, err := c.GetRepo(mirror)
+		if err == nil {
+			_, err = mirrorRepo.Resolve(ctx, ref)
+		}
+		if err == nil {
+			logger.Log().Debug("Using registry mirror", zap.String("repo", repo), zap.String("mirror", mirror))
+			return mirrorRepo, nil
+		}
+		logger.Log().Warn("Registry mirror failed, trying next", zap.String("repo", repo), zap.String("mirror", mirror), zap.Error(err))
+	}
+	return c.GetRepo(repo)
+}
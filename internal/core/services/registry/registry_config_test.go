@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+func TestRegistryRewriteAndHostPlainHTTP(t *testing.T) {
+	srv := fakeRegistry(t)
+	defer srv.Close()
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+
+	client := &OciClient{credentialStore: NewCredentialStore(nil)}
+	client.SetRegistryConfig(domain.RegistryConfig{
+		Hosts:    []domain.RegistryHost{{Host: registryHost, PlainHTTP: true}},
+		Rewrites: []domain.RegistryRewrite{{From: "artifacts.example.test/*", To: registryHost + "/mirror/*"}},
+	})
+	pushed := pushTestScroll(t, client, "artifacts.example.test/team/scroll", "1.0")
+
+	direct := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+	digest, err := direct.ResolveDigest(registryHost + "/mirror/team/scroll:1.0")
+	if err != nil {
+		t.Fatalf("rewritten push did not land in the mirror: %v", err)
+	}
+	if digest != pushed {
+		t.Fatalf("digest = %s, want %s", digest, pushed)
+	}
+	if digest, err = client.ResolveDigest("artifacts.example.test/team/scroll:1.0"); err != nil || digest != pushed {
+		t.Fatalf("ResolveDigest through rewrite = %s, %v", digest, err)
+	}
+}
+
+func TestRegistryMirrorsAreTriedInOrder(t *testing.T) {
+	srv := fakeRegistry(t)
+	defer srv.Close()
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+
+	direct := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+	pushed := pushTestScroll(t, direct, registryHost+"/cache/team/scroll", "1.0")
+
+	client := &OciClient{credentialStore: NewCredentialStore(nil)}
+	client.SetRegistryConfig(domain.RegistryConfig{
+		Hosts: []domain.RegistryHost{{Host: registryHost, PlainHTTP: true}},
+		Mirrors: []domain.RegistryMirror{{
+			Registry:  "upstream.example.test",
+			Endpoints: []string{registryHost + "/empty", registryHost + "/cache"},
+		}},
+	})
+	// upstream.example.test does not resolve, so only the second mirror can
+	// answer.
+	digest, err := client.ResolveDigest("upstream.example.test/team/scroll:1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != pushed {
+		t.Fatalf("digest = %s, want %s", digest, pushed)
+	}
+}
+
+func TestRegistryConfigMatching(t *testing.T) {
+	config := domain.RegistryConfig{
+		Mirrors: []domain.RegistryMirror{{Registry: "artifacts.druid.gg", Endpoints: []string{"mirror.local/druid", "backup.local"}}},
+		Rewrites: []domain.RegistryRewrite{
+			{From: "artifacts.druid.gg/*", To: "mirror.local/druid/*"},
+			{From: "artifacts.druid.gg/team/*", To: "team.local/*"},
+			{From: "ghcr.io/org/app", To: "mirror.local/app"},
+		},
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for repo, want := range map[string]string{
+		"artifacts.druid.gg/user/scroll": "mirror.local/druid/user/scroll",
+		"artifacts.druid.gg/team/scroll": "team.local/scroll",
+		"ghcr.io/org/app":                "mirror.local/app",
+		"ghcr.io/org/app2":               "ghcr.io/org/app2",
+	} {
+		if got := config.Rewrite(repo); got != want {
+			t.Errorf("Rewrite(%q) = %q, want %q", repo, got, want)
+		}
+	}
+	mirrors := config.MirrorsFor("artifacts.druid.gg/user/scroll")
+	if !slices.Equal(mirrors, []string{"mirror.local/druid/user/scroll", "backup.local/user/scroll"}) {
+		t.Fatalf("MirrorsFor = %v", mirrors)
+	}
+	if mirrors := config.MirrorsFor("artifacts.druid.gg.evil/user/scroll"); len(mirrors) != 0 {
+		t.Fatalf("MirrorsFor matched a different host: %v", mirrors)
+	}
+	bad := domain.RegistryConfig{Rewrites: []domain.RegistryRewrite{{From: "a.test/*", To: "b.test/x"}}}
+	if err := bad.Validate(); err == nil {
+		t.Fatal("Validate accepted a rewrite with a wildcard on one side only")
+	}
+}
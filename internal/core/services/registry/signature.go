@@ -0,0 +1,346 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	ocidigest "github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Signatures use cosign's simple signing payload and annotation, attached to
+// the scroll manifest as an OCI 1.1 referrer.
+const (
+	SignatureArtifactType     = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	signaturePayloadMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	signatureAnnotation       = "dev.cosignproject.cosign/signature"
+	signaturePayloadType      = "cosign container image signature"
+)
+
+// ErrUntrustedArtifact is returned when an artifact has no signature the
+// trust policy accepts.
+var ErrUntrustedArtifact = errors.New("untrusted scroll artifact")
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+// LoadSigningKey reads an unencrypted PEM private key (PKCS#8, SEC 1 EC or
+// PKCS#1 RSA).
+func LoadSigningKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+	if block.Headers["Proc-Type"] != "" || strings.Contains(block.Type, "ENCRYPTED") {
+		return nil, fmt.Errorf("signing key %s is encrypted; decrypt it first", path)
+	}
+	var key any
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s has unsupported type %T", path, key)
+	}
+	return signer, nil
+}
+
+// ParsePublicKey decodes a PEM public key or certificate.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("public key is not PEM encoded")
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// LoadScrollTrustPolicy combines --verify-key files and an optional policy
+// file into a policy with inline keys, so it can be handed to workers that
+// cannot read the daemon's files. Key paths in the policy file are relative
+// to the file.
+func LoadScrollTrustPolicy(policyPath string, verifyKeys []string) (*domain.ScrollTrustPolicy, error) {
+	policy := &domain.ScrollTrustPolicy{}
+	baseDir := ""
+	if policyPath != "" {
+		data, err := os.ReadFile(policyPath)
+		if err != nil {
+			return nil, fmt.Errorf("read trust policy: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(data, policy); err != nil {
+			return nil, fmt.Errorf("parse trust policy %s: %w", policyPath, err)
+		}
+		baseDir = filepath.Dir(policyPath)
+	}
+	keys, err := readTrustKeys(baseDir, policy.Keys)
+	if err != nil {
+		return nil, err
+	}
+	flagKeys, err := readTrustKeys("", verifyKeys)
+	if err != nil {
+		return nil, err
+	}
+	policy.Keys = append(keys, flagKeys...)
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if strings.TrimSpace(rule.Repo) == "" {
+			return nil, fmt.Errorf("trust policy rule %d has no repo", i+1)
+		}
+		if len(rule.Keys) == 0 && !rule.AllowUnsigned {
+			return nil, fmt.Errorf("trust policy rule for %s needs keys or allow_unsigned", rule.Repo)
+		}
+		if rule.Keys, err = readTrustKeys(baseDir, rule.Keys); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+func readTrustKeys(baseDir string, keys []string) ([]string, error) {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		data := []byte(key)
+		if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+			path := key
+			if baseDir != "" && !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			var err error
+			if data, err = os.ReadFile(path); err != nil {
+				return nil, fmt.Errorf("read verify key: %w", err)
+			}
+		}
+		if _, err := ParsePublicKey(data); err != nil {
+			return nil, fmt.Errorf("verify key %s: %w", key, err)
+		}
+		out = append(out, string(data))
+	}
+	return out, nil
+}
+
+// Sign attaches a signature for the manifest artifact resolves to.
+func (c *OciClient) Sign(artifact string, key crypto.Signer) (v1.Descriptor, error) {
+	repo, ref, _ := utils.ParseArtifactRef(artifact)
+	if repo == "" || ref == "" {
+		return v1.Descriptor{}, fmt.Errorf("reference (tag or digest) must be set")
+	}
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	ctx := context.Background()
+	subject, err := oras.Resolve(ctx, repoInstance, ref, oras.DefaultResolveOptions)
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return signManifest(ctx, repoInstance, repo, subject, key)
+}
+
+func signManifest(ctx context.Context, repoInstance *remote.Repository, repo string, subject v1.Descriptor, key crypto.Signer) (v1.Descriptor, error) {
+	payload := simpleSigningPayload{}
+	payload.Critical.Identity.DockerReference = repo
+	payload.Critical.Image.DockerManifestDigest = subject.Digest.String()
+	payload.Critical.Type = signaturePayloadType
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	signature, err := signPayload(key, data)
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("sign %s: %w", subject.Digest, err)
+	}
+	layer := v1.Descriptor{
+		MediaType:   signaturePayloadMediaType,
+		Digest:      ocidigest.FromBytes(data),
+		Size:        int64(len(data)),
+		Annotations: map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	}
+	if err := repoInstance.Push(ctx, layer, bytes.NewReader(data)); err != nil {
+		return v1.Descriptor{}, fmt.Errorf("push signature payload: %w", err)
+	}
+	desc, err := oras.PackManifest(ctx, repoInstance, oras.PackManifestVersion1_1, SignatureArtifactType, oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []v1.Descriptor{layer},
+	})
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("push signature manifest: %w", err)
+	}
+	logger.Log().Info("Signed scroll artifact",
+		zap.String("repo", repo),
+		zap.String("digest", subject.Digest.String()),
+		zap.String("signature", desc.Digest.String()),
+	)
+	return desc, nil
+}
+
+// VerifyTrusted applies policy to artifact and returns the manifest digest it
+// accepted. Callers should pull that digest rather than the tag, so the tag
+// cannot move between verification and pull.
+func (c *OciClient) VerifyTrusted(artifact string, policy *domain.ScrollTrustPolicy) (string, error) {
+	repo, ref, _ := utils.ParseArtifactRef(artifact)
+	if repo == "" || ref == "" {
+		return "", fmt.Errorf("reference (tag or digest) must be set")
+	}
+	repoInstance, err := c.GetRepo(repo)
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	subject, err := oras.Resolve(ctx, repoInstance, ref, oras.DefaultResolveOptions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	rule := policy.RuleFor(repo)
+	if rule == nil {
+		return "", fmt.Errorf("%w: no trust rule covers %s", ErrUntrustedArtifact, repo)
+	}
+	keys := make([]crypto.PublicKey, 0, len(rule.Keys))
+	for _, raw := range rule.Keys {
+		key, err := ParsePublicKey([]byte(raw))
+		if err != nil {
+			return "", fmt.Errorf("trust policy key for %s: %w", repo, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 && rule.AllowUnsigned {
+		logger.Log().Warn("Trust policy accepts unsigned artifacts from repo", zap.String("repo", repo))
+		return subject.Digest.String(), nil
+	}
+	signed, err := verifySignatures(ctx, repoInstance, repo, subject, keys)
+	if err != nil {
+		return "", err
+	}
+	if !signed {
+		if rule.AllowUnsigned {
+			logger.Log().Warn("No trusted signature found; trust policy accepts unsigned artifacts from repo", zap.String("repo", repo))
+			return subject.Digest.String(), nil
+		}
+		return "", fmt.Errorf("%w: %s@%s has no signature from a trusted key", ErrUntrustedArtifact, repo, subject.Digest)
+	}
+	logger.Log().Info("Verified scroll artifact signature", zap.String("repo", repo), zap.String("digest", subject.Digest.String()))
+	return subject.Digest.String(), nil
+}
+
+// verifySignatures reports whether any signature referrer of subject was made
+// by one of keys for repo. Signatures whose identity names another repository
+// do not count, so a signature cannot be replayed under a different trust
+// rule. Malformed signatures are skipped, not fatal.
+func verifySignatures(ctx context.Context, repoInstance *remote.Repository, repo string, subject v1.Descriptor, keys []crypto.PublicKey) (bool, error) {
+	var referrers []v1.Descriptor
+	err := repoInstance.Referrers(ctx, subject, SignatureArtifactType, func(page []v1.Descriptor) error {
+		referrers = append(referrers, page...)
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("list signatures of %s: %w", subject.Digest, err)
+	}
+	for _, referrer := range referrers {
+		data, err := content.FetchAll(ctx, repoInstance, referrer)
+		if err != nil {
+			return false, fmt.Errorf("fetch signature %s: %w", referrer.Digest, err)
+		}
+		var manifest v1.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			logger.Log().Warn("Skipping malformed signature manifest", zap.String("signature", referrer.Digest.String()), zap.Error(err))
+			continue
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != signaturePayloadMediaType || layer.Size > 64*1024 {
+				continue
+			}
+			signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+			if err != nil || len(signature) == 0 {
+				continue
+			}
+			payload, err := content.FetchAll(ctx, repoInstance, layer)
+			if err != nil {
+				return false, fmt.Errorf("fetch signature payload %s: %w", layer.Digest, err)
+			}
+			var signed simpleSigningPayload
+			if err := json.Unmarshal(payload, &signed); err != nil || signed.Critical.Image.DockerManifestDigest != subject.Digest.String() {
+				continue
+			}
+			if domain.NormalizeTrustRepo(signed.Critical.Identity.DockerReference) != domain.NormalizeTrustRepo(repo) {
+				continue
+			}
+			for _, key := range keys {
+				if verifyPayload(key, payload, signature) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func signPayload(key crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	sum := sha256.Sum256(payload)
+	return key.Sign(rand.Reader, sum[:], crypto.SHA256)
+}
+
+func verifyPayload(key crypto.PublicKey, payload []byte, signature []byte) bool {
+	sum := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, sum[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payload, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature) == nil
+	}
+	return false
+}
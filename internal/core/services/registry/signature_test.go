@@ -0,0 +1,202 @@
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2"
+)
+
+func pushTestScroll(t *testing.T, client *OciClient, repo string, tag string) string {
+	t.Helper()
+	folder := filepath.Join(t.TempDir(), "scroll")
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, "scroll.yaml"), []byte("name: test\nversion: 0.1.0\napp_version: \""+tag+"\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	desc, err := client.Push(folder, repo, tag, map[string]string{}, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return desc.Digest.String()
+}
+
+func writeTestKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "cosign.key")
+	publicPath := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return privatePath, publicPath
+}
+
+func TestSignedArtifactVerifiesAgainstTrustPolicy(t *testing.T) {
+	srv := fakeRegistry(t)
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+	client := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+	repo := registryHost + "/scrolls/minecraft"
+	digest := pushTestScroll(t, client, repo, "1.21")
+
+	privatePath, publicPath := writeTestKeyPair(t)
+	policy, err := LoadScrollTrustPolicy("", []string{publicPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyTrusted(repo+":1.21", policy); !errors.Is(err, ErrUntrustedArtifact) {
+		t.Fatalf("unsigned artifact err = %v, want ErrUntrustedArtifact", err)
+	}
+
+	key, err := LoadSigningKey(privatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sign(repo+":1.21", key); err != nil {
+		t.Fatal(err)
+	}
+	verified, err := client.VerifyTrusted(repo+":1.21", policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified != digest {
+		t.Fatalf("verified digest = %s, want %s", verified, digest)
+	}
+
+	_, otherPublic := writeTestKeyPair(t)
+	otherPolicy, err := LoadScrollTrustPolicy("", []string{otherPublic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyTrusted(repo+":1.21", otherPolicy); !errors.Is(err, ErrUntrustedArtifact) {
+		t.Fatalf("signature from another key err = %v, want ErrUntrustedArtifact", err)
+	}
+}
+
+func TestTrustPolicyRulesPerRepo(t *testing.T) {
+	srv := fakeRegistry(t)
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+	client := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+	pushTestScroll(t, client, registryHost+"/dev/scroll", "latest")
+	pushTestScroll(t, client, registryHost+"/prod/scroll", "latest")
+
+	_, publicPath := writeTestKeyPair(t)
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "trust.yaml")
+	if err := os.Rename(publicPath, filepath.Join(dir, "prod.pub")); err != nil {
+		t.Fatal(err)
+	}
+	policyYAML := "rules:\n" +
+		"  - repo: " + registryHost + "/dev/*\n" +
+		"    allow_unsigned: true\n" +
+		"  - repo: " + registryHost + "/prod/*\n" +
+		"    keys: [prod.pub]\n"
+	if err := os.WriteFile(policyPath, []byte(policyYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadScrollTrustPolicy(policyPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(policy.Rules[1].Keys[0], "-----BEGIN PUBLIC KEY-----") {
+		t.Fatalf("rule key was not inlined: %q", policy.Rules[1].Keys[0])
+	}
+
+	if _, err := client.VerifyTrusted(registryHost+"/dev/scroll:latest", policy); err != nil {
+		t.Fatalf("allow_unsigned repo rejected: %v", err)
+	}
+	if _, err := client.VerifyTrusted(registryHost+"/prod/scroll:latest", policy); !errors.Is(err, ErrUntrustedArtifact) {
+		t.Fatalf("unsigned prod artifact err = %v, want ErrUntrustedArtifact", err)
+	}
+	pushTestScroll(t, client, registryHost+"/other/scroll", "latest")
+	if _, err := client.VerifyTrusted(registryHost+"/other/scroll:latest", policy); !errors.Is(err, ErrUntrustedArtifact) {
+		t.Fatalf("repo without rule err = %v, want ErrUntrustedArtifact", err)
+	}
+}
+
+func TestSignatureForAnotherRepoIsNotTrusted(t *testing.T) {
+	srv := fakeRegistry(t)
+	registryHost := strings.TrimPrefix(srv.URL, "http://")
+	client := &OciClient{credentialStore: NewCredentialStore(nil), plainHTTP: true}
+	repo := registryHost + "/prod/scroll"
+	pushTestScroll(t, client, repo, "latest")
+
+	privatePath, publicPath := writeTestKeyPair(t)
+	policy, err := LoadScrollTrustPolicy("", []string{publicPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := LoadSigningKey(privatePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repoInstance, err := client.GetRepo(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fake registry cannot delete the replaced referrers index.
+	repoInstance.SkipReferrersGC = true
+	ctx := context.Background()
+	subject, err := oras.Resolve(ctx, repoInstance, "latest", oras.DefaultResolveOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signManifest(ctx, repoInstance, registryHost+"/dev/scroll", subject, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyTrusted(repo+":latest", policy); !errors.Is(err, ErrUntrustedArtifact) {
+		t.Fatalf("signature for another repo err = %v, want ErrUntrustedArtifact", err)
+	}
+
+	if _, err := signManifest(ctx, repoInstance, "http://"+repo+"/", subject, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyTrusted(repo+":latest", policy); err != nil {
+		t.Fatalf("signature for the same repo: %v", err)
+	}
+}
+
+func TestSignVerifyEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte(`{"critical":{}}`)
+	signature, err := signPayload(private, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verifyPayload(public, payload, signature) {
+		t.Fatal("ed25519 signature did not verify")
+	}
+	if verifyPayload(public, append(payload, ' '), signature) {
+		t.Fatal("ed25519 signature verified a different payload")
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/utils"
+)
+
+const runtimeCallbackTokenPrefix = "druid-runtime."
+
+// RuntimeCallbackTokenTTL is how long a runtime callback token is valid.
+// Procedures get a fresh one every time their command starts, and the
+// callback listener renews tokens past half their lifetime.
+const RuntimeCallbackTokenTTL = 24 * time.Hour
+
+// RuntimeCallbackTokens issues the bearer tokens runtime procedures use on
+// the callback listener. A token is an HMAC of the runtime ID and its expiry
+// under a key in the state dir, so it survives daemon restarts and is only
+// valid for its own runtime. Other tokens are passed on to the platform
+// authenticator.
+type RuntimeCallbackTokens struct {
+	key  []byte
+	next ports.RuntimeWorkloadAuthenticator
+	now  func() time.Time
+}
+
+func NewRuntimeCallbackTokens(keyFile string, next ports.RuntimeWorkloadAuthenticator) (*RuntimeCallbackTokens, error) {
+	key, err := os.ReadFile(keyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		key, err = utils.GenerateRandomBytes(32)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(keyFile), 0o700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(keyFile, key, 0o600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("runtime callback key %s is too short", keyFile)
+	}
+	return &RuntimeCallbackTokens{key: key, next: next, now: time.Now}, nil
+}
+
+func (t *RuntimeCallbackTokens) Issue(runtimeID string) string {
+	expires := strconv.FormatInt(t.now().Add(RuntimeCallbackTokenTTL).Unix(), 10)
+	return runtimeCallbackTokenPrefix +
+		base64.RawURLEncoding.EncodeToString([]byte(runtimeID)) + "." +
+		expires + "." +
+		base64.RawURLEncoding.EncodeToString(t.sign(runtimeID, expires))
+}
+
+// Renew returns a fresh token for identity once its token is past half its
+// lifetime, and "" before that or for other identities.
+func (t *RuntimeCallbackTokens) Renew(identity ports.RuntimeWorkloadIdentity) string {
+	if identity.Kind != "runtime" || identity.ExpiresAt.IsZero() || identity.ExpiresAt.Sub(t.now()) > RuntimeCallbackTokenTTL/2 {
+		return ""
+	}
+	return t.Issue(identity.RuntimeID)
+}
+
+func (t *RuntimeCallbackTokens) AuthenticateWorkload(ctx context.Context, token string) (ports.RuntimeWorkloadIdentity, error) {
+	encoded, ok := strings.CutPrefix(token, runtimeCallbackTokenPrefix)
+	if !ok {
+		if t.next == nil {
+			return ports.RuntimeWorkloadIdentity{}, errors.New("workload identity authentication is unavailable")
+		}
+		return t.next.AuthenticateWorkload(ctx, token)
+	}
+	parts := strings.Split(encoded, ".")
+	if len(parts) != 3 {
+		return ports.RuntimeWorkloadIdentity{}, errors.New("malformed runtime callback token")
+	}
+	runtimeID, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ports.RuntimeWorkloadIdentity{}, errors.New("malformed runtime callback token")
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ports.RuntimeWorkloadIdentity{}, errors.New("malformed runtime callback token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(mac, t.sign(string(runtimeID), parts[1])) {
+		return ports.RuntimeWorkloadIdentity{}, errors.New("invalid runtime callback token")
+	}
+	expiresAt := time.Unix(expires, 0)
+	if !t.now().Before(expiresAt) {
+		return ports.RuntimeWorkloadIdentity{}, errors.New("runtime callback token expired")
+	}
+	return ports.RuntimeWorkloadIdentity{Kind: "runtime", RuntimeID: string(runtimeID), ExpiresAt: expiresAt}, nil
+}
+
+func (t *RuntimeCallbackTokens) sign(runtimeID string, expires string) []byte {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte("runtime:" + runtimeID + ":" + expires))
+	return mac.Sum(nil)
+}
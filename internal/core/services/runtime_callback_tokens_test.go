@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRuntimeCallbackTokensExpireAndRenew(t *testing.T) {
+	tokens, err := NewRuntimeCallbackTokens(filepath.Join(t.TempDir(), "callback.key"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	tokens.now = func() time.Time { return now }
+	token := tokens.Issue("runtime-a")
+
+	identity, err := tokens.AuthenticateWorkload(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.RuntimeID != "runtime-a" || !identity.ExpiresAt.Equal(now.Add(RuntimeCallbackTokenTTL)) {
+		t.Fatalf("identity = %#v", identity)
+	}
+	if renewed := tokens.Renew(identity); renewed != "" {
+		t.Fatal("a fresh token was renewed")
+	}
+
+	now = now.Add(RuntimeCallbackTokenTTL * 3 / 4)
+	renewed := tokens.Renew(identity)
+	if renewed == "" || renewed == token {
+		t.Fatal("a token past half its lifetime was not renewed")
+	}
+
+	now = now.Add(RuntimeCallbackTokenTTL / 2)
+	if _, err := tokens.AuthenticateWorkload(context.Background(), token); err == nil {
+		t.Fatal("an expired token was accepted")
+	}
+	if _, err := tokens.AuthenticateWorkload(context.Background(), renewed); err != nil {
+		t.Fatalf("renewed token: %v", err)
+	}
+}
@@ -13,6 +13,13 @@ type RuntimeEnvContext struct {
 	ScrollName string
 	Backend    string
 	Routing    []domain.RuntimeRouteAssignment
+	// CallbackURL and CallbackToken let the coldstarter proxy report back
+	// to the daemon callback listener. Only coldstarter procedures get
+	// them; the others run the game server, which must not report.
+	CallbackURL   string
+	CallbackToken string
+	// CallbackCA holds PEM CAs for an https CallbackURL.
+	CallbackCA string
 }
 
 func BuildRuntimeProcedureEnv(file *domain.File, commandName string, command *domain.CommandInstructionSet, context RuntimeEnvContext) (map[string]map[string]string, error) {
@@ -38,7 +45,13 @@ func BuildRuntimeProcedureEnv(file *domain.File, commandName string, command *do
 		for key, value := range base {
 			env[key] = value
 		}
-		result[domain.ProcedureName(commandName, idx, procedure)] = env
+		name := domain.ProcedureName(commandName, idx, procedure)
+		if domain.IsColdstarterProcedure(name, procedure) {
+			for key, value := range callbackEnv(context) {
+				env[key] = value
+			}
+		}
+		result[name] = env
 	}
 	return result, nil
 }
@@ -138,3 +151,18 @@ func envSuffix(name string) string {
 	}
 	return strings.TrimRight(b.String(), "_")
 }
+
+func callbackEnv(context RuntimeEnvContext) map[string]string {
+	env := map[string]string{}
+	if context.CallbackURL == "" {
+		return env
+	}
+	env["DRUID_CALLBACK_URL"] = context.CallbackURL
+	if context.CallbackToken != "" {
+		env["DRUID_CALLBACK_TOKEN"] = context.CallbackToken
+	}
+	if context.CallbackCA != "" {
+		env["DRUID_CALLBACK_CA"] = context.CallbackCA
+	}
+	return env
+}
@@ -61,3 +61,24 @@ func TestBuildRuntimeProcedureEnvRejectsDuplicateNormalizedPortNames(t *testing.
 		t.Fatal("expected duplicate normalized port names to fail")
 	}
 }
+
+func TestBuildRuntimeProcedureEnvGivesCallbackTokenOnlyToColdstarter(t *testing.T) {
+	coldstart := "coldstart"
+	command := &domain.CommandInstructionSet{Procedures: []*domain.Procedure{
+		{Image: "itzg/minecraft-server"},
+		{Id: &coldstart, Image: "ghcr.io/highcard-dev/druid-coldstarter"},
+	}}
+	envs, err := services.BuildRuntimeProcedureEnv(&domain.File{Name: "test"}, "serve", command, services.RuntimeEnvContext{
+		CallbackURL:   "http://druid-cli:8083",
+		CallbackToken: "runtime-token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := envs["serve.0"]["DRUID_CALLBACK_TOKEN"]; ok {
+		t.Fatalf("game server env = %#v, want no callback token", envs["serve.0"])
+	}
+	if envs["coldstart"]["DRUID_CALLBACK_TOKEN"] != "runtime-token" || envs["coldstart"]["DRUID_CALLBACK_URL"] == "" {
+		t.Fatalf("coldstarter env = %#v, want callback url and token", envs["coldstart"])
+	}
+}
@@ -117,7 +117,8 @@ func MaterializeScrollArtifact(artifact string, root string, ociRegistry ports.O
 	if err := os.MkdirAll(filepath.Join(root, domain.RuntimeDataDir), 0755); err != nil {
 		return err
 	}
-	if localPathExists(artifact) {
+	// Bundles are OCI layouts and are read by the registry client.
+	if localPathExists(artifact) && !domain.IsScrollBundle(artifact) {
 		if err := materializeLocalArtifact(artifact, root); err != nil {
 			return err
 		}
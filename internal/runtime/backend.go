@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/highcard-dev/daemon/internal/core/ports"
 	"github.com/highcard-dev/daemon/internal/runtime/docker"
@@ -51,7 +52,11 @@ func NewRuntime(name string, consoleManager ports.ConsoleManagerInterface, state
 	}
 	switch name {
 	case "", "docker":
-		backend, err := newDockerBackend(options.Docker, consoleManager)
+		dockerConfig := options.Docker.WithDefaults()
+		if dockerConfig.BlobCache == "" && stateDir != "" {
+			dockerConfig.BlobCache = filepath.Join(stateDir, "blob-cache")
+		}
+		backend, err := newDockerBackend(dockerConfig, consoleManager)
 		if err != nil {
 			return nil, err
 		}
@@ -61,7 +66,7 @@ func NewRuntime(name string, consoleManager ports.ConsoleManagerInterface, state
 		}
 		return &Runtime{
 			Backend: backend,
-			Store:   dockerRuntimeStore{RuntimeScrollStore: store, config: options.Docker.WithDefaults()},
+			Store:   dockerRuntimeStore{RuntimeScrollStore: store, config: dockerConfig},
 		}, nil
 	case "kubernetes":
 		backend, err := newKubernetesBackend(options.Kubernetes, consoleManager)
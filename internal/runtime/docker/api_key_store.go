@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const apiKeysTableSQL = `
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		hash TEXT NOT NULL,
+		role TEXT NOT NULL,
+		scrolls_json TEXT NOT NULL DEFAULT '[]',
+		commands_json TEXT NOT NULL DEFAULT '[]',
+		expires_at TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		created_by TEXT NOT NULL DEFAULT ''
+	)
+`
+
+func (s *StateStore) CreateAPIKey(key *domain.RuntimeAPIKey) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	scrolls, err := json.Marshal(key.Scrolls)
+	if err != nil {
+		return err
+	}
+	commands, err := json.Marshal(key.Commands)
+	if err != nil {
+		return err
+	}
+	expiresAt := ""
+	if key.ExpiresAt != nil {
+		expiresAt = formatTime(*key.ExpiresAt)
+	}
+	_, err = db.Exec(`
+		INSERT INTO api_keys (id, name, hash, role, scrolls_json, commands_json, expires_at, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, key.ID, key.Name, key.Hash, string(key.Role), string(scrolls), string(commands), expiresAt, formatTime(key.CreatedAt), key.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("create api key %s: %w", key.ID, err)
+	}
+	return nil
+}
+
+func (s *StateStore) ListAPIKeys() ([]*domain.RuntimeAPIKey, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+		SELECT id, name, hash, role, scrolls_json, commands_json, expires_at, created_at, created_by
+		FROM api_keys
+		ORDER BY created_at, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*domain.RuntimeAPIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *StateStore) GetAPIKey(id string) (*domain.RuntimeAPIKey, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	row := db.QueryRow(`
+		SELECT id, name, hash, role, scrolls_json, commands_json, expires_at, created_at, created_by
+		FROM api_keys
+		WHERE id = ?
+	`, id)
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRuntimeAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (s *StateStore) DeleteAPIKey(id string) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	res, err := db.Exec(`DELETE FROM api_keys WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	changed, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		return domain.ErrRuntimeAPIKeyNotFound
+	}
+	return nil
+}
+
+func scanAPIKey(scanner runtimeScrollScanner) (*domain.RuntimeAPIKey, error) {
+	var key domain.RuntimeAPIKey
+	var role string
+	var scrollsJSON string
+	var commandsJSON string
+	var expiresAt string
+	var createdAt string
+	if err := scanner.Scan(&key.ID, &key.Name, &key.Hash, &role, &scrollsJSON, &commandsJSON, &expiresAt, &createdAt, &key.CreatedBy); err != nil {
+		return nil, err
+	}
+	key.Role = domain.RuntimeRole(role)
+	key.CreatedAt = parseTime(createdAt)
+	if expiresAt != "" {
+		expires := parseTime(expiresAt)
+		key.ExpiresAt = &expires
+	}
+	if err := json.Unmarshal([]byte(scrollsJSON), &key.Scrolls); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(commandsJSON), &key.Commands); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
@@ -0,0 +1,124 @@
+package docker
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+// at_unix holds nanoseconds so records sort and filter by time in SQL.
+const auditTableSQL = `
+	CREATE TABLE IF NOT EXISTS audit_log (
+		id TEXT PRIMARY KEY,
+		at_unix INTEGER NOT NULL,
+		subject TEXT NOT NULL DEFAULT '',
+		source_ip TEXT NOT NULL DEFAULT '',
+		scroll_id TEXT NOT NULL DEFAULT '',
+		action TEXT NOT NULL,
+		params_json TEXT NOT NULL DEFAULT '{}',
+		result TEXT NOT NULL,
+		status INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS audit_log_at ON audit_log (at_unix)
+`
+
+func (s *StateStore) AppendAudit(record *domain.AuditRecord) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	params, err := json.Marshal(record.Params)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+		INSERT INTO audit_log (id, at_unix, subject, source_ip, scroll_id, action, params_json, result, status, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, record.ID, record.At.UnixNano(), record.Subject, record.SourceIP, record.ScrollID, record.Action, string(params), record.Result, record.Status, record.Error)
+	return err
+}
+
+func (s *StateStore) ListAudit(filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	where := []string{"1 = 1"}
+	args := []any{}
+	if filter.ScrollID != "" {
+		where = append(where, "scroll_id = ?")
+		args = append(args, filter.ScrollID)
+	}
+	if filter.Subject != "" {
+		where = append(where, "subject = ?")
+		args = append(args, filter.Subject)
+	}
+	if filter.Action != "" {
+		where = append(where, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "at_unix >= ?")
+		args = append(args, filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, "at_unix < ?")
+		args = append(args, filter.Until.UnixNano())
+	}
+	limit := -1
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+	args = append(args, limit)
+	// The newest records win the limit; they are returned oldest first.
+	rows, err := db.Query(`
+		SELECT id, at_unix, subject, source_ip, scroll_id, action, params_json, result, status, error FROM (
+			SELECT * FROM audit_log
+			WHERE `+strings.Join(where, " AND ")+`
+			ORDER BY at_unix DESC, id DESC
+			LIMIT ?
+		) ORDER BY at_unix, id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []*domain.AuditRecord{}
+	for rows.Next() {
+		var record domain.AuditRecord
+		var at int64
+		var params string
+		if err := rows.Scan(&record.ID, &at, &record.Subject, &record.SourceIP, &record.ScrollID, &record.Action, &params, &record.Result, &record.Status, &record.Error); err != nil {
+			return nil, err
+		}
+		record.At = time.Unix(0, at).UTC()
+		if err := json.Unmarshal([]byte(params), &record.Params); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, rows.Err()
+}
+
+func (s *StateStore) PruneAudit(before time.Time) (int, error) {
+	db, err := s.open()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	res, err := db.Exec(`DELETE FROM audit_log WHERE at_unix < ?`, before.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	pruned, err := res.RowsAffected()
+	return int(pruned), err
+}
@@ -5,12 +5,17 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/docker/docker/client"
 	"github.com/highcard-dev/daemon/internal/core/ports"
 )
 
+// defaultWorkerUID is the uid and gid of the druid user in the worker image.
+const defaultWorkerUID = 1000
+
 type Backend struct {
 	client         *client.Client
 	consoleManager ports.ConsoleManagerInterface
@@ -18,14 +23,22 @@ type Backend struct {
 	mu             sync.Mutex
 	containers     map[string]string
 	stdin          map[string]io.Writer
+	blobCacheOnce  sync.Once
 }
 
 type Config struct {
-	WorkerImage       string
-	Network           string
-	Storage           string
-	BindRoot          string
-	VolumePrefix      string
+	WorkerImage  string
+	Network      string
+	Storage      string
+	BindRoot     string
+	VolumePrefix string
+	// BlobCache is the host directory pull workers share as their registry
+	// blob cache; "off" disables it.
+	BlobCache string
+	// WorkerUID and WorkerGID are the user pull workers run as, the druid
+	// user of the worker image. The blob cache is handed to them.
+	WorkerUID         int
+	WorkerGID         int
 	UIS3Bucket        string
 	UIS3PublicBaseURL string
 	UIS3Region        string
@@ -58,6 +71,15 @@ func (c Config) WithDefaults() Config {
 	if c.VolumePrefix == "" {
 		c.VolumePrefix = "druid"
 	}
+	if c.BlobCache == "" {
+		c.BlobCache = os.Getenv("DRUID_DOCKER_BLOB_CACHE")
+	}
+	if c.WorkerUID == 0 {
+		c.WorkerUID = envInt("DRUID_DOCKER_WORKER_UID", defaultWorkerUID)
+	}
+	if c.WorkerGID == 0 {
+		c.WorkerGID = envInt("DRUID_DOCKER_WORKER_GID", defaultWorkerUID)
+	}
 	if c.UIS3Bucket == "" {
 		c.UIS3Bucket = os.Getenv("DRUID_DOCKER_UI_S3_BUCKET")
 	}
@@ -85,6 +107,16 @@ func (c Config) WithDefaults() Config {
 	return c
 }
 
+// envInt reads a number from key, or returns fallback when it is unset or
+// not a number.
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(os.Getenv(key)))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 func (c Config) ValidateForUIPublishing() error {
 	if c.UIS3Bucket == "" || c.UIS3PublicBaseURL == "" || c.UIS3Region == "" || c.UIS3AccessKey == "" || c.UIS3SecretKey == "" {
 		return fmt.Errorf("docker UI publishing requires S3 bucket, public URL, region, access key, and secret key configuration")
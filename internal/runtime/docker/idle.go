@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"context"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/highcard-dev/daemon/internal/core/domain"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+type keepAliveThreshold struct {
+	expectedPort domain.ExpectedPort
+	bytes        uint64
+	window       time.Duration
+}
+
+// CommandIdle reports whether every running procedure of command that
+// declares keepAliveTraffic received less than its budget over its window.
+// Procedures without thresholds and coldstart gates are ignored. Docker has
+// no platform-side idle stop, so the daemon polls this and stops the command.
+func (b *Backend) CommandIdle(root string, commandName string, command *domain.CommandInstructionSet, globalPorts []domain.Port) (bool, error) {
+	if command == nil {
+		return false, nil
+	}
+	ports := portsByName(globalPorts)
+	ctx := context.Background()
+	now := time.Now()
+	checked := 0
+	for idx, procedure := range command.Procedures {
+		procedureName := domain.ProcedureName(commandName, idx, procedure)
+		if procedure == nil || !procedure.IsContainer() || domain.IsColdstarterProcedure(procedureName, procedure) {
+			continue
+		}
+		thresholds := make([]keepAliveThreshold, 0, len(procedure.ExpectedPorts))
+		for _, expectedPort := range procedure.ExpectedPorts {
+			if expectedPort.KeepAliveTraffic == "" {
+				continue
+			}
+			threshold, err := domain.ParseKeepAliveTraffic(expectedPort.KeepAliveTraffic)
+			if err != nil {
+				return false, err
+			}
+			if _, ok := ports[expectedPort.Name]; !ok {
+				return false, nil
+			}
+			thresholds = append(thresholds, keepAliveThreshold{
+				expectedPort: expectedPort,
+				bytes:        threshold.Bytes,
+				window:       threshold.Window,
+			})
+		}
+		if len(thresholds) == 0 {
+			continue
+		}
+		inspected, err := b.client.ContainerInspect(ctx, ContainerName(root, procedureResourceName(commandName, idx)))
+		if err != nil {
+			if cerrdefs.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if inspected.State == nil || !inspected.State.Running {
+			return false, nil
+		}
+		startedAt, err := time.Parse(time.RFC3339Nano, inspected.State.StartedAt)
+		if err != nil {
+			return false, nil
+		}
+		traffic, err := b.containerTraffic(ctx, inspected.ID)
+		if err != nil {
+			logger.Log().Warn("Docker container stats unavailable; keepAliveTraffic enforcement skipped",
+				zap.String("command", commandName),
+				zap.String("procedure", procedureName),
+				zap.Error(err),
+			)
+			return false, nil
+		}
+		for _, threshold := range thresholds {
+			if now.Sub(startedAt) < threshold.window {
+				return false, nil
+			}
+			if !traffic.windowReady(threshold.window, now) {
+				return false, nil
+			}
+			if traffic.rxDelta(threshold.window, now) >= threshold.bytes {
+				return false, nil
+			}
+		}
+		checked++
+	}
+	return checked > 0, nil
+}
+
+func (t containerTraffic) windowReady(window time.Duration, now time.Time) bool {
+	if window <= 0 {
+		return len(t.samples) > 1
+	}
+	if len(t.samples) < 2 {
+		return false
+	}
+	return !t.samples[0].at.After(now.Add(-window))
+}
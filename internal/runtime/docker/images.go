@@ -22,3 +22,17 @@ func (b *Backend) pullImage(ctx context.Context, imageRef string) error {
 	_, _ = io.Copy(io.Discard, reader)
 	return nil
 }
+
+// MissingImages returns the images of images the Docker engine does not have.
+func (b *Backend) MissingImages(ctx context.Context, images []string) ([]string, error) {
+	var missing []string
+	for _, imageRef := range images {
+		if _, err := b.client.ImageInspect(ctx, imageRef); err != nil {
+			if !cerrdefs.IsNotFound(err) {
+				return nil, err
+			}
+			missing = append(missing, imageRef)
+		}
+	}
+	return missing, nil
+}
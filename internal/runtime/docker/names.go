@@ -81,8 +81,10 @@ func sanitizeContainerName(name string) string {
 }
 
 func dockerWorkerEnv(base []string) []string {
-	if plainHTTP := os.Getenv("DRUID_REGISTRY_PLAIN_HTTP"); plainHTTP != "" {
-		base = append(base, "DRUID_REGISTRY_PLAIN_HTTP="+plainHTTP)
+	for _, key := range []string{"DRUID_REGISTRY_PLAIN_HTTP", "DRUID_BLOB_CACHE_SIZE", "DRUID_PULL_CONCURRENCY"} {
+		if value := os.Getenv(key); value != "" {
+			base = append(base, key+"="+value)
+		}
 	}
 	return base
 }
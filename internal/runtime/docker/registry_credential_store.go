@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const registryCredentialsTableSQL = `
+	CREATE TABLE IF NOT EXISTS registry_credentials (
+		id TEXT PRIMARY KEY,
+		credentials_json TEXT NOT NULL
+	)
+`
+
+func (s *StateStore) SetRegistryCredentials(id string, credentials []domain.RegistryCredential) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if len(credentials) == 0 {
+		_, err = db.Exec(`DELETE FROM registry_credentials WHERE id = ?`, id)
+		return err
+	}
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+		INSERT INTO registry_credentials (id, credentials_json)
+		VALUES (?, ?)
+		ON CONFLICT(id) DO UPDATE SET credentials_json = excluded.credentials_json
+	`, id, string(data))
+	return err
+}
+
+func (s *StateStore) GetRegistryCredentials(id string) ([]domain.RegistryCredential, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var data string
+	err = db.QueryRow(`SELECT credentials_json FROM registry_credentials WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var credentials []domain.RegistryCredential
+	if err := json.Unmarshal([]byte(data), &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
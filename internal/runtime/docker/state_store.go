@@ -35,7 +35,13 @@ const scrollsTableSQL = `
 			procedures_json TEXT NOT NULL DEFAULT '{}',
 			routing_json TEXT NOT NULL DEFAULT '[]',
 			reserved_ports_json TEXT NOT NULL DEFAULT '[]',
-			ui_packages_json TEXT NOT NULL DEFAULT '{}'
+			ui_packages_json TEXT NOT NULL DEFAULT '{}',
+			wake_events_json TEXT NOT NULL DEFAULT '[]',
+			update_policy_json TEXT NOT NULL DEFAULT '',
+			update_history_json TEXT NOT NULL DEFAULT '[]',
+			image_lock_json TEXT NOT NULL DEFAULT '{}',
+			grants_json TEXT NOT NULL DEFAULT '[]',
+			resources_json TEXT NOT NULL DEFAULT ''
 		)
 	`
 
@@ -94,10 +100,34 @@ func (s *StateStore) CreateScroll(scroll *domain.RuntimeScroll) error {
 	if err != nil {
 		return err
 	}
+	wakeEvents, err := json.Marshal(scroll.WakeEvents)
+	if err != nil {
+		return err
+	}
+	updatePolicy, err := marshalUpdatePolicy(scroll.UpdatePolicy)
+	if err != nil {
+		return err
+	}
+	updateHistory, err := json.Marshal(scroll.UpdateHistory)
+	if err != nil {
+		return err
+	}
+	imageLock, err := json.Marshal(scroll.ImageLock)
+	if err != nil {
+		return err
+	}
+	grants, err := json.Marshal(scroll.Grants)
+	if err != nil {
+		return err
+	}
+	resources, err := marshalResources(scroll.Resources)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec(`
-			INSERT INTO scrolls (id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		`, scroll.ID, scroll.OwnerID, scroll.Artifact, scroll.ArtifactDigest, scroll.Root, scroll.ScrollName, scroll.ScrollYAML, scroll.Status, scroll.LastError, formatTime(scroll.CreatedAt), formatTime(scroll.UpdatedAt), string(procedures), string(routing), string(reservedPorts), string(uiPackages))
+			INSERT INTO scrolls (id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json, wake_events_json, update_policy_json, update_history_json, image_lock_json, grants_json, resources_json)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, scroll.ID, scroll.OwnerID, scroll.Artifact, scroll.ArtifactDigest, scroll.Root, scroll.ScrollName, scroll.ScrollYAML, scroll.Status, scroll.LastError, formatTime(scroll.CreatedAt), formatTime(scroll.UpdatedAt), string(procedures), string(routing), string(reservedPorts), string(uiPackages), string(wakeEvents), updatePolicy, string(updateHistory), string(imageLock), string(grants), resources)
 	if err != nil {
 		return fmt.Errorf("create runtime scroll %s: %w", scroll.ID, err)
 	}
@@ -112,7 +142,7 @@ func (s *StateStore) ListScrolls() ([]*domain.RuntimeScroll, error) {
 	defer db.Close()
 
 	rows, err := db.Query(`
-			SELECT id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json
+			SELECT id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json, wake_events_json, update_policy_json, update_history_json, image_lock_json, grants_json, resources_json
 			FROM scrolls
 			ORDER BY id
 		`)
@@ -140,7 +170,7 @@ func (s *StateStore) GetScroll(id string) (*domain.RuntimeScroll, error) {
 	defer db.Close()
 
 	row := db.QueryRow(`
-			SELECT id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json
+			SELECT id, owner_id, artifact, artifact_digest, root, scroll_name, scroll_yaml, status, last_error, created_at, updated_at, procedures_json, routing_json, reserved_ports_json, ui_packages_json, wake_events_json, update_policy_json, update_history_json, image_lock_json, grants_json, resources_json
 			FROM scrolls
 			WHERE id = ?
 		`, id)
@@ -175,11 +205,35 @@ func (s *StateStore) UpdateScroll(scroll *domain.RuntimeScroll) error {
 	if err != nil {
 		return err
 	}
+	wakeEvents, err := json.Marshal(scroll.WakeEvents)
+	if err != nil {
+		return err
+	}
+	updatePolicy, err := marshalUpdatePolicy(scroll.UpdatePolicy)
+	if err != nil {
+		return err
+	}
+	updateHistory, err := json.Marshal(scroll.UpdateHistory)
+	if err != nil {
+		return err
+	}
+	imageLock, err := json.Marshal(scroll.ImageLock)
+	if err != nil {
+		return err
+	}
+	grants, err := json.Marshal(scroll.Grants)
+	if err != nil {
+		return err
+	}
+	resources, err := marshalResources(scroll.Resources)
+	if err != nil {
+		return err
+	}
 	res, err := db.Exec(`
 		UPDATE scrolls
-			SET owner_id = ?, artifact = ?, artifact_digest = ?, root = ?, scroll_name = ?, scroll_yaml = ?, status = ?, last_error = ?, updated_at = ?, procedures_json = ?, routing_json = ?, reserved_ports_json = ?, ui_packages_json = ?
+			SET owner_id = ?, artifact = ?, artifact_digest = ?, root = ?, scroll_name = ?, scroll_yaml = ?, status = ?, last_error = ?, updated_at = ?, procedures_json = ?, routing_json = ?, reserved_ports_json = ?, ui_packages_json = ?, wake_events_json = ?, update_policy_json = ?, update_history_json = ?, image_lock_json = ?, grants_json = ?, resources_json = ?
 			WHERE id = ?
-		`, scroll.OwnerID, scroll.Artifact, scroll.ArtifactDigest, scroll.Root, scroll.ScrollName, scroll.ScrollYAML, scroll.Status, scroll.LastError, formatTime(scroll.UpdatedAt), string(procedures), string(routing), string(reservedPorts), string(uiPackages), scroll.ID)
+		`, scroll.OwnerID, scroll.Artifact, scroll.ArtifactDigest, scroll.Root, scroll.ScrollName, scroll.ScrollYAML, scroll.Status, scroll.LastError, formatTime(scroll.UpdatedAt), string(procedures), string(routing), string(reservedPorts), string(uiPackages), string(wakeEvents), updatePolicy, string(updateHistory), string(imageLock), string(grants), resources, scroll.ID)
 	if err != nil {
 		return err
 	}
@@ -211,7 +265,8 @@ func (s *StateStore) DeleteScroll(id string) error {
 	if changed == 0 {
 		return domain.ErrRuntimeScrollNotFound
 	}
-	return nil
+	_, err = db.Exec(`DELETE FROM registry_credentials WHERE id = ?`, id)
+	return err
 }
 
 func (s *StateStore) open() (*sql.DB, error) {
@@ -234,6 +289,18 @@ func (s *StateStore) open() (*sql.DB, error) {
 		db.Close()
 		return nil, err
 	}
+	if _, err := db.Exec(apiKeysTableSQL); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(auditTableSQL); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(registryCredentialsTableSQL); err != nil {
+		db.Close()
+		return nil, err
+	}
 	hasLegacyCommands, err := tableHasColumn(db, "scrolls", "commands_"+"json")
 	if err != nil {
 		db.Close()
@@ -278,6 +345,30 @@ func (s *StateStore) open() (*sql.DB, error) {
 		db.Close()
 		return nil, err
 	}
+	if err := ensureColumn(db, "scrolls", "wake_events_json", "TEXT NOT NULL DEFAULT '[]'"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureColumn(db, "scrolls", "update_policy_json", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureColumn(db, "scrolls", "update_history_json", "TEXT NOT NULL DEFAULT '[]'"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureColumn(db, "scrolls", "image_lock_json", "TEXT NOT NULL DEFAULT '{}'"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureColumn(db, "scrolls", "grants_json", "TEXT NOT NULL DEFAULT '[]'"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := ensureColumn(db, "scrolls", "resources_json", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		db.Close()
+		return nil, err
+	}
 	return db, nil
 }
 
@@ -346,7 +437,13 @@ func scanRuntimeScroll(scanner runtimeScrollScanner) (*domain.RuntimeScroll, err
 	var routingJSON string
 	var reservedPortsJSON string
 	var uiPackagesJSON string
-	if err := scanner.Scan(&scroll.ID, &scroll.OwnerID, &scroll.Artifact, &scroll.ArtifactDigest, &scroll.Root, &scroll.ScrollName, &scroll.ScrollYAML, &status, &lastError, &createdAt, &updatedAt, &proceduresJSON, &routingJSON, &reservedPortsJSON, &uiPackagesJSON); err != nil {
+	var wakeEventsJSON string
+	var updatePolicyJSON string
+	var updateHistoryJSON string
+	var imageLockJSON string
+	var grantsJSON string
+	var resourcesJSON string
+	if err := scanner.Scan(&scroll.ID, &scroll.OwnerID, &scroll.Artifact, &scroll.ArtifactDigest, &scroll.Root, &scroll.ScrollName, &scroll.ScrollYAML, &status, &lastError, &createdAt, &updatedAt, &proceduresJSON, &routingJSON, &reservedPortsJSON, &uiPackagesJSON, &wakeEventsJSON, &updatePolicyJSON, &updateHistoryJSON, &imageLockJSON, &grantsJSON, &resourcesJSON); err != nil {
 		return nil, err
 	}
 	scroll.Status = domain.RuntimeScrollStatus(status)
@@ -380,9 +477,59 @@ func scanRuntimeScroll(scanner runtimeScrollScanner) (*domain.RuntimeScroll, err
 	if err := json.Unmarshal([]byte(uiPackagesJSON), &scroll.UIPackages); err != nil {
 		return nil, err
 	}
+	if wakeEventsJSON == "" {
+		wakeEventsJSON = "[]"
+	}
+	if err := json.Unmarshal([]byte(wakeEventsJSON), &scroll.WakeEvents); err != nil {
+		return nil, err
+	}
+	if updatePolicyJSON != "" {
+		if err := json.Unmarshal([]byte(updatePolicyJSON), &scroll.UpdatePolicy); err != nil {
+			return nil, err
+		}
+	}
+	if updateHistoryJSON == "" {
+		updateHistoryJSON = "[]"
+	}
+	if err := json.Unmarshal([]byte(updateHistoryJSON), &scroll.UpdateHistory); err != nil {
+		return nil, err
+	}
+	if imageLockJSON == "" {
+		imageLockJSON = "{}"
+	}
+	if err := json.Unmarshal([]byte(imageLockJSON), &scroll.ImageLock); err != nil {
+		return nil, err
+	}
+	if grantsJSON == "" {
+		grantsJSON = "[]"
+	}
+	if err := json.Unmarshal([]byte(grantsJSON), &scroll.Grants); err != nil {
+		return nil, err
+	}
+	if resourcesJSON != "" {
+		if err := json.Unmarshal([]byte(resourcesJSON), &scroll.Resources); err != nil {
+			return nil, err
+		}
+	}
 	return &scroll, nil
 }
 
+func marshalUpdatePolicy(policy *domain.RuntimeUpdatePolicy) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(policy)
+	return string(data), err
+}
+
+func marshalResources(resources *domain.RuntimeResources) (string, error) {
+	if resources == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(resources)
+	return string(data), err
+}
+
 func formatTime(t time.Time) string {
 	return t.UTC().Format(time.RFC3339Nano)
 }
@@ -3,6 +3,7 @@ package docker
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
 )
@@ -42,6 +43,11 @@ func TestStateStorePersistsProcedureStatuses(t *testing.T) {
 		LastStatusChange: 20,
 	}
 	scroll.Status = domain.RuntimeScrollStatusError
+	scroll.WakeEvents = []domain.RuntimeWakeEvent{{ID: "wake-1", Source: domain.RuntimeWakeSourceIdle, Port: "game", RemoteAddress: "198.51.100.7:5000"}}
+	scroll.UpdatePolicy = &domain.RuntimeUpdatePolicy{Mode: domain.RuntimeUpdatePolicySemver, Constraint: "~1.2", Window: "03:00-05:00"}
+	scroll.UpdateHistory = []domain.RuntimeUpdateRecord{{Artifact: "registry.local/test:1.0", Digest: "sha256:one", Action: domain.RuntimeUpdateActionUpdate, Initiator: "local"}}
+	scroll.ImageLock = map[string]string{"alpine:3.20": "docker.io/library/alpine@sha256:one"}
+	scroll.Grants = []domain.RuntimeGrant{{Subject: "bob", Role: domain.RuntimeRoleViewer, Permissions: []string{"scroll:run:backup"}}}
 	if err := store.UpdateScroll(scroll); err != nil {
 		t.Fatal(err)
 	}
@@ -66,6 +72,33 @@ func TestStateStorePersistsProcedureStatuses(t *testing.T) {
 	if len(got.ReservedPorts) != 1 || got.ReservedPorts[0].Name != "ssh" {
 		t.Fatalf("reserved ports = %#v, want ssh", got.ReservedPorts)
 	}
+	if len(got.WakeEvents) != 1 || got.WakeEvents[0].RemoteAddress != "198.51.100.7:5000" {
+		t.Fatalf("wake events = %#v, want persisted wake", got.WakeEvents)
+	}
+	if got.UpdatePolicy == nil || got.UpdatePolicy.Constraint != "~1.2" || got.UpdatePolicy.Window != "03:00-05:00" {
+		t.Fatalf("update policy = %#v, want persisted policy", got.UpdatePolicy)
+	}
+	if len(got.UpdateHistory) != 1 || got.UpdateHistory[0].Digest != "sha256:one" || got.UpdateHistory[0].Initiator != "local" {
+		t.Fatalf("update history = %#v, want persisted record", got.UpdateHistory)
+	}
+	if got.ImageLock["alpine:3.20"] != "docker.io/library/alpine@sha256:one" {
+		t.Fatalf("image lock = %#v, want persisted pin", got.ImageLock)
+	}
+	if len(got.Grants) != 1 || got.Grants[0].Role != domain.RuntimeRoleViewer || got.Grants[0].Permissions[0] != "scroll:run:backup" {
+		t.Fatalf("grants = %#v, want persisted viewer grant", got.Grants)
+	}
+
+	scroll.UpdatePolicy = nil
+	if err := store.UpdateScroll(scroll); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetScroll("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UpdatePolicy != nil {
+		t.Fatalf("update policy = %#v, want cleared", got.UpdatePolicy)
+	}
 }
 
 func TestStateStorePersistsUIPackages(t *testing.T) {
@@ -110,3 +143,79 @@ func TestStateStoreUsesSingleRuntimeRoot(t *testing.T) {
 		t.Fatalf("Root = %s, want %s", got, want)
 	}
 }
+
+func TestStateStoreFiltersAndPrunesAuditRecords(t *testing.T) {
+	store, err := NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	for i, record := range []domain.AuditRecord{
+		{ID: "a", Subject: "alice", ScrollID: "scroll-1", Action: "scroll.stop", Result: domain.AuditResultOK},
+		{ID: "b", Subject: "bob", ScrollID: "scroll-1", Action: domain.AuditActionConsoleInput, Params: map[string]string{"input": "op bob"}, Result: domain.AuditResultOK},
+		{ID: "c", Subject: "alice", ScrollID: "scroll-2", Action: "scroll.start", Result: domain.AuditResultDenied, Status: 403},
+	} {
+		record.At = base.Add(time.Duration(i) * time.Hour)
+		if err := store.AppendAudit(&record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := store.ListAudit(domain.AuditFilter{ScrollID: "scroll-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].ID != "a" || records[1].Params["input"] != "op bob" {
+		t.Fatalf("scroll-1 records = %#v", records)
+	}
+	records, err = store.ListAudit(domain.AuditFilter{Subject: "alice", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].ID != "c" || records[0].Status != 403 || !records[0].At.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("newest alice record = %#v", records)
+	}
+
+	pruned, err := store.PruneAudit(base.Add(90 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err = store.ListAudit(domain.AuditFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != 2 || len(records) != 1 || records[0].ID != "c" {
+		t.Fatalf("pruned %d, left %#v", pruned, records)
+	}
+}
+
+func TestStateStoreKeepsRegistryCredentialsUntilTheScrollIsDeleted(t *testing.T) {
+	store, err := NewStateStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateScroll(&domain.RuntimeScroll{ID: "scroll-a", Artifact: "registry.local/a:1"}); err != nil {
+		t.Fatal(err)
+	}
+	credentials := []domain.RegistryCredential{{Host: "registry.local", Username: "alice", Password: "secret"}}
+	if err := store.SetRegistryCredentials("scroll-a", credentials); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetRegistryCredentials("scroll-a", credentials); err != nil {
+		t.Fatalf("replacing credentials: %v", err)
+	}
+	got, err := store.GetRegistryCredentials("scroll-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != credentials[0] {
+		t.Fatalf("credentials = %#v", got)
+	}
+
+	if err := store.DeleteScroll("scroll-a"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.GetRegistryCredentials("scroll-a"); err != nil || got != nil {
+		t.Fatalf("after delete: credentials = %#v, err = %v", got, err)
+	}
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -16,6 +17,36 @@ func TestConfigWithDefaultsUsesDockerVolumeStorage(t *testing.T) {
 	if config.VolumePrefix != "druid" {
 		t.Fatalf("volume prefix = %s, want druid", config.VolumePrefix)
 	}
+	if config.WorkerUID != defaultWorkerUID || config.WorkerGID != defaultWorkerUID {
+		t.Fatalf("worker user = %d:%d, want the worker image's druid user", config.WorkerUID, config.WorkerGID)
+	}
+}
+
+func TestBlobCacheMountDropsWorldWritableModes(t *testing.T) {
+	dir := t.TempDir()
+	blob := filepath.Join(dir, "blobs", "sha256", "abc")
+	if err := os.MkdirAll(filepath.Dir(blob), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(blob, []byte("layer"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(blob, 0666); err != nil {
+		t.Fatal(err)
+	}
+	backend := &Backend{config: Config{BlobCache: dir, WorkerUID: os.Getuid(), WorkerGID: os.Getgid()}}
+	if _, ok := backend.blobCacheMount(); !ok {
+		t.Fatal("blob cache was not mounted")
+	}
+	for path, want := range map[string]os.FileMode{dir: 0755, filepath.Dir(blob): 0755, blob: 0644} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != want {
+			t.Fatalf("%s mode = %v, want %v", path, info.Mode().Perm(), want)
+		}
+	}
 }
 
 func TestRuntimeRootRefUsesVolumeByDefault(t *testing.T) {
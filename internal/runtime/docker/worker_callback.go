@@ -21,6 +21,10 @@ func (b *Backend) WorkerCallbackAfterListen(config ports.RuntimeWorkerCallbackCo
 	if err != nil {
 		return config, err
 	}
-	config.URL = "http://host.docker.internal:" + port
+	scheme := "http"
+	if config.TLS {
+		scheme = "https"
+	}
+	config.URL = scheme + "://host.docker.internal:" + port
 	return config, nil
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +15,8 @@ import (
 	"github.com/docker/docker/api/types/mount"
 	"github.com/highcard-dev/daemon/internal/core/domain"
 	"github.com/highcard-dev/daemon/internal/core/ports"
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
 )
 
 func (b *Backend) BackupRuntime(ctx context.Context, root string, artifact string, registryCredentials []domain.RegistryCredential) error {
@@ -114,7 +117,9 @@ func (b *Backend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorke
 	}
 	registryConfig, err := json.Marshal(struct {
 		Registries []domain.RegistryCredential `json:"registries"`
-	}{Registries: action.RegistryCredentials})
+		Trust      *domain.ScrollTrustPolicy   `json:"trust,omitempty"`
+		domain.RegistryConfig
+	}{Registries: action.RegistryCredentials, Trust: action.TrustPolicy, RegistryConfig: action.RegistryConfig})
 	if err != nil {
 		return err
 	}
@@ -137,6 +142,11 @@ func (b *Backend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorke
 			artifact = "/artifact-src/" + filepath.Base(abs)
 		}
 	}
+	blobCacheEnv := "DRUID_BLOB_CACHE_DIR=off"
+	if cacheMount, ok := b.blobCacheMount(); ok {
+		mounts = append(mounts, cacheMount)
+		blobCacheEnv = "DRUID_BLOB_CACHE_DIR=" + cacheMount.Target
+	}
 	hostConfig := &container.HostConfig{Mounts: mounts, ExtraHosts: dockerExtraHosts()}
 	if b.config.Network != "" {
 		hostConfig.NetworkMode = container.NetworkMode(b.config.Network)
@@ -157,6 +167,8 @@ func (b *Backend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorke
 		Env: dockerWorkerEnv([]string{
 			"DRUID_WORKER_TOKEN_FILE=" + action.TokenFile,
 			"DRUID_RUNTIME_REGISTRY_CONFIG_JSON=" + string(registryConfig),
+			"DRUID_WORKER_CALLBACK_CA=" + action.CallbackCA,
+			blobCacheEnv,
 		}),
 		Labels: map[string]string{
 			"druid.worker":     "pull",
@@ -194,3 +206,50 @@ func (b *Backend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorke
 	}
 	return nil
 }
+
+// blobCacheMount binds the shared registry blob cache into pull workers. The
+// workers run as the image's druid user, so the cache is handed to that user
+// rather than made world writable; the daemon's own pulls keep what they add
+// owned by it.
+func (b *Backend) blobCacheMount() (mount.Mount, bool) {
+	dir := b.config.BlobCache
+	if dir == "" || dir == "off" {
+		return mount.Mount{}, false
+	}
+	abs, err := filepath.Abs(dir)
+	if err == nil {
+		err = os.MkdirAll(abs, 0755)
+	}
+	if err != nil {
+		logger.Log().Warn("Pull worker blob cache disabled", zap.String("dir", dir), zap.Error(err))
+		return mount.Mount{}, false
+	}
+	b.blobCacheOnce.Do(func() {
+		if err := handOverBlobCache(abs, b.config.WorkerUID, b.config.WorkerGID); err != nil {
+			logger.Log().Warn("Failed to hand the blob cache to the pull worker user", zap.String("dir", abs), zap.Int("uid", b.config.WorkerUID), zap.Error(err))
+		}
+	})
+	return mount.Mount{Type: mount.TypeBind, Source: abs, Target: "/var/cache/druid/blobs"}, true
+}
+
+// handOverBlobCache makes uid:gid own everything in the cache at dir, with
+// directories 0755 and files 0644, so caches written by older versions lose
+// their world writable modes.
+func handOverBlobCache(dir string, uid int, gid int) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return os.Remove(path)
+		}
+		mode := fs.FileMode(0644)
+		if d.IsDir() {
+			mode = 0755
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+}
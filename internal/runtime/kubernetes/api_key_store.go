@@ -0,0 +1,106 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const (
+	apiKeyComponent = "api-key"
+
+	secretKeyAPIKey     = "api_key_json"
+	secretKeyAPIKeyHash = "hash"
+)
+
+// API keys are kept one Secret each. The key itself is never stored, but the
+// hash still should not be readable with ConfigMap access.
+
+func (s *ConfigMapStateStore) CreateAPIKey(key *domain.RuntimeAPIKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiKeySecretName(key.ID),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				labelManagedBy: "druid",
+				labelComponent: apiKeyComponent,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			secretKeyAPIKey:     data,
+			secretKeyAPIKeyHash: []byte(key.Hash),
+		},
+	}
+	_, err = s.client.CoreV1().Secrets(s.namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	return err
+}
+
+func (s *ConfigMapStateStore) ListAPIKeys() ([]*domain.RuntimeAPIKey, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		labelManagedBy: "druid",
+		labelComponent: apiKeyComponent,
+	})
+	secrets, err := s.client.CoreV1().Secrets(s.namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*domain.RuntimeAPIKey, 0, len(secrets.Items))
+	for i := range secrets.Items {
+		key, err := apiKeyFromSecret(&secrets.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (s *ConfigMapStateStore) GetAPIKey(id string) (*domain.RuntimeAPIKey, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(context.Background(), apiKeySecretName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, domain.ErrRuntimeAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return apiKeyFromSecret(secret)
+}
+
+func (s *ConfigMapStateStore) DeleteAPIKey(id string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(context.Background(), apiKeySecretName(id), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return domain.ErrRuntimeAPIKeyNotFound
+	}
+	return err
+}
+
+func apiKeyFromSecret(secret *corev1.Secret) (*domain.RuntimeAPIKey, error) {
+	var key domain.RuntimeAPIKey
+	if err := json.Unmarshal(secret.Data[secretKeyAPIKey], &key); err != nil {
+		return nil, err
+	}
+	key.Hash = string(secret.Data[secretKeyAPIKeyHash])
+	return &key, nil
+}
+
+func apiKeySecretName(id string) string {
+	return dnsLabel("druid-apikey-" + id)
+}
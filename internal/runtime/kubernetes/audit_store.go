@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const (
+	auditComponent = "audit"
+	labelAuditHour = "druid.gg/audit-hour"
+
+	// auditChunkRecords keeps audit ConfigMaps well below the 1 MiB object
+	// limit; an hour with more records spills into further chunks.
+	auditChunkRecords = 500
+)
+
+// Audit records are kept in ConfigMaps per UTC hour, one data key per
+// record, so retention can drop whole chunks.
+
+func (s *ConfigMapStateStore) AppendAudit(record *domain.AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	hour := record.At.UTC().Format("2006010215")
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		chunks, err := s.auditChunks(labels.Set{labelAuditHour: hour})
+		if err != nil {
+			return err
+		}
+		if n := len(chunks); n > 0 && len(chunks[n-1].Data) < auditChunkRecords {
+			chunk := chunks[n-1].DeepCopy()
+			if chunk.Data == nil {
+				chunk.Data = map[string]string{}
+			}
+			chunk.Data[record.ID] = string(data)
+			_, err := s.client.CoreV1().ConfigMaps(s.namespace).Update(context.Background(), chunk, metav1.UpdateOptions{})
+			return err
+		}
+		name := fmt.Sprintf("druid-audit-%s-%03d", hour, len(chunks))
+		chunk := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					labelManagedBy: "druid",
+					labelComponent: auditComponent,
+					labelAuditHour: hour,
+				},
+			},
+			Data: map[string]string{record.ID: string(data)},
+		}
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(context.Background(), chunk, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, name, err)
+		}
+		return err
+	})
+}
+
+func (s *ConfigMapStateStore) ListAudit(filter domain.AuditFilter) ([]*domain.AuditRecord, error) {
+	chunks, err := s.auditChunks(nil)
+	if err != nil {
+		return nil, err
+	}
+	records := []*domain.AuditRecord{}
+	for i := range chunks {
+		chunkRecords, err := auditRecordsFromConfigMap(&chunks[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range chunkRecords {
+			if filter.Matches(record) {
+				records = append(records, record)
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].At.Equal(records[j].At) {
+			return records[i].ID < records[j].ID
+		}
+		return records[i].At.Before(records[j].At)
+	})
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, nil
+}
+
+func (s *ConfigMapStateStore) PruneAudit(before time.Time) (int, error) {
+	chunks, err := s.auditChunks(nil)
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for i := range chunks {
+		chunk := chunks[i].DeepCopy()
+		records, err := auditRecordsFromConfigMap(chunk)
+		if err != nil {
+			return pruned, err
+		}
+		expired := 0
+		for _, record := range records {
+			if record.At.Before(before) {
+				delete(chunk.Data, record.ID)
+				expired++
+			}
+		}
+		if expired == 0 {
+			continue
+		}
+		if len(chunk.Data) == 0 {
+			err = s.client.CoreV1().ConfigMaps(s.namespace).Delete(context.Background(), chunk.Name, metav1.DeleteOptions{})
+		} else {
+			_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(context.Background(), chunk, metav1.UpdateOptions{})
+		}
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return pruned, err
+		}
+		if err == nil {
+			pruned += expired
+		}
+	}
+	return pruned, nil
+}
+
+// auditChunks lists audit ConfigMaps matching extra labels, oldest first.
+func (s *ConfigMapStateStore) auditChunks(extra labels.Set) ([]corev1.ConfigMap, error) {
+	set := labels.Set{
+		labelManagedBy: "druid",
+		labelComponent: auditComponent,
+	}
+	for name, value := range extra {
+		set[name] = value
+	}
+	list, err := s.client.CoreV1().ConfigMaps(s.namespace).List(context.Background(), metav1.ListOptions{LabelSelector: labels.SelectorFromSet(set).String()})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	return list.Items, nil
+}
+
+func auditRecordsFromConfigMap(configMap *corev1.ConfigMap) ([]*domain.AuditRecord, error) {
+	records := make([]*domain.AuditRecord, 0, len(configMap.Data))
+	for id, data := range configMap.Data {
+		var record domain.AuditRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("decode audit record %s in %s: %w", id, configMap.Name, err)
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
@@ -2,7 +2,6 @@ package kubernetes
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -14,7 +13,7 @@ import (
 )
 
 func (b *Backend) keepAliveTrafficIdleStopper(namespace string, root string, commandName string, procedureName string, procedure *domain.Procedure, globalPorts []domain.Port) jobIdleStopFunc {
-	if procedure == nil || coldstarterProcedure(procedureName, procedure) {
+	if procedure == nil || domain.IsColdstarterProcedure(procedureName, procedure) {
 		return nil
 	}
 	ports := portsByName(globalPorts)
@@ -119,18 +118,3 @@ func keepAliveWindowElapsed(now time.Time, created metav1.Time, window time.Dura
 	}
 	return now.Sub(created.Time) >= window
 }
-
-func coldstarterProcedure(procedureName string, procedure *domain.Procedure) bool {
-	if strings.EqualFold(procedureName, "coldstart") {
-		return true
-	}
-	if procedure == nil {
-		return false
-	}
-	for _, part := range procedure.Command {
-		if strings.Contains(part, "druid-coldstarter") {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/highcard-dev/daemon/internal/core/domain"
+)
+
+const (
+	registryCredentialsComponent = "registry-credentials"
+
+	secretKeyRegistryCredentials = "registry_credentials_json"
+)
+
+// The registry credentials of a runtime are kept in a Secret of their own,
+// next to the ConfigMap with its state.
+
+func (s *ConfigMapStateStore) SetRegistryCredentials(id string, credentials []domain.RegistryCredential) error {
+	if len(credentials) == 0 {
+		return s.deleteRegistryCredentials(id)
+	}
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+	secret, err := secrets.Get(context.Background(), registryCredentialsSecretName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      registryCredentialsSecretName(id),
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					labelManagedBy: "druid",
+					labelComponent: registryCredentialsComponent,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{secretKeyRegistryCredentials: data},
+		}
+		_, err = secrets.Create(context.Background(), secret, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	secret.Data = map[string][]byte{secretKeyRegistryCredentials: data}
+	_, err = secrets.Update(context.Background(), secret, metav1.UpdateOptions{})
+	return err
+}
+
+func (s *ConfigMapStateStore) GetRegistryCredentials(id string) ([]domain.RegistryCredential, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(context.Background(), registryCredentialsSecretName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var credentials []domain.RegistryCredential
+	if err := json.Unmarshal(secret.Data[secretKeyRegistryCredentials], &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func (s *ConfigMapStateStore) deleteRegistryCredentials(id string) error {
+	err := s.client.CoreV1().Secrets(s.namespace).Delete(context.Background(), registryCredentialsSecretName(id), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func registryCredentialsSecretName(id string) string {
+	return dnsLabel("druid-credentials-" + id)
+}
@@ -107,6 +107,9 @@ func workerPullJobSpec(namespace string, jobName string, pvc string, image strin
 	if registryPlainHTTP {
 		container.Env = append(container.Env, corev1.EnvVar{Name: "DRUID_REGISTRY_PLAIN_HTTP", Value: "true"})
 	}
+	if action.CallbackCA != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "DRUID_WORKER_CALLBACK_CA", Value: action.CallbackCA})
+	}
 	pod := &job.Spec.Template.Spec
 	pod.ServiceAccountName = runtimeWorkerServiceAccount
 	pod.AutomountServiceAccountToken = ptrBool(false)
@@ -726,7 +726,7 @@ func TestRegistryConfigSecretUsesDruidClientConfigShape(t *testing.T) {
 		Host:     "artifacts.druid.gg/user/scroll",
 		Username: "robot$scroll",
 		Password: "secret",
-	}})
+	}}, nil, domain.RegistryConfig{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -746,6 +746,61 @@ func TestRegistryConfigSecretUsesDruidClientConfigShape(t *testing.T) {
 	}
 }
 
+func TestRegistryConfigSecretCarriesTrustPolicyWithoutCredentials(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	backend := NewWithClient(Config{Namespace: "druid"}, coreservices.NewConsoleManager(coreservices.NewLogManager()), client)
+	trust := &domain.ScrollTrustPolicy{Rules: []domain.ScrollTrustRule{{Repo: "artifacts.druid.gg/dev/*", AllowUnsigned: true}}}
+	secretName, cleanup, err := backend.createRegistryConfigSecret(context.Background(), "druid", "artifact", nil, trust, domain.RegistryConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if secretName == "" {
+		t.Fatal("trust policy without credentials did not create a registry config secret")
+	}
+	secret, err := client.CoreV1().Secrets("druid").Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Trust *domain.ScrollTrustPolicy `json:"trust"`
+	}
+	if err := json.Unmarshal(secret.Data[registryConfigSecretKey], &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Trust == nil || len(config.Trust.Rules) != 1 || !config.Trust.Rules[0].AllowUnsigned {
+		t.Fatalf("trust = %#v", config.Trust)
+	}
+}
+
+func TestRegistryConfigSecretCarriesMirrorsAndRewrites(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	backend := NewWithClient(Config{Namespace: "druid"}, coreservices.NewConsoleManager(coreservices.NewLogManager()), client)
+	registryConfig := domain.RegistryConfig{
+		Hosts:    []domain.RegistryHost{{Host: "mirror.local", PlainHTTP: true}},
+		Rewrites: []domain.RegistryRewrite{{From: "artifacts.druid.gg/*", To: "mirror.local/druid/*"}},
+	}
+	secretName, cleanup, err := backend.createRegistryConfigSecret(context.Background(), "druid", "artifact", nil, nil, registryConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	if secretName == "" {
+		t.Fatal("registry config without credentials did not create a registry config secret")
+	}
+	secret, err := client.CoreV1().Secrets("druid").Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config domain.RegistryConfig
+	if err := json.Unmarshal(secret.Data[registryConfigSecretKey], &config); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Rewrites) != 1 || config.Rewrites[0].To != "mirror.local/druid/*" || len(config.Hosts) != 1 || !config.Hosts[0].PlainHTTP {
+		t.Fatalf("config = %#v", config)
+	}
+}
+
 func TestExpectedPortsUsesPodStatsTraffic(t *testing.T) {
 	client := fake.NewSimpleClientset()
 	backend := NewWithClient(Config{Namespace: "druid"}, coreservices.NewConsoleManager(coreservices.NewLogManager()), client)
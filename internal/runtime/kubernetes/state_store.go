@@ -34,6 +34,12 @@ const (
 	configMapKeyRoutingJSON    = "routing_json"
 	configMapKeyReservedPorts  = "reserved_ports_json"
 	configMapKeyUIPackagesJSON = "ui_packages_json"
+	configMapKeyWakeEventsJSON = "wake_events_json"
+	configMapKeyUpdatePolicy   = "update_policy_json"
+	configMapKeyUpdateHistory  = "update_history_json"
+	configMapKeyImageLock      = "image_lock_json"
+	configMapKeyGrants         = "grants_json"
+	configMapKeyResources      = "resources_json"
 )
 
 type ConfigMapStateStore struct {
@@ -140,6 +146,9 @@ func (s *ConfigMapStateStore) UpdateScroll(scroll *domain.RuntimeScroll) error {
 	if scroll.ReservedPorts == nil {
 		scroll.ReservedPorts = currentScroll.ReservedPorts
 	}
+	if scroll.WakeEvents == nil {
+		scroll.WakeEvents = currentScroll.WakeEvents
+	}
 	scroll.UpdatedAt = time.Now().UTC()
 	next, err := runtimeScrollConfigMap(s.namespace, scroll)
 	if err != nil {
@@ -173,7 +182,10 @@ func (s *ConfigMapStateStore) DeleteScroll(id string) error {
 	if apierrors.IsNotFound(err) {
 		return domain.ErrRuntimeScrollNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return s.deleteRegistryCredentials(id)
 }
 
 func runtimeScrollConfigMap(namespace string, scroll *domain.RuntimeScroll) (*corev1.ConfigMap, error) {
@@ -193,6 +205,38 @@ func runtimeScrollConfigMap(namespace string, scroll *domain.RuntimeScroll) (*co
 	if err != nil {
 		return nil, err
 	}
+	wakeEvents, err := json.Marshal(scroll.WakeEvents)
+	if err != nil {
+		return nil, err
+	}
+	updateHistory, err := json.Marshal(scroll.UpdateHistory)
+	if err != nil {
+		return nil, err
+	}
+	imageLock, err := json.Marshal(scroll.ImageLock)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := json.Marshal(scroll.Grants)
+	if err != nil {
+		return nil, err
+	}
+	updatePolicy := ""
+	if scroll.UpdatePolicy != nil {
+		data, err := json.Marshal(scroll.UpdatePolicy)
+		if err != nil {
+			return nil, err
+		}
+		updatePolicy = string(data)
+	}
+	resources := ""
+	if scroll.Resources != nil {
+		data, err := json.Marshal(scroll.Resources)
+		if err != nil {
+			return nil, err
+		}
+		resources = string(data)
+	}
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      scrollConfigMapName(scroll.ID),
@@ -220,6 +264,12 @@ func runtimeScrollConfigMap(namespace string, scroll *domain.RuntimeScroll) (*co
 			configMapKeyRoutingJSON:    string(routing),
 			configMapKeyReservedPorts:  string(reservedPorts),
 			configMapKeyUIPackagesJSON: string(uiPackages),
+			configMapKeyWakeEventsJSON: string(wakeEvents),
+			configMapKeyUpdatePolicy:   updatePolicy,
+			configMapKeyUpdateHistory:  string(updateHistory),
+			configMapKeyImageLock:      string(imageLock),
+			configMapKeyGrants:         string(grants),
+			configMapKeyResources:      resources,
 		},
 	}, nil
 }
@@ -258,6 +308,50 @@ func runtimeScrollFromConfigMap(configMap *corev1.ConfigMap) (*domain.RuntimeScr
 	if err := json.Unmarshal([]byte(uiPackagesJSON), &uiPackages); err != nil {
 		return nil, err
 	}
+	wakeEventsJSON := data[configMapKeyWakeEventsJSON]
+	if wakeEventsJSON == "" {
+		wakeEventsJSON = "[]"
+	}
+	var wakeEvents []domain.RuntimeWakeEvent
+	if err := json.Unmarshal([]byte(wakeEventsJSON), &wakeEvents); err != nil {
+		return nil, err
+	}
+	var updatePolicy *domain.RuntimeUpdatePolicy
+	if updatePolicyJSON := data[configMapKeyUpdatePolicy]; updatePolicyJSON != "" {
+		if err := json.Unmarshal([]byte(updatePolicyJSON), &updatePolicy); err != nil {
+			return nil, err
+		}
+	}
+	updateHistoryJSON := data[configMapKeyUpdateHistory]
+	if updateHistoryJSON == "" {
+		updateHistoryJSON = "[]"
+	}
+	var updateHistory []domain.RuntimeUpdateRecord
+	if err := json.Unmarshal([]byte(updateHistoryJSON), &updateHistory); err != nil {
+		return nil, err
+	}
+	imageLockJSON := data[configMapKeyImageLock]
+	if imageLockJSON == "" {
+		imageLockJSON = "{}"
+	}
+	var imageLock map[string]string
+	if err := json.Unmarshal([]byte(imageLockJSON), &imageLock); err != nil {
+		return nil, err
+	}
+	grantsJSON := data[configMapKeyGrants]
+	if grantsJSON == "" {
+		grantsJSON = "[]"
+	}
+	var grants []domain.RuntimeGrant
+	if err := json.Unmarshal([]byte(grantsJSON), &grants); err != nil {
+		return nil, err
+	}
+	var resources *domain.RuntimeResources
+	if resourcesJSON := data[configMapKeyResources]; resourcesJSON != "" {
+		if err := json.Unmarshal([]byte(resourcesJSON), &resources); err != nil {
+			return nil, err
+		}
+	}
 	id := data[configMapKeyID]
 	if id == "" {
 		id = configMap.Labels[labelScrollID]
@@ -275,6 +369,12 @@ func runtimeScrollFromConfigMap(configMap *corev1.ConfigMap) (*domain.RuntimeScr
 		Routing:        routing,
 		ReservedPorts:  reservedPorts,
 		UIPackages:     uiPackages,
+		WakeEvents:     wakeEvents,
+		UpdatePolicy:   updatePolicy,
+		UpdateHistory:  updateHistory,
+		ImageLock:      imageLock,
+		Grants:         grants,
+		Resources:      resources,
 		CreatedAt:      parseRuntimeTime(data[configMapKeyCreatedAt]),
 		UpdatedAt:      parseRuntimeTime(data[configMapKeyUpdatedAt]),
 		Procedures:     procedures,
@@ -2,10 +2,12 @@ package kubernetes
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/highcard-dev/daemon/internal/core/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -249,3 +251,76 @@ func TestConfigMapStateStoreDerivesKubernetesRoots(t *testing.T) {
 		t.Fatalf("Root = %s, want %s", got, want)
 	}
 }
+
+func TestConfigMapStateStoreRoundTripsAPIKeys(t *testing.T) {
+	store := NewConfigMapStateStoreWithClient("druid", fake.NewSimpleClientset())
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	key := &domain.RuntimeAPIKey{
+		ID:        "0123abcd",
+		Name:      "ci",
+		Hash:      "hash",
+		Role:      domain.RuntimeRoleViewer,
+		Scrolls:   []string{"scroll-1"},
+		ExpiresAt: &expires,
+		CreatedAt: expires.Add(-time.Hour),
+	}
+	if err := store.CreateAPIKey(key); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetAPIKey(key.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hash != "hash" || got.Role != domain.RuntimeRoleViewer || len(got.Scrolls) != 1 || !got.ExpiresAt.Equal(expires) {
+		t.Fatalf("api key = %#v", got)
+	}
+	keys, err := store.ListAPIKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("listed %d api keys, want 1", len(keys))
+	}
+	if err := store.DeleteAPIKey(key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetAPIKey(key.ID); !errors.Is(err, domain.ErrRuntimeAPIKeyNotFound) {
+		t.Fatalf("GetAPIKey error = %v, want domain.ErrRuntimeAPIKeyNotFound", err)
+	}
+}
+
+func TestConfigMapStateStoreKeepsRegistryCredentialsInASecret(t *testing.T) {
+	store := NewConfigMapStateStoreWithClient("druid", fake.NewSimpleClientset())
+	scroll := &domain.RuntimeScroll{ID: "private", Artifact: "registry.local/private:1", Root: ref("druid", "druid-private-data")}
+	if err := store.CreateScroll(scroll); err != nil {
+		t.Fatal(err)
+	}
+	for _, password := range []string{"first", "second"} {
+		if err := store.SetRegistryCredentials("private", []domain.RegistryCredential{{Host: "registry.local", Username: "alice", Password: password}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := store.GetRegistryCredentials("private")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Password != "second" {
+		t.Fatalf("credentials = %#v", got)
+	}
+	configMap, err := store.client.CoreV1().ConfigMaps("druid").Get(t.Context(), scrollConfigMapName("private"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range configMap.Data {
+		if strings.Contains(value, "second") {
+			t.Fatalf("config map key %s holds the password", key)
+		}
+	}
+
+	if err := store.DeleteScroll("private"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.client.CoreV1().Secrets("druid").Get(t.Context(), registryCredentialsSecretName("private"), metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("secret after delete: err = %v, want not found", err)
+	}
+}
@@ -64,7 +64,7 @@ func (b *Backend) SpawnPullWorker(ctx context.Context, action ports.RuntimeWorke
 			return err
 		}
 	}
-	registryConfigSecret, cleanupRegistryConfig, err := b.createRegistryConfigSecret(ctx, namespace, action.Artifact+action.RuntimeID, action.RegistryCredentials)
+	registryConfigSecret, cleanupRegistryConfig, err := b.createRegistryConfigSecret(ctx, namespace, action.Artifact+action.RuntimeID, action.RegistryCredentials, action.TrustPolicy, action.RegistryConfig)
 	if err != nil {
 		logger.Log().Error("Failed to create registry config secret for pull worker", zap.String("runtime_id", action.RuntimeID), zap.String("namespace", namespace), zap.Error(err))
 		return err
@@ -97,7 +97,7 @@ func (b *Backend) BackupRuntime(ctx context.Context, root string, artifact strin
 		return err
 	}
 	logger.Log().Info("Backing up Kubernetes runtime", zap.String("namespace", namespace), zap.String("pvc", pvc), zap.String("artifact", artifact))
-	registryConfigSecret, cleanupRegistryConfig, err := b.createRegistryConfigSecret(ctx, namespace, artifact+root, registryCredentials)
+	registryConfigSecret, cleanupRegistryConfig, err := b.createRegistryConfigSecret(ctx, namespace, artifact+root, registryCredentials, nil, domain.RegistryConfig{})
 	if err != nil {
 		logger.Log().Error("Failed to create registry config secret for Kubernetes backup", zap.String("namespace", namespace), zap.String("artifact", artifact), zap.Error(err))
 		return err
@@ -144,14 +144,16 @@ func (b *Backend) ensurePVC(ctx context.Context, namespace string, name string,
 	return err
 }
 
-func (b *Backend) createRegistryConfigSecret(ctx context.Context, namespace string, seed string, credentials []domain.RegistryCredential) (string, func(), error) {
-	if len(credentials) == 0 {
+func (b *Backend) createRegistryConfigSecret(ctx context.Context, namespace string, seed string, credentials []domain.RegistryCredential, trust *domain.ScrollTrustPolicy, registryConfig domain.RegistryConfig) (string, func(), error) {
+	if len(credentials) == 0 && !trust.Enabled() && registryConfig.Empty() {
 		logger.Log().Debug("No registry credentials supplied; skipping Kubernetes registry config secret", zap.String("namespace", namespace))
 		return "", func() {}, nil
 	}
 	data, err := json.Marshal(struct {
 		Registries []domain.RegistryCredential `json:"registries"`
-	}{Registries: credentials})
+		Trust      *domain.ScrollTrustPolicy   `json:"trust,omitempty"`
+		domain.RegistryConfig
+	}{Registries: credentials, Trust: trust, RegistryConfig: registryConfig})
 	if err != nil {
 		logger.Log().Error("Failed to marshal registry credentials for Kubernetes secret", zap.String("namespace", namespace), zap.Int("registries", len(credentials)), zap.Error(err))
 		return "", nil, err
@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/highcard-dev/daemon/internal/utils/logger"
+	"go.uber.org/zap"
+)
+
+// tlsReloadCheckInterval bounds how often handshakes stat the watched files.
+var tlsReloadCheckInterval = time.Second
+
+// reloadingFiles holds a value loaded from files and loads it again once any
+// of them changes. A failed reload keeps the last good value, so a renewal
+// that writes the certificate before the key does not break handshakes.
+type reloadingFiles[T any] struct {
+	files []string
+	load  func() (T, error)
+
+	mu      sync.Mutex
+	value   T
+	stamp   string
+	checked time.Time
+}
+
+func newReloadingFiles[T any](load func() (T, error), files ...string) (*reloadingFiles[T], error) {
+	r := &reloadingFiles[T]{files: files, load: load}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.value = value
+	r.stamp = r.fileStamp()
+	r.checked = time.Now()
+	return r, nil
+}
+
+func (r *reloadingFiles[T]) get() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) < tlsReloadCheckInterval {
+		return r.value
+	}
+	r.checked = time.Now()
+	stamp := r.fileStamp()
+	if stamp == r.stamp {
+		return r.value
+	}
+	value, err := r.load()
+	if err != nil {
+		logger.Log().Warn("Failed to reload TLS files; keeping the previous ones", zap.Strings("files", r.files), zap.Error(err))
+		return r.value
+	}
+	logger.Log().Info("Reloaded TLS files", zap.Strings("files", r.files))
+	r.value = value
+	r.stamp = stamp
+	return r.value
+}
+
+func (r *reloadingFiles[T]) fileStamp() string {
+	parts := make([]string, 0, len(r.files))
+	for _, file := range r.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			parts = append(parts, "missing")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size()))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ServerTLSConfig serves certFile and keyFile and picks up renewed files
+// without a restart. With clientCAFile, clients must present a certificate
+// signed by one of its CAs, which is reloaded the same way.
+func ServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("TLS needs both a certificate and a key file")
+	}
+	cert, err := newReloadingFiles(func() (*tls.Certificate, error) {
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &pair, nil
+	}, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return cert.get(), nil
+		},
+	}
+	if clientCAFile == "" {
+		return config, nil
+	}
+	clientCAs, err := newReloadingFiles(func() (*x509.CertPool, error) {
+		return certPoolFromFile(clientCAFile)
+	}, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		next := config.Clone()
+		next.GetConfigForClient = nil
+		next.ClientAuth = tls.RequireAndVerifyClientCert
+		next.ClientCAs = clientCAs.get()
+		return next, nil
+	}
+	return config, nil
+}
+
+// ClientTLSConfig trusts the CAs in caFile on top of the system roots and
+// presents certFile and keyFile as client certificate. It returns nil when
+// all of them are empty.
+func ClientTLSConfig(caFile string, certFile string, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := systemCertPoolWith(pem)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", caFile, err)
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+	return config, nil
+}
+
+// ClientTLSConfigFromPEM trusts the PEM encoded CAs on top of the system
+// roots. It returns nil for an empty PEM.
+func ClientTLSConfigFromPEM(caPEM string) (*tls.Config, error) {
+	if strings.TrimSpace(caPEM) == "" {
+		return nil, nil
+	}
+	pool, err := systemCertPoolWith([]byte(caPEM))
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}, nil
+}
+
+func certPoolFromFile(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s holds no PEM certificates", file)
+	}
+	return pool, nil
+}
+
+func systemCertPoolWith(pem []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found")
+	}
+	return pool, nil
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, name string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerTLSConfigReloadsChangedCertificate(t *testing.T) {
+	previous := tlsReloadCheckInterval
+	tlsReloadCheckInterval = 0
+	t.Cleanup(func() { tlsReloadCheckInterval = previous })
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := newTestCert(t, "first", nil, x509.ExtKeyUsageServerAuth)
+	writeTestFile(t, certFile, first.certPEM)
+	writeTestFile(t, keyFile, first.keyPEM)
+	config, err := ServerTLSConfig(certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	servedName := func() string {
+		cert, err := config.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed.Subject.CommonName
+	}
+	if got := servedName(); got != "first" {
+		t.Fatalf("served %q, want first", got)
+	}
+
+	second := newTestCert(t, "second", nil, x509.ExtKeyUsageServerAuth)
+	writeTestFile(t, certFile, second.certPEM)
+	if got := servedName(); got != "first" {
+		t.Fatalf("served %q with a mismatched key pair, want the previous first", got)
+	}
+	writeTestFile(t, keyFile, second.keyPEM)
+	if got := servedName(); got != "second" {
+		t.Fatalf("served %q after renewal, want second", got)
+	}
+}
+
+func TestServerTLSConfigRequiresClientCertificatesFromClientCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil, x509.ExtKeyUsageAny)
+	server := newTestCert(t, "server", ca, x509.ExtKeyUsageServerAuth)
+	client := newTestCert(t, "ci", ca, x509.ExtKeyUsageClientAuth)
+	paths := map[string][]byte{
+		"ca.crt": ca.certPEM, "server.crt": server.certPEM, "server.key": server.keyPEM,
+		"client.crt": client.certPEM, "client.key": client.keyPEM,
+	}
+	for name, data := range paths {
+		writeTestFile(t, filepath.Join(dir, name), data)
+	}
+	serverConfig, err := ServerTLSConfig(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	peers := make(chan string, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			tlsConn := conn.(*tls.Conn)
+			if err := tlsConn.Handshake(); err != nil {
+				peers <- ""
+			} else {
+				peers <- tlsConn.ConnectionState().VerifiedChains[0][0].Subject.CommonName
+			}
+			conn.Close()
+		}
+	}()
+
+	withCert, err := ClientTLSConfig(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withoutCert, err := ClientTLSConfig(filepath.Join(dir, "ca.crt"), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), withCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if got := <-peers; got != "ci" {
+		t.Fatalf("server saw client %q, want ci", got)
+	}
+
+	conn, err = tls.Dial("tcp", listener.Addr().String(), withoutCert)
+	if err == nil {
+		// TLS 1.3 reports a missing client certificate on first read.
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	if err == nil {
+		t.Fatal("handshake without a client certificate succeeded")
+	}
+	if got := <-peers; got != "" {
+		t.Fatalf("server accepted client %q without a certificate", got)
+	}
+}
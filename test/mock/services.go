@@ -77,17 +77,17 @@ func (mr *MockAuthorizerServiceInterfaceMockRecorder) CheckQuery(runtimeID, toke
 }
 
 // GenerateQueryToken mocks base method.
-func (m *MockAuthorizerServiceInterface) GenerateQueryToken(runtimeID, ownerID string) string {
+func (m *MockAuthorizerServiceInterface) GenerateQueryToken(runtimeID, ownerID, console string) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateQueryToken", runtimeID, ownerID)
+	ret := m.ctrl.Call(m, "GenerateQueryToken", runtimeID, ownerID, console)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
 // GenerateQueryToken indicates an expected call of GenerateQueryToken.
-func (mr *MockAuthorizerServiceInterfaceMockRecorder) GenerateQueryToken(runtimeID, ownerID any) *gomock.Call {
+func (mr *MockAuthorizerServiceInterfaceMockRecorder) GenerateQueryToken(runtimeID, ownerID, console any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateQueryToken", reflect.TypeOf((*MockAuthorizerServiceInterface)(nil).GenerateQueryToken), runtimeID, ownerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateQueryToken", reflect.TypeOf((*MockAuthorizerServiceInterface)(nil).GenerateQueryToken), runtimeID, ownerID, console)
 }
 
 // MockScrollServiceInterface is a mock of ScrollServiceInterface interface.
@@ -469,6 +469,45 @@ func (mr *MockRuntimeCommandStopperMockRecorder) StopCommand(root, command any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCommand", reflect.TypeOf((*MockRuntimeCommandStopper)(nil).StopCommand), root, command)
 }
 
+// MockRuntimeIdleBackend is a mock of RuntimeIdleBackend interface.
+type MockRuntimeIdleBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuntimeIdleBackendMockRecorder
+	isgomock struct{}
+}
+
+// MockRuntimeIdleBackendMockRecorder is the mock recorder for MockRuntimeIdleBackend.
+type MockRuntimeIdleBackendMockRecorder struct {
+	mock *MockRuntimeIdleBackend
+}
+
+// NewMockRuntimeIdleBackend creates a new mock instance.
+func NewMockRuntimeIdleBackend(ctrl *gomock.Controller) *MockRuntimeIdleBackend {
+	mock := &MockRuntimeIdleBackend{ctrl: ctrl}
+	mock.recorder = &MockRuntimeIdleBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuntimeIdleBackend) EXPECT() *MockRuntimeIdleBackendMockRecorder {
+	return m.recorder
+}
+
+// CommandIdle mocks base method.
+func (m *MockRuntimeIdleBackend) CommandIdle(root, commandName string, command *domain.CommandInstructionSet, globalPorts []domain.Port) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommandIdle", root, commandName, command, globalPorts)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommandIdle indicates an expected call of CommandIdle.
+func (mr *MockRuntimeIdleBackendMockRecorder) CommandIdle(root, commandName, command, globalPorts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommandIdle", reflect.TypeOf((*MockRuntimeIdleBackend)(nil).CommandIdle), root, commandName, command, globalPorts)
+}
+
 // MockRuntimeWorkerCallbackBackend is a mock of RuntimeWorkerCallbackBackend interface.
 type MockRuntimeWorkerCallbackBackend struct {
 	ctrl     *gomock.Controller
@@ -1238,7 +1277,7 @@ func (mr *MockColdStarterServerInterfaceMockRecorder) Close() *gomock.Call {
 }
 
 // Start mocks base method.
-func (m *MockColdStarterServerInterface) Start(port int, onFinish func()) error {
+func (m *MockColdStarterServerInterface) Start(port int, onFinish func(string)) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Start", port, onFinish)
 	ret0, _ := ret[0].(error)